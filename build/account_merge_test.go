@@ -53,7 +53,8 @@ var _ = Describe("AccountMergeBuilder Mutators", func() {
 			It("sets the destination to the correct xdr.AccountId", func() {
 				var aid xdr.AccountId
 				aid.SetAddress(address)
-				Expect(subject.O.SourceAccount.Equals(aid)).To(BeTrue())
+				source := subject.O.SourceAccount.ToAccountId()
+				Expect(source.Equals(aid)).To(BeTrue())
 			})
 		})
 
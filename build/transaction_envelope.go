@@ -17,7 +17,14 @@ type TransactionEnvelopeMutator interface {
 	MutateTransactionEnvelope(*TransactionEnvelopeBuilder) error
 }
 
-// TransactionEnvelopeBuilder helps you build a TransactionEnvelope
+var errUnsupportedEnvelope = errors.New("fee bump transaction envelopes are not supported")
+
+// TransactionEnvelopeBuilder helps you build a TransactionEnvelope.
+//
+// While being mutated, E is always held as a V1 envelope. Legacy (V0)
+// envelopes provided by the caller are converted on Init, and Bytes converts
+// the envelope back to the V0 format so that the encoded output is accepted by
+// every protocol version.
 type TransactionEnvelopeBuilder struct {
 	E *xdr.TransactionEnvelope
 
@@ -29,8 +36,25 @@ func (b *TransactionEnvelopeBuilder) Init() {
 		b.E = &xdr.TransactionEnvelope{}
 	}
 
-	if b.child == nil {
-		b.child = &TransactionBuilder{TX: &b.E.Tx}
+	switch b.E.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		v1 := &xdr.TransactionV1Envelope{}
+		if b.E.V0 != nil {
+			v1.Tx = b.E.V0.Tx.ToTransaction()
+			v1.Signatures = b.E.V0.Signatures
+		}
+		*b.E = xdr.TransactionEnvelope{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1:   v1,
+		}
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		if b.E.V1 == nil {
+			b.E.V1 = &xdr.TransactionV1Envelope{}
+		}
+	}
+
+	if b.child == nil && b.E.V1 != nil {
+		b.child = &TransactionBuilder{TX: &b.E.V1.Tx}
 	}
 }
 
@@ -53,6 +77,9 @@ func (b *TransactionEnvelopeBuilder) Mutate(muts ...TransactionEnvelopeMutator)
 // mutators.
 func (b *TransactionEnvelopeBuilder) MutateTX(muts ...TransactionMutator) error {
 	b.Init()
+	if b.child == nil {
+		return errUnsupportedEnvelope
+	}
 
 	err := b.child.Mutate(muts...)
 	if err != nil {
@@ -64,8 +91,16 @@ func (b *TransactionEnvelopeBuilder) MutateTX(muts ...TransactionMutator) error
 
 // Bytes encodes the builder's underlying envelope to XDR
 func (b *TransactionEnvelopeBuilder) Bytes() ([]byte, error) {
+	envelope := *b.E
+	if envelope.Type == xdr.EnvelopeTypeEnvelopeTypeTx {
+		v0, err := xdr.NewTransactionV0Envelope(envelope.V1.Tx, envelope.V1.Signatures)
+		if err == nil {
+			envelope = v0
+		}
+	}
+
 	var txBytes bytes.Buffer
-	_, err := xdr.Marshal(&txBytes, b.E)
+	_, err := xdr.Marshal(&txBytes, envelope)
 	if err != nil {
 		return nil, errors.Wrap(err, "marshal xdr failed")
 	}
@@ -92,6 +127,10 @@ func (b *TransactionEnvelopeBuilder) Base64() (string, error) {
 
 // MutateTransactionEnvelope adds a signature to the provided envelope
 func (m Sign) MutateTransactionEnvelope(txe *TransactionEnvelopeBuilder) error {
+	if txe.child == nil {
+		return errUnsupportedEnvelope
+	}
+
 	hash, err := txe.child.Hash()
 	if err != nil {
 		return errors.Wrap(err, "hash tx failed")
@@ -107,16 +146,20 @@ func (m Sign) MutateTransactionEnvelope(txe *TransactionEnvelopeBuilder) error {
 		return errors.Wrap(err, "sign tx failed")
 	}
 
-	txe.E.Signatures = append(txe.E.Signatures, sig)
+	txe.E.V1.Signatures = append(txe.E.V1.Signatures, sig)
 	return nil
 }
 
 // MutateTransactionEnvelope for TransactionBuilder causes the underylying
 // transaction to be set as the provided envelope's Tx field
 func (m *TransactionBuilder) MutateTransactionEnvelope(txe *TransactionEnvelopeBuilder) error {
-	txe.E.Tx = *m.TX
+	if txe.E.V1 == nil {
+		return errUnsupportedEnvelope
+	}
+
+	txe.E.V1.Tx = *m.TX
 	newChild := *m
 	txe.child = &newChild
-	m.TX = &txe.E.Tx
+	m.TX = &txe.E.V1.Tx
 	return nil
 }
@@ -19,6 +19,21 @@ func setAccountId(addressOrSeed string, aid *xdr.AccountId) error {
 	return aid.SetAddress(kp.Address())
 }
 
+func setMuxedAccount(addressOrSeed string, muxed *xdr.MuxedAccount) error {
+	var aid xdr.AccountId
+	err := setAccountId(addressOrSeed, &aid)
+	if err != nil {
+		return err
+	}
+
+	if muxed == nil {
+		return errors.New("muxed is nil in setMuxedAccount")
+	}
+
+	*muxed = aid.ToMuxedAccount()
+	return nil
+}
+
 func createAlphaNumAsset(code, issuerAccountId string) (xdr.Asset, error) {
 	var issuer xdr.AccountId
 	err := setAccountId(issuerAccountId, &issuer)
@@ -1,9 +1,14 @@
 package horizonclient
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
+	"time"
 
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/support/errors"
 )
 
@@ -22,11 +27,29 @@ func (ar AccountRequest) BuildURL() (endpoint string, err error) {
 		err = errors.New("invalid request: no parameters")
 	}
 
+	historical := ar.Ledger > 0 || !ar.At.IsZero()
+	if historical && ar.DataKey != "" {
+		err = errors.New("invalid request: account data has no historical state")
+	}
+
 	if err != nil {
 		return endpoint, err
 	}
 
-	if ar.DataKey != "" && ar.AccountID != "" {
+	if historical {
+		params := map[string]string{}
+		if ar.Ledger > 0 {
+			params["ledger"] = strconv.FormatUint(uint64(ar.Ledger), 10)
+		}
+		if !ar.At.IsZero() {
+			params["at"] = ar.At.UTC().Format(time.RFC3339)
+		}
+		endpoint = fmt.Sprintf(
+			"accounts/%s/balances?%s",
+			ar.AccountID,
+			addQueryParams(params),
+		)
+	} else if ar.DataKey != "" && ar.AccountID != "" {
 		endpoint = fmt.Sprintf(
 			"accounts/%s/data/%s",
 			ar.AccountID,
@@ -46,3 +69,36 @@ func (ar AccountRequest) BuildURL() (endpoint string, err error) {
 
 	return endpoint, err
 }
+
+// AccountHandler is a function that is called when a new account state is received
+type AccountHandler func(hProtocol.Account)
+
+// StreamAccount streams the state of an account, which is sent again every time the account
+// changes. Use context.WithCancel to stop streaming or context.Background() if you want to stream
+// indefinitely. AccountHandler is a user-supplied function that is executed for each account state received.
+func (ar AccountRequest) StreamAccount(ctx context.Context, client *Client, handler AccountHandler) error {
+	if ar.AccountID == "" {
+		return errors.New("no account ID provided")
+	}
+	if ar.DataKey != "" {
+		return errors.New("account data can't be streamed")
+	}
+	if ar.Ledger > 0 || !ar.At.IsZero() {
+		return errors.New("historical account states can't be streamed")
+	}
+	endpoint, err := ar.BuildURL()
+	if err != nil {
+		return errors.Wrap(err, "unable to build endpoint for account request")
+	}
+
+	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
+	return client.stream(ctx, url, streamCursor{}, func(data []byte) error {
+		var account hProtocol.Account
+		err = json.Unmarshal(data, &account)
+		if err != nil {
+			return errors.Wrap(err, "error unmarshaling data for account request")
+		}
+		handler(account)
+		return nil
+	})
+}
@@ -1,8 +1,12 @@
 package horizonclient
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -39,4 +43,59 @@ func TestAccountRequestBuildUrl(t *testing.T) {
 	// It should return valid account data endpoint and no errors
 	require.NoError(t, err)
 	assert.Equal(t, "accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/data/test", endpoint)
+
+	ar.Ledger = 1234
+	_, err = ar.BuildURL()
+
+	// error case: account data has no historical state
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid request: account data has no historical state")
+	}
+
+	ar.DataKey = ""
+	endpoint, err = ar.BuildURL()
+
+	// It should return valid account state endpoint and no errors
+	require.NoError(t, err)
+	assert.Equal(t, "accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/balances?ledger=1234", endpoint)
+
+	ar.Ledger = 0
+	ar.At = time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	endpoint, err = ar.BuildURL()
+
+	require.NoError(t, err)
+	assert.Equal(t, "accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/balances?at=2020-01-02T15%3A04%3A05Z", endpoint)
+}
+
+func TestAccountRequestStreamAccount(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU?cursor=now",
+	).ReturnString(200, accountStreamResponse)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var accounts []hProtocol.Account
+	err := client.StreamAccount(ctx, AccountRequest{AccountID: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}, func(account hProtocol.Account) {
+		accounts = append(accounts, account)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, "9865509814140929", accounts[0].Sequence)
+
+	err = client.StreamAccount(context.Background(), AccountRequest{}, func(hProtocol.Account) {})
+	assert.EqualError(t, err, "no account ID provided")
+
+	err = client.StreamAccount(context.Background(), AccountRequest{AccountID: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", DataKey: "test"}, func(hProtocol.Account) {})
+	assert.EqualError(t, err, "account data can't be streamed")
 }
+
+var accountStreamResponse = `data: {"id":"GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU","account_id":"GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU","sequence":"9865509814140929","subentry_count":0,"balances":[{"balance":"9999.9999900","asset_type":"native"}],"signers":[{"weight":1,"key":"GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU","type":"ed25519_public_key"}],"data":{}}
+
+`
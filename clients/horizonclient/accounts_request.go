@@ -0,0 +1,36 @@
+package horizonclient
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// BuildURL creates the endpoint to be queried based on the data in the AccountsRequest struct.
+func (ar AccountsRequest) BuildURL() (endpoint string, err error) {
+	nParams := countParams(ar.Signer, ar.Asset)
+	if nParams <= 0 {
+		return endpoint, errors.New("invalid request: no parameters - Signer or Asset must be provided")
+	}
+	if nParams > 1 {
+		return endpoint, errors.New("invalid request: too many parameters - only one of Signer or Asset can be provided")
+	}
+
+	endpoint = "accounts"
+	queryParams := addQueryParams(
+		map[string]string{
+			"signer": ar.Signer,
+			"asset":  ar.Asset,
+		},
+		cursor(ar.Cursor), limit(ar.Limit), ar.Order,
+	)
+	endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
+
+	_, err = url.Parse(endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse endpoint")
+	}
+
+	return endpoint, err
+}
@@ -0,0 +1,134 @@
+package horizonclient
+
+import (
+	"testing"
+
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountsRequestBuildUrl(t *testing.T) {
+	_, err := AccountsRequest{}.BuildURL()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid request: no parameters")
+	}
+
+	_, err = AccountsRequest{
+		Signer: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		Asset:  "USD:GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM",
+	}.BuildURL()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid request: too many parameters")
+	}
+
+	endpoint, err := AccountsRequest{Signer: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}.BuildURL()
+	require.NoError(t, err)
+	assert.Equal(t, "accounts?signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", endpoint)
+
+	endpoint, err = AccountsRequest{
+		Asset:  "USD:GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM",
+		Cursor: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		Limit:  10,
+		Order:  OrderDesc,
+	}.BuildURL()
+	require.NoError(t, err)
+	assert.Equal(t, "accounts?asset=USD%3AGDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM&cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=10&order=desc", endpoint)
+}
+
+func TestAccountsRequest(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts?limit=1&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, firstAccountsPage)
+
+	accounts, err := client.Accounts(AccountsRequest{Signer: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, accounts.Embedded.Records, 1)
+	assert.Equal(t, "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", accounts.Embedded.Records[0].AccountID)
+	assert.Equal(t, "9865509814140929", accounts.Embedded.Records[0].Sequence)
+
+	hmock.On(
+		"GET",
+		"https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=asc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+	).ReturnString(200, emptyAccountsPage)
+
+	nextPage, err := client.NextAccountsPage(accounts)
+	require.NoError(t, err)
+	assert.Len(t, nextPage.Embedded.Records, 0)
+}
+
+var firstAccountsPage = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=&limit=1&order=asc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    },
+    "next": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=asc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    },
+    "prev": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=desc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    }
+  },
+  "_embedded": {
+    "records": [
+      {
+        "id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+        "account_id": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+        "sequence": "9865509814140929",
+        "subentry_count": 0,
+        "last_modified_ledger": 2297002,
+        "thresholds": {
+          "low_threshold": 0,
+          "med_threshold": 0,
+          "high_threshold": 0
+        },
+        "flags": {
+          "auth_required": false,
+          "auth_revocable": false,
+          "auth_immutable": false
+        },
+        "balances": [
+          {
+            "balance": "9999.9999900",
+            "buying_liabilities": "0.0000000",
+            "selling_liabilities": "0.0000000",
+            "asset_type": "native"
+          }
+        ],
+        "signers": [
+          {
+            "weight": 1,
+            "key": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+            "type": "ed25519_public_key"
+          }
+        ],
+        "data": {},
+        "paging_token": "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+      }
+    ]
+  }
+}`
+
+var emptyAccountsPage = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=asc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    },
+    "next": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=asc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    },
+    "prev": {
+      "href": "https://horizon-testnet.paydex.org/accounts?cursor=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&limit=1&order=desc&signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+    }
+  },
+  "_embedded": {
+    "records": []
+  }
+}`
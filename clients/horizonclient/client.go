@@ -29,17 +29,35 @@ func (c *Client) sendRequest(hr HorizonRequest, resp interface{}) (err error) {
 	}
 
 	c.HorizonURL = c.fixHorizonURL()
-	_, ok := hr.(submitRequest)
-	if ok {
+	sr, ok := hr.(submitRequest)
+	if ok && sr.async {
+		// an asynchronous submission isn't retried: sending it again is safe,
+		// the response tells whether paydex-core already received it
 		return c.sendRequestURL(c.HorizonURL+endpoint, "post", resp)
 	}
+	if ok {
+		return c.submitTransaction(c.HorizonURL+endpoint, sr.transactionXdr, resp)
+	}
 
 	return c.sendRequestURL(c.HorizonURL+endpoint, "get", resp)
 }
 
 // sendRequestURL sends a url to a horizon server.
 // It can be used for requests that do not implement the HorizonRequest interface.
+// GET requests are retried according to the RetryPolicy of the client.
 func (c *Client) sendRequestURL(requestURL string, method string, a interface{}) (err error) {
+	if method == "post" || method == "POST" {
+		info := c.requestStarted(method, requestURL)
+		var result requestResult
+		result, err = c.doRequest(requestURL, method, a)
+		c.requestFinished(info, result, 0, err)
+		return
+	}
+	return c.sendRequestWithRetries(requestURL, a)
+}
+
+// doRequest sends a single request to a horizon server.
+func (c *Client) doRequest(requestURL string, method string, a interface{}) (result requestResult, err error) {
 	var req *http.Request
 
 	if method == "post" || method == "POST" {
@@ -50,7 +68,7 @@ func (c *Client) sendRequestURL(requestURL string, method string, a interface{})
 	}
 
 	if err != nil {
-		return errors.Wrap(err, "error creating HTTP request")
+		return result, errors.Wrap(err, "error creating HTTP request")
 	}
 	c.setClientAppHeaders(req)
 	c.setDefaultClient()
@@ -58,13 +76,20 @@ func (c *Client) sendRequestURL(requestURL string, method string, a interface{})
 		c.horizonTimeOut = HorizonTimeOut
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*c.horizonTimeOut)
+	result.sent = true
 	resp, err := c.HTTP.Do(req.WithContext(ctx))
 	if err != nil {
 		cancel()
 		return
 	}
 
+	result.status = resp.StatusCode
+	result.header = resp.Header
+	c.trackRateLimit(resp.Header)
+	body := &countingReader{ReadCloser: resp.Body}
+	resp.Body = body
 	err = decodeResponse(resp, &a, c)
+	result.bytes = body.bytes
 	cancel()
 	return
 }
@@ -73,8 +98,18 @@ func (c *Client) sendRequestURL(requestURL string, method string, a interface{})
 func (c *Client) stream(
 	ctx context.Context,
 	streamURL string,
+	cursor streamCursor,
 	handler func(data []byte) error,
-) error {
+) (err error) {
+	info := StreamInfo{URL: streamURL, Route: route(streamURL)}
+	if c.Hooks != nil {
+		defer func() {
+			if err != nil {
+				c.Hooks.StreamError(info, err)
+			}
+		}()
+	}
+
 	su, err := url.Parse(streamURL)
 	if err != nil {
 		return errors.Wrap(err, "error parsing stream url")
@@ -84,8 +119,22 @@ func (c *Client) stream(
 	if query.Get("cursor") == "" {
 		query.Set("cursor", "now")
 	}
+	// a stored cursor takes precedence over the cursor of the request
+	if cursor.store != nil {
+		stored, err := cursor.store.Load()
+		if err != nil {
+			return errors.Wrap(err, "error loading cursor")
+		}
+		if stored != "" {
+			resumed, err := c.resumeCursor(stored, cursor.onGap)
+			if err != nil {
+				return err
+			}
+			query.Set("cursor", resumed)
+		}
+	}
 
-	for {
+	for connections := 0; ; connections++ {
 		// updates the url with new cursor
 		su.RawQuery = query.Encode()
 		req, err := http.NewRequest("GET", su.String(), nil)
@@ -108,6 +157,16 @@ func (c *Client) stream(
 		}
 		defer resp.Body.Close()
 
+		if c.Hooks != nil {
+			info.Cursor = query.Get("cursor")
+			if connections == 0 {
+				c.Hooks.StreamConnected(info)
+			} else {
+				info.Reconnects = connections
+				c.Hooks.StreamReconnected(info)
+			}
+		}
+
 		reader := bufio.NewReader(resp.Body)
 
 		// Read events one by one. Break this loop when there is no more data to be
@@ -173,11 +232,6 @@ func (c *Client) stream(
 					continue
 				}
 
-				// Update cursor with event ID
-				if event.Id != "" {
-					query.Set("cursor", event.Id)
-				}
-
 				switch data := event.Data.(type) {
 				case string:
 					err = handler([]byte(data))
@@ -191,6 +245,23 @@ func (c *Client) stream(
 				if err != nil {
 					return err
 				}
+
+				// Update cursor with event ID once the event is processed
+				if event.Id != "" {
+					query.Set("cursor", event.Id)
+					if cursor.store != nil {
+						if err = cursor.store.Save(event.Id); err != nil {
+							return errors.Wrap(err, "error saving cursor")
+						}
+					}
+				}
+
+				if c.Hooks != nil {
+					if event.Id != "" {
+						info.Cursor = event.Id
+					}
+					c.Hooks.StreamEvent(info)
+				}
 			}
 		}
 	}
@@ -256,6 +327,13 @@ func (c *Client) AccountData(request AccountRequest) (accountData hProtocol.Acco
 	return
 }
 
+// Accounts returns the accounts which have a given signer, or a trustline to a given asset.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/accounts.html
+func (c *Client) Accounts(request AccountsRequest) (accounts hProtocol.AccountsPage, err error) {
+	err = c.sendRequest(request, &accounts)
+	return
+}
+
 // Effects returns effects(https://www.paydex.org/developers/horizon/reference/resources/effect.html)
 // It can be used to return effects for an account, a ledger, an operation, a transaction and all effects on the network.
 func (c *Client) Effects(request EffectRequest) (effects effects.EffectsPage, err error) {
@@ -316,6 +394,18 @@ func (c *Client) Offers(request OfferRequest) (offers hProtocol.OffersPage, err
 	return
 }
 
+// OfferDetails returns information about a single offer for a given offer ID.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/offer-details.html
+func (c *Client) OfferDetails(offerID string) (offer hProtocol.Offer, err error) {
+	if offerID == "" {
+		return offer, errors.New("invalid offer ID provided")
+	}
+
+	request := OfferRequest{forOfferID: offerID}
+	err = c.sendRequest(request, &offer)
+	return
+}
+
 // Operations returns paydex operations (https://www.paydex.org/developers/horizon/reference/resources/operation.html)
 // It can be used to return operations for an account, a ledger, a transaction and all operations on the network.
 func (c *Client) Operations(request OperationRequest) (ops operations.OperationsPage, err error) {
@@ -377,6 +467,59 @@ func (c *Client) SubmitTransaction(transaction txnbuild.Transaction) (txSuccess
 	return c.SubmitTransactionXDR(txeBase64)
 }
 
+// SubmitFeeBumpTransaction submits a fee bump transaction to the network. err can be either error object or horizon.Error object.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/transactions-create.html
+func (c *Client) SubmitFeeBumpTransaction(transaction txnbuild.FeeBumpTransaction) (txSuccess hProtocol.TransactionSuccess,
+	err error) {
+	txeBase64, err := transaction.Base64()
+	if err != nil {
+		err = errors.Wrap(err, "Unable to convert fee bump transaction object to base64 string")
+		return
+	}
+
+	return c.SubmitTransactionXDR(txeBase64)
+}
+
+// SubmitTransactionXDRAsync submits a transaction represented as a base64 XDR
+// string to the network, without waiting for it to be included in a ledger.
+// The response holds the status returned by paydex-core, also when paydex-core
+// rejected the transaction; err can be either error object or horizon.Error
+// object. The outcome of a PENDING transaction is polled with
+// AsyncTransactionStatus, on the same horizon instance.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/transactions-create-async.html
+func (c *Client) SubmitTransactionXDRAsync(transactionXdr string) (resp hProtocol.AsyncTransactionSubmissionResponse,
+	err error) {
+	request := submitRequest{endpoint: "transactions_async", transactionXdr: transactionXdr, async: true}
+	err = c.sendRequest(request, &resp)
+	return
+}
+
+// SubmitTransactionAsync submits a transaction to the network, without waiting
+// for it to be included in a ledger. See SubmitTransactionXDRAsync.
+func (c *Client) SubmitTransactionAsync(transaction txnbuild.Transaction) (resp hProtocol.AsyncTransactionSubmissionResponse,
+	err error) {
+	txeBase64, err := transaction.Base64()
+	if err != nil {
+		err = errors.Wrap(err, "Unable to convert transaction object to base64 string")
+		return
+	}
+
+	return c.SubmitTransactionXDRAsync(txeBase64)
+}
+
+// AsyncTransactionStatus returns the status of a transaction submitted with
+// SubmitTransactionXDRAsync: PENDING, SUCCESS, FAILED or NOT_FOUND.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/transactions-async-status.html
+func (c *Client) AsyncTransactionStatus(txHash string) (status hProtocol.AsyncTransactionStatus, err error) {
+	if txHash == "" {
+		return status, errors.New("no transaction hash provided")
+	}
+
+	request := asyncTransactionStatusRequest{hash: txHash}
+	err = c.sendRequest(request, &status)
+	return
+}
+
 // Transactions returns paydex transactions (https://www.paydex.org/developers/horizon/reference/resources/transaction.html)
 // It can be used to return transactions for an account, a ledger,and all transactions on the network.
 func (c *Client) Transactions(request TransactionRequest) (txs hProtocol.TransactionsPage, err error) {
@@ -408,6 +551,14 @@ func (c *Client) Paths(request PathsRequest) (paths hProtocol.PathsPage, err err
 	return
 }
 
+// StrictSendPaths returns the available paths to make a strict send payment, that is a payment
+// sending an exact amount of the source asset.
+// See https://www.paydex.org/developers/horizon/reference/endpoints/path-finding-strict-send.html
+func (c *Client) StrictSendPaths(request StrictSendPathsRequest) (paths hProtocol.PathsPage, err error) {
+	err = c.sendRequest(request, &paths)
+	return
+}
+
 // Payments returns paydex account_merge, create_account, path payment and payment operations.
 // It can be used to return payments for an account, a ledger, a transaction and all payments on the network.
 func (c *Client) Payments(request OperationRequest) (ops operations.OperationsPage, err error) {
@@ -447,6 +598,13 @@ func (c *Client) TradeAggregations(request TradeAggregationRequest) (tds hProtoc
 	return
 }
 
+// StreamAccount streams the state of an account, which is sent again every time the account changes.
+// Use context.WithCancel to stop streaming or context.Background() if you want to stream indefinitely.
+// AccountHandler is a user-supplied function that is executed for each account state received.
+func (c *Client) StreamAccount(ctx context.Context, request AccountRequest, handler AccountHandler) error {
+	return request.StreamAccount(ctx, c, handler)
+}
+
 // StreamTransactions streams processed transactions. It can be used to stream all transactions and
 // transactions for an account. Use context.WithCancel to stop streaming or context.Background()
 // if you want to stream indefinitely. TransactionHandler is a user-supplied function that is executed for each streamed transaction received.
@@ -529,6 +687,18 @@ func (c *Client) Version() string {
 	return version
 }
 
+// NextAccountsPage returns the next page of accounts.
+func (c *Client) NextAccountsPage(page hProtocol.AccountsPage) (accounts hProtocol.AccountsPage, err error) {
+	err = c.sendRequestURL(page.Links.Next.Href, "get", &accounts)
+	return
+}
+
+// PrevAccountsPage returns the previous page of accounts.
+func (c *Client) PrevAccountsPage(page hProtocol.AccountsPage) (accounts hProtocol.AccountsPage, err error) {
+	err = c.sendRequestURL(page.Links.Prev.Href, "get", &accounts)
+	return
+}
+
 // NextAssetsPage returns the next page of assets.
 func (c *Client) NextAssetsPage(page hProtocol.AssetsPage) (assets hProtocol.AssetsPage, err error) {
 	err = c.sendRequestURL(page.Links.Next.Href, "get", &assets)
@@ -653,3 +823,6 @@ func (c *Client) PrevTradeAggregationsPage(page hProtocol.TradeAggregationsPage)
 
 // ensure that the horizon client implements ClientInterface
 var _ ClientInterface = &Client{}
+
+// ensure that the horizon client can be used to suggest transaction fees
+var _ txnbuild.FeeStatsSource = &Client{}
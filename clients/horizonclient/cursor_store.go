@@ -0,0 +1,187 @@
+package horizonclient
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// CursorStore persists the cursor of a stream, so that the stream resumes
+// where it stopped after a reconnection or a restart of the process. The
+// cursor is the paging token of the last record processed by the handler of
+// the stream.
+//
+// A stream uses the CursorStore field of its request, if set, loading the
+// stored cursor instead of the Cursor of the request, and saving the cursor
+// after every record. When the stored cursor is older than the history of the
+// Horizon server, the OnGap field of the request is called, see GapHandler.
+type CursorStore interface {
+	// Load returns the stored cursor, or an empty string if there is none.
+	Load() (string, error)
+	// Save stores cursor, replacing the previously stored cursor.
+	Save(cursor string) error
+}
+
+// GapHandler, set in the OnGap field of a request, is called when a stream
+// resumes from a stored cursor which is older than the history of the Horizon
+// server, which means that the records between the cursor and historyElder,
+// the oldest ledger in the history of the server, have been reaped and would
+// be silently skipped. It returns the cursor the stream resumes from: "now" to
+// only stream new records, or an empty string to stream from the oldest ledger
+// in history. The stream stops with the error of the handler if it returns
+// one.
+type GapHandler func(cursor string, historyElder int32) (string, error)
+
+// MemoryCursorStore is a CursorStore holding the cursor in memory. It resumes
+// streams after reconnections, but not after restarts of the process.
+type MemoryCursorStore struct {
+	mutex  sync.Mutex
+	cursor string
+}
+
+// Load returns the stored cursor.
+func (s *MemoryCursorStore) Load() (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursor, nil
+}
+
+// Save stores cursor.
+func (s *MemoryCursorStore) Save(cursor string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cursor = cursor
+	return nil
+}
+
+// FileCursorStore is a CursorStore holding the cursor in the file at Path.
+// The file is replaced atomically, so that it is never left half written.
+type FileCursorStore struct {
+	Path string
+}
+
+// Load reads the cursor from the file, if it exists.
+func (s *FileCursorStore) Load() (string, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "reading cursor file")
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save writes cursor to a temporary file which then replaces the file.
+func (s *FileCursorStore) Save(cursor string) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "creating temporary cursor file")
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.WriteString(cursor + "\n"); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Wrap(err, "writing temporary cursor file")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.Path), "replacing cursor file")
+}
+
+// SQLCursorStoreSchema creates the table used by SQLCursorStore in a
+// PostgreSQL database.
+const SQLCursorStoreSchema = `CREATE TABLE IF NOT EXISTS horizon_cursors (
+	name character varying(255) NOT NULL PRIMARY KEY,
+	cursor character varying(255) NOT NULL,
+	updated_at timestamp without time zone NOT NULL DEFAULT now()
+);`
+
+// SQLCursorStore is a CursorStore holding cursors in the horizon_cursors table
+// of a PostgreSQL database, see SQLCursorStoreSchema. Every stream is stored
+// under its own name, so that one table holds the cursors of many streams.
+type SQLCursorStore struct {
+	db   *sql.DB
+	name string
+}
+
+// NewSQLCursorStore returns a SQLCursorStore storing the cursor of the stream
+// called name in db.
+func NewSQLCursorStore(db *sql.DB, name string) *SQLCursorStore {
+	return &SQLCursorStore{db: db, name: name}
+}
+
+// Load selects the cursor of the stream.
+func (s *SQLCursorStore) Load() (string, error) {
+	var cursor string
+	err := s.db.QueryRow(`SELECT cursor FROM horizon_cursors WHERE name = $1`, s.name).Scan(&cursor)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "loading cursor")
+	}
+	return cursor, nil
+}
+
+// Save upserts the cursor of the stream.
+func (s *SQLCursorStore) Save(cursor string) error {
+	_, err := s.db.Exec(`
+		INSERT INTO horizon_cursors (name, cursor, updated_at) VALUES ($1, $2, now())
+		ON CONFLICT (name) DO UPDATE SET cursor = EXCLUDED.cursor, updated_at = EXCLUDED.updated_at`,
+		s.name, cursor,
+	)
+	return errors.Wrap(err, "saving cursor")
+}
+
+// streamCursor configures the persistence of the cursor of a stream.
+type streamCursor struct {
+	store CursorStore
+	onGap GapHandler
+}
+
+// cursorLedger returns the ledger of a paging token. Paging tokens are total
+// order IDs, whose upper 32 bits hold the ledger sequence, or, for effects,
+// a total order ID and an index separated by a dash. The cursor "0" is not in
+// a ledger: streams starting from it start from the oldest record in history.
+func cursorLedger(cursor string) (int32, bool) {
+	if i := strings.IndexByte(cursor, '-'); i >= 0 {
+		cursor = cursor[:i]
+	}
+	id, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id >> 32), true
+}
+
+// resumeCursor returns the cursor a stream resumes from after loading cursor
+// from its CursorStore, checking that the history of the Horizon server still
+// covers it.
+func (c *Client) resumeCursor(cursor string, onGap GapHandler) (string, error) {
+	ledger, ok := cursorLedger(cursor)
+	if !ok {
+		return cursor, nil
+	}
+	root, err := c.Root()
+	if err != nil {
+		return "", errors.Wrap(err, "loading history elder ledger")
+	}
+	if ledger >= root.HistoryElderSequence {
+		return cursor, nil
+	}
+	if onGap == nil {
+		return "", errors.Wrapf(ErrHistoryGap, "cursor %s is in ledger %d, history starts at ledger %d",
+			cursor, ledger, root.HistoryElderSequence)
+	}
+	return onGap(cursor, root.HistoryElderSequence)
+}
@@ -0,0 +1,216 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/db/dbtest"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCursorStore(t *testing.T) {
+	store := &MemoryCursorStore{}
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	require.NoError(t, store.Save("2608707301036032"))
+	cursor, err = store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036032", cursor)
+}
+
+func TestFileCursorStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "horizonclient")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store := &FileCursorStore{Path: filepath.Join(dir, "cursor")}
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	require.NoError(t, store.Save("2608707301036032"))
+	require.NoError(t, store.Save("2608707301036033"))
+	cursor, err = (&FileCursorStore{Path: store.Path}).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036033", cursor)
+
+	// temporary files are cleaned up
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	store = &FileCursorStore{Path: filepath.Join(dir, "missing", "cursor")}
+	assert.Error(t, store.Save("2608707301036032"))
+}
+
+func TestSQLCursorStore(t *testing.T) {
+	if _, err := exec.LookPath("createdb"); err != nil {
+		t.Skip("no postgres database is configured")
+	}
+	db := dbtest.Postgres(t).Load(SQLCursorStoreSchema)
+	defer db.Close()
+	conn := db.Open()
+	defer conn.Close()
+
+	payments := NewSQLCursorStore(conn.DB, "payments")
+	transactions := NewSQLCursorStore(conn.DB, "transactions")
+
+	cursor, err := payments.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "", cursor)
+
+	require.NoError(t, payments.Save("2608707301036032"))
+	require.NoError(t, payments.Save("2608707301036033"))
+	require.NoError(t, transactions.Save("2608707301036034"))
+
+	cursor, err = payments.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036033", cursor)
+	cursor, err = transactions.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036034", cursor)
+}
+
+func TestCursorLedger(t *testing.T) {
+	ledger, ok := cursorLedger("2608707301036032")
+	assert.True(t, ok)
+	assert.Equal(t, int32(607387), ledger)
+
+	ledger, ok = cursorLedger("2608707301036033-2")
+	assert.True(t, ok)
+	assert.Equal(t, int32(607387), ledger)
+
+	_, ok = cursorLedger("now")
+	assert.False(t, ok)
+	_, ok = cursorLedger("")
+	assert.False(t, ok)
+	_, ok = cursorLedger("0")
+	assert.False(t, ok)
+}
+
+var paymentStreamResponse = `id: 2608707301036033
+data: {"id":"2608707301036033","paging_token":"2608707301036033","type":"payment","type_i":1,"amount":"10.0000000","asset_type":"native"}
+
+`
+
+func TestStreamPaymentsResumesFromCursorStore(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/").ReturnString(200, `{"history_elder_ledger": 600000}`)
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=2608707301036032",
+	).ReturnString(200, paymentStreamResponse)
+
+	store := &MemoryCursorStore{}
+	require.NoError(t, store.Save("2608707301036032"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var received []operations.Operation
+	err := client.StreamPayments(ctx, OperationRequest{Cursor: "now", CursorStore: store}, func(op operations.Operation) {
+		received = append(received, op)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, "2608707301036033", received[0].PagingToken())
+
+	// the cursor is saved once the payment is handled
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036033", cursor)
+}
+
+func TestStreamPaymentsHistoryGap(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/").ReturnString(200, `{"history_elder_ledger": 700000}`)
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=now",
+	).ReturnString(200, paymentStreamResponse)
+
+	store := &MemoryCursorStore{}
+	require.NoError(t, store.Save("2608707301036032"))
+
+	// without a GapHandler the stream stops
+	err := client.StreamPayments(context.Background(), OperationRequest{CursorStore: store}, func(operations.Operation) {
+		t.Fatal("no payment should be streamed")
+	})
+	assert.Equal(t, ErrHistoryGap, errors.Cause(err))
+
+	// the GapHandler chooses where the stream resumes
+	var gapCursor string
+	var gapElder int32
+	ctx, cancel := context.WithCancel(context.Background())
+	request := OperationRequest{
+		CursorStore: store,
+		OnGap: func(cursor string, historyElder int32) (string, error) {
+			gapCursor, gapElder = cursor, historyElder
+			return "now", nil
+		},
+	}
+	err = client.StreamPayments(ctx, request, func(operations.Operation) {
+		cancel()
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036032", gapCursor)
+	assert.Equal(t, int32(700000), gapElder)
+
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036033", cursor)
+
+	// errors of the GapHandler stop the stream
+	request.OnGap = func(string, int32) (string, error) {
+		return "", errors.New("payments were missed")
+	}
+	err = client.StreamPayments(context.Background(), request, func(operations.Operation) {})
+	assert.EqualError(t, err, "payments were missed")
+}
+
+func TestStreamPaymentsFromOldestRecord(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On(
+		"GET",
+		"https://localhost/payments?cursor=0",
+	).ReturnString(200, paymentStreamResponse)
+
+	// the cursor saved by an iteration which found no records resumes from
+	// the oldest record, without a history gap
+	store := &MemoryCursorStore{}
+	require.NoError(t, store.Save("0"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var received []operations.Operation
+	err := client.StreamPayments(ctx, OperationRequest{CursorStore: store}, func(op operations.Operation) {
+		received = append(received, op)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "2608707301036033", cursor)
+}
@@ -40,7 +40,8 @@ func (er EffectRequest) BuildURL() (endpoint string, err error) {
 		endpoint = fmt.Sprintf("transactions/%s/effects", er.ForTransaction)
 	}
 
-	queryParams := addQueryParams(cursor(er.Cursor), limit(er.Limit), er.Order)
+	queryParams := addQueryParams(cursor(er.Cursor), limit(er.Limit), er.Order,
+		startTime(er.StartTime), endTime(er.EndTime), types(er.Types), asset(er.Asset))
 	if queryParams != "" {
 		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
 	}
@@ -63,7 +64,7 @@ func (er EffectRequest) StreamEffects(ctx context.Context, client *Client, handl
 	}
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{er.CursorStore, er.OnGap}, func(data []byte) error {
 		var baseEffect effects.Base
 		// unmarshal into the base effect type
 		if err = json.Unmarshal(data, &baseEffect); err != nil {
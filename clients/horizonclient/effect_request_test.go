@@ -3,6 +3,7 @@ package horizonclient
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
 	"github.com/paydex-core/paydex-go/support/http/httptest"
@@ -62,6 +63,27 @@ func TestEffectRequestBuildUrl(t *testing.T) {
 
 }
 
+func TestEffectRequestBuildUrlWithFilters(t *testing.T) {
+	er := EffectRequest{
+		ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		StartTime:  time.Unix(1583020800, 0),
+		Types:      []string{"account_credited"},
+		Asset:      "USD:GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		Cursor:     "123-1",
+	}
+	endpoint, err := er.BuildURL()
+
+	// It should return valid account effects endpoint with the filters and no errors
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/effects?"+
+			"asset=USD%3AGCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&cursor=123-1&"+
+			"start_time=1583020800000&type=account_credited",
+		endpoint,
+	)
+}
+
 func TestEffectRequestStreamEffects(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
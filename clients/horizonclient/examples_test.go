@@ -1080,3 +1080,35 @@ func ExampleClient_Transactions() {
 		fmt.Print(tx)
 	}
 }
+
+func ExampleNewPaymentIterator() {
+	client := horizonclient.DefaultTestNetClient
+	// payments of an account, starting with its oldest payment
+	opRequest := horizonclient.OperationRequest{ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU", Limit: 200}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// Stop after 60 seconds.
+		time.Sleep(60 * time.Second)
+		cancel()
+	}()
+
+	// backfill the past payments
+	it := horizonclient.NewPaymentIterator(ctx, client, opRequest)
+	for it.Next() {
+		fmt.Println(it.Operation())
+	}
+	if err := it.Err(); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// then stream the new payments
+	printHandler := func(op operations.Operation) {
+		fmt.Println(op)
+	}
+	err := it.Stream(ctx, printHandler)
+	if err != nil {
+		fmt.Println(err)
+	}
+}
@@ -0,0 +1,985 @@
+package horizonclient
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/clock"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+)
+
+// Routing chooses the Horizon instance serving a read of a FailoverClient.
+type Routing int
+
+const (
+	// RoundRobin spreads reads over the healthy Horizon instances in turn.
+	RoundRobin Routing = iota
+	// LowestLatency sends reads to the healthy Horizon instance which answered
+	// the last health check the fastest.
+	LowestLatency
+)
+
+const (
+	// DefaultMaxLedgerLag is the number of ledgers a Horizon instance may lag
+	// behind the network before it is unhealthy, when the MaxLedgerLag of a
+	// FailoverClient is 0.
+	DefaultMaxLedgerLag = 5
+	// DefaultCheckInterval is the interval between health checks when the
+	// CheckInterval of a FailoverClient is 0.
+	DefaultCheckInterval = 10 * time.Second
+	// DefaultSubmissionMargin is the time a transaction may take to be
+	// applied after its submission failed, when the SubmissionMargin of a
+	// FailoverClient is 0.
+	DefaultSubmissionMargin = 30 * time.Second
+
+	// submissionPollInterval is the interval between the lookups of a
+	// transaction whose submission failed.
+	submissionPollInterval = time.Second
+)
+
+// ErrNoHorizon is the error returned by a FailoverClient without clients.
+var ErrNoHorizon = errors.New("no horizon instances configured")
+
+// FailoverClient is a ClientInterface spreading requests over several Horizon
+// instances, and failing over from the instances which error or fall behind
+// the network.
+//
+// The health of the instances is checked by Run, or by calling CheckHealth,
+// which loads the root endpoint of every instance. An instance is unhealthy
+// when that fails, or when its history_latest_ledger lags more than
+// MaxLedgerLag ledgers behind the highest core_latest_ledger of the instances.
+// An instance is also unhealthy from the moment a request to it fails with a
+// connection error, or a 429 or 5xx response, until the next health check.
+// When no instance is healthy, requests are sent to every instance in turn.
+//
+// Reads are routed according to Routing, and are sent to the next healthy
+// instance when they fail with a connection error, or a 429 or 5xx response.
+// Streams run on a single instance and don't fail over: set the CursorStore of
+// the request so that they resume from another instance once restarted.
+//
+// Transactions are submitted to one pinned instance, the first healthy one,
+// so that the sequence numbers of an account are consumed by a single
+// Horizon. When a submission fails with a connection error, or a 429 or 5xx
+// response such as a timeout, the transaction may still be applied, so it is
+// looked up by hash on another healthy instance, which becomes the pinned one.
+// A transaction still pending on the failed instance is not found yet, so the
+// lookup is repeated until the latest ledger of the other instance closed more
+// than SubmissionMargin after the submission. The transaction is only
+// submitted to that instance when it still isn't found then, and the original
+// error is returned when the lookup fails or the instance doesn't ingest
+// ledgers in time. Submitting the same envelope again is safe: it can only be
+// applied once.
+type FailoverClient struct {
+	// Routing chooses the instance serving each read.
+	Routing Routing
+	// MaxLedgerLag is the number of ledgers an instance may lag behind the
+	// network before it is unhealthy. DefaultMaxLedgerLag is used when it is
+	// 0.
+	MaxLedgerLag int32
+	// CheckInterval is the interval between the health checks of Run.
+	// DefaultCheckInterval is used when it is 0.
+	CheckInterval time.Duration
+	// SubmissionMargin is the time a transaction may take to be applied
+	// after its submission failed. DefaultSubmissionMargin is used when it
+	// is 0.
+	SubmissionMargin time.Duration
+
+	nodes []*failoverNode
+	// next is the index of the instance serving the next round robin read.
+	// It is accessed atomically.
+	next uint32
+
+	mutex sync.Mutex
+	// pinned is the instance transactions are submitted to.
+	pinned     *failoverNode
+	passphrase string
+
+	// clock is a Clock returning the current time.
+	clock *clock.Clock
+	// sleep pauses the client between the lookups of a transaction. time.Sleep
+	// is used when it is nil.
+	sleep func(time.Duration)
+}
+
+// failoverNode holds the health of an instance. Its fields other than client
+// are guarded by the mutex of the FailoverClient.
+type failoverNode struct {
+	client        ClientInterface
+	healthy       bool
+	latency       time.Duration
+	historyLedger int32
+	coreLedger    int32
+	err           error
+}
+
+// InstanceHealth is the health of a Horizon instance of a FailoverClient.
+type InstanceHealth struct {
+	Client  ClientInterface
+	Healthy bool
+	// Latency is the time the last health check took.
+	Latency             time.Duration
+	HistoryLatestLedger int32
+	CoreLatestLedger    int32
+	// Err is the reason the instance is unhealthy.
+	Err error
+}
+
+// NewFailoverClient returns a FailoverClient over clients, each of them
+// connected to a Horizon instance of the same network. The instances are
+// considered healthy until they are checked.
+func NewFailoverClient(clients ...ClientInterface) *FailoverClient {
+	f := &FailoverClient{}
+	for _, client := range clients {
+		f.nodes = append(f.nodes, &failoverNode{client: client, healthy: true})
+	}
+	return f
+}
+
+// Run checks the health of the instances every CheckInterval until ctx is
+// done.
+func (f *FailoverClient) Run(ctx context.Context) {
+	interval := f.CheckInterval
+	if interval == 0 {
+		interval = DefaultCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		f.CheckHealth()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth loads the root endpoint of every instance, concurrently, and
+// updates their health.
+func (f *FailoverClient) CheckHealth() {
+	roots := make([]hProtocol.Root, len(f.nodes))
+	latencies := make([]time.Duration, len(f.nodes))
+	errs := make([]error, len(f.nodes))
+
+	var wg sync.WaitGroup
+	for i, node := range f.nodes {
+		wg.Add(1)
+		go func(i int, client ClientInterface) {
+			defer wg.Done()
+			start := time.Now()
+			roots[i], errs[i] = client.Root()
+			latencies[i] = time.Since(start)
+		}(i, node.client)
+	}
+	wg.Wait()
+
+	// The latest ledger of the network is the latest ledger known to any of
+	// the instances.
+	var networkLedger int32
+	for i := range f.nodes {
+		if errs[i] == nil && roots[i].CoreSequence > networkLedger {
+			networkLedger = roots[i].CoreSequence
+		}
+	}
+	maxLag := f.MaxLedgerLag
+	if maxLag == 0 {
+		maxLag = DefaultMaxLedgerLag
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for i, node := range f.nodes {
+		node.latency = latencies[i]
+		node.err = errs[i]
+		if errs[i] != nil {
+			node.healthy = false
+			continue
+		}
+
+		node.historyLedger = roots[i].HorizonSequence
+		node.coreLedger = roots[i].CoreSequence
+		if lag := networkLedger - node.historyLedger; lag > maxLag {
+			node.err = errors.Errorf("history_latest_ledger %d lags %d ledgers behind the network", node.historyLedger, lag)
+		}
+		node.healthy = node.err == nil
+		if f.passphrase == "" {
+			f.passphrase = roots[i].NetworkPassphrase
+		}
+	}
+}
+
+// Health returns the health of the instances, in the order of the clients
+// given to NewFailoverClient.
+func (f *FailoverClient) Health() []InstanceHealth {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	health := make([]InstanceHealth, len(f.nodes))
+	for i, node := range f.nodes {
+		health[i] = InstanceHealth{
+			Client:              node.client,
+			Healthy:             node.healthy,
+			Latency:             node.latency,
+			HistoryLatestLedger: node.historyLedger,
+			CoreLatestLedger:    node.coreLedger,
+			Err:                 node.err,
+		}
+	}
+	return health
+}
+
+// markUnhealthy records that a request to node failed.
+func (f *FailoverClient) markUnhealthy(node *failoverNode, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	node.healthy = false
+	node.err = err
+}
+
+// candidates returns the healthy instances, or every instance when none is
+// healthy, in the order reads are tried.
+func (f *FailoverClient) candidates() []*failoverNode {
+	f.mutex.Lock()
+	var nodes []*failoverNode
+	for _, node := range f.nodes {
+		if node.healthy {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		nodes = append(nodes, f.nodes...)
+	}
+	f.mutex.Unlock()
+
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	switch f.Routing {
+	case LowestLatency:
+		f.mutex.Lock()
+		sort.SliceStable(nodes, func(i, j int) bool {
+			return nodes[i].latency < nodes[j].latency
+		})
+		f.mutex.Unlock()
+	default:
+		start := int((atomic.AddUint32(&f.next, 1) - 1) % uint32(len(nodes)))
+		rotated := make([]*failoverNode, 0, len(nodes))
+		rotated = append(rotated, nodes[start:]...)
+		nodes = append(rotated, nodes[:start]...)
+	}
+	return nodes
+}
+
+// isInstanceFailure returns true for errors showing that an instance is not
+// able to serve requests, rather than errors about the request itself.
+func isInstanceFailure(err error) bool {
+	herr, ok := err.(*Error)
+	if !ok {
+		return true
+	}
+	return herr.Problem.Status == http.StatusTooManyRequests ||
+		herr.Problem.Status >= http.StatusInternalServerError
+}
+
+// read sends a read to the instances in turn, until one of them serves it.
+func (f *FailoverClient) read(request func(client ClientInterface) error) error {
+	err := ErrNoHorizon
+	for _, node := range f.candidates() {
+		err = request(node.client)
+		if err == nil || !isInstanceFailure(err) {
+			return err
+		}
+		f.markUnhealthy(node, err)
+	}
+	return err
+}
+
+// streamClient returns the client of the instance a stream runs on.
+func (f *FailoverClient) streamClient() (ClientInterface, error) {
+	nodes := f.candidates()
+	if len(nodes) == 0 {
+		return nil, ErrNoHorizon
+	}
+	return nodes[0].client, nil
+}
+
+// submissionNode returns the pinned instance, pinning the first healthy
+// instance, other than exclude, when the pinned one is unhealthy.
+func (f *FailoverClient) submissionNode(exclude *failoverNode) *failoverNode {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.pinned != nil && f.pinned != exclude && f.pinned.healthy {
+		return f.pinned
+	}
+	for _, node := range f.nodes {
+		if node != exclude && node.healthy {
+			f.pinned = node
+			return node
+		}
+	}
+	if exclude == nil && len(f.nodes) > 0 {
+		// Without any healthy instance, the first one is tried.
+		f.pinned = f.nodes[0]
+		return f.pinned
+	}
+	return nil
+}
+
+// networkPassphrase returns the passphrase of the network of the instances,
+// loading it from node when no health check has loaded it yet.
+func (f *FailoverClient) networkPassphrase(node *failoverNode) (string, error) {
+	f.mutex.Lock()
+	passphrase := f.passphrase
+	f.mutex.Unlock()
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	root, err := node.client.Root()
+	if err != nil {
+		return "", errors.Wrap(err, "loading network passphrase")
+	}
+	f.mutex.Lock()
+	f.passphrase = root.NetworkPassphrase
+	f.mutex.Unlock()
+	return root.NetworkPassphrase, nil
+}
+
+// SubmitTransactionXDR submits a transaction to the pinned instance, see
+// FailoverClient.
+func (f *FailoverClient) SubmitTransactionXDR(transactionXdr string) (txSuccess hProtocol.TransactionSuccess, err error) {
+	node := f.submissionNode(nil)
+	if node == nil {
+		return txSuccess, ErrNoHorizon
+	}
+	submitted := f.clock.Now()
+	txSuccess, err = node.client.SubmitTransactionXDR(transactionXdr)
+	if err == nil || !isInstanceFailure(err) {
+		return txSuccess, err
+	}
+	f.markUnhealthy(node, err)
+
+	other := f.submissionNode(node)
+	if other == nil {
+		return txSuccess, err
+	}
+	passphrase, lookupErr := f.networkPassphrase(other)
+	if lookupErr != nil {
+		return txSuccess, err
+	}
+	margin := f.SubmissionMargin
+	if margin == 0 {
+		margin = DefaultSubmissionMargin
+	}
+	settled := submitted.Add(margin)
+	for {
+		tx, found, lookupErr := lookupTransaction(other.client, passphrase, transactionXdr)
+		if lookupErr != nil {
+			// Without knowing whether the transaction was applied it can't
+			// be submitted again.
+			return txSuccess, err
+		}
+		if found {
+			err = submittedTransactionResult(tx, &txSuccess)
+			return txSuccess, err
+		}
+
+		closedAt, lookupErr := latestLedgerCloseTime(other.client)
+		if lookupErr != nil {
+			return txSuccess, err
+		}
+		if closedAt.After(settled) {
+			break
+		}
+		if f.clock.Now().After(settled.Add(margin)) {
+			// The instance doesn't ingest ledgers, so the transaction may
+			// still be pending.
+			return txSuccess, err
+		}
+		f.pause(submissionPollInterval)
+	}
+	return other.client.SubmitTransactionXDR(transactionXdr)
+}
+
+// latestLedgerCloseTime returns the close time of the latest ledger ingested
+// by the Horizon instance of client.
+func latestLedgerCloseTime(client ClientInterface) (time.Time, error) {
+	root, err := client.Root()
+	if err != nil {
+		return time.Time{}, err
+	}
+	ledger, err := client.LedgerDetail(uint32(root.HorizonSequence))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return ledger.ClosedAt, nil
+}
+
+func (f *FailoverClient) pause(delay time.Duration) {
+	if f.sleep != nil {
+		f.sleep(delay)
+		return
+	}
+	time.Sleep(delay)
+}
+
+// SubmitTransaction submits a transaction to the pinned instance, see
+// FailoverClient.
+func (f *FailoverClient) SubmitTransaction(transaction txnbuild.Transaction) (txSuccess hProtocol.TransactionSuccess, err error) {
+	txeBase64, err := transaction.Base64()
+	if err != nil {
+		err = errors.Wrap(err, "Unable to convert transaction object to base64 string")
+		return
+	}
+	return f.SubmitTransactionXDR(txeBase64)
+}
+
+// SubmitFeeBumpTransaction submits a fee bump transaction to the pinned
+// instance, see FailoverClient.
+func (f *FailoverClient) SubmitFeeBumpTransaction(transaction txnbuild.FeeBumpTransaction) (txSuccess hProtocol.TransactionSuccess, err error) {
+	txeBase64, err := transaction.Base64()
+	if err != nil {
+		err = errors.Wrap(err, "Unable to convert fee bump transaction object to base64 string")
+		return
+	}
+	return f.SubmitTransactionXDR(txeBase64)
+}
+
+// SubmitTransactionXDRAsync submits a transaction to the pinned instance,
+// without waiting for it to be included in a ledger, see FailoverClient. When
+// the submission fails with a connection error, or a 429 or 5xx response, it
+// is sent to another healthy instance, which becomes the pinned one.
+func (f *FailoverClient) SubmitTransactionXDRAsync(transactionXdr string) (resp hProtocol.AsyncTransactionSubmissionResponse, err error) {
+	node := f.submissionNode(nil)
+	if node == nil {
+		return resp, ErrNoHorizon
+	}
+	resp, err = node.client.SubmitTransactionXDRAsync(transactionXdr)
+	if err == nil || !isInstanceFailure(err) {
+		return resp, err
+	}
+	f.markUnhealthy(node, err)
+
+	other := f.submissionNode(node)
+	if other == nil {
+		return resp, err
+	}
+	return other.client.SubmitTransactionXDRAsync(transactionXdr)
+}
+
+// SubmitTransactionAsync submits a transaction to the pinned instance, without
+// waiting for it to be included in a ledger, see FailoverClient.
+func (f *FailoverClient) SubmitTransactionAsync(transaction txnbuild.Transaction) (resp hProtocol.AsyncTransactionSubmissionResponse, err error) {
+	txeBase64, err := transaction.Base64()
+	if err != nil {
+		err = errors.Wrap(err, "Unable to convert transaction object to base64 string")
+		return
+	}
+	return f.SubmitTransactionXDRAsync(txeBase64)
+}
+
+// AsyncTransactionStatus loads the status of a transaction from the pinned
+// instance, the only one knowing which of the transactions it received are
+// PENDING.
+func (f *FailoverClient) AsyncTransactionStatus(txHash string) (status hProtocol.AsyncTransactionStatus, err error) {
+	node := f.submissionNode(nil)
+	if node == nil {
+		return status, ErrNoHorizon
+	}
+	status, err = node.client.AsyncTransactionStatus(txHash)
+	if err != nil && isInstanceFailure(err) {
+		f.markUnhealthy(node, err)
+	}
+	return status, err
+}
+
+// Root loads the root endpoint of a healthy Horizon instance.
+func (f *FailoverClient) Root() (root hProtocol.Root, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		root, err = client.Root()
+		return
+	})
+	return
+}
+
+// AccountDetail is routed to a healthy Horizon instance.
+func (f *FailoverClient) AccountDetail(request AccountRequest) (result hProtocol.Account, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.AccountDetail(request)
+		return
+	})
+	return
+}
+
+// AccountData is routed to a healthy Horizon instance.
+func (f *FailoverClient) AccountData(request AccountRequest) (result hProtocol.AccountData, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.AccountData(request)
+		return
+	})
+	return
+}
+
+// Accounts is routed to a healthy Horizon instance.
+func (f *FailoverClient) Accounts(request AccountsRequest) (result hProtocol.AccountsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Accounts(request)
+		return
+	})
+	return
+}
+
+// Effects is routed to a healthy Horizon instance.
+func (f *FailoverClient) Effects(request EffectRequest) (result effects.EffectsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Effects(request)
+		return
+	})
+	return
+}
+
+// Assets is routed to a healthy Horizon instance.
+func (f *FailoverClient) Assets(request AssetRequest) (result hProtocol.AssetsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Assets(request)
+		return
+	})
+	return
+}
+
+// Ledgers is routed to a healthy Horizon instance.
+func (f *FailoverClient) Ledgers(request LedgerRequest) (result hProtocol.LedgersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Ledgers(request)
+		return
+	})
+	return
+}
+
+// LedgerDetail is routed to a healthy Horizon instance.
+func (f *FailoverClient) LedgerDetail(sequence uint32) (result hProtocol.Ledger, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.LedgerDetail(sequence)
+		return
+	})
+	return
+}
+
+// Metrics is routed to a healthy Horizon instance.
+func (f *FailoverClient) Metrics() (result hProtocol.Metrics, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Metrics()
+		return
+	})
+	return
+}
+
+// FeeStats is routed to a healthy Horizon instance.
+func (f *FailoverClient) FeeStats() (result hProtocol.FeeStats, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.FeeStats()
+		return
+	})
+	return
+}
+
+// Offers is routed to a healthy Horizon instance.
+func (f *FailoverClient) Offers(request OfferRequest) (result hProtocol.OffersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Offers(request)
+		return
+	})
+	return
+}
+
+// OfferDetails is routed to a healthy Horizon instance.
+func (f *FailoverClient) OfferDetails(offerID string) (result hProtocol.Offer, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.OfferDetails(offerID)
+		return
+	})
+	return
+}
+
+// Operations is routed to a healthy Horizon instance.
+func (f *FailoverClient) Operations(request OperationRequest) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Operations(request)
+		return
+	})
+	return
+}
+
+// OperationDetail is routed to a healthy Horizon instance.
+func (f *FailoverClient) OperationDetail(id string) (result operations.Operation, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.OperationDetail(id)
+		return
+	})
+	return
+}
+
+// Transactions is routed to a healthy Horizon instance.
+func (f *FailoverClient) Transactions(request TransactionRequest) (result hProtocol.TransactionsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Transactions(request)
+		return
+	})
+	return
+}
+
+// TransactionDetail is routed to a healthy Horizon instance.
+func (f *FailoverClient) TransactionDetail(txHash string) (result hProtocol.Transaction, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.TransactionDetail(txHash)
+		return
+	})
+	return
+}
+
+// OrderBook is routed to a healthy Horizon instance.
+func (f *FailoverClient) OrderBook(request OrderBookRequest) (result hProtocol.OrderBookSummary, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.OrderBook(request)
+		return
+	})
+	return
+}
+
+// Paths is routed to a healthy Horizon instance.
+func (f *FailoverClient) Paths(request PathsRequest) (result hProtocol.PathsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Paths(request)
+		return
+	})
+	return
+}
+
+// StrictSendPaths is routed to a healthy Horizon instance.
+func (f *FailoverClient) StrictSendPaths(request StrictSendPathsRequest) (result hProtocol.PathsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.StrictSendPaths(request)
+		return
+	})
+	return
+}
+
+// Payments is routed to a healthy Horizon instance.
+func (f *FailoverClient) Payments(request OperationRequest) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Payments(request)
+		return
+	})
+	return
+}
+
+// TradeAggregations is routed to a healthy Horizon instance.
+func (f *FailoverClient) TradeAggregations(request TradeAggregationRequest) (result hProtocol.TradeAggregationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.TradeAggregations(request)
+		return
+	})
+	return
+}
+
+// Trades is routed to a healthy Horizon instance.
+func (f *FailoverClient) Trades(request TradeRequest) (result hProtocol.TradesPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Trades(request)
+		return
+	})
+	return
+}
+
+// Fund is routed to a healthy Horizon instance.
+func (f *FailoverClient) Fund(addr string) (result hProtocol.TransactionSuccess, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.Fund(addr)
+		return
+	})
+	return
+}
+
+// StreamAccount streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamAccount(ctx context.Context, request AccountRequest, handler AccountHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamAccount(ctx, request, handler)
+}
+
+// StreamTransactions streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamTransactions(ctx, request, handler)
+}
+
+// StreamTrades streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamTrades(ctx context.Context, request TradeRequest, handler TradeHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamTrades(ctx, request, handler)
+}
+
+// StreamEffects streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamEffects(ctx context.Context, request EffectRequest, handler EffectHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamEffects(ctx, request, handler)
+}
+
+// StreamOperations streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamOperations(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamOperations(ctx, request, handler)
+}
+
+// StreamPayments streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamPayments(ctx context.Context, request OperationRequest, handler OperationHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamPayments(ctx, request, handler)
+}
+
+// StreamOffers streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamOffers(ctx context.Context, request OfferRequest, handler OfferHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamOffers(ctx, request, handler)
+}
+
+// StreamLedgers streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamLedgers(ctx context.Context, request LedgerRequest, handler LedgerHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamLedgers(ctx, request, handler)
+}
+
+// StreamOrderBooks streams from a healthy Horizon instance, see FailoverClient.
+func (f *FailoverClient) StreamOrderBooks(ctx context.Context, request OrderBookRequest, handler OrderBookHandler) error {
+	client, err := f.streamClient()
+	if err != nil {
+		return err
+	}
+	return client.StreamOrderBooks(ctx, request, handler)
+}
+
+// NextAccountsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextAccountsPage(page hProtocol.AccountsPage) (result hProtocol.AccountsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextAccountsPage(page)
+		return
+	})
+	return
+}
+
+// PrevAccountsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevAccountsPage(page hProtocol.AccountsPage) (result hProtocol.AccountsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevAccountsPage(page)
+		return
+	})
+	return
+}
+
+// NextAssetsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextAssetsPage(page hProtocol.AssetsPage) (result hProtocol.AssetsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextAssetsPage(page)
+		return
+	})
+	return
+}
+
+// PrevAssetsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevAssetsPage(page hProtocol.AssetsPage) (result hProtocol.AssetsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevAssetsPage(page)
+		return
+	})
+	return
+}
+
+// NextLedgersPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextLedgersPage(page hProtocol.LedgersPage) (result hProtocol.LedgersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextLedgersPage(page)
+		return
+	})
+	return
+}
+
+// PrevLedgersPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevLedgersPage(page hProtocol.LedgersPage) (result hProtocol.LedgersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevLedgersPage(page)
+		return
+	})
+	return
+}
+
+// NextEffectsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextEffectsPage(page effects.EffectsPage) (result effects.EffectsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextEffectsPage(page)
+		return
+	})
+	return
+}
+
+// PrevEffectsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevEffectsPage(page effects.EffectsPage) (result effects.EffectsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevEffectsPage(page)
+		return
+	})
+	return
+}
+
+// NextTransactionsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextTransactionsPage(page hProtocol.TransactionsPage) (result hProtocol.TransactionsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextTransactionsPage(page)
+		return
+	})
+	return
+}
+
+// PrevTransactionsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevTransactionsPage(page hProtocol.TransactionsPage) (result hProtocol.TransactionsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevTransactionsPage(page)
+		return
+	})
+	return
+}
+
+// NextOperationsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextOperationsPage(page operations.OperationsPage) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextOperationsPage(page)
+		return
+	})
+	return
+}
+
+// PrevOperationsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevOperationsPage(page operations.OperationsPage) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevOperationsPage(page)
+		return
+	})
+	return
+}
+
+// NextPaymentsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextPaymentsPage(page operations.OperationsPage) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextPaymentsPage(page)
+		return
+	})
+	return
+}
+
+// PrevPaymentsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevPaymentsPage(page operations.OperationsPage) (result operations.OperationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevPaymentsPage(page)
+		return
+	})
+	return
+}
+
+// NextOffersPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextOffersPage(page hProtocol.OffersPage) (result hProtocol.OffersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextOffersPage(page)
+		return
+	})
+	return
+}
+
+// PrevOffersPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevOffersPage(page hProtocol.OffersPage) (result hProtocol.OffersPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevOffersPage(page)
+		return
+	})
+	return
+}
+
+// NextTradesPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextTradesPage(page hProtocol.TradesPage) (result hProtocol.TradesPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextTradesPage(page)
+		return
+	})
+	return
+}
+
+// PrevTradesPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevTradesPage(page hProtocol.TradesPage) (result hProtocol.TradesPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevTradesPage(page)
+		return
+	})
+	return
+}
+
+// HomeDomainForAccount is routed to a healthy Horizon instance.
+func (f *FailoverClient) HomeDomainForAccount(aid string) (result string, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.HomeDomainForAccount(aid)
+		return
+	})
+	return
+}
+
+// NextTradeAggregationsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) NextTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (result hProtocol.TradeAggregationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.NextTradeAggregationsPage(page)
+		return
+	})
+	return
+}
+
+// PrevTradeAggregationsPage is routed to a healthy Horizon instance.
+func (f *FailoverClient) PrevTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (result hProtocol.TradeAggregationsPage, err error) {
+	err = f.read(func(client ClientInterface) (err error) {
+		result, err = client.PrevTradeAggregationsPage(page)
+		return
+	})
+	return
+}
+
+// ensure that the failover client implements ClientInterface
+var _ ClientInterface = &FailoverClient{}
@@ -0,0 +1,242 @@
+package horizonclient
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/support/clock"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFailoverClient() (*FailoverClient, *httptest.Client) {
+	hmock := httptest.NewClient()
+	return NewFailoverClient(
+		&Client{HorizonURL: "https://a/", HTTP: hmock},
+		&Client{HorizonURL: "https://b/", HTTP: hmock},
+		&Client{HorizonURL: "https://c/", HTTP: hmock},
+	), hmock
+}
+
+func instanceRoot(historyLedger, coreLedger int) *http.Response {
+	return response(200, `{"history_latest_ledger": `+strconv.Itoa(historyLedger)+`, "core_latest_ledger": `+strconv.Itoa(coreLedger)+`, "network_passphrase": "`+network.TestNetworkPassphrase+`"}`, nil)
+}
+
+func TestFailoverCheckHealth(t *testing.T) {
+	client, hmock := newFailoverClient()
+	var a, b, c int
+	hmock.On("GET", "https://a/").Return(responses(&a, instanceRoot(100, 101)))
+	hmock.On("GET", "https://b/").Return(responses(&b, instanceRoot(90, 100)))
+	hmock.On("GET", "https://c/").Return(responses(&c, response(500, `{"status": 500}`, nil)))
+
+	client.CheckHealth()
+	health := client.Health()
+	require.Len(t, health, 3)
+
+	assert.True(t, health[0].Healthy)
+	assert.NoError(t, health[0].Err)
+	assert.Equal(t, int32(100), health[0].HistoryLatestLedger)
+	assert.Equal(t, int32(101), health[0].CoreLatestLedger)
+
+	assert.False(t, health[1].Healthy)
+	assert.EqualError(t, health[1].Err, "history_latest_ledger 90 lags 11 ledgers behind the network")
+
+	assert.False(t, health[2].Healthy)
+	assert.Error(t, health[2].Err)
+
+	// lagging instances are healthy again once they catch up
+	hmock.On("GET", "https://a/").Return(responses(&a, instanceRoot(100, 101)))
+	hmock.On("GET", "https://b/").Return(responses(&b, instanceRoot(98, 101)))
+	client.MaxLedgerLag = 3
+	client.CheckHealth()
+	assert.True(t, client.Health()[1].Healthy)
+}
+
+func TestFailoverReads(t *testing.T) {
+	client, hmock := newFailoverClient()
+	var a, b, c int
+	hmock.On("GET", "https://a/ledgers/1").Return(responses(&a, response(200, `{"sequence": 1}`, nil)))
+	hmock.On("GET", "https://b/ledgers/1").Return(responses(&b, response(200, `{"sequence": 1}`, nil)))
+	hmock.On("GET", "https://c/ledgers/1").Return(responses(&c, response(200, `{"sequence": 1}`, nil)))
+
+	// reads are spread round robin
+	for i := 0; i < 6; i++ {
+		_, err := client.LedgerDetail(1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{2, 2, 2}, []int{a, b, c})
+
+	// reads go to the instance with the lowest latency
+	client.Routing = LowestLatency
+	client.nodes[0].latency = 30 * time.Millisecond
+	client.nodes[1].latency = 10 * time.Millisecond
+	client.nodes[2].latency = 20 * time.Millisecond
+	a, b, c = 0, 0, 0
+	for i := 0; i < 3; i++ {
+		_, err := client.LedgerDetail(1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{0, 3, 0}, []int{a, b, c})
+
+	// failed reads are sent to the next instance, and the failing instance
+	// is skipped from then on
+	hmock.On("GET", "https://b/ledgers/1").Return(responses(&b, response(503, `{"status": 503}`, nil)))
+	a, b, c = 0, 0, 0
+	for i := 0; i < 2; i++ {
+		_, err := client.LedgerDetail(1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int{0, 1, 2}, []int{a, b, c})
+	assert.False(t, client.Health()[1].Healthy)
+
+	// errors about the request itself are not retried
+	hmock.On("GET", "https://c/ledgers/2").Return(responses(&c, response(404, `{"status": 404}`, nil)))
+	a, b, c = 0, 0, 0
+	_, err := client.LedgerDetail(2)
+	assert.Error(t, err)
+	assert.Equal(t, []int{0, 0, 1}, []int{a, b, c})
+	assert.True(t, client.Health()[2].Healthy)
+
+	_, err = NewFailoverClient().LedgerDetail(1)
+	assert.Equal(t, ErrNoHorizon, err)
+}
+
+// steppedSource is a clock source which is moved forward by sleeping.
+type steppedSource struct {
+	now time.Time
+}
+
+func (s *steppedSource) Now() time.Time {
+	return s.now
+}
+
+func (s *steppedSource) sleep(delay time.Duration) {
+	s.now = s.now.Add(delay)
+}
+
+func ledgerClosedAt(closedAt time.Time) *http.Response {
+	return response(200, `{"sequence": 100, "closed_at": "`+closedAt.Format(time.RFC3339)+`"}`, nil)
+}
+
+func TestFailoverSubmission(t *testing.T) {
+	client, hmock := newFailoverClient()
+	source := &steppedSource{now: time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)}
+	client.clock = &clock.Clock{Source: source}
+	client.sleep = source.sleep
+	hash := retryTxHash(t)
+	var submissionsA, submissionsB, lookups int
+	hmock.On("GET", "https://b/").Return(responses(&lookups, instanceRoot(100, 100)))
+	hmock.On("GET", "https://b/ledgers/100").Return(responses(&lookups, ledgerClosedAt(source.now.Add(time.Minute))))
+
+	// transactions are pinned to the first healthy instance
+	hmock.On("POST", "https://a/transactions").Return(responses(&submissionsA,
+		response(200, `{"hash": "`+hash+`", "ledger": 8}`, nil),
+	))
+	success, err := client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), success.Ledger)
+	assert.Equal(t, 1, submissionsA)
+
+	// a transaction which timed out is looked up on another instance, and
+	// submitted there when it is not found once that instance ingested the
+	// ledgers closed after the submission
+	submissionsA = 0
+	hmock.On("POST", "https://a/transactions").Return(responses(&submissionsA,
+		response(504, `{"type": "timeout", "status": 504}`, nil),
+	))
+	hmock.On("GET", "https://b/transactions/"+hash).Return(responses(&lookups,
+		response(404, `{"status": 404}`, nil),
+	))
+	hmock.On("POST", "https://b/transactions").Return(responses(&submissionsB,
+		response(200, `{"hash": "`+hash+`", "ledger": 9}`, nil),
+	))
+	success, err = client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(9), success.Ledger)
+	assert.Equal(t, 1, submissionsA)
+	assert.Equal(t, 1, submissionsB)
+
+	// b is now pinned
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, 1, submissionsA)
+	assert.Equal(t, 2, submissionsB)
+
+	// a transaction applied despite the failure is not submitted again
+	submissionsB = 0
+	hmock.On("POST", "https://b/transactions").Return(responses(&submissionsB, nil))
+	var submissionsC int
+	hmock.On("GET", "https://c/transactions/"+hash).Return(responses(&lookups,
+		response(200, `{"successful": true, "hash": "`+hash+`", "ledger": 10, "result_xdr": "result"}`, nil),
+	))
+	hmock.On("POST", "https://c/transactions").Return(responses(&submissionsC, nil))
+	success, err = client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), success.Ledger)
+	assert.Equal(t, "result", success.Result)
+	assert.Equal(t, 1, submissionsB)
+	assert.Equal(t, 0, submissionsC)
+
+	// transactions rejected by Horizon are not looked up
+	hmock.On("POST", "https://c/transactions").Return(responses(&submissionsC,
+		response(400, `{"type": "transaction_failed", "status": 400}`, nil),
+	))
+	lookups = 0
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	assert.Error(t, err)
+	assert.Equal(t, 1, submissionsC)
+	assert.Equal(t, 0, lookups)
+}
+
+func TestFailoverSubmissionPendingLookup(t *testing.T) {
+	start := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	hash := retryTxHash(t)
+	var submissionsB, lookups int
+	newClient := func(ledgers ...*http.Response) (*FailoverClient, *httptest.Client, *steppedSource) {
+		client, hmock := newFailoverClient()
+		source := &steppedSource{now: start}
+		client.clock = &clock.Clock{Source: source}
+		client.sleep = source.sleep
+		client.SubmissionMargin = 3 * time.Second
+		submissionsB, lookups = 0, 0
+		hmock.On("GET", "https://b/").Return(responses(new(int), instanceRoot(100, 100)))
+		hmock.On("POST", "https://a/transactions").Return(responses(new(int),
+			response(504, `{"type": "timeout", "status": 504}`, nil),
+		))
+		hmock.On("POST", "https://b/transactions").Return(responses(&submissionsB, nil))
+		hmock.On("GET", "https://b/transactions/"+hash).Return(responses(&lookups,
+			response(404, `{"status": 404}`, nil),
+			response(404, `{"status": 404}`, nil),
+			response(200, `{"successful": true, "hash": "`+hash+`", "ledger": 101}`, nil),
+		))
+		hmock.On("GET", "https://b/ledgers/100").Return(responses(new(int), ledgers...))
+		return client, hmock, source
+	}
+
+	// a transaction pending on the failed instance is found once it is
+	// applied, and is not submitted again
+	client, _, source := newClient(ledgerClosedAt(start))
+	success, err := client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(101), success.Ledger)
+	assert.Equal(t, 0, submissionsB)
+	assert.Equal(t, 3, lookups)
+	assert.Equal(t, start.Add(2*time.Second), source.now)
+
+	// the original error is returned when the instance doesn't ingest the
+	// ledgers closed after the submission
+	client, hmock, source := newClient(ledgerClosedAt(start.Add(-time.Minute)))
+	hmock.On("GET", "https://b/transactions/"+hash).Return(responses(&lookups,
+		response(404, `{"status": 404}`, nil),
+	))
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	require.Error(t, err)
+	assert.Equal(t, 504, err.(*Error).Problem.Status)
+	assert.Equal(t, 0, submissionsB)
+	assert.Equal(t, 8, lookups)
+	assert.Equal(t, start.Add(7*time.Second), source.now)
+}
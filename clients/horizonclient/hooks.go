@@ -0,0 +1,337 @@
+package horizonclient
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/paydex-core/paydex-go/support/log"
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// Hooks receives events about the requests and streams of a Client, for
+// instrumenting them. Events are delivered synchronously, from the goroutine
+// sending the request or running the stream, so hooks should return quickly.
+//
+// MetricsHooks and LogHooks are ready-made implementations, and MultiHooks
+// combines several of them.
+type Hooks interface {
+	// RequestStarted is called before a request is sent.
+	RequestStarted(info RequestInfo)
+	// RequestFinished is called once a request succeeds or fails, after its
+	// retries.
+	RequestFinished(info RequestInfo)
+	// StreamConnected is called when a stream first connects.
+	StreamConnected(info StreamInfo)
+	// StreamEvent is called once the handler of a stream returns for an
+	// event.
+	StreamEvent(info StreamInfo)
+	// StreamReconnected is called when a stream connects again, after the
+	// connection was closed.
+	StreamReconnected(info StreamInfo)
+	// StreamError is called when a stream stops with an error.
+	StreamError(info StreamInfo, err error)
+}
+
+// RequestInfo describes a request sent to Horizon.
+type RequestInfo struct {
+	// Method is the HTTP method of the request, GET or POST.
+	Method string
+	// URL is the full URL of the request.
+	URL string
+	// Route is the path of the URL with its parameters replaced by
+	// placeholders, such as /accounts/{account_id}/payments, for grouping
+	// requests.
+	Route string
+	// Started is the time at which the request started.
+	Started time.Time
+	// Status is the HTTP status of the last response, or 0 when no response
+	// was received. It is only set once the request finished.
+	Status int
+	// Duration is the time taken by the request, including its retries.
+	Duration time.Duration
+	// Bytes is the size of the body of the last response.
+	Bytes int64
+	// Retries is the number of times the request was retried.
+	Retries int
+	// Err is the error returned for the request.
+	Err error
+}
+
+// StreamInfo describes a stream from Horizon.
+type StreamInfo struct {
+	// URL is the URL the stream was started with.
+	URL string
+	// Route is the path of the URL with its parameters replaced by
+	// placeholders, see RequestInfo.
+	Route string
+	// Cursor is the cursor the stream connected with or, for events, the
+	// ID of the event.
+	Cursor string
+	// Reconnects is the number of times the stream reconnected.
+	Reconnects int
+}
+
+// requestStarted returns the description of a request to requestURL, and
+// notifies the hooks of the client.
+func (c *Client) requestStarted(method, requestURL string) RequestInfo {
+	info := RequestInfo{
+		Method:  strings.ToUpper(method),
+		URL:     requestURL,
+		Route:   route(requestURL),
+		Started: c.clock.Now(),
+	}
+	if c.Hooks != nil {
+		c.Hooks.RequestStarted(info)
+	}
+	return info
+}
+
+// requestFinished notifies the hooks of the client that a request finished
+// with the given result.
+func (c *Client) requestFinished(info RequestInfo, result requestResult, retries int, err error) {
+	if c.Hooks == nil {
+		return
+	}
+	info.Status = result.status
+	info.Bytes = result.bytes
+	info.Duration = c.clock.Now().Sub(info.Started)
+	info.Retries = retries
+	info.Err = err
+	c.Hooks.RequestFinished(info)
+}
+
+// routeParams maps the collections of Horizon to the placeholder of the
+// parameter following them in routes.
+var routeParams = map[string]string{
+	"accounts":     "{account_id}",
+	"data":         "{key}",
+	"ledgers":      "{ledger_id}",
+	"offers":       "{offer_id}",
+	"operations":   "{op_id}",
+	"transactions": "{tx_id}",
+}
+
+// route returns the path of requestURL with its parameters replaced by
+// placeholders.
+func route(requestURL string) string {
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "undefined"
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	param := false
+	for i := 1; i < len(segments); i++ {
+		placeholder, ok := routeParams[segments[i-1]]
+		if ok && !param && segments[i] != "" {
+			segments[i] = placeholder
+			param = true
+			continue
+		}
+		param = false
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// countingReader counts the bytes read from a response body.
+type countingReader struct {
+	io.ReadCloser
+	bytes int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// MultiHooks sends the events of a Client to several hooks, in order.
+type MultiHooks []Hooks
+
+// RequestStarted implements Hooks.
+func (m MultiHooks) RequestStarted(info RequestInfo) {
+	for _, hooks := range m {
+		hooks.RequestStarted(info)
+	}
+}
+
+// RequestFinished implements Hooks.
+func (m MultiHooks) RequestFinished(info RequestInfo) {
+	for _, hooks := range m {
+		hooks.RequestFinished(info)
+	}
+}
+
+// StreamConnected implements Hooks.
+func (m MultiHooks) StreamConnected(info StreamInfo) {
+	for _, hooks := range m {
+		hooks.StreamConnected(info)
+	}
+}
+
+// StreamEvent implements Hooks.
+func (m MultiHooks) StreamEvent(info StreamInfo) {
+	for _, hooks := range m {
+		hooks.StreamEvent(info)
+	}
+}
+
+// StreamReconnected implements Hooks.
+func (m MultiHooks) StreamReconnected(info StreamInfo) {
+	for _, hooks := range m {
+		hooks.StreamReconnected(info)
+	}
+}
+
+// StreamError implements Hooks.
+func (m MultiHooks) StreamError(info StreamInfo, err error) {
+	for _, hooks := range m {
+		hooks.StreamError(info, err)
+	}
+}
+
+// MetricsHooks records the requests and streams of a Client in a go-metrics
+// registry, such as the one of Horizon. The following metrics are
+// registered, with the prefix of the hooks:
+//
+//	requests.total         timer of every request
+//	requests.succeeded     meter of successful requests
+//	requests.failed        meter of failed requests
+//	requests.retries       meter of retries
+//	requests.bytes         counter of response bytes
+//	requests.<route>       timer of the requests of each method and route,
+//	                       such as "requests.GET /accounts/{account_id}"
+//	streams.connected      meter of stream connections
+//	streams.reconnected    meter of stream reconnections
+//	streams.events         meter of stream events
+//	streams.errors         meter of stream errors
+type MetricsHooks struct {
+	registry metrics.Registry
+	prefix   string
+}
+
+// NewMetricsHooks returns hooks registering metrics in registry, with names
+// starting with prefix followed by a dot when it is not empty.
+func NewMetricsHooks(registry metrics.Registry, prefix string) *MetricsHooks {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+	return &MetricsHooks{registry: registry, prefix: prefix}
+}
+
+func (m *MetricsHooks) timer(name string) metrics.Timer {
+	return metrics.GetOrRegisterTimer(m.prefix+name, m.registry)
+}
+
+func (m *MetricsHooks) meter(name string) metrics.Meter {
+	return metrics.GetOrRegisterMeter(m.prefix+name, m.registry)
+}
+
+// RequestStarted implements Hooks.
+func (m *MetricsHooks) RequestStarted(info RequestInfo) {}
+
+// RequestFinished implements Hooks.
+func (m *MetricsHooks) RequestFinished(info RequestInfo) {
+	m.timer("requests.total").Update(info.Duration)
+	m.timer("requests." + info.Method + " " + info.Route).Update(info.Duration)
+	if info.Err == nil {
+		m.meter("requests.succeeded").Mark(1)
+	} else {
+		m.meter("requests.failed").Mark(1)
+	}
+	m.meter("requests.retries").Mark(int64(info.Retries))
+	metrics.GetOrRegisterCounter(m.prefix+"requests.bytes", m.registry).Inc(info.Bytes)
+}
+
+// StreamConnected implements Hooks.
+func (m *MetricsHooks) StreamConnected(info StreamInfo) {
+	m.meter("streams.connected").Mark(1)
+}
+
+// StreamEvent implements Hooks.
+func (m *MetricsHooks) StreamEvent(info StreamInfo) {
+	m.meter("streams.events").Mark(1)
+}
+
+// StreamReconnected implements Hooks.
+func (m *MetricsHooks) StreamReconnected(info StreamInfo) {
+	m.meter("streams.reconnected").Mark(1)
+}
+
+// StreamError implements Hooks.
+func (m *MetricsHooks) StreamError(info StreamInfo, err error) {
+	m.meter("streams.errors").Mark(1)
+}
+
+// LogHooks logs the requests and streams of a Client. Finished requests,
+// stream connections and errors are logged at the info level, or warn level
+// for failures, and started requests and stream events at the debug level.
+type LogHooks struct {
+	Log *log.Entry
+}
+
+// NewLogHooks returns hooks logging to l, or to the default logger when l is
+// nil.
+func NewLogHooks(l *log.Entry) *LogHooks {
+	if l == nil {
+		l = log.DefaultLogger
+	}
+	return &LogHooks{Log: l}
+}
+
+func (l *LogHooks) streamLog(info StreamInfo) *log.Entry {
+	return l.Log.WithFields(log.F{
+		"url":        info.URL,
+		"route":      info.Route,
+		"cursor":     info.Cursor,
+		"reconnects": info.Reconnects,
+	})
+}
+
+// RequestStarted implements Hooks.
+func (l *LogHooks) RequestStarted(info RequestInfo) {
+	l.Log.WithFields(log.F{
+		"method": info.Method,
+		"url":    info.URL,
+		"route":  info.Route,
+	}).Debug("Starting horizon request")
+}
+
+// RequestFinished implements Hooks.
+func (l *LogHooks) RequestFinished(info RequestInfo) {
+	entry := l.Log.WithFields(log.F{
+		"method":   info.Method,
+		"url":      info.URL,
+		"route":    info.Route,
+		"status":   info.Status,
+		"duration": info.Duration.Seconds(),
+		"bytes":    info.Bytes,
+		"retries":  info.Retries,
+	})
+	if info.Err != nil {
+		entry.WithField("err", info.Err).Warn("Horizon request failed")
+		return
+	}
+	entry.Info("Finished horizon request")
+}
+
+// StreamConnected implements Hooks.
+func (l *LogHooks) StreamConnected(info StreamInfo) {
+	l.streamLog(info).Info("Horizon stream connected")
+}
+
+// StreamEvent implements Hooks.
+func (l *LogHooks) StreamEvent(info StreamInfo) {
+	l.streamLog(info).Debug("Horizon stream event")
+}
+
+// StreamReconnected implements Hooks.
+func (l *LogHooks) StreamReconnected(info StreamInfo) {
+	l.streamLog(info).Info("Horizon stream reconnected")
+}
+
+// StreamError implements Hooks.
+func (l *LogHooks) StreamError(info StreamInfo, err error) {
+	l.streamLog(info).WithField("err", err).Warn("Horizon stream failed")
+}
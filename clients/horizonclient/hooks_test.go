@@ -0,0 +1,198 @@
+package horizonclient
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/paydex-core/paydex-go/support/log"
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHooks records the events it receives.
+type recordingHooks struct {
+	started     []RequestInfo
+	finished    []RequestInfo
+	connected   []StreamInfo
+	events      []StreamInfo
+	reconnected []StreamInfo
+	errors      []error
+}
+
+func (h *recordingHooks) RequestStarted(info RequestInfo) {
+	h.started = append(h.started, info)
+}
+
+func (h *recordingHooks) RequestFinished(info RequestInfo) {
+	h.finished = append(h.finished, info)
+}
+
+func (h *recordingHooks) StreamConnected(info StreamInfo) {
+	h.connected = append(h.connected, info)
+}
+
+func (h *recordingHooks) StreamEvent(info StreamInfo) {
+	h.events = append(h.events, info)
+}
+
+func (h *recordingHooks) StreamReconnected(info StreamInfo) {
+	h.reconnected = append(h.reconnected, info)
+}
+
+func (h *recordingHooks) StreamError(info StreamInfo, err error) {
+	h.errors = append(h.errors, err)
+}
+
+func TestRoute(t *testing.T) {
+	for url, expected := range map[string]string{
+		"https://localhost/":          "/",
+		"https://localhost/ledgers/1": "/ledgers/{ledger_id}",
+		"https://localhost/accounts?signer=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU":    "/accounts",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments":  "/accounts/{account_id}/payments",
+		"https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/data/name": "/accounts/{account_id}/data/{key}",
+		"https://localhost/transactions/f08a4c2b/operations?cursor=now":                                 "/transactions/{tx_id}/operations",
+		"https://localhost/order_book?selling_asset_type=native":                                        "/order_book",
+		"https://localhost/horizon/ledgers/2/transactions":                                              "/horizon/ledgers/{ledger_id}/transactions",
+	} {
+		assert.Equal(t, expected, route(url), url)
+	}
+}
+
+func TestHooksRequests(t *testing.T) {
+	client, hmock, _ := newRetryClient()
+	hooks := &recordingHooks{}
+	client.Hooks = hooks
+	requests := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(responses(&requests,
+		response(503, `{"status": 503}`, nil),
+		response(200, `{"sequence": 1}`, nil),
+	))
+
+	_, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	require.Len(t, hooks.started, 1)
+	assert.Equal(t, "GET", hooks.started[0].Method)
+	assert.Equal(t, "https://localhost/ledgers/1", hooks.started[0].URL)
+	assert.Equal(t, "/ledgers/{ledger_id}", hooks.started[0].Route)
+
+	// a single event is sent for a request and its retries
+	require.Len(t, hooks.finished, 1)
+	finished := hooks.finished[0]
+	assert.Equal(t, 200, finished.Status)
+	assert.Equal(t, int64(len(`{"sequence": 1}`)), finished.Bytes)
+	assert.Equal(t, 1, finished.Retries)
+	assert.NoError(t, finished.Err)
+
+	hmock.On("GET", "https://localhost/ledgers/2").ReturnString(404, `{"status": 404}`)
+	_, err = client.LedgerDetail(2)
+	require.Error(t, err)
+	require.Len(t, hooks.finished, 2)
+	assert.Equal(t, 404, hooks.finished[1].Status)
+	assert.Equal(t, 0, hooks.finished[1].Retries)
+	assert.Equal(t, err, hooks.finished[1].Err)
+
+	// submissions are instrumented too
+	hmock.On("POST", "https://localhost/transactions?tx=AAAA").ReturnString(400, `{"status": 400}`)
+	_, err = client.SubmitTransactionXDR("AAAA")
+	require.Error(t, err)
+	require.Len(t, hooks.finished, 3)
+	assert.Equal(t, "POST", hooks.finished[2].Method)
+	assert.Equal(t, "/transactions", hooks.finished[2].Route)
+	assert.Equal(t, 400, hooks.finished[2].Status)
+}
+
+func TestHooksStreams(t *testing.T) {
+	hmock := httptest.NewClient()
+	hooks := &recordingHooks{}
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		Hooks:      hooks,
+	}
+	// every connection sends a single event before it is closed
+	hmock.On("GET", "https://localhost/ledgers?cursor=1").Return(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(strings.NewReader(ledgerStreamResponse)),
+		}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := 0
+	err := client.StreamLedgers(ctx, LedgerRequest{Cursor: "1"}, func(ledger hProtocol.Ledger) {
+		received++
+		if received == 2 {
+			cancel()
+		}
+	})
+	require.NoError(t, err)
+	require.Len(t, hooks.connected, 1)
+	assert.Equal(t, "/ledgers", hooks.connected[0].Route)
+	assert.Equal(t, "1", hooks.connected[0].Cursor)
+	require.Len(t, hooks.reconnected, 1)
+	assert.Equal(t, 1, hooks.reconnected[0].Reconnects)
+	assert.Len(t, hooks.events, 2)
+	assert.Len(t, hooks.errors, 0)
+
+	hmock.On("GET", "https://localhost/ledgers?cursor=now").ReturnString(500, `{"status": 500}`)
+	err = client.StreamLedgers(context.Background(), LedgerRequest{}, func(hProtocol.Ledger) {})
+	require.Error(t, err)
+	assert.Equal(t, []error{err}, hooks.errors)
+}
+
+func TestMetricsHooks(t *testing.T) {
+	registry := metrics.NewRegistry()
+	hooks := NewMetricsHooks(registry, "horizon")
+
+	hooks.RequestFinished(RequestInfo{Method: "GET", Route: "/ledgers/{ledger_id}", Bytes: 10, Retries: 2})
+	hooks.RequestFinished(RequestInfo{Method: "GET", Route: "/ledgers/{ledger_id}", Bytes: 5, Err: assert.AnError})
+	hooks.StreamConnected(StreamInfo{})
+	hooks.StreamEvent(StreamInfo{})
+	hooks.StreamEvent(StreamInfo{})
+	hooks.StreamReconnected(StreamInfo{})
+	hooks.StreamError(StreamInfo{}, assert.AnError)
+
+	assert.Equal(t, int64(2), registry.Get("horizon.requests.total").(metrics.Timer).Count())
+	assert.Equal(t, int64(2), registry.Get("horizon.requests.GET /ledgers/{ledger_id}").(metrics.Timer).Count())
+	assert.Equal(t, int64(1), registry.Get("horizon.requests.succeeded").(metrics.Meter).Count())
+	assert.Equal(t, int64(1), registry.Get("horizon.requests.failed").(metrics.Meter).Count())
+	assert.Equal(t, int64(2), registry.Get("horizon.requests.retries").(metrics.Meter).Count())
+	assert.Equal(t, int64(15), registry.Get("horizon.requests.bytes").(metrics.Counter).Count())
+	assert.Equal(t, int64(1), registry.Get("horizon.streams.connected").(metrics.Meter).Count())
+	assert.Equal(t, int64(2), registry.Get("horizon.streams.events").(metrics.Meter).Count())
+	assert.Equal(t, int64(1), registry.Get("horizon.streams.reconnected").(metrics.Meter).Count())
+	assert.Equal(t, int64(1), registry.Get("horizon.streams.errors").(metrics.Meter).Count())
+}
+
+func TestLogHooks(t *testing.T) {
+	logger := log.New()
+	hooks := NewLogHooks(logger)
+	done := logger.StartTest(logrus.InfoLevel)
+
+	hooks.RequestStarted(RequestInfo{Method: "GET", Route: "/ledgers"})
+	hooks.RequestFinished(RequestInfo{Method: "GET", Route: "/ledgers", Status: 200, Bytes: 10})
+	hooks.RequestFinished(RequestInfo{Method: "GET", Route: "/ledgers", Status: 500, Err: assert.AnError})
+	hooks.StreamEvent(StreamInfo{Route: "/ledgers"})
+	hooks.StreamReconnected(StreamInfo{Route: "/ledgers", Reconnects: 1})
+
+	// started requests and stream events are logged at the debug level
+	logged := done()
+	require.Len(t, logged, 3)
+	assert.Equal(t, logrus.InfoLevel, logged[0].Level)
+	assert.Equal(t, "Finished horizon request", logged[0].Message)
+	assert.Equal(t, "/ledgers", logged[0].Data["route"])
+	assert.Equal(t, 200, logged[0].Data["status"])
+	assert.Equal(t, int64(10), logged[0].Data["bytes"])
+	assert.Equal(t, logrus.WarnLevel, logged[1].Level)
+	assert.Equal(t, assert.AnError, logged[1].Data["err"])
+	assert.Equal(t, "Horizon stream reconnected", logged[2].Message)
+	assert.Equal(t, 1, logged[2].Data["reconnects"])
+}
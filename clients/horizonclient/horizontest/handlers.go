@@ -0,0 +1,466 @@
+package horizontest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+const (
+	defaultLimit = 10
+	maxLimit     = 200
+)
+
+// paymentTypes are the types of the operations listed by the payments
+// endpoints.
+var paymentTypes = map[string]bool{
+	"create_account":              true,
+	"payment":                     true,
+	"path_payment_strict_receive": true,
+	"path_payment_strict_send":    true,
+	"account_merge":               true,
+}
+
+// participantFields are the fields of operations holding the accounts
+// involved.
+var participantFields = []string{"source_account", "from", "to", "funder", "account", "into", "trustor", "trustee"}
+
+// collection identifies the records listed by an endpoint, such as the
+// payments of an account.
+type collection struct {
+	kind    string
+	account string
+	ledger  int32
+	tx      string
+}
+
+// ServeHTTP serves the Horizon API from the seeded state.
+func (h *Horizon) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p, ok := h.failure(r); ok {
+		problem.Render(r.Context(), w, p)
+		return
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/transactions" {
+		h.submit(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/":
+		h.root(w, r)
+	case parts[0] == "order_book" && len(parts) == 1:
+		h.orderBook(w, r)
+	case parts[0] == "accounts" && len(parts) == 2:
+		h.account(w, r, parts[1])
+	case parts[0] == "accounts" && len(parts) == 4 && parts[2] == "data":
+		h.accountData(w, r, parts[1], parts[3])
+	case parts[0] == "accounts" && len(parts) == 3:
+		h.collection(w, r, collection{kind: parts[2], account: parts[1]})
+	case parts[0] == "ledgers" && len(parts) == 1:
+		h.collection(w, r, collection{kind: "ledgers"})
+	case parts[0] == "ledgers" && len(parts) <= 3:
+		sequence, err := strconv.ParseInt(parts[1], 10, 32)
+		if err != nil {
+			problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("ledger_id", err))
+			return
+		}
+		if len(parts) == 3 {
+			h.collection(w, r, collection{kind: parts[2], ledger: int32(sequence)})
+		} else {
+			h.ledgerDetail(w, r, int32(sequence))
+		}
+	case parts[0] == "transactions" && len(parts) == 1:
+		h.collection(w, r, collection{kind: "transactions"})
+	case parts[0] == "transactions" && len(parts) == 2:
+		h.transactionDetail(w, r, parts[1])
+	case parts[0] == "transactions" && len(parts) == 3:
+		h.collection(w, r, collection{kind: parts[2], tx: parts[1]})
+	case (parts[0] == "operations" || parts[0] == "payments") && len(parts) == 1:
+		h.collection(w, r, collection{kind: parts[0]})
+	case parts[0] == "operations" && len(parts) == 2:
+		h.operationDetail(w, r, parts[1])
+	default:
+		problem.Render(r.Context(), w, problem.NotFound)
+	}
+}
+
+func (h *Horizon) root(w http.ResponseWriter, r *http.Request) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	root := hProtocol.Root{
+		HorizonVersion:         "horizontest",
+		HorizonSequence:        h.latestLedger(),
+		CoreSequence:           h.latestLedger(),
+		NetworkPassphrase:      h.passphrase,
+		CurrentProtocolVersion: 13,
+	}
+	if len(h.ledgers) > 0 {
+		root.HistoryElderSequence = h.ledgers[0].Sequence
+	}
+	hal.Render(w, root)
+}
+
+func (h *Horizon) account(w http.ResponseWriter, r *http.Request, accountID string) {
+	account, ok := h.Account(accountID)
+	if !ok {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, account)
+}
+
+func (h *Horizon) accountData(w http.ResponseWriter, r *http.Request, accountID, key string) {
+	account, ok := h.Account(accountID)
+	value, found := account.Data[key]
+	if !ok || !found {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, hProtocol.AccountData{Value: value})
+}
+
+func (h *Horizon) ledgerDetail(w http.ResponseWriter, r *http.Request, sequence int32) {
+	h.mutex.Lock()
+	ledger, ok := h.ledger(sequence)
+	h.mutex.Unlock()
+	if !ok {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, ledger)
+}
+
+func (h *Horizon) transactionDetail(w http.ResponseWriter, r *http.Request, hash string) {
+	h.mutex.Lock()
+	tx, ok := h.transaction(hash)
+	h.mutex.Unlock()
+	if !ok {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+	hal.Render(w, tx)
+}
+
+func (h *Horizon) operationDetail(w http.ResponseWriter, r *http.Request, id string) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for _, op := range h.operations {
+		if op.GetID() == id {
+			hal.Render(w, op)
+			return
+		}
+	}
+	problem.Render(r.Context(), w, problem.NotFound)
+}
+
+func (h *Horizon) transaction(hash string) (hProtocol.Transaction, bool) {
+	for _, tx := range h.transactions {
+		if tx.Hash == hash {
+			return tx, true
+		}
+	}
+	return hProtocol.Transaction{}, false
+}
+
+func (h *Horizon) orderBook(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	selling := hProtocol.Asset{
+		Type:   query.Get("selling_asset_type"),
+		Code:   query.Get("selling_asset_code"),
+		Issuer: query.Get("selling_asset_issuer"),
+	}
+	buying := hProtocol.Asset{
+		Type:   query.Get("buying_asset_type"),
+		Code:   query.Get("buying_asset_code"),
+		Issuer: query.Get("buying_asset_issuer"),
+	}
+	find := func() hProtocol.OrderBookSummary {
+		for _, book := range h.orderBooks {
+			if book.Selling == selling && book.Buying == buying {
+				return book
+			}
+		}
+		return hProtocol.OrderBookSummary{
+			Bids:    []hProtocol.PriceLevel{},
+			Asks:    []hProtocol.PriceLevel{},
+			Selling: selling,
+			Buying:  buying,
+		}
+	}
+
+	if !isStream(r) {
+		h.mutex.Lock()
+		book := find()
+		h.mutex.Unlock()
+		hal.Render(w, book)
+		return
+	}
+
+	// the order book is streamed every time it changes
+	flusher := startStream(w)
+	var last hProtocol.OrderBookSummary
+	for first := true; ; first = false {
+		h.mutex.Lock()
+		book, changed := find(), h.changed
+		h.mutex.Unlock()
+		if first || !reflect.DeepEqual(book, last) {
+			if err := writeEvent(w, "", book); err != nil {
+				return
+			}
+			flusher.Flush()
+			last = book
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-h.closed:
+			return
+		}
+	}
+}
+
+// collection serves the records of c as a page or, when requested, as a
+// stream.
+func (h *Horizon) collection(w http.ResponseWriter, r *http.Request, c collection) {
+	switch c.kind {
+	case "ledgers", "transactions", "operations", "payments":
+	default:
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	order := query.Get("order")
+	switch order {
+	case "":
+		order = "asc"
+	case "asc", "desc":
+	default:
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("order", fmt.Errorf("order must be asc or desc")))
+		return
+	}
+	limit := uint64(defaultLimit)
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 || limit > maxLimit {
+			problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("limit", fmt.Errorf("limit must be between 1 and %d", maxLimit)))
+			return
+		}
+	}
+	cursor := query.Get("cursor")
+	if cursor != "" && cursor != "now" {
+		if _, err := strconv.ParseInt(cursor, 10, 64); err != nil {
+			problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("cursor", err))
+			return
+		}
+	}
+	includeFailed := query.Get("include_failed") == "true"
+
+	if isStream(r) {
+		h.stream(w, r, c, cursor, includeFailed)
+		return
+	}
+
+	h.mutex.Lock()
+	if cursor == "now" {
+		cursor = h.nowCursor()
+	}
+	records := h.records(c, includeFailed)
+	h.mutex.Unlock()
+
+	page := hal.Page{
+		Order:  order,
+		Limit:  limit,
+		Cursor: query.Get("cursor"),
+	}
+	page.FullURL = &url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+	if order == "asc" {
+		for _, record := range records {
+			if uint64(len(page.Embedded.Records)) == limit {
+				break
+			}
+			if cursor == "" || pagingTokenLess(cursor, record.PagingToken()) {
+				page.Add(record)
+			}
+		}
+	} else {
+		for i := len(records) - 1; i >= 0; i-- {
+			if uint64(len(page.Embedded.Records)) == limit {
+				break
+			}
+			if cursor == "" || pagingTokenLess(records[i].PagingToken(), cursor) {
+				page.Add(records[i])
+			}
+		}
+	}
+	page.PopulateLinks()
+	hal.Render(w, page)
+}
+
+// stream streams the records of c following cursor, as they are added.
+func (h *Horizon) stream(w http.ResponseWriter, r *http.Request, c collection, cursor string, includeFailed bool) {
+	flusher := startStream(w)
+	for {
+		h.mutex.Lock()
+		if cursor == "now" {
+			cursor = h.nowCursor()
+		}
+		records, changed := h.records(c, includeFailed), h.changed
+		h.mutex.Unlock()
+
+		for _, record := range records {
+			if cursor != "" && !pagingTokenLess(cursor, record.PagingToken()) {
+				continue
+			}
+			if err := writeEvent(w, record.PagingToken(), record); err != nil {
+				return
+			}
+			cursor = record.PagingToken()
+		}
+		flusher.Flush()
+
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-h.closed:
+			return
+		}
+	}
+}
+
+// nowCursor returns the cursor following the records of the latest ledger.
+// It must be called with the mutex held.
+func (h *Horizon) nowCursor() string {
+	return strconv.FormatInt(toid(h.latestLedger()+1, 0, 0)-1, 10)
+}
+
+// records returns the records of c, sorted by paging token. It must be called
+// with the mutex held.
+func (h *Horizon) records(c collection, includeFailed bool) []hal.Pageable {
+	var records []hal.Pageable
+	switch c.kind {
+	case "ledgers":
+		for _, ledger := range h.ledgers {
+			records = append(records, ledger)
+		}
+	case "transactions":
+		for _, tx := range h.transactions {
+			if !includeFailed && !tx.Successful {
+				continue
+			}
+			if c.ledger != 0 && tx.Ledger != c.ledger {
+				continue
+			}
+			if c.tx != "" && tx.Hash != c.tx {
+				continue
+			}
+			if c.account != "" && !h.transactionInvolves(tx, c.account) {
+				continue
+			}
+			records = append(records, tx)
+		}
+	case "operations", "payments":
+		for _, op := range h.operations {
+			if !includeFailed && !op.IsTransactionSuccessful() {
+				continue
+			}
+			if c.kind == "payments" && !paymentTypes[op.GetType()] {
+				continue
+			}
+			if c.ledger != 0 && ledgerOf(op.PagingToken()) != c.ledger {
+				continue
+			}
+			if c.tx != "" && op.GetTransactionHash() != c.tx {
+				continue
+			}
+			if c.account != "" && !involves(op, c.account) {
+				continue
+			}
+			records = append(records, op)
+		}
+	}
+	return records
+}
+
+func (h *Horizon) transactionInvolves(tx hProtocol.Transaction, accountID string) bool {
+	if tx.Account == accountID || tx.FeeAccount == accountID {
+		return true
+	}
+	for _, op := range h.operations {
+		if op.GetTransactionHash() == tx.Hash && involves(op, accountID) {
+			return true
+		}
+	}
+	return false
+}
+
+// involves returns true when accountID participates in op.
+func involves(op operations.Operation, accountID string) bool {
+	data, err := json.Marshal(op)
+	if err != nil {
+		return false
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return false
+	}
+	for _, field := range participantFields {
+		if fields[field] == accountID {
+			return true
+		}
+	}
+	return false
+}
+
+func ledgerOf(pagingToken string) int32 {
+	id, _ := strconv.ParseInt(pagingToken, 10, 64)
+	return int32(id >> 32)
+}
+
+func isStream(r *http.Request) bool {
+	return r.Header.Get("Accept") == "text/event-stream"
+}
+
+// startStream starts an SSE response the way Horizon does.
+func startStream(w http.ResponseWriter) http.Flusher {
+	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, "retry: 1000\nevent: open\ndata: \"hello\"\n\n")
+	flusher := w.(http.Flusher)
+	flusher.Flush()
+	return flusher
+}
+
+// writeEvent writes data as an SSE message with the given ID.
+func writeEvent(w http.ResponseWriter, id string, data interface{}) error {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	if id != "" {
+		if _, err = fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", js)
+	return err
+}
@@ -0,0 +1,337 @@
+// Package horizontest provides an in-process fake Horizon server for the
+// integration tests of code talking to Horizon over HTTP, such as
+// horizonclient or wrappers around it.
+//
+// The fake serves the accounts, ledgers, transactions, operations and order
+// books it is seeded with, as pages and as SSE streams. Transactions posted to
+// /transactions are validated against the seeded accounts, and their payments
+// are applied to the balances of the accounts. Signatures are not verified.
+//
+//	server := horizontest.NewServer(t, network.TestNetworkPassphrase)
+//	defer server.Close()
+//	server.AddAccount(hProtocol.Account{AccountID: "G...", Sequence: "1", Balances: ...})
+//	client := &horizonclient.Client{HorizonURL: server.URL}
+//
+// Failures, such as rate limiting or timeouts, are injected with FailNext.
+package horizontest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// DefaultBaseFee is the fee, in stroops, charged by the fake for every
+// operation of the transactions submitted.
+const DefaultBaseFee = 100
+
+var (
+	// RateLimitExceeded is the problem returned by Horizon when a client
+	// exceeds its rate limit.
+	RateLimitExceeded = problem.P{
+		Type:   "rate_limit_exceeded",
+		Title:  "Rate Limit Exceeded",
+		Status: http.StatusTooManyRequests,
+		Detail: "The rate limit for the requesting IP address is over its alloted " +
+			"limit.",
+	}
+
+	// Timeout is the problem returned by Horizon when a request, such as a
+	// transaction submission, times out.
+	Timeout = problem.P{
+		Type:   "timeout",
+		Title:  "Timeout",
+		Status: http.StatusGatewayTimeout,
+		Detail: "Your request timed out before completing.  Please try your " +
+			"request again.",
+	}
+)
+
+// TransactionFailed returns the problem returned by Horizon when a transaction
+// fails with the given result codes, such as "tx_bad_seq".
+func TransactionFailed(transactionCode string, operationCodes ...string) problem.P {
+	return problem.P{
+		Type:   "transaction_failed",
+		Title:  "Transaction Failed",
+		Status: http.StatusBadRequest,
+		Detail: "The transaction failed when submitted to the paydex network.",
+		Extras: map[string]interface{}{
+			"result_codes": hProtocol.TransactionResultCodes{
+				TransactionCode: transactionCode,
+				OperationCodes:  operationCodes,
+			},
+		},
+	}
+}
+
+// Horizon is a fake Horizon server. It is an http.Handler, see NewServer to
+// run it.
+type Horizon struct {
+	// BaseFee is the fee charged for every operation of the transactions
+	// submitted.
+	BaseFee int64
+
+	passphrase   string
+	mutex        sync.Mutex
+	accounts     map[string]hProtocol.Account
+	ledgers      []hProtocol.Ledger
+	transactions []hProtocol.Transaction
+	operations   []operations.Operation
+	orderBooks   []hProtocol.OrderBookSummary
+	failures     []*failure
+	// changed is closed, and replaced, when records are added
+	changed chan struct{}
+	closed  chan struct{}
+	once    sync.Once
+}
+
+// failure is a problem returned for the next requests to a path.
+type failure struct {
+	method string
+	path   string
+	times  int
+	p      problem.P
+}
+
+// New returns a fake Horizon server for the network with the given
+// passphrase.
+func New(passphrase string) *Horizon {
+	return &Horizon{
+		BaseFee:    DefaultBaseFee,
+		passphrase: passphrase,
+		accounts:   map[string]hProtocol.Account{},
+		changed:    make(chan struct{}),
+		closed:     make(chan struct{}),
+	}
+}
+
+// Close ends the streams served by the fake.
+func (h *Horizon) Close() {
+	h.once.Do(func() {
+		close(h.closed)
+	})
+}
+
+// AddAccount seeds an account, replacing any account with the same ID. The
+// sequence and balances of the account are updated by the transactions
+// submitted.
+func (h *Horizon) AddAccount(account hProtocol.Account) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if account.ID == "" {
+		account.ID = account.AccountID
+	}
+	if account.PT == "" {
+		account.PT = account.AccountID
+	}
+	h.accounts[account.AccountID] = account
+	h.notify()
+}
+
+// Account returns the current state of an account.
+func (h *Horizon) Account(accountID string) (hProtocol.Account, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	account, ok := h.accounts[accountID]
+	return account, ok
+}
+
+// AddLedger seeds a ledger. Its ID, hash and paging token are set from its
+// sequence when empty.
+func (h *Horizon) AddLedger(ledger hProtocol.Ledger) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.addLedger(ledger)
+	h.notify()
+}
+
+func (h *Horizon) addLedger(ledger hProtocol.Ledger) {
+	if ledger.Hash == "" {
+		hash := sha256.Sum256([]byte(strconv.Itoa(int(ledger.Sequence))))
+		ledger.Hash = hex.EncodeToString(hash[:])
+	}
+	if ledger.ID == "" {
+		ledger.ID = ledger.Hash
+	}
+	if ledger.PT == "" {
+		ledger.PT = strconv.FormatInt(toid(ledger.Sequence, 0, 0), 10)
+	}
+	h.ledgers = append(h.ledgers, ledger)
+	sort.Slice(h.ledgers, func(i, j int) bool {
+		return h.ledgers[i].Sequence < h.ledgers[j].Sequence
+	})
+}
+
+// AddTransaction seeds a transaction. Its ID is set from its hash and its
+// paging token from its ledger when empty, and the ledger is added when it is
+// missing.
+func (h *Horizon) AddTransaction(tx hProtocol.Transaction) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.addTransaction(tx)
+	h.notify()
+}
+
+func (h *Horizon) addTransaction(tx hProtocol.Transaction) {
+	if tx.ID == "" {
+		tx.ID = tx.Hash
+	}
+	if tx.PT == "" {
+		order := 1
+		for _, other := range h.transactions {
+			if other.Ledger == tx.Ledger {
+				order++
+			}
+		}
+		tx.PT = strconv.FormatInt(toid(tx.Ledger, order, 0), 10)
+	}
+	if _, ok := h.ledger(tx.Ledger); !ok {
+		h.addLedger(hProtocol.Ledger{Sequence: tx.Ledger, ClosedAt: tx.LedgerCloseTime})
+	}
+	h.transactions = append(h.transactions, tx)
+	sort.SliceStable(h.transactions, func(i, j int) bool {
+		return pagingTokenLess(h.transactions[i].PT, h.transactions[j].PT)
+	})
+}
+
+// AddOperation seeds an operation. Its paging token must be set, see
+// OperationID.
+func (h *Horizon) AddOperation(op operations.Operation) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.addOperation(op)
+	h.notify()
+}
+
+func (h *Horizon) addOperation(op operations.Operation) {
+	h.operations = append(h.operations, op)
+	sort.SliceStable(h.operations, func(i, j int) bool {
+		return pagingTokenLess(h.operations[i].PagingToken(), h.operations[j].PagingToken())
+	})
+}
+
+// SetOrderBook seeds the order book between the selling and buying assets of
+// summary, replacing the previous one.
+func (h *Horizon) SetOrderBook(summary hProtocol.OrderBookSummary) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for i, book := range h.orderBooks {
+		if book.Selling == summary.Selling && book.Buying == summary.Buying {
+			h.orderBooks[i] = summary
+			h.notify()
+			return
+		}
+	}
+	h.orderBooks = append(h.orderBooks, summary)
+	h.notify()
+}
+
+// FailNext makes the next requests with the given method and path, such as
+// "POST" and "/transactions", fail with p. The failure applies to the given
+// number of requests.
+func (h *Horizon) FailNext(method, path string, times int, p problem.P) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.failures = append(h.failures, &failure{method: method, path: path, times: times, p: p})
+}
+
+// failure returns the failure injected for r, if any.
+func (h *Horizon) failure(r *http.Request) (problem.P, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for i, f := range h.failures {
+		if f.method != r.Method || f.path != r.URL.Path {
+			continue
+		}
+		f.times--
+		if f.times <= 0 {
+			h.failures = append(h.failures[:i], h.failures[i+1:]...)
+		}
+		return f.p, true
+	}
+	return problem.P{}, false
+}
+
+// notify wakes up the streams waiting for new records. It must be called with
+// the mutex held.
+func (h *Horizon) notify() {
+	close(h.changed)
+	h.changed = make(chan struct{})
+}
+
+func (h *Horizon) ledger(sequence int32) (hProtocol.Ledger, bool) {
+	for _, ledger := range h.ledgers {
+		if ledger.Sequence == sequence {
+			return ledger, true
+		}
+	}
+	return hProtocol.Ledger{}, false
+}
+
+func (h *Horizon) latestLedger() int32 {
+	if len(h.ledgers) == 0 {
+		return 0
+	}
+	return h.ledgers[len(h.ledgers)-1].Sequence
+}
+
+// closeTime returns the close time of the next ledger.
+func (h *Horizon) closeTime() time.Time {
+	now := time.Now().UTC().Truncate(time.Second)
+	if len(h.ledgers) > 0 {
+		if last := h.ledgers[len(h.ledgers)-1].ClosedAt; !now.After(last) {
+			return last.Add(time.Second)
+		}
+	}
+	return now
+}
+
+// OperationID returns the ID, and paging token, of the operation at index op
+// of the transaction at index tx of a ledger. Indexes start at 1.
+func OperationID(ledger int32, tx, op int) string {
+	return strconv.FormatInt(toid(ledger, tx, op), 10)
+}
+
+func toid(ledger int32, tx, op int) int64 {
+	return int64(ledger)<<32 | int64(tx)<<12 | int64(op)
+}
+
+// pagingTokenLess compares two numeric paging tokens.
+func pagingTokenLess(a, b string) bool {
+	x, _ := strconv.ParseInt(a, 10, 64)
+	y, _ := strconv.ParseInt(b, 10, 64)
+	return x < y
+}
+
+// Server is a fake Horizon listening on a local address. The URL of the
+// server is the Horizon URL of the clients under test.
+type Server struct {
+	*Horizon
+	*httptest.Server
+}
+
+// NewServer starts a fake Horizon server for the network with the given
+// passphrase.
+func NewServer(t *testing.T, passphrase string) *Server {
+	horizon := New(passphrase)
+	return &Server{
+		Horizon: horizon,
+		Server:  httptest.NewServer(t, horizon),
+	}
+}
+
+// Close ends the streams served and shuts the server down.
+func (s *Server) Close() {
+	s.Horizon.Close()
+	s.Server.Close()
+}
@@ -0,0 +1,304 @@
+package horizontest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/paydex-core/paydex-go/clients/horizonclient"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/base"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = keypair.MustRandom()
+	bob   = keypair.MustRandom()
+)
+
+func newServer(t *testing.T) (*Server, *horizonclient.Client) {
+	server := NewServer(t, network.TestNetworkPassphrase)
+	server.AddLedger(hProtocol.Ledger{Sequence: 100, ClosedAt: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)})
+	for _, kp := range []*keypair.Full{alice, bob} {
+		server.AddAccount(hProtocol.Account{
+			AccountID: kp.Address(),
+			Sequence:  "429496729600",
+			Balances: []hProtocol.Balance{
+				{Balance: "100.0000000", Asset: base.Asset{Type: "native"}},
+			},
+		})
+	}
+	return server, &horizonclient.Client{HorizonURL: server.URL}
+}
+
+func payment(t *testing.T, client *horizonclient.Client, from *keypair.Full, amount string) string {
+	source, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: from.Address()})
+	require.NoError(t, err)
+	tx := txnbuild.Transaction{
+		SourceAccount: &source,
+		Operations: []txnbuild.Operation{&txnbuild.Payment{
+			Destination: bob.Address(),
+			Amount:      amount,
+			Asset:       txnbuild.NativeAsset{},
+		}},
+		Timebounds: txnbuild.NewInfiniteTimeout(),
+		Network:    network.TestNetworkPassphrase,
+	}
+	txe, err := tx.BuildSignEncode(from)
+	require.NoError(t, err)
+	return txe
+}
+
+func TestSeededState(t *testing.T) {
+	server, client := newServer(t)
+	defer server.Close()
+
+	server.AddTransaction(hProtocol.Transaction{Hash: "a1", Ledger: 100, Successful: true, Account: alice.Address()})
+	server.AddTransaction(hProtocol.Transaction{Hash: "a2", Ledger: 100, Successful: true, Account: bob.Address()})
+	server.AddTransaction(hProtocol.Transaction{Hash: "a3", Ledger: 101, Successful: false, Account: alice.Address()})
+	seeded := operations.Payment{From: alice.Address(), To: bob.Address(), Amount: "1.0000000"}
+	seeded.ID = OperationID(100, 1, 1)
+	seeded.PT = seeded.ID
+	seeded.Base.Type = "payment"
+	seeded.TransactionHash = "a1"
+	seeded.TransactionSuccessful = true
+	server.AddOperation(seeded)
+
+	root, err := client.Root()
+	require.NoError(t, err)
+	assert.Equal(t, int32(101), root.HorizonSequence)
+	assert.Equal(t, int32(100), root.HistoryElderSequence)
+	assert.Equal(t, network.TestNetworkPassphrase, root.NetworkPassphrase)
+
+	account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+	require.NoError(t, err)
+	assert.Equal(t, "429496729600", account.Sequence)
+
+	ledger, err := client.LedgerDetail(100)
+	require.NoError(t, err)
+	assert.Equal(t, OperationID(100, 0, 0), ledger.PT)
+
+	// failed transactions are only listed on request
+	txs, err := client.Transactions(horizonclient.TransactionRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, txs.Embedded.Records, 1)
+	assert.Equal(t, "a1", txs.Embedded.Records[0].Hash)
+	txs, err = client.NextTransactionsPage(txs)
+	require.NoError(t, err)
+	require.Len(t, txs.Embedded.Records, 1)
+	assert.Equal(t, "a2", txs.Embedded.Records[0].Hash)
+	txs, err = client.NextTransactionsPage(txs)
+	require.NoError(t, err)
+	assert.Len(t, txs.Embedded.Records, 0)
+
+	txs, err = client.Transactions(horizonclient.TransactionRequest{ForAccount: alice.Address(), IncludeFailed: true, Order: horizonclient.OrderDesc})
+	require.NoError(t, err)
+	require.Len(t, txs.Embedded.Records, 2)
+	assert.Equal(t, "a3", txs.Embedded.Records[0].Hash)
+	assert.Equal(t, "a1", txs.Embedded.Records[1].Hash)
+
+	payments, err := client.Payments(horizonclient.OperationRequest{ForAccount: bob.Address()})
+	require.NoError(t, err)
+	require.Len(t, payments.Embedded.Records, 1)
+	assert.Equal(t, seeded.ID, payments.Embedded.Records[0].GetID())
+
+	_, err = client.TransactionDetail("missing")
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusNotFound, err.(*horizonclient.Error).Problem.Status)
+	}
+
+	selling := hProtocol.Asset{Type: "native"}
+	buying := hProtocol.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: alice.Address()}
+	server.SetOrderBook(hProtocol.OrderBookSummary{
+		Bids:    []hProtocol.PriceLevel{{Price: "2.0000000", Amount: "10.0000000"}},
+		Selling: selling,
+		Buying:  buying,
+	})
+	book, err := client.OrderBook(horizonclient.OrderBookRequest{
+		SellingAssetType:  horizonclient.AssetTypeNative,
+		BuyingAssetType:   horizonclient.AssetType4,
+		BuyingAssetCode:   "USD",
+		BuyingAssetIssuer: alice.Address(),
+	})
+	require.NoError(t, err)
+	require.Len(t, book.Bids, 1)
+	assert.Equal(t, "2.0000000", book.Bids[0].Price)
+}
+
+func TestSubmitPayment(t *testing.T) {
+	server, client := newServer(t)
+	defer server.Close()
+
+	txe := payment(t, client, alice, "10")
+	success, err := client.SubmitTransactionXDR(txe)
+	require.NoError(t, err)
+	assert.Equal(t, int32(101), success.Ledger)
+
+	account, ok := server.Account(alice.Address())
+	require.True(t, ok)
+	assert.Equal(t, "89.9999900", account.Balances[0].Balance)
+	assert.Equal(t, "429496729601", account.Sequence)
+	account, ok = server.Account(bob.Address())
+	require.True(t, ok)
+	assert.Equal(t, "110.0000000", account.Balances[0].Balance)
+
+	tx, err := client.TransactionDetail(success.Hash)
+	require.NoError(t, err)
+	assert.True(t, tx.Successful)
+	assert.Equal(t, alice.Address(), tx.Account)
+	ops, err := client.Payments(horizonclient.OperationRequest{ForTransaction: success.Hash})
+	require.NoError(t, err)
+	require.Len(t, ops.Embedded.Records, 1)
+	assert.Equal(t, "10.0000000", ops.Embedded.Records[0].(operations.Payment).Amount)
+
+	// transactions submitted again are not applied twice
+	again, err := client.SubmitTransactionXDR(txe)
+	require.NoError(t, err)
+	assert.Equal(t, success.Ledger, again.Ledger)
+	account, _ = server.Account(bob.Address())
+	assert.Equal(t, "110.0000000", account.Balances[0].Balance)
+
+	// bad sequence numbers are rejected
+	stale := payment(t, client, alice, "2")
+	_, err = client.SubmitTransactionXDR(payment(t, client, alice, "1"))
+	require.NoError(t, err)
+	_, err = client.SubmitTransactionXDR(stale)
+	if assert.Error(t, err) {
+		codes, codesErr := err.(*horizonclient.Error).ResultCodes()
+		require.NoError(t, codesErr)
+		assert.Equal(t, "tx_bad_seq", codes.TransactionCode)
+	}
+
+	// failed payments consume the fee and the sequence number
+	_, err = client.SubmitTransactionXDR(payment(t, client, alice, "1000"))
+	if assert.Error(t, err) {
+		codes, codesErr := err.(*horizonclient.Error).ResultCodes()
+		require.NoError(t, codesErr)
+		assert.Equal(t, "tx_failed", codes.TransactionCode)
+		assert.Equal(t, []string{"op_underfunded"}, codes.OperationCodes)
+	}
+	account, _ = server.Account(alice.Address())
+	assert.Equal(t, "88.9999700", account.Balances[0].Balance)
+	assert.Equal(t, "429496729603", account.Sequence)
+}
+
+func TestStreams(t *testing.T) {
+	server, client := newServer(t)
+	defer server.Close()
+
+	// clients are not safe for concurrent use, the streams use their own
+	streamClient := &horizonclient.Client{HorizonURL: server.URL}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	payments := make(chan operations.Operation, 10)
+	errs := make(chan error, 2)
+	go func() {
+		errs <- streamClient.StreamPayments(ctx, horizonclient.OperationRequest{ForAccount: bob.Address()}, func(op operations.Operation) {
+			payments <- op
+		})
+	}()
+
+	// the stream starts after the latest ledger, so payments are submitted
+	// until the stream is connected
+	var op operations.Operation
+	for op == nil {
+		_, err := client.SubmitTransactionXDR(payment(t, client, alice, "5"))
+		require.NoError(t, err)
+		select {
+		case op = <-payments:
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+	assert.Equal(t, bob.Address(), op.(operations.Payment).To)
+
+	ledgerClient := &horizonclient.Client{HorizonURL: server.URL}
+	ledgers := make(chan hProtocol.Ledger, 10)
+	go func() {
+		errs <- ledgerClient.StreamLedgers(ctx, horizonclient.LedgerRequest{Cursor: OperationID(100, 0, 0)}, func(ledger hProtocol.Ledger) {
+			ledgers <- ledger
+		})
+	}()
+	ledger := <-ledgers
+	assert.True(t, ledger.Sequence > 100)
+
+	// the streams stop once an event follows the cancellation
+	cancel()
+	_, err := client.SubmitTransactionXDR(payment(t, client, alice, "5"))
+	require.NoError(t, err)
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+}
+
+func TestStreamOrderBook(t *testing.T) {
+	server, _ := newServer(t)
+	defer server.Close()
+	client := &horizonclient.Client{HorizonURL: server.URL}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	books := make(chan hProtocol.OrderBookSummary, 10)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.StreamOrderBooks(ctx, horizonclient.OrderBookRequest{
+			SellingAssetType: horizonclient.AssetTypeNative,
+			BuyingAssetType:  horizonclient.AssetTypeNative,
+		}, func(book hProtocol.OrderBookSummary) {
+			books <- book
+		})
+	}()
+
+	// the current order book is streamed first, then every change
+	book := <-books
+	assert.Len(t, book.Bids, 0)
+	server.SetOrderBook(hProtocol.OrderBookSummary{
+		Bids:    []hProtocol.PriceLevel{{Price: "1.0000000", Amount: "5.0000000"}},
+		Selling: hProtocol.Asset{Type: "native"},
+		Buying:  hProtocol.Asset{Type: "native"},
+	})
+	book = <-books
+	assert.Len(t, book.Bids, 1)
+
+	cancel()
+	server.SetOrderBook(hProtocol.OrderBookSummary{
+		Selling: hProtocol.Asset{Type: "native"},
+		Buying:  hProtocol.Asset{Type: "native"},
+	})
+	require.NoError(t, <-errs)
+}
+
+func TestFailNext(t *testing.T) {
+	server, client := newServer(t)
+	defer server.Close()
+
+	server.FailNext(http.MethodGet, "/accounts/"+alice.Address(), 2, RateLimitExceeded)
+	for i := 0; i < 2; i++ {
+		_, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+		if assert.Error(t, err) {
+			assert.Equal(t, http.StatusTooManyRequests, err.(*horizonclient.Error).Problem.Status)
+		}
+	}
+	_, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: alice.Address()})
+	require.NoError(t, err)
+
+	txe := payment(t, client, alice, "1")
+	server.FailNext(http.MethodPost, "/transactions", 1, Timeout)
+	server.FailNext(http.MethodPost, "/transactions", 1, TransactionFailed("tx_bad_seq"))
+	_, err = client.SubmitTransactionXDR(txe)
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusGatewayTimeout, err.(*horizonclient.Error).Problem.Status)
+	}
+	_, err = client.SubmitTransactionXDR(txe)
+	if assert.Error(t, err) {
+		codes, codesErr := err.(*horizonclient.Error).ResultCodes()
+		require.NoError(t, codesErr)
+		assert.Equal(t, "tx_bad_seq", codes.TransactionCode)
+	}
+	_, err = client.SubmitTransactionXDR(txe)
+	require.NoError(t, err)
+}
@@ -0,0 +1,381 @@
+package horizontest
+
+import (
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/paydex-core/paydex-go/amount"
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/base"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// transactionMalformed is the problem returned when the transaction submitted
+// can't be decoded.
+var transactionMalformed = problem.P{
+	Type:   "transaction_malformed",
+	Title:  "Transaction Malformed",
+	Status: http.StatusBadRequest,
+	Detail: "Horizon could not decode the transaction envelope in this request.",
+}
+
+// submit applies the transaction posted in a new ledger. Only payments are
+// supported, other operations fail with op_not_supported.
+func (h *Horizon) submit(w http.ResponseWriter, r *http.Request) {
+	envelopeXDR := r.FormValue("tx")
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXDR, &envelope); err != nil {
+		p := transactionMalformed
+		p.Extras = map[string]interface{}{"envelope_xdr": envelopeXDR}
+		problem.Render(r.Context(), w, p)
+		return
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, h.passphrase)
+	if err != nil {
+		problem.Render(r.Context(), w, errors.Wrap(err, "hashing transaction"))
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// transactions submitted again return the result of their first submission
+	if tx, ok := h.transaction(hex.EncodeToString(hash[:])); ok {
+		h.renderResult(w, r, tx)
+		return
+	}
+
+	tx, err := h.apply(envelope, envelopeXDR, hex.EncodeToString(hash[:]))
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	h.renderResult(w, r, tx)
+}
+
+// renderResult renders the submission result of tx.
+func (h *Horizon) renderResult(w http.ResponseWriter, r *http.Request, tx hProtocol.Transaction) {
+	if !tx.Successful {
+		var result xdr.TransactionResult
+		if err := xdr.SafeUnmarshalBase64(tx.ResultXdr, &result); err != nil {
+			problem.Render(r.Context(), w, errors.Wrap(err, "decoding result"))
+			return
+		}
+		var opCodes []string
+		if results, ok := result.OperationResults(); ok {
+			for _, opResult := range results {
+				opCodes = append(opCodes, operationCode(opResult))
+			}
+		}
+		p := TransactionFailed(transactionCode(result.Result.Code), opCodes...)
+		p.Extras["envelope_xdr"] = tx.EnvelopeXdr
+		p.Extras["result_xdr"] = tx.ResultXdr
+		problem.Render(r.Context(), w, p)
+		return
+	}
+
+	success := hProtocol.TransactionSuccess{
+		Hash:   tx.Hash,
+		Ledger: tx.Ledger,
+		Env:    tx.EnvelopeXdr,
+		Result: tx.ResultXdr,
+		Meta:   tx.ResultMetaXdr,
+	}
+	success.Links.Transaction = hal.NewLink("http://" + r.Host + "/transactions/" + tx.Hash)
+	hal.Render(w, success)
+}
+
+// apply applies the transaction in a new ledger, unless it is rejected without
+// being included in a ledger, and returns the transaction. It must be called
+// with the mutex held.
+func (h *Horizon) apply(envelope xdr.TransactionEnvelope, envelopeXDR, hash string) (hProtocol.Transaction, error) {
+	sourceAccount := envelope.SourceAccount()
+	source := sourceAccount.ToAccountId()
+	feeSource := source
+	ops := envelope.Operations()
+	fee := h.BaseFee * int64(len(ops))
+	if envelope.IsFeeBump() {
+		feeAccount := envelope.FeeBumpAccount()
+		feeSource = feeAccount.ToAccountId()
+		fee += h.BaseFee
+	}
+
+	rejected := func(code xdr.TransactionResultCode) error {
+		result, err := xdr.MarshalBase64(xdr.TransactionResult{
+			Result: xdr.TransactionResultResult{Code: code},
+		})
+		if err != nil {
+			return err
+		}
+		p := TransactionFailed(transactionCode(code))
+		p.Extras["envelope_xdr"] = envelopeXDR
+		p.Extras["result_xdr"] = result
+		return p
+	}
+
+	// work on copies of the accounts, which are only stored once the
+	// transaction is applied
+	accounts := map[string]*hProtocol.Account{}
+	load := func(accountID xdr.AccountId) *hProtocol.Account {
+		address := accountID.Address()
+		if account, ok := accounts[address]; ok {
+			return account
+		}
+		account, ok := h.accounts[address]
+		if !ok {
+			return nil
+		}
+		account.Balances = append([]hProtocol.Balance(nil), account.Balances...)
+		accounts[address] = &account
+		return &account
+	}
+
+	account := load(source)
+	feeAccount := load(feeSource)
+	if account == nil || feeAccount == nil {
+		return hProtocol.Transaction{}, rejected(xdr.TransactionResultCodeTxNoAccount)
+	}
+	sequence, err := strconv.ParseInt(account.Sequence, 10, 64)
+	if err != nil || envelope.SeqNum() != sequence+1 {
+		return hProtocol.Transaction{}, rejected(xdr.TransactionResultCodeTxBadSeq)
+	}
+	if !debit(feeAccount, base.Asset{Type: "native"}, fee) {
+		return hProtocol.Transaction{}, rejected(xdr.TransactionResultCodeTxInsufficientBalance)
+	}
+	account.Sequence = strconv.FormatInt(envelope.SeqNum(), 10)
+
+	ledger := hProtocol.Ledger{
+		Sequence: h.latestLedger() + 1,
+		ClosedAt: h.closeTime(),
+		BaseFee:  int32(h.BaseFee),
+	}
+	txOrder := 1
+	txID := toid(ledger.Sequence, txOrder, 0)
+	successful := true
+	var records []operations.Payment
+	results := make([]xdr.OperationResult, len(ops))
+	for i, op := range ops {
+		from := account
+		fromAddress := source.Address()
+		if op.SourceAccount != nil {
+			opSource := op.SourceAccount.ToAccountId()
+			fromAddress = opSource.Address()
+			from = load(opSource)
+		}
+
+		payment, ok := op.Body.GetPaymentOp()
+		if !ok || from == nil {
+			successful = false
+			results[i] = xdr.OperationResult{Code: xdr.OperationResultCodeOpNotSupported}
+			if from == nil {
+				results[i].Code = xdr.OperationResultCodeOpNoAccount
+			}
+			continue
+		}
+		destination := payment.Destination.ToAccountId()
+		to := load(destination)
+		var asset base.Asset
+		if err = payment.Asset.Extract(&asset.Type, &asset.Code, &asset.Issuer); err != nil {
+			return hProtocol.Transaction{}, err
+		}
+
+		code := xdr.PaymentResultCodePaymentSuccess
+		switch {
+		case to == nil:
+			code = xdr.PaymentResultCodePaymentNoDestination
+		case !holds(from, asset):
+			code = xdr.PaymentResultCodePaymentSrcNoTrust
+		case !holds(to, asset):
+			code = xdr.PaymentResultCodePaymentNoTrust
+		case !debit(from, asset, int64(payment.Amount)):
+			code = xdr.PaymentResultCodePaymentUnderfunded
+		default:
+			credit(to, asset, int64(payment.Amount))
+		}
+		results[i] = xdr.OperationResult{
+			Code: xdr.OperationResultCodeOpInner,
+			Tr: &xdr.OperationResultTr{
+				Type:          xdr.OperationTypePayment,
+				PaymentResult: &xdr.PaymentResult{Code: code},
+			},
+		}
+		if code != xdr.PaymentResultCodePaymentSuccess {
+			successful = false
+		}
+
+		record := operations.Payment{
+			Asset:  asset,
+			From:   fromAddress,
+			To:     destination.Address(),
+			Amount: amount.String(payment.Amount),
+		}
+		record.ID = strconv.FormatInt(toid(ledger.Sequence, txOrder, i+1), 10)
+		record.PT = record.ID
+		record.SourceAccount = fromAddress
+		record.Base.Type = "payment"
+		record.TypeI = int32(xdr.OperationTypePayment)
+		record.LedgerCloseTime = ledger.ClosedAt
+		record.TransactionHash = hash
+		records = append(records, record)
+	}
+
+	resultCode := xdr.TransactionResultCodeTxSuccess
+	if !successful {
+		resultCode = xdr.TransactionResultCodeTxFailed
+	}
+	resultXDR, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: xdr.Int64(fee),
+		Result: xdr.TransactionResultResult{
+			Code:    resultCode,
+			Results: &results,
+		},
+	})
+	if err != nil {
+		return hProtocol.Transaction{}, err
+	}
+
+	// the fee and sequence number are consumed by failed transactions, the
+	// payments are only applied by successful ones
+	if successful {
+		for address, account := range accounts {
+			account.LastModifiedLedger = uint32(ledger.Sequence)
+			h.accounts[address] = *account
+		}
+	} else {
+		stored := h.accounts[feeAccount.AccountID]
+		debit(&stored, base.Asset{Type: "native"}, fee)
+		stored.LastModifiedLedger = uint32(ledger.Sequence)
+		h.accounts[feeAccount.AccountID] = stored
+
+		stored = h.accounts[account.AccountID]
+		stored.Sequence = account.Sequence
+		stored.LastModifiedLedger = uint32(ledger.Sequence)
+		h.accounts[account.AccountID] = stored
+	}
+
+	var failed int32
+	if successful {
+		ledger.SuccessfulTransactionCount = 1
+	} else {
+		failed = 1
+	}
+	ledger.FailedTransactionCount = &failed
+	ledger.OperationCount = int32(len(ops))
+	h.addLedger(ledger)
+
+	tx := hProtocol.Transaction{
+		ID:              hash,
+		PT:              strconv.FormatInt(txID, 10),
+		Successful:      successful,
+		Hash:            hash,
+		Ledger:          ledger.Sequence,
+		LedgerCloseTime: ledger.ClosedAt,
+		Account:         source.Address(),
+		AccountSequence: account.Sequence,
+		FeeAccount:      feeSource.Address(),
+		FeeCharged:      fee,
+		MaxFee:          int64(envelope.Fee()),
+		OperationCount:  int32(len(ops)),
+		EnvelopeXdr:     envelopeXDR,
+		ResultXdr:       resultXDR,
+		MemoType:        "none",
+	}
+	if envelope.IsFeeBump() {
+		tx.MaxFee = envelope.FeeBumpFee()
+	}
+	h.addTransaction(tx)
+	for _, record := range records {
+		record.TransactionSuccessful = successful
+		h.addOperation(record)
+	}
+	h.notify()
+	return tx, nil
+}
+
+// holds returns true when account can hold asset. Issuers hold their own
+// assets without a trust line.
+func holds(account *hProtocol.Account, asset base.Asset) bool {
+	if asset.Issuer == account.AccountID {
+		return true
+	}
+	for _, balance := range account.Balances {
+		if balance.Asset == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// credit adds value, in stroops, to the balance of asset of account.
+func credit(account *hProtocol.Account, asset base.Asset, value int64) {
+	for i, balance := range account.Balances {
+		if balance.Asset != asset {
+			continue
+		}
+		current, err := amount.ParseInt64(balance.Balance)
+		if err == nil {
+			account.Balances[i].Balance = amount.StringFromInt64(current + value)
+		}
+		return
+	}
+}
+
+// debit removes value, in stroops, from the balance of asset of account. It
+// returns false when the balance is insufficient.
+func debit(account *hProtocol.Account, asset base.Asset, value int64) bool {
+	if asset.Issuer == account.AccountID {
+		return true
+	}
+	for i, balance := range account.Balances {
+		if balance.Asset != asset {
+			continue
+		}
+		current, err := amount.ParseInt64(balance.Balance)
+		if err != nil || current < value {
+			return false
+		}
+		account.Balances[i].Balance = amount.StringFromInt64(current - value)
+		return true
+	}
+	return false
+}
+
+// transactionCodes are the result codes rendered by Horizon for the
+// transaction results of the fake.
+var transactionCodes = map[xdr.TransactionResultCode]string{
+	xdr.TransactionResultCodeTxSuccess:             "tx_success",
+	xdr.TransactionResultCodeTxFailed:              "tx_failed",
+	xdr.TransactionResultCodeTxBadSeq:              "tx_bad_seq",
+	xdr.TransactionResultCodeTxInsufficientBalance: "tx_insufficient_balance",
+	xdr.TransactionResultCodeTxNoAccount:           "tx_no_source_account",
+}
+
+// paymentCodes are the result codes rendered by Horizon for the payment
+// results of the fake.
+var paymentCodes = map[xdr.PaymentResultCode]string{
+	xdr.PaymentResultCodePaymentSuccess:       "op_success",
+	xdr.PaymentResultCodePaymentUnderfunded:   "op_underfunded",
+	xdr.PaymentResultCodePaymentSrcNoTrust:    "op_src_no_trust",
+	xdr.PaymentResultCodePaymentNoDestination: "op_no_destination",
+	xdr.PaymentResultCodePaymentNoTrust:       "op_no_trust",
+}
+
+func transactionCode(code xdr.TransactionResultCode) string {
+	return transactionCodes[code]
+}
+
+func operationCode(result xdr.OperationResult) string {
+	switch result.Code {
+	case xdr.OperationResultCodeOpNoAccount:
+		return "op_no_source_account"
+	case xdr.OperationResultCodeOpNotSupported:
+		return "op_not_supported"
+	}
+	return paymentCodes[result.Tr.PaymentResult.Code]
+}
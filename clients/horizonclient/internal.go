@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/paydex-core/paydex-go/support/errors"
@@ -21,7 +22,7 @@ func decodeResponse(resp *http.Response, object interface{}, hc *Client) (err er
 	}
 	setCurrentServerTime(u.Hostname(), resp.Header["Date"], hc)
 
-	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) && !isResource(resp) {
 		horizonError := &Error{
 			Response: resp,
 		}
@@ -39,6 +40,14 @@ func decodeResponse(resp *http.Response, object interface{}, hc *Client) (err er
 	return
 }
 
+// isResource returns true when resp holds a resource rendered by horizon
+// rather than a problem. Horizon renders the response to an asynchronous
+// submission with a status code matching the status of paydex-core, such as
+// 409 Conflict for a DUPLICATE transaction.
+func isResource(resp *http.Response) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Type"), "application/hal+json")
+}
+
 // countParams counts the number of parameters provided
 func countParams(params ...interface{}) int {
 	counter := 0
@@ -100,6 +109,30 @@ func addQueryParams(params ...interface{}) string {
 			if param != "" {
 				query.Add("join", string(param))
 			}
+		case startTime:
+			if t := time.Time(param); !t.IsZero() {
+				query.Add("start_time", strconv.FormatInt(t.UnixNano()/1e6, 10))
+			}
+		case endTime:
+			if t := time.Time(param); !t.IsZero() {
+				query.Add("end_time", strconv.FormatInt(t.UnixNano()/1e6, 10))
+			}
+		case types:
+			if len(param) > 0 {
+				query.Add("type", strings.Join(param, ","))
+			}
+		case asset:
+			if param != "" {
+				query.Add("asset", string(param))
+			}
+		case memo:
+			if param != "" {
+				query.Add("memo", string(param))
+			}
+		case memoType:
+			if param != "" {
+				query.Add("memo_type", string(param))
+			}
 		case map[string]string:
 			for key, value := range param {
 				if value != "" {
@@ -0,0 +1,507 @@
+package horizonclient
+
+import (
+	"context"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+)
+
+// ErrDescendingStream is returned when an iterator walking records in descending order is
+// asked to continue as a stream, as Horizon only streams records in ascending order.
+var ErrDescendingStream = errors.New("descending iterations can't be streamed")
+
+// pageIterator walks the records of a Horizon collection page by page. The pages are fetched
+// lazily by fetch, which returns the records of the first page when first is true and of the
+// page following the last fetched page otherwise. It is embedded by the typed iterators.
+type pageIterator struct {
+	ctx     context.Context
+	fetch   func(first bool) ([]hal.Pageable, error)
+	started bool
+	done    bool
+	err     error
+	records []hal.Pageable
+	record  hal.Pageable
+	cursor  string
+}
+
+func newPageIterator(ctx context.Context, cursor string, fetch func(first bool) ([]hal.Pageable, error)) pageIterator {
+	return pageIterator{ctx: ctx, cursor: cursor, fetch: fetch}
+}
+
+// Next advances the iterator to the next record, fetching the next page when the records of the
+// current page are exhausted. It returns false once an empty page is found, the context is
+// cancelled or an error occurs. Err tells these cases apart.
+func (it *pageIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.stop(err)
+		return false
+	}
+
+	if len(it.records) == 0 {
+		records, err := it.fetch(!it.started)
+		it.started = true
+		if err != nil {
+			it.stop(err)
+			return false
+		}
+		if len(records) == 0 {
+			it.stop(nil)
+			return false
+		}
+		it.records = records
+	}
+
+	it.record, it.records = it.records[0], it.records[1:]
+	it.cursor = it.record.PagingToken()
+	return true
+}
+
+func (it *pageIterator) stop(err error) {
+	it.done = true
+	it.err = err
+	it.record = nil
+	it.records = nil
+}
+
+// Err returns the error which stopped the iteration, if any. It is the error of the context when
+// the iteration was cancelled.
+func (it *pageIterator) Err() error {
+	return it.err
+}
+
+// Cursor returns the paging token of the last record returned by the iterator, or the cursor of
+// the request when no record has been returned yet. The iteration continues after this cursor.
+func (it *pageIterator) Cursor() string {
+	return it.cursor
+}
+
+// take advances the iterator up to limit times, calling add with every record. There is no limit
+// when limit is 0 or less.
+func (it *pageIterator) take(limit int, add func(hal.Pageable)) error {
+	for n := 0; (limit <= 0 || n < limit) && it.Next(); n++ {
+		add(it.record)
+	}
+	return it.Err()
+}
+
+// streamCursor returns the cursor a stream continuing the iteration starts from. The cursor is
+// saved in store, if set, so that the stream doesn't resume from an older stored cursor.
+func (it *pageIterator) streamCursor(order Order, store CursorStore) (string, error) {
+	if order == OrderDesc {
+		return "", ErrDescendingStream
+	}
+	cursor := it.cursor
+	if cursor == "" {
+		// the iteration started from the oldest record, and found none
+		cursor = "0"
+	}
+	if store != nil {
+		if err := store.Save(cursor); err != nil {
+			return "", errors.Wrap(err, "error saving cursor")
+		}
+	}
+	return cursor, nil
+}
+
+// OperationIterator walks the operations, or the payments, matching a request across pages.
+//
+//	it := horizonclient.NewOperationIterator(ctx, client, horizonclient.OperationRequest{ForAccount: "G..."})
+//	for it.Next() {
+//		op := it.Operation()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type OperationIterator struct {
+	pageIterator
+	client   ClientInterface
+	request  OperationRequest
+	payments bool
+	page     operations.OperationsPage
+}
+
+// NewOperationIterator returns an iterator over the operations matching request.
+func NewOperationIterator(ctx context.Context, client ClientInterface, request OperationRequest) *OperationIterator {
+	it := &OperationIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+// NewPaymentIterator returns an iterator over the payments matching request.
+func NewPaymentIterator(ctx context.Context, client ClientInterface, request OperationRequest) *OperationIterator {
+	it := NewOperationIterator(ctx, client, request)
+	it.payments = true
+	return it
+}
+
+func (it *OperationIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	switch {
+	case first && it.payments:
+		it.page, err = it.client.Payments(it.request)
+	case first:
+		it.page, err = it.client.Operations(it.request)
+	case it.payments:
+		it.page, err = it.client.NextPaymentsPage(it.page)
+	default:
+		it.page, err = it.client.NextOperationsPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Operation returns the current operation.
+func (it *OperationIterator) Operation() operations.Operation {
+	return it.record.(operations.Operation)
+}
+
+// Collect returns the next operations, up to limit, or all of them when limit is 0.
+func (it *OperationIterator) Collect(limit int) ([]operations.Operation, error) {
+	var ops []operations.Operation
+	err := it.take(limit, func(record hal.Pageable) {
+		ops = append(ops, record.(operations.Operation))
+	})
+	return ops, err
+}
+
+// Stream streams the operations, or payments, following the last one returned by the iterator,
+// so that a backfill continues seamlessly with new operations.
+func (it *OperationIterator) Stream(ctx context.Context, handler OperationHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	if it.payments {
+		return it.client.StreamPayments(ctx, request, handler)
+	}
+	return it.client.StreamOperations(ctx, request, handler)
+}
+
+// EffectIterator walks the effects matching a request across pages. See OperationIterator.
+type EffectIterator struct {
+	pageIterator
+	client  ClientInterface
+	request EffectRequest
+	page    effects.EffectsPage
+}
+
+// NewEffectIterator returns an iterator over the effects matching request.
+func NewEffectIterator(ctx context.Context, client ClientInterface, request EffectRequest) *EffectIterator {
+	it := &EffectIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *EffectIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Effects(it.request)
+	} else {
+		it.page, err = it.client.NextEffectsPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Effect returns the current effect.
+func (it *EffectIterator) Effect() effects.Effect {
+	return it.record.(effects.Effect)
+}
+
+// Collect returns the next effects, up to limit, or all of them when limit is 0.
+func (it *EffectIterator) Collect(limit int) ([]effects.Effect, error) {
+	var effs []effects.Effect
+	err := it.take(limit, func(record hal.Pageable) {
+		effs = append(effs, record.(effects.Effect))
+	})
+	return effs, err
+}
+
+// Stream streams the effects following the last one returned by the iterator.
+func (it *EffectIterator) Stream(ctx context.Context, handler EffectHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	return it.client.StreamEffects(ctx, request, handler)
+}
+
+// TransactionIterator walks the transactions matching a request across pages. See
+// OperationIterator.
+type TransactionIterator struct {
+	pageIterator
+	client  ClientInterface
+	request TransactionRequest
+	page    hProtocol.TransactionsPage
+}
+
+// NewTransactionIterator returns an iterator over the transactions matching request.
+func NewTransactionIterator(ctx context.Context, client ClientInterface, request TransactionRequest) *TransactionIterator {
+	it := &TransactionIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *TransactionIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Transactions(it.request)
+	} else {
+		it.page, err = it.client.NextTransactionsPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Transaction returns the current transaction.
+func (it *TransactionIterator) Transaction() hProtocol.Transaction {
+	return it.record.(hProtocol.Transaction)
+}
+
+// Collect returns the next transactions, up to limit, or all of them when limit is 0.
+func (it *TransactionIterator) Collect(limit int) ([]hProtocol.Transaction, error) {
+	var txs []hProtocol.Transaction
+	err := it.take(limit, func(record hal.Pageable) {
+		txs = append(txs, record.(hProtocol.Transaction))
+	})
+	return txs, err
+}
+
+// Stream streams the transactions following the last one returned by the iterator.
+func (it *TransactionIterator) Stream(ctx context.Context, handler TransactionHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	return it.client.StreamTransactions(ctx, request, handler)
+}
+
+// TradeIterator walks the trades matching a request across pages. See OperationIterator.
+type TradeIterator struct {
+	pageIterator
+	client  ClientInterface
+	request TradeRequest
+	page    hProtocol.TradesPage
+}
+
+// NewTradeIterator returns an iterator over the trades matching request.
+func NewTradeIterator(ctx context.Context, client ClientInterface, request TradeRequest) *TradeIterator {
+	it := &TradeIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *TradeIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Trades(it.request)
+	} else {
+		it.page, err = it.client.NextTradesPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Trade returns the current trade.
+func (it *TradeIterator) Trade() hProtocol.Trade {
+	return it.record.(hProtocol.Trade)
+}
+
+// Collect returns the next trades, up to limit, or all of them when limit is 0.
+func (it *TradeIterator) Collect(limit int) ([]hProtocol.Trade, error) {
+	var trades []hProtocol.Trade
+	err := it.take(limit, func(record hal.Pageable) {
+		trades = append(trades, record.(hProtocol.Trade))
+	})
+	return trades, err
+}
+
+// Stream streams the trades following the last one returned by the iterator.
+func (it *TradeIterator) Stream(ctx context.Context, handler TradeHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	return it.client.StreamTrades(ctx, request, handler)
+}
+
+// OfferIterator walks the offers matching a request across pages. See OperationIterator.
+type OfferIterator struct {
+	pageIterator
+	client  ClientInterface
+	request OfferRequest
+	page    hProtocol.OffersPage
+}
+
+// NewOfferIterator returns an iterator over the offers matching request.
+func NewOfferIterator(ctx context.Context, client ClientInterface, request OfferRequest) *OfferIterator {
+	it := &OfferIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *OfferIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Offers(it.request)
+	} else {
+		it.page, err = it.client.NextOffersPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Offer returns the current offer.
+func (it *OfferIterator) Offer() hProtocol.Offer {
+	return it.record.(hProtocol.Offer)
+}
+
+// Collect returns the next offers, up to limit, or all of them when limit is 0.
+func (it *OfferIterator) Collect(limit int) ([]hProtocol.Offer, error) {
+	var offers []hProtocol.Offer
+	err := it.take(limit, func(record hal.Pageable) {
+		offers = append(offers, record.(hProtocol.Offer))
+	})
+	return offers, err
+}
+
+// Stream streams the offers following the last one returned by the iterator. Only the offers of
+// an account can be streamed.
+func (it *OfferIterator) Stream(ctx context.Context, handler OfferHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	return it.client.StreamOffers(ctx, request, handler)
+}
+
+// LedgerIterator walks the ledgers matching a request across pages. See OperationIterator.
+type LedgerIterator struct {
+	pageIterator
+	client  ClientInterface
+	request LedgerRequest
+	page    hProtocol.LedgersPage
+}
+
+// NewLedgerIterator returns an iterator over the ledgers matching request.
+func NewLedgerIterator(ctx context.Context, client ClientInterface, request LedgerRequest) *LedgerIterator {
+	it := &LedgerIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *LedgerIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Ledgers(it.request)
+	} else {
+		it.page, err = it.client.NextLedgersPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Ledger returns the current ledger.
+func (it *LedgerIterator) Ledger() hProtocol.Ledger {
+	return it.record.(hProtocol.Ledger)
+}
+
+// Collect returns the next ledgers, up to limit, or all of them when limit is 0.
+func (it *LedgerIterator) Collect(limit int) ([]hProtocol.Ledger, error) {
+	var ledgers []hProtocol.Ledger
+	err := it.take(limit, func(record hal.Pageable) {
+		ledgers = append(ledgers, record.(hProtocol.Ledger))
+	})
+	return ledgers, err
+}
+
+// Stream streams the ledgers following the last one returned by the iterator.
+func (it *LedgerIterator) Stream(ctx context.Context, handler LedgerHandler) error {
+	request := it.request
+	cursor, err := it.streamCursor(request.Order, request.CursorStore)
+	if err != nil {
+		return err
+	}
+	request.Cursor = cursor
+	return it.client.StreamLedgers(ctx, request, handler)
+}
+
+// AssetIterator walks the assets matching a request across pages. See OperationIterator. Assets
+// can't be streamed.
+type AssetIterator struct {
+	pageIterator
+	client  ClientInterface
+	request AssetRequest
+	page    hProtocol.AssetsPage
+}
+
+// NewAssetIterator returns an iterator over the assets matching request.
+func NewAssetIterator(ctx context.Context, client ClientInterface, request AssetRequest) *AssetIterator {
+	it := &AssetIterator{client: client, request: request}
+	it.pageIterator = newPageIterator(ctx, request.Cursor, it.fetch)
+	return it
+}
+
+func (it *AssetIterator) fetch(first bool) ([]hal.Pageable, error) {
+	var err error
+	if first {
+		it.page, err = it.client.Assets(it.request)
+	} else {
+		it.page, err = it.client.NextAssetsPage(it.page)
+	}
+	records := make([]hal.Pageable, len(it.page.Embedded.Records))
+	for i, record := range it.page.Embedded.Records {
+		records[i] = record
+	}
+	return records, err
+}
+
+// Asset returns the current asset.
+func (it *AssetIterator) Asset() hProtocol.AssetStat {
+	return it.record.(hProtocol.AssetStat)
+}
+
+// Collect returns the next assets, up to limit, or all of them when limit is 0.
+func (it *AssetIterator) Collect(limit int) ([]hProtocol.AssetStat, error) {
+	var assets []hProtocol.AssetStat
+	err := it.take(limit, func(record hal.Pageable) {
+		assets = append(assets, record.(hProtocol.AssetStat))
+	})
+	return assets, err
+}
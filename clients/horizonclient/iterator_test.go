@@ -0,0 +1,205 @@
+package horizonclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ledgersPage returns a page of ledgers linking to the page following the last ledger.
+func ledgersPage(sequences ...int) string {
+	records := ""
+	cursor := ""
+	for i, sequence := range sequences {
+		if i > 0 {
+			records += ","
+		}
+		cursor = fmt.Sprintf("%d", sequence<<32)
+		records += fmt.Sprintf(`{"paging_token": "%s", "sequence": %d}`, cursor, sequence)
+	}
+	return `{
+  "_links": {
+    "next": {"href": "https://localhost/ledgers?cursor=` + cursor + `&limit=2&order=asc"}
+  },
+  "_embedded": {"records": [` + records + `]}
+}`
+}
+
+func TestLedgerIterator(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/ledgers?limit=2").ReturnString(200, ledgersPage(1, 2))
+	hmock.On("GET", "https://localhost/ledgers?cursor=8589934592&limit=2&order=asc").ReturnString(200, ledgersPage(3))
+	hmock.On("GET", "https://localhost/ledgers?cursor=12884901888&limit=2&order=asc").ReturnString(200, ledgersPage())
+
+	it := NewLedgerIterator(context.Background(), client, LedgerRequest{Limit: 2})
+	assert.Equal(t, "", it.Cursor())
+
+	var sequences []int32
+	for it.Next() {
+		sequences = append(sequences, it.Ledger().Sequence)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []int32{1, 2, 3}, sequences)
+	assert.Equal(t, "12884901888", it.Cursor())
+
+	// the iteration stays stopped
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
+
+func TestLedgerIteratorCollect(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/ledgers?limit=2").ReturnString(200, ledgersPage(1, 2))
+	hmock.On("GET", "https://localhost/ledgers?cursor=8589934592&limit=2&order=asc").ReturnString(200, ledgersPage(3))
+	hmock.On("GET", "https://localhost/ledgers?cursor=12884901888&limit=2&order=asc").ReturnString(200, ledgersPage())
+
+	it := NewLedgerIterator(context.Background(), client, LedgerRequest{Limit: 2})
+	ledgers, err := it.Collect(1)
+	require.NoError(t, err)
+	require.Len(t, ledgers, 1)
+	assert.Equal(t, int32(1), ledgers[0].Sequence)
+	assert.Equal(t, "4294967296", it.Cursor())
+
+	// the iteration continues after the collected ledgers
+	ledgers, err = it.Collect(0)
+	require.NoError(t, err)
+	require.Len(t, ledgers, 2)
+	assert.Equal(t, int32(2), ledgers[0].Sequence)
+	assert.Equal(t, int32(3), ledgers[1].Sequence)
+
+	ledgers, err = it.Collect(0)
+	require.NoError(t, err)
+	assert.Len(t, ledgers, 0)
+}
+
+func TestLedgerIteratorErrors(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/ledgers?limit=2").ReturnString(200, ledgersPage(1, 2))
+	hmock.On("GET", "https://localhost/ledgers?cursor=8589934592&limit=2&order=asc").ReturnString(500, `{"status": 500}`)
+
+	// errors fetching a page stop the iteration
+	ledgers, err := NewLedgerIterator(context.Background(), client, LedgerRequest{Limit: 2}).Collect(0)
+	assert.Len(t, ledgers, 2)
+	if assert.Error(t, err) {
+		hError, ok := err.(*Error)
+		require.True(t, ok)
+		assert.Equal(t, 500, hError.Problem.Status)
+	}
+
+	// cancelling the context stops the iteration
+	ctx, cancel := context.WithCancel(context.Background())
+	it := NewLedgerIterator(ctx, client, LedgerRequest{Limit: 2})
+	require.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+	assert.Equal(t, "4294967296", it.Cursor())
+}
+
+func TestLedgerIteratorStream(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/ledgers?cursor=8589934592&limit=2").ReturnString(200, ledgersPage(3))
+	hmock.On("GET", "https://localhost/ledgers?cursor=12884901888&limit=2&order=asc").ReturnString(200, ledgersPage())
+	hmock.On("GET", "https://localhost/ledgers?cursor=12884901888&limit=2").ReturnString(200, ledgerStreamResponse)
+	hmock.On("GET", "https://localhost/").ReturnString(200, `{"history_elder_ledger": 1}`)
+
+	// the stream continues from the last ledger of the backfill, which is saved in the cursor
+	// store
+	store := &MemoryCursorStore{}
+	it := NewLedgerIterator(context.Background(), client, LedgerRequest{Cursor: "8589934592", Limit: 2, CursorStore: store})
+	ledgers, err := it.Collect(0)
+	require.NoError(t, err)
+	require.Len(t, ledgers, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var streamed []hProtocol.Ledger
+	err = it.Stream(ctx, func(ledger hProtocol.Ledger) {
+		streamed = append(streamed, ledger)
+		cancel()
+	})
+	require.NoError(t, err)
+	require.Len(t, streamed, 1)
+	assert.Equal(t, int32(560339), streamed[0].Sequence)
+	cursor, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "12884901888", cursor)
+
+	// descending iterations can't be streamed
+	it = NewLedgerIterator(context.Background(), client, LedgerRequest{Order: OrderDesc})
+	err = it.Stream(context.Background(), func(hProtocol.Ledger) {})
+	assert.Equal(t, ErrDescendingStream, err)
+}
+
+func TestPaymentIterator(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+	hmock.On("GET", "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments").ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments?cursor=2608707301036033&order=asc"}},
+  "_embedded": {"records": [
+    {"id": "2608707301036033", "paging_token": "2608707301036033", "type": "payment", "type_i": 1, "amount": "10.0000000", "asset_type": "native"}
+  ]}
+}`)
+	hmock.On("GET", "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments?cursor=2608707301036033&order=asc").ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments?cursor=2608707301036033&order=asc"}},
+  "_embedded": {"records": []}
+}`)
+	hmock.On("GET", "https://localhost/accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/payments?cursor=2608707301036033").ReturnString(200, paymentStreamResponse)
+
+	request := OperationRequest{ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}
+	it := NewPaymentIterator(context.Background(), client, request)
+	require.True(t, it.Next())
+	payment, ok := it.Operation().(operations.Payment)
+	require.True(t, ok)
+	assert.Equal(t, "10.0000000", payment.Amount)
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var streamed []operations.Operation
+	err := it.Stream(ctx, func(op operations.Operation) {
+		streamed = append(streamed, op)
+		cancel()
+	})
+	require.NoError(t, err)
+	assert.Len(t, streamed, 1)
+}
+
+func TestIteratorsWithMockClient(t *testing.T) {
+	client := &MockClient{}
+	request := TransactionRequest{ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"}
+
+	var first, empty hProtocol.TransactionsPage
+	first.Embedded.Records = []hProtocol.Transaction{{PT: "1"}, {PT: "2"}}
+	client.On("Transactions", request).Return(first, nil)
+	client.On("NextTransactionsPage", first).Return(empty, nil)
+
+	txs, err := NewTransactionIterator(context.Background(), client, request).Collect(0)
+	require.NoError(t, err)
+	assert.Equal(t, []hProtocol.Transaction{{PT: "1"}, {PT: "2"}}, txs)
+	client.AssertExpectations(t)
+}
@@ -54,7 +54,7 @@ func (lr LedgerRequest) StreamLedgers(ctx context.Context, client *Client,
 	}
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{lr.CursorStore, lr.OnGap}, func(data []byte) error {
 		var ledger hProtocol.Ledger
 		err = json.Unmarshal(data, &ledger)
 		if err != nil {
@@ -50,6 +50,24 @@ type AssetType string
 // join represents `join` param in queries
 type join string
 
+// startTime represents `start_time` param in queries
+type startTime time.Time
+
+// endTime represents `end_time` param in queries
+type endTime time.Time
+
+// types represents the multi-valued `type` param in queries
+type types []string
+
+// asset represents `asset` param in queries
+type asset string
+
+// memo represents `memo` param in queries
+type memo string
+
+// memoType represents `memo_type` param in queries
+type memoType string
+
 const (
 	// OrderAsc represents an ascending order parameter
 	OrderAsc Order = "asc"
@@ -85,6 +103,11 @@ var (
 	// "result_xdr" extra field populated when it is expected to be.
 	ErrResultNotPopulated = errors.New("result_xdr not populated")
 
+	// ErrHistoryGap is the error returned by streams resuming from a stored
+	// cursor older than the history of the Horizon server, when no GapHandler
+	// is set.
+	ErrHistoryGap = errors.New("stored cursor is older than horizon history")
+
 	// HorizonTimeOut is the default number of seconds before a request to horizon times out.
 	HorizonTimeOut = time.Duration(60)
 
@@ -121,6 +144,11 @@ type UniversalTimeHandler func() int64
 
 // Client struct contains data for creating a horizon client that connects to the paydex network.
 type Client struct {
+	// rateLimitReset is the time, in unix nanoseconds, at which the rate
+	// limit of the client resets after it was exhausted. It is accessed
+	// atomically, and is the first field so that it is 64-bit aligned.
+	rateLimitReset int64
+
 	// URL of Horizon server to connect
 	HorizonURL string
 
@@ -135,14 +163,26 @@ type Client struct {
 	horizonTimeOut time.Duration
 	isTestNet      bool
 
+	// RetryPolicy controls how failed requests are retried. Requests are
+	// sent once when it is nil.
+	RetryPolicy *RetryPolicy
+
+	// Hooks receives the events of the requests and streams of the client,
+	// for instrumenting them. No events are sent when it is nil.
+	Hooks Hooks
+
 	// clock is a Clock returning the current time.
 	clock *clock.Clock
+	// sleep pauses the client before retrying a request. time.Sleep is used
+	// when it is nil.
+	sleep func(time.Duration)
 }
 
 // ClientInterface contains methods implemented by the horizon client
 type ClientInterface interface {
 	AccountDetail(request AccountRequest) (hProtocol.Account, error)
 	AccountData(request AccountRequest) (hProtocol.AccountData, error)
+	Accounts(request AccountsRequest) (hProtocol.AccountsPage, error)
 	Effects(request EffectRequest) (effects.EffectsPage, error)
 	Assets(request AssetRequest) (hProtocol.AssetsPage, error)
 	Ledgers(request LedgerRequest) (hProtocol.LedgersPage, error)
@@ -150,18 +190,25 @@ type ClientInterface interface {
 	Metrics() (hProtocol.Metrics, error)
 	FeeStats() (hProtocol.FeeStats, error)
 	Offers(request OfferRequest) (hProtocol.OffersPage, error)
+	OfferDetails(offerID string) (hProtocol.Offer, error)
 	Operations(request OperationRequest) (operations.OperationsPage, error)
 	OperationDetail(id string) (operations.Operation, error)
 	SubmitTransactionXDR(transactionXdr string) (hProtocol.TransactionSuccess, error)
 	SubmitTransaction(transactionXdr txnbuild.Transaction) (hProtocol.TransactionSuccess, error)
+	SubmitFeeBumpTransaction(transaction txnbuild.FeeBumpTransaction) (hProtocol.TransactionSuccess, error)
+	SubmitTransactionXDRAsync(transactionXdr string) (hProtocol.AsyncTransactionSubmissionResponse, error)
+	SubmitTransactionAsync(transaction txnbuild.Transaction) (hProtocol.AsyncTransactionSubmissionResponse, error)
+	AsyncTransactionStatus(txHash string) (hProtocol.AsyncTransactionStatus, error)
 	Transactions(request TransactionRequest) (hProtocol.TransactionsPage, error)
 	TransactionDetail(txHash string) (hProtocol.Transaction, error)
 	OrderBook(request OrderBookRequest) (hProtocol.OrderBookSummary, error)
 	Paths(request PathsRequest) (hProtocol.PathsPage, error)
+	StrictSendPaths(request StrictSendPathsRequest) (hProtocol.PathsPage, error)
 	Payments(request OperationRequest) (operations.OperationsPage, error)
 	TradeAggregations(request TradeAggregationRequest) (hProtocol.TradeAggregationsPage, error)
 	Trades(request TradeRequest) (hProtocol.TradesPage, error)
 	Fund(addr string) (hProtocol.TransactionSuccess, error)
+	StreamAccount(ctx context.Context, request AccountRequest, handler AccountHandler) error
 	StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error
 	StreamTrades(ctx context.Context, request TradeRequest, handler TradeHandler) error
 	StreamEffects(ctx context.Context, request EffectRequest, handler EffectHandler) error
@@ -171,6 +218,8 @@ type ClientInterface interface {
 	StreamLedgers(ctx context.Context, request LedgerRequest, handler LedgerHandler) error
 	StreamOrderBooks(ctx context.Context, request OrderBookRequest, handler OrderBookHandler) error
 	Root() (hProtocol.Root, error)
+	NextAccountsPage(hProtocol.AccountsPage) (hProtocol.AccountsPage, error)
+	PrevAccountsPage(hProtocol.AccountsPage) (hProtocol.AccountsPage, error)
 	NextAssetsPage(hProtocol.AssetsPage) (hProtocol.AssetsPage, error)
 	PrevAssetsPage(hProtocol.AssetsPage) (hProtocol.AssetsPage, error)
 	NextLedgersPage(hProtocol.LedgersPage) (hProtocol.LedgersPage, error)
@@ -214,16 +263,36 @@ type HorizonRequest interface {
 
 // AccountRequest struct contains data for making requests to the accounts endpoint of a horizon server.
 // "AccountID" and "DataKey" fields should both be set when retrieving AccountData.
-// When getting the AccountDetail, only "AccountID" needs to be set.
+// When getting the AccountDetail, only "AccountID" needs to be set. Setting "Ledger" or "At"
+// returns the state of the account as it was when that ledger closed, or at that time, instead
+// of its current state. Historical states can't be streamed and don't include the account data.
 type AccountRequest struct {
 	AccountID string
 	DataKey   string
+	Ledger    uint32
+	At        time.Time
+}
+
+// AccountsRequest struct contains data for getting the accounts which have a signer, or a trustline
+// to an asset, from a horizon server. Exactly one of "Signer" and "Asset" must be set. "Asset" is
+// an issued asset in the "Code:IssuerAccountID" form.
+// The query parameters (Order, Cursor and Limit) are optional. All or none can be set.
+type AccountsRequest struct {
+	Signer string
+	Asset  string
+	Order  Order
+	Cursor string
+	Limit  uint
 }
 
 // EffectRequest struct contains data for getting effects from a horizon server.
 // "ForAccount", "ForLedger", "ForOperation" and "ForTransaction": Not more than one of these
 // can be set at a time. If none are set, the default is to return all effects.
 // The query parameters (Order, Cursor and Limit) are optional. All or none can be set.
+// The filters (StartTime, EndTime, Types and Asset) are optional and compose with the other
+// parameters. StartTime and EndTime select the effects of the ledgers closed in
+// [StartTime, EndTime), Types the effects of one of the named types, for example
+// "account_credited", and Asset ("native" or "Code:Issuer") the effects for an asset.
 type EffectRequest struct {
 	ForAccount     string
 	ForLedger      string
@@ -232,6 +301,12 @@ type EffectRequest struct {
 	Order          Order
 	Cursor         string
 	Limit          uint
+	StartTime      time.Time
+	EndTime        time.Time
+	Types          []string
+	Asset          string
+	CursorStore    CursorStore
+	OnGap          GapHandler
 }
 
 // AssetRequest struct contains data for getting asset details from a horizon server.
@@ -252,6 +327,8 @@ type LedgerRequest struct {
 	Cursor      string
 	Limit       uint
 	forSequence uint32
+	CursorStore CursorStore
+	OnGap       GapHandler
 }
 
 type metricsRequest struct {
@@ -262,20 +339,37 @@ type feeStatsRequest struct {
 	endpoint string
 }
 
-// OfferRequest struct contains data for getting offers made by an account from a horizon server.
-// "ForAccount" is required.
+// OfferRequest struct contains data for getting offers from a horizon server.
+// When "ForAccount" is set, the offers made by that account are returned. Otherwise all offers are
+// returned, optionally filtered by "Seller" and the selling and buying assets.
 // The query parameters (Order, Cursor and Limit) are optional. All or none can be set.
+// Only the offers of an account can be streamed.
 type OfferRequest struct {
-	ForAccount string
-	Order      Order
-	Cursor     string
-	Limit      uint
+	ForAccount         string
+	Seller             string
+	SellingAssetType   AssetType
+	SellingAssetCode   string
+	SellingAssetIssuer string
+	BuyingAssetType    AssetType
+	BuyingAssetCode    string
+	BuyingAssetIssuer  string
+	Order              Order
+	Cursor             string
+	Limit              uint
+	forOfferID         string
+	CursorStore        CursorStore
+	OnGap              GapHandler
 }
 
 // OperationRequest struct contains data for getting operation details from a horizon server.
 // "ForAccount", "ForLedger", "ForTransaction": Only one of these can be set at a time. If none
 // are provided, the default is to return all operations.
 // The query parameters (Order, Cursor, Limit and IncludeFailed) are optional. All or none can be set.
+// The filters (StartTime, EndTime, Types and Asset) are optional and compose with the other
+// parameters. StartTime and EndTime select the operations of the ledgers closed in
+// [StartTime, EndTime), Types the operations of one of the named types, for example
+// "path_payment_strict_send", and Asset ("native" or "Code:Issuer") the operations moving or
+// trusting an asset.
 type OperationRequest struct {
 	ForAccount     string
 	ForLedger      uint
@@ -286,18 +380,37 @@ type OperationRequest struct {
 	Limit          uint
 	IncludeFailed  bool
 	Join           string
+	StartTime      time.Time
+	EndTime        time.Time
+	Types          []string
+	Asset          string
 	endpoint       string
+	CursorStore    CursorStore
+	OnGap          GapHandler
 }
 
 type submitRequest struct {
 	endpoint       string
 	transactionXdr string
+	async          bool
+}
+
+type asyncTransactionStatusRequest struct {
+	hash string
 }
 
 // TransactionRequest struct contains data for getting transaction details from a horizon server.
 // "ForAccount", "ForLedger": Only one of these can be set at a time. If none are provided, the
 // default is to return all transactions.
 // The query parameters (Order, Cursor, Limit and IncludeFailed) are optional. All or none can be set.
+// The filters (StartTime, EndTime, OperationTypes and Asset) are optional and compose with the
+// other parameters. StartTime and EndTime select the transactions of the ledgers closed in
+// [StartTime, EndTime), OperationTypes the transactions containing an operation of one of the
+// named types and Asset ("native" or "Code:Issuer") the transactions containing an operation
+// moving or trusting an asset.
+// Memo selects the transactions with a memo, given as it is in transaction resources (a text, a
+// decimal id or a base64 encoded hash), and MemoType, if set, its type ("text", "id", "hash" or
+// "return"). Memo requires ForAccount.
 type TransactionRequest struct {
 	ForAccount         string
 	ForLedger          uint
@@ -306,6 +419,14 @@ type TransactionRequest struct {
 	Cursor             string
 	Limit              uint
 	IncludeFailed      bool
+	StartTime          time.Time
+	EndTime            time.Time
+	OperationTypes     []string
+	Asset              string
+	Memo               string
+	MemoType           string
+	CursorStore        CursorStore
+	OnGap              GapHandler
 }
 
 // OrderBookRequest struct contains data for getting the orderbook for an asset pair from a horizon server.
@@ -320,8 +441,9 @@ type OrderBookRequest struct {
 	Limit              uint
 }
 
-// PathsRequest struct contains data for getting available payment paths from a horizon server.
-// All parameters are required.
+// PathsRequest struct contains data for getting available strict receive payment paths from a horizon server.
+// All parameters are required, except that "SourceAssets", a comma separated list of assets in the
+// "native" or "Code:IssuerAccountID" form, can be set instead of "SourceAccount".
 type PathsRequest struct {
 	DestinationAccount     string
 	DestinationAssetType   AssetType
@@ -329,12 +451,28 @@ type PathsRequest struct {
 	DestinationAssetIssuer string
 	DestinationAmount      string
 	SourceAccount          string
+	SourceAssets           string
+}
+
+// StrictSendPathsRequest struct contains data for getting available strict send payment paths from a horizon server.
+// The source asset and amount are required, and one of "DestinationAccount" and "DestinationAssets",
+// a comma separated list of assets in the "native" or "Code:IssuerAccountID" form, must be set.
+type StrictSendPathsRequest struct {
+	DestinationAccount string
+	DestinationAssets  string
+	SourceAssetType    AssetType
+	SourceAssetCode    string
+	SourceAssetIssuer  string
+	SourceAmount       string
 }
 
 // TradeRequest struct contains data for getting trade details from a horizon server.
 // "ForAccount", "ForOfferID": Only one of these can be set at a time. If none are provided, the
 // default is to return all trades.
 // All other query parameters are optional. All or none can be set.
+// StartTime and EndTime select the trades of the ledgers closed in [StartTime, EndTime) and
+// Asset ("native" or "Code:Issuer") the trades of an asset, either as the base or the counter
+// asset.
 type TradeRequest struct {
 	ForOfferID         string
 	ForAccount         string
@@ -347,6 +485,11 @@ type TradeRequest struct {
 	Order              Order
 	Cursor             string
 	Limit              uint
+	StartTime          time.Time
+	EndTime            time.Time
+	Asset              string
+	CursorStore        CursorStore
+	OnGap              GapHandler
 }
 
 // TradeAggregationRequest struct contains data for getting trade aggregations from a horizon server.
@@ -2,9 +2,12 @@ package horizonclient
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
 	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
@@ -14,6 +17,7 @@ import (
 	"github.com/paydex-core/paydex-go/support/http/httptest"
 	"github.com/paydex-core/paydex-go/txnbuild"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFixHTTP(t *testing.T) {
@@ -558,6 +562,127 @@ func TestSubmitRequest(t *testing.T) {
 	}
 }
 
+func TestSubmitFeeBumpTransaction(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	kp := keypair.MustParse("SA26PHIKZM6CXDGR472SSGUQQRYXM6S437ZNHZGRM6QA4FOPLLLFRGDX").(*keypair.Full)
+	feeKP := keypair.MustRandom()
+	sourceAccount := txnbuild.NewSimpleAccount(kp.Address(), int64(9605939170639897))
+	inner := txnbuild.Transaction{
+		SourceAccount: &sourceAccount,
+		Operations:    []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 0}},
+		Timebounds:    txnbuild.NewInfiniteTimeout(),
+		Network:       network.TestNetworkPassphrase,
+	}
+	require.NoError(t, inner.Build())
+	require.NoError(t, inner.Sign(kp))
+
+	feeBump := txnbuild.FeeBumpTransaction{
+		FeeAccount: feeKP.Address(),
+		BaseFee:    200,
+		Inner:      &inner,
+	}
+	require.NoError(t, feeBump.Build())
+	require.NoError(t, feeBump.Sign(feeKP))
+	txeB64, err := feeBump.Base64()
+	require.NoError(t, err)
+
+	query := url.Values{}
+	query.Set("tx", txeB64)
+	hmock.On(
+		"POST",
+		"https://localhost/transactions?"+query.Encode(),
+	).ReturnString(200, txSuccess)
+
+	resp, err := client.SubmitFeeBumpTransaction(feeBump)
+	if assert.NoError(t, err) {
+		assert.IsType(t, resp, hProtocol.TransactionSuccess{})
+		assert.Equal(t, resp.Ledger, int32(354811))
+	}
+
+	// fee bump transaction which has not been built
+	_, err = client.SubmitFeeBumpTransaction(txnbuild.FeeBumpTransaction{})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Unable to convert fee bump transaction object to base64 string")
+	}
+}
+
+func TestSubmitTransactionXDRAsync(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	txXdr := `AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0AAuV/AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAyTBGxOgfSApppsTnb/YRr6gOR8WT0LZNrhLh4y3FCgoAAAAXSHboAAAAAAAAAAABhlbgnAAAAEAivKe977CQCxMOKTuj+cWTFqc2OOJU8qGr9afrgu2zDmQaX5Q0cNshc3PiBwe0qw/+D/qJk5QqM5dYeSUGeDQP`
+	query := url.Values{}
+	query.Set("tx", txXdr)
+	submitURL := "https://localhost/transactions_async?" + query.Encode()
+	halHeader := http.Header{"Content-Type": []string{"application/hal+json; charset=utf-8"}}
+
+	// accepted by paydex-core
+	hmock.On("POST", submitURL).
+		ReturnStringWithHeader(201, asyncSubmissionPending, halHeader)
+
+	resp, err := client.SubmitTransactionXDRAsync(txXdr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca", resp.Hash)
+		assert.Equal(t, hProtocol.SubmissionStatusPending, resp.TxStatus)
+		assert.Equal(t, "https://localhost/transactions_async/bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca", resp.Links.Status.Href)
+	}
+
+	// rejected by paydex-core, the response isn't a horizon error
+	hmock.On("POST", submitURL).
+		ReturnStringWithHeader(400, asyncSubmissionError, halHeader)
+
+	resp, err = client.SubmitTransactionXDRAsync(txXdr)
+	if assert.NoError(t, err) {
+		assert.Equal(t, hProtocol.SubmissionStatusError, resp.TxStatus)
+		assert.Equal(t, "AAAAAAAAAGT////7AAAAAA==", resp.ErrorResultXDR)
+	}
+
+	// malformed transaction
+	hmock.On("POST", submitURL).
+		ReturnStringWithHeader(400, transactionMalformed, http.Header{"Content-Type": []string{"application/problem+json; charset=utf-8"}})
+
+	_, err = client.SubmitTransactionXDRAsync(txXdr)
+	if assert.Error(t, err) {
+		horizonError, ok := errors.Cause(err).(*Error)
+		if assert.True(t, ok) {
+			assert.Equal(t, "transaction_malformed", horizonError.Problem.Type)
+		}
+	}
+}
+
+func TestAsyncTransactionStatus(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/transactions_async/bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca",
+	).ReturnString(200, asyncTransactionSuccess)
+
+	status, err := client.AsyncTransactionStatus("bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca")
+	if assert.NoError(t, err) {
+		assert.Equal(t, hProtocol.TransactionStatusSuccess, status.Status)
+		assert.Equal(t, int32(354811), status.Ledger)
+		assert.Equal(t, "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAA=", status.ResultXDR)
+	}
+
+	_, err = client.AsyncTransactionStatus("")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no transaction hash provided")
+	}
+}
+
 func TestTransactionsRequest(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
@@ -1652,3 +1777,42 @@ var paymentsResponse = `{
     ]
   }
 }`
+
+var asyncSubmissionPending = `{
+  "_links": {
+    "status": {
+      "href": "https://localhost/transactions_async/bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca"
+    }
+  },
+  "hash": "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca",
+  "tx_status": "PENDING"
+}`
+
+var asyncSubmissionError = `{
+  "_links": {
+    "status": {
+      "href": "https://localhost/transactions_async/bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca"
+    }
+  },
+  "hash": "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca",
+  "tx_status": "ERROR",
+  "error_result_xdr": "AAAAAAAAAGT////7AAAAAA=="
+}`
+
+var transactionMalformed = `{
+  "type": "transaction_malformed",
+  "title": "Transaction Malformed",
+  "status": 400,
+  "detail": "Horizon could not decode the transaction envelope in this request.",
+  "extras": {
+    "envelope_xdr": "AAAA"
+  }
+}`
+
+var asyncTransactionSuccess = `{
+  "hash": "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca",
+  "status": "SUCCESS",
+  "ledger": 354811,
+  "envelope_xdr": "AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0AAuV/AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAyTBGxOgfSApppsTnb/YRr6gOR8WT0LZNrhLh4y3FCgoAAAAXSHboAAAAAAAAAAABhlbgnAAAAEAivKe977CQCxMOKTuj+cWTFqc2OOJU8qGr9afrgu2zDmQaX5Q0cNshc3PiBwe0qw/+D/qJk5QqM5dYeSUGeDQP",
+  "result_xdr": "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAA="
+}`
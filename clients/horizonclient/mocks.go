@@ -27,6 +27,12 @@ func (m *MockClient) AccountData(request AccountRequest) (hProtocol.AccountData,
 	return a.Get(0).(hProtocol.AccountData), a.Error(1)
 }
 
+// Accounts is a mocking method
+func (m *MockClient) Accounts(request AccountsRequest) (hProtocol.AccountsPage, error) {
+	a := m.Called(request)
+	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
+}
+
 // Effects is a mocking method
 func (m *MockClient) Effects(request EffectRequest) (effects.EffectsPage, error) {
 	a := m.Called(request)
@@ -69,6 +75,12 @@ func (m *MockClient) Offers(request OfferRequest) (hProtocol.OffersPage, error)
 	return a.Get(0).(hProtocol.OffersPage), a.Error(1)
 }
 
+// OfferDetails is a mocking method
+func (m *MockClient) OfferDetails(offerID string) (hProtocol.Offer, error) {
+	a := m.Called(offerID)
+	return a.Get(0).(hProtocol.Offer), a.Error(1)
+}
+
 // Operations is a mocking method
 func (m *MockClient) Operations(request OperationRequest) (operations.OperationsPage, error) {
 	a := m.Called(request)
@@ -93,6 +105,30 @@ func (m *MockClient) SubmitTransaction(transaction txnbuild.Transaction) (hProto
 	return a.Get(0).(hProtocol.TransactionSuccess), a.Error(1)
 }
 
+// SubmitFeeBumpTransaction is a mocking method
+func (m *MockClient) SubmitFeeBumpTransaction(transaction txnbuild.FeeBumpTransaction) (hProtocol.TransactionSuccess, error) {
+	a := m.Called(transaction)
+	return a.Get(0).(hProtocol.TransactionSuccess), a.Error(1)
+}
+
+// SubmitTransactionXDRAsync is a mocking method
+func (m *MockClient) SubmitTransactionXDRAsync(transactionXdr string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	a := m.Called(transactionXdr)
+	return a.Get(0).(hProtocol.AsyncTransactionSubmissionResponse), a.Error(1)
+}
+
+// SubmitTransactionAsync is a mocking method
+func (m *MockClient) SubmitTransactionAsync(transaction txnbuild.Transaction) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	a := m.Called(transaction)
+	return a.Get(0).(hProtocol.AsyncTransactionSubmissionResponse), a.Error(1)
+}
+
+// AsyncTransactionStatus is a mocking method
+func (m *MockClient) AsyncTransactionStatus(txHash string) (hProtocol.AsyncTransactionStatus, error) {
+	a := m.Called(txHash)
+	return a.Get(0).(hProtocol.AsyncTransactionStatus), a.Error(1)
+}
+
 // Transactions is a mocking method
 func (m *MockClient) Transactions(request TransactionRequest) (hProtocol.TransactionsPage, error) {
 	a := m.Called(request)
@@ -117,6 +153,12 @@ func (m *MockClient) Paths(request PathsRequest) (hProtocol.PathsPage, error) {
 	return a.Get(0).(hProtocol.PathsPage), a.Error(1)
 }
 
+// StrictSendPaths is a mocking method
+func (m *MockClient) StrictSendPaths(request StrictSendPathsRequest) (hProtocol.PathsPage, error) {
+	a := m.Called(request)
+	return a.Get(0).(hProtocol.PathsPage), a.Error(1)
+}
+
 // Payments is a mocking method
 func (m *MockClient) Payments(request OperationRequest) (operations.OperationsPage, error) {
 	a := m.Called(request)
@@ -141,6 +183,11 @@ func (m *MockClient) Fund(addr string) (hProtocol.TransactionSuccess, error) {
 	return a.Get(0).(hProtocol.TransactionSuccess), a.Error(1)
 }
 
+// StreamAccount is a mocking method
+func (m *MockClient) StreamAccount(ctx context.Context, request AccountRequest, handler AccountHandler) error {
+	return m.Called(ctx, request, handler).Error(0)
+}
+
 // StreamTransactions is a mocking method
 func (m *MockClient) StreamTransactions(ctx context.Context, request TransactionRequest, handler TransactionHandler) error {
 	return m.Called(ctx, request, handler).Error(0)
@@ -187,6 +234,18 @@ func (m *MockClient) Root() (hProtocol.Root, error) {
 	return a.Get(0).(hProtocol.Root), a.Error(1)
 }
 
+// NextAccountsPage is a mocking method
+func (m *MockClient) NextAccountsPage(page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
+	a := m.Called(page)
+	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
+}
+
+// PrevAccountsPage is a mocking method
+func (m *MockClient) PrevAccountsPage(page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
+	a := m.Called(page)
+	return a.Get(0).(hProtocol.AccountsPage), a.Error(1)
+}
+
 // NextAssetsPage is a mocking method
 func (m *MockClient) NextAssetsPage(page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
 	a := m.Called(page)
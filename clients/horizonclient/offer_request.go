@@ -11,15 +11,36 @@ import (
 )
 
 // BuildURL creates the endpoint to be queried based on the data in the OfferRequest struct.
+// If "ForAccount" is set, the endpoint for the offers of the account is returned. Otherwise the
+// endpoint for all offers is returned.
 func (or OfferRequest) BuildURL() (endpoint string, err error) {
-	if or.ForAccount == "" {
-		return endpoint, errors.New(`parameter "ForAccount" required`)
-	}
-	endpoint = fmt.Sprintf("accounts/%s/offers", or.ForAccount)
-
-	queryParams := addQueryParams(cursor(or.Cursor), limit(or.Limit), or.Order)
-	if queryParams != "" {
-		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
+	if or.forOfferID != "" {
+		endpoint = fmt.Sprintf("offers/%s", or.forOfferID)
+	} else if or.ForAccount != "" {
+		endpoint = fmt.Sprintf("accounts/%s/offers", or.ForAccount)
+		queryParams := addQueryParams(cursor(or.Cursor), limit(or.Limit), or.Order)
+		if queryParams != "" {
+			endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
+		}
+	} else {
+		endpoint = "offers"
+		// The asset parameters are added to a map because their names differ from the assetCode
+		// and assetIssuer types.
+		queryParams := addQueryParams(
+			map[string]string{
+				"seller":               or.Seller,
+				"selling_asset_type":   string(or.SellingAssetType),
+				"selling_asset_code":   or.SellingAssetCode,
+				"selling_asset_issuer": or.SellingAssetIssuer,
+				"buying_asset_type":    string(or.BuyingAssetType),
+				"buying_asset_code":    or.BuyingAssetCode,
+				"buying_asset_issuer":  or.BuyingAssetIssuer,
+			},
+			cursor(or.Cursor), limit(or.Limit), or.Order,
+		)
+		if queryParams != "" {
+			endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
+		}
 	}
 
 	_, err = url.Parse(endpoint)
@@ -37,6 +58,9 @@ type OfferHandler func(hProtocol.Offer)
 // to stop streaming or context.Background() if you want to stream indefinitely.
 // OfferHandler is a user-supplied function that is executed for each streamed offer received.
 func (or OfferRequest) StreamOffers(ctx context.Context, client *Client, handler OfferHandler) (err error) {
+	if or.ForAccount == "" {
+		return errors.New(`parameter "ForAccount" required`)
+	}
 	endpoint, err := or.BuildURL()
 	if err != nil {
 		return errors.Wrap(err, "unable to build endpoint for offers request")
@@ -44,7 +68,7 @@ func (or OfferRequest) StreamOffers(ctx context.Context, client *Client, handler
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
 
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{or.CursorStore, or.OnGap}, func(data []byte) error {
 		var offer hProtocol.Offer
 		err = json.Unmarshal(data, &offer)
 		if err != nil {
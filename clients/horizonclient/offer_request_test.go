@@ -2,6 +2,7 @@ package horizonclient
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
@@ -25,6 +26,49 @@ func TestOfferRequestBuildUrl(t *testing.T) {
 	// It should return valid offers endpoint and no errors
 	require.NoError(t, err)
 	assert.Equal(t, "accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/offers?cursor=now&order=desc", endpoint)
+
+	er = OfferRequest{}
+	endpoint, err = er.BuildURL()
+
+	// It should return the endpoint for all offers
+	require.NoError(t, err)
+	assert.Equal(t, "offers", endpoint)
+
+	er = OfferRequest{
+		Seller:            "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		SellingAssetType:  AssetTypeNative,
+		BuyingAssetType:   AssetType4,
+		BuyingAssetCode:   "USD",
+		BuyingAssetIssuer: "GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM",
+		Limit:             20,
+	}
+	endpoint, err = er.BuildURL()
+
+	// It should return the endpoint for all offers filtered by seller and assets
+	require.NoError(t, err)
+	assert.Equal(t, "offers?buying_asset_code=USD&buying_asset_issuer=GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM&buying_asset_type=credit_alphanum4&limit=20&seller=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&selling_asset_type=native", endpoint)
+}
+
+func TestOfferDetails(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	_, err := client.OfferDetails("")
+	assert.EqualError(t, err, "invalid offer ID provided")
+
+	hmock.On(
+		"GET",
+		"https://localhost/offers/5269100",
+	).ReturnString(200, strings.TrimPrefix(offerStreamResponse, "data: "))
+
+	offer, err := client.OfferDetails("5269100")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(5269100), offer.ID)
+		assert.Equal(t, "20.4266087", offer.Amount)
+	}
 }
 
 func TestNextOffersPage(t *testing.T) {
@@ -103,6 +147,10 @@ func TestOfferRequestStreamOffers(t *testing.T) {
 	if assert.Error(t, err) {
 		assert.Contains(t, err.Error(), "got bad HTTP status code 500")
 	}
+
+	// only the offers of an account can be streamed
+	err = client.StreamOffers(context.Background(), OfferRequest{}, func(offer hProtocol.Offer) {})
+	assert.EqualError(t, err, `parameter "ForAccount" required`)
 }
 
 var offerStreamResponse = `data: {"_links":{"self":{"href":"https://horizon-testnet.paydex.org/offers/5269100"},"offer_maker":{"href":"https://horizon-testnet.paydex.org/accounts/GAQHWQYBBW272OOXNQMMLCA5WY2XAZPODGB7Q3S5OKKIXVESKO55ZQ7C"}},"id":5269100,"paging_token":"5269100","seller":"GAQHWQYBBW272OOXNQMMLCA5WY2XAZPODGB7Q3S5OKKIXVESKO55ZQ7C","selling":{"asset_type":"credit_alphanum4","asset_code":"DSQ","asset_issuer":"GBDQPTQJDATT7Z7EO4COS4IMYXH44RDLLI6N6WIL5BZABGMUOVMLWMQF"},"buying":{"asset_type":"credit_alphanum4","asset_code":"XCS6","asset_issuer":"GBH2V47NOZRC56QAYCPV5JUBG5NVFJQF5AQTUNFNWNDHSWWTKH2MWR2L"},"amount":"20.4266087","price_r":{"n":24819,"d":10000000},"price":"0.0024819","last_modified_ledger":674449,"last_modified_time":"2019-04-08T11:56:41Z"}
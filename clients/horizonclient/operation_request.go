@@ -38,7 +38,8 @@ func (op OperationRequest) BuildURL() (endpoint string, err error) {
 	}
 
 	queryParams := addQueryParams(cursor(op.Cursor), limit(op.Limit), op.Order,
-		includeFailed(op.IncludeFailed), join(op.Join))
+		includeFailed(op.IncludeFailed), join(op.Join), startTime(op.StartTime), endTime(op.EndTime),
+		types(op.Types), asset(op.Asset))
 	if queryParams != "" {
 		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
 	}
@@ -86,7 +87,7 @@ func (op OperationRequest) StreamOperations(ctx context.Context, client *Client,
 	}
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{op.CursorStore, op.OnGap}, func(data []byte) error {
 		var baseRecord operations.Base
 
 		if err = json.Unmarshal(data, &baseRecord); err != nil {
@@ -3,6 +3,7 @@ package horizonclient
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
 	"github.com/paydex-core/paydex-go/support/http/httptest"
@@ -79,6 +80,28 @@ func TestOperationRequestBuildUrl(t *testing.T) {
 	assert.Equal(t, "operations/1234?join=transactions", endpoint)
 }
 
+func TestOperationRequestBuildUrlWithFilters(t *testing.T) {
+	op := OperationRequest{
+		ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		StartTime:  time.Unix(1583020800, 0),
+		EndTime:    time.Unix(1585699200, 0),
+		Types:      []string{"path_payment_strict_receive", "path_payment_strict_send"},
+		Asset:      "native",
+		endpoint:   "operations",
+	}
+	endpoint, err := op.BuildURL()
+
+	// It should return valid account operations endpoint with the filters and no errors
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/operations?"+
+			"asset=native&end_time=1585699200000&start_time=1583020800000&"+
+			"type=path_payment_strict_receive%2Cpath_payment_strict_send",
+		endpoint,
+	)
+}
+
 func TestNextOperationsPage(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
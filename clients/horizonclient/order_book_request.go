@@ -50,7 +50,7 @@ func (obr OrderBookRequest) StreamOrderBooks(ctx context.Context, client *Client
 	}
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{}, func(data []byte) error {
 		var orderbook hProtocol.OrderBookSummary
 		err = json.Unmarshal(data, &orderbook)
 		if err != nil {
@@ -0,0 +1,322 @@
+package horizonclient
+
+import (
+	"encoding/hex"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// RetryPolicy controls how a Client retries failed requests.
+//
+// Only idempotent requests, that is every request except transaction
+// submissions, are retried after connection errors and 429, 502, 503 and 504
+// responses. Submissions are retried after 429 responses, which Horizon sends
+// before submitting the transaction. After connection errors, and 502, 503 and
+// 504 responses such as submission timeouts, the transaction may still be
+// applied, so it is looked up by hash and only submitted again, with the same
+// envelope, when Horizon doesn't know about it. A transaction is therefore
+// never applied, or rejected, in a way the caller isn't told about.
+//
+// Retries wait for an exponential backoff with jitter, or for the delay
+// requested by Horizon with the Retry-After or X-Ratelimit-Reset headers. When
+// the X-Ratelimit-Remaining header shows that the rate limit of the client is
+// exhausted, requests wait until it resets.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of times a request is retried.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry. It doubles for
+	// every retry, up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Jitter is the fraction, between 0 and 1, of every backoff delay which
+	// is randomized, so that clients failing together don't retry together.
+	Jitter float64
+	// MaxWait is the longest delay requested by Horizon which the client
+	// waits for. Requests fail instead of waiting for longer delays. There is
+	// no limit when it is 0.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most clients.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxRetries:     5,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     30 * time.Second,
+	Jitter:         0.5,
+	MaxWait:        5 * time.Minute,
+}
+
+// backoff returns the delay before a retry.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 0; i < retry && (p.MaxBackoff == 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff != 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		jitter := time.Duration(p.Jitter * float64(delay))
+		if jitter > 0 {
+			delay = delay - jitter + time.Duration(rand.Int63n(int64(jitter)+1))
+		}
+	}
+	return delay
+}
+
+// requestResult describes the response to a request, for deciding whether
+// the request is retried.
+type requestResult struct {
+	// sent is false when the request failed before it was sent, in which
+	// case retrying it doesn't help.
+	sent bool
+	// status is 0 when no response was received.
+	status int
+	header http.Header
+	// bytes is the size of the body of the response.
+	bytes int64
+}
+
+// retryable returns true for results which may be transient.
+func (r requestResult) retryable() bool {
+	if !r.sent {
+		return false
+	}
+	switch r.status {
+	case 0, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryDelay returns the delay before the given retry of a request, and false
+// if the request shouldn't be retried.
+func (c *Client) retryDelay(retry int, result requestResult) (time.Duration, bool) {
+	p := c.RetryPolicy
+	if p == nil || retry >= p.MaxRetries || !result.retryable() {
+		return 0, false
+	}
+
+	delay := p.backoff(retry)
+	if after, ok := c.retryAfter(result.header); ok {
+		delay = after
+	} else if reset, ok := rateLimitReset(result.header); ok && result.status == http.StatusTooManyRequests {
+		delay = reset
+	}
+
+	if p.MaxWait != 0 && delay > p.MaxWait {
+		return 0, false
+	}
+	return delay, true
+}
+
+// retryAfter parses the Retry-After header, which holds either a number of
+// seconds or a date.
+func (c *Client) retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(c.clock.Now())
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// rateLimitReset parses the X-Ratelimit-Reset header, the number of seconds
+// until the rate limit of the client resets.
+func rateLimitReset(header http.Header) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(header.Get("X-Ratelimit-Reset"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// trackRateLimit records when the rate limit of the client resets, if the
+// response shows that it is exhausted.
+func (c *Client) trackRateLimit(header http.Header) {
+	if header.Get("X-Ratelimit-Remaining") != "0" {
+		return
+	}
+	if reset, ok := rateLimitReset(header); ok {
+		atomic.StoreInt64(&c.rateLimitReset, c.clock.Now().Add(reset).UnixNano())
+	}
+}
+
+// waitForRateLimit waits until the rate limit of the client resets, if it is
+// exhausted and a RetryPolicy is set.
+func (c *Client) waitForRateLimit() {
+	p := c.RetryPolicy
+	if p == nil {
+		return
+	}
+	reset := atomic.LoadInt64(&c.rateLimitReset)
+	if reset == 0 {
+		return
+	}
+	delay := time.Duration(reset - c.clock.Now().UnixNano())
+	if delay <= 0 || (p.MaxWait != 0 && delay > p.MaxWait) {
+		return
+	}
+	c.pause(delay)
+}
+
+func (c *Client) pause(delay time.Duration) {
+	if c.sleep != nil {
+		c.sleep(delay)
+		return
+	}
+	time.Sleep(delay)
+}
+
+// sendRequestWithRetries sends an idempotent request, retrying it according
+// to the RetryPolicy of the client.
+func (c *Client) sendRequestWithRetries(requestURL string, a interface{}) error {
+	info := c.requestStarted("get", requestURL)
+	for retry := 0; ; retry++ {
+		c.waitForRateLimit()
+		result, err := c.doRequest(requestURL, "get", a)
+		if err == nil {
+			c.requestFinished(info, result, retry, nil)
+			return nil
+		}
+		delay, ok := c.retryDelay(retry, result)
+		if !ok {
+			c.requestFinished(info, result, retry, err)
+			return err
+		}
+		c.pause(delay)
+	}
+}
+
+// submitTransaction submits a transaction, retrying the submission according
+// to the RetryPolicy of the client without ever submitting a transaction
+// which may have been applied.
+func (c *Client) submitTransaction(requestURL, transactionXdr string, a interface{}) (err error) {
+	var result requestResult
+	retry := 0
+	info := c.requestStarted("post", requestURL)
+	defer func() {
+		c.requestFinished(info, result, retry, err)
+	}()
+
+	for ; ; retry++ {
+		c.waitForRateLimit()
+		result, err = c.doRequest(requestURL, "post", a)
+		if err == nil {
+			return nil
+		}
+		delay, ok := c.retryDelay(retry, result)
+		if !ok {
+			return err
+		}
+		c.pause(delay)
+
+		if result.status == http.StatusTooManyRequests {
+			// the transaction was rejected before being submitted
+			continue
+		}
+
+		tx, found, lookupErr := c.findTransaction(transactionXdr)
+		if lookupErr != nil {
+			// Without knowing whether the transaction was applied it
+			// can't be submitted again.
+			return err
+		}
+		if found {
+			return submittedTransactionResult(tx, a)
+		}
+	}
+}
+
+// findTransaction looks up a submitted transaction by hash.
+func (c *Client) findTransaction(transactionXdr string) (hProtocol.Transaction, bool, error) {
+	passphrase, err := c.networkPassphrase()
+	if err != nil {
+		return hProtocol.Transaction{}, false, err
+	}
+	return lookupTransaction(c, passphrase, transactionXdr)
+}
+
+// lookupTransaction looks up a submitted transaction by hash with client,
+// returning false if the Horizon server doesn't know about it.
+func lookupTransaction(client ClientInterface, passphrase, transactionXdr string) (hProtocol.Transaction, bool, error) {
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(transactionXdr, &envelope); err != nil {
+		return hProtocol.Transaction{}, false, errors.Wrap(err, "decoding transaction envelope")
+	}
+	hash, err := network.HashTransactionInEnvelope(envelope, passphrase)
+	if err != nil {
+		return hProtocol.Transaction{}, false, errors.Wrap(err, "hashing transaction")
+	}
+
+	tx, err := client.TransactionDetail(hex.EncodeToString(hash[:]))
+	if err != nil {
+		if herr, ok := err.(*Error); ok && herr.Problem.Status == http.StatusNotFound {
+			return hProtocol.Transaction{}, false, nil
+		}
+		return hProtocol.Transaction{}, false, err
+	}
+	return tx, true, nil
+}
+
+// networkPassphrase returns the passphrase of the network of the Horizon
+// server.
+func (c *Client) networkPassphrase() (string, error) {
+	if c.isTestNet {
+		return network.TestNetworkPassphrase, nil
+	}
+	root, err := c.Root()
+	if err != nil {
+		return "", errors.Wrap(err, "loading network passphrase")
+	}
+	return root.NetworkPassphrase, nil
+}
+
+// submittedTransactionResult returns the result of the submission of a
+// transaction found in the history of Horizon, either by filling the
+// TransactionSuccess a, or with an error similar to the error returned by
+// Horizon for failed transactions.
+func submittedTransactionResult(tx hProtocol.Transaction, a interface{}) error {
+	if !tx.Successful {
+		return &Error{Problem: problem.P{
+			Type:   "transaction_failed",
+			Title:  "Transaction Failed",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction failed when submitted to the paydex network. " +
+				"The `extras.result_xdr` field on this response contains further details.",
+			Extras: map[string]interface{}{
+				"envelope_xdr": tx.EnvelopeXdr,
+				"result_xdr":   tx.ResultXdr,
+			},
+		}}
+	}
+
+	if success, ok := a.(*hProtocol.TransactionSuccess); ok {
+		success.Links.Transaction = tx.Links.Self
+		success.Hash = tx.Hash
+		success.Ledger = tx.Ledger
+		success.Env = tx.EnvelopeXdr
+		success.Result = tx.ResultXdr
+		success.Meta = tx.ResultMetaXdr
+	}
+	return nil
+}
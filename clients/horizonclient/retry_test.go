@@ -0,0 +1,273 @@
+package horizonclient
+
+import (
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/support/clock"
+	"github.com/paydex-core/paydex-go/support/clock/clocktest"
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const retryTxXdr = `AAAAABB90WssODNIgi6BHveqzxTRmIpvAFRyVNM+Hm2GVuCcAAAAZAAABD0AAuV/AAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAyTBGxOgfSApppsTnb/YRr6gOR8WT0LZNrhLh4y3FCgoAAAAXSHboAAAAAAAAAAABhlbgnAAAAEAivKe977CQCxMOKTuj+cWTFqc2OOJU8qGr9afrgu2zDmQaX5Q0cNshc3PiBwe0qw/+D/qJk5QqM5dYeSUGeDQP`
+
+// responses returns a responder which returns the given responses in order,
+// and counts the requests it receives.
+func responses(requests *int, responses ...*http.Response) httpmock.Responder {
+	return func(*http.Request) (*http.Response, error) {
+		i := *requests
+		*requests++
+		if i >= len(responses) {
+			i = len(responses) - 1
+		}
+		if responses[i] == nil {
+			return nil, &timeoutError{}
+		}
+		return responses[i], nil
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func response(status int, body string, header map[string]string) *http.Response {
+	resp := httpmock.NewStringResponse(status, body)
+	for key, value := range header {
+		resp.Header.Set(key, value)
+	}
+	return resp
+}
+
+func newRetryClient() (*Client, *httptest.Client, *[]time.Duration) {
+	hmock := httptest.NewClient()
+	var delays []time.Duration
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries:     3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     3 * time.Second,
+			MaxWait:        time.Minute,
+		},
+		isTestNet: true,
+		clock: &clock.Clock{
+			Source: clocktest.FixedSource(time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		sleep: func(delay time.Duration) { delays = append(delays, delay) },
+	}
+	return client, hmock, &delays
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, policy.backoff(0))
+	assert.Equal(t, 2*time.Second, policy.backoff(1))
+	assert.Equal(t, 4*time.Second, policy.backoff(2))
+	assert.Equal(t, 5*time.Second, policy.backoff(3))
+	assert.Equal(t, 5*time.Second, policy.backoff(100))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(1)
+		assert.True(t, delay >= time.Second && delay <= 2*time.Second, "delay %v", delay)
+	}
+}
+
+func TestRetryReads(t *testing.T) {
+	client, hmock, delays := newRetryClient()
+	requests := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(responses(&requests,
+		response(503, `{"status": 503}`, nil),
+		nil,
+		response(200, `{"sequence": 1}`, nil),
+	))
+
+	ledger, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), ledger.Sequence)
+	assert.Equal(t, 3, requests)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *delays)
+
+	// requests are retried MaxRetries times
+	requests = 0
+	*delays = nil
+	hmock.On("GET", "https://localhost/ledgers/2").Return(responses(&requests,
+		response(502, `{"status": 502}`, nil),
+	))
+	_, err = client.LedgerDetail(2)
+	assert.Error(t, err)
+	assert.Equal(t, 4, requests)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second, 3 * time.Second}, *delays)
+
+	// other errors are not retried
+	requests = 0
+	hmock.On("GET", "https://localhost/ledgers/3").Return(responses(&requests,
+		response(404, `{"status": 404, "title": "Resource Missing"}`, nil),
+	))
+	_, err = client.LedgerDetail(3)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+
+	// requests are sent once without a retry policy
+	client.RetryPolicy = nil
+	requests = 0
+	_, err = client.LedgerDetail(2)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRetryRateLimits(t *testing.T) {
+	client, hmock, delays := newRetryClient()
+	requests := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(responses(&requests,
+		response(429, `{"status": 429}`, map[string]string{"Retry-After": "7"}),
+		response(429, `{"status": 429}`, map[string]string{"Retry-After": "Wed, 01 Apr 2020 00:00:09 GMT"}),
+		response(429, `{"status": 429}`, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "11"}),
+		response(200, `{"sequence": 1}`, nil),
+	))
+
+	_, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, 4, requests)
+	// The rate limit reset recorded from the last 429 response is waited
+	// for before the following request too.
+	assert.Equal(t, []time.Duration{7 * time.Second, 9 * time.Second, 11 * time.Second, 11 * time.Second}, *delays)
+
+	// requests fail instead of waiting longer than MaxWait
+	client, hmock, delays = newRetryClient()
+	requests = 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(responses(&requests,
+		response(429, `{"status": 429}`, map[string]string{"Retry-After": "3600"}),
+	))
+	_, err = client.LedgerDetail(1)
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests)
+	assert.Empty(t, *delays)
+}
+
+func TestRetryWaitsForExhaustedRateLimit(t *testing.T) {
+	client, hmock, delays := newRetryClient()
+	requests := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(responses(&requests,
+		response(200, `{"sequence": 1}`, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "30"}),
+	))
+
+	_, err := client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Empty(t, *delays)
+
+	_, err = client.LedgerDetail(1)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{30 * time.Second}, *delays)
+}
+
+func retryTxHash(t *testing.T) string {
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(retryTxXdr, &envelope))
+	hash, err := network.HashTransactionInEnvelope(envelope, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	return hex.EncodeToString(hash[:])
+}
+
+func TestRetrySubmissionTimeout(t *testing.T) {
+	client, hmock, delays := newRetryClient()
+	hash := retryTxHash(t)
+
+	// the transaction was applied although the submission timed out
+	submissions := 0
+	hmock.On("POST", "https://localhost/transactions").Return(responses(&submissions,
+		response(504, `{"type": "timeout", "status": 504}`, nil),
+	))
+	lookups := 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(responses(&lookups,
+		response(200, `{"successful": true, "hash": "`+hash+`", "ledger": 7, "envelope_xdr": "`+retryTxXdr+`", "result_xdr": "result"}`, nil),
+	))
+
+	success, err := client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, hash, success.Hash)
+	assert.Equal(t, int32(7), success.Ledger)
+	assert.Equal(t, "result", success.Result)
+	assert.Equal(t, 1, submissions)
+	assert.Equal(t, 1, lookups)
+	assert.Equal(t, []time.Duration{time.Second}, *delays)
+
+	// the transaction failed although the submission timed out
+	submissions, lookups = 0, 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(responses(&lookups,
+		response(200, `{"successful": false, "hash": "`+hash+`", "ledger": 7, "result_xdr": "failed"}`, nil),
+	))
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	if assert.Error(t, err) {
+		herr, ok := err.(*Error)
+		require.True(t, ok)
+		assert.Equal(t, "transaction_failed", herr.Problem.Type)
+		result, rerr := herr.ResultString()
+		require.NoError(t, rerr)
+		assert.Equal(t, "failed", result)
+	}
+	assert.Equal(t, 1, submissions)
+}
+
+func TestRetrySubmissionResubmits(t *testing.T) {
+	client, hmock, _ := newRetryClient()
+	hash := retryTxHash(t)
+
+	// the transaction is submitted again once Horizon confirms it doesn't
+	// know about it
+	submissions := 0
+	hmock.On("POST", "https://localhost/transactions").Return(responses(&submissions,
+		nil,
+		response(429, `{"status": 429}`, map[string]string{"Retry-After": "1"}),
+		response(200, `{"hash": "`+hash+`", "ledger": 8}`, nil),
+	))
+	lookups := 0
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(responses(&lookups,
+		response(404, `{"status": 404, "title": "Resource Missing"}`, nil),
+	))
+
+	success, err := client.SubmitTransactionXDR(retryTxXdr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(8), success.Ledger)
+	assert.Equal(t, 3, submissions)
+	// 429 responses are sent before the transaction is submitted, so the
+	// transaction is only looked up after the connection error
+	assert.Equal(t, 1, lookups)
+
+	// the transaction is not submitted again if it can't be looked up
+	submissions, lookups = 0, 0
+	hmock.On("POST", "https://localhost/transactions").Return(responses(&submissions,
+		response(504, `{"type": "timeout", "status": 504}`, nil),
+	))
+	hmock.On("GET", "https://localhost/transactions/"+hash).Return(responses(&lookups,
+		response(500, `{"status": 500}`, nil),
+	))
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	if assert.Error(t, err) {
+		herr, ok := err.(*Error)
+		require.True(t, ok)
+		assert.Equal(t, "timeout", herr.Problem.Type)
+	}
+	assert.Equal(t, 1, submissions)
+	assert.Equal(t, 1, lookups)
+
+	// failed transactions are not submitted again
+	submissions = 0
+	hmock.On("POST", "https://localhost/transactions").Return(responses(&submissions,
+		response(400, `{"type": "transaction_failed", "status": 400}`, nil),
+	))
+	_, err = client.SubmitTransactionXDR(retryTxXdr)
+	assert.Error(t, err)
+	assert.Equal(t, 1, submissions)
+}
@@ -0,0 +1,204 @@
+package horizonclient
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// horizonRoutes parses the routes installed by the horizon router in
+// services/horizon/internal/web.go, as "METHOD /path" strings.
+func horizonRoutes(t *testing.T) []string {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "../../services/horizon/internal/web.go", nil, 0)
+	require.NoError(t, err)
+
+	// string variables holding paths, such as the path of the account offers
+	// route
+	variables := map[string]string{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if assign, ok := node.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 {
+			ident, identOK := assign.Lhs[0].(*ast.Ident)
+			if value, valueOK := stringValue(assign.Rhs[0], nil); identOK && valueOK {
+				variables[ident.Name] = value
+			}
+		}
+		return true
+	})
+
+	routes := map[string]bool{}
+	var walk func(node ast.Node, prefix string)
+	walk = func(node ast.Node, prefix string) {
+		ast.Inspect(node, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+
+			var method, path string
+			switch selector.Sel.Name {
+			case "Route":
+				if len(call.Args) == 2 {
+					if sub, ok := stringValue(call.Args[0], variables); ok {
+						walk(call.Args[1], prefix+sub)
+						return false
+					}
+				}
+				return true
+			case "Get", "Post":
+				if len(call.Args) != 2 {
+					return true
+				}
+				method = strings.ToUpper(selector.Sel.Name)
+				path, ok = stringValue(call.Args[0], variables)
+			case "Method":
+				if len(call.Args) != 3 {
+					return true
+				}
+				method, ok = stringValue(call.Args[0], variables)
+				if ok {
+					path, ok = stringValue(call.Args[1], variables)
+				}
+			default:
+				return true
+			}
+			if !ok {
+				return true
+			}
+
+			route := strings.TrimSuffix(prefix+path, "/")
+			if route == "" {
+				route = "/"
+			}
+			routes[method+" "+route] = true
+			return true
+		})
+	}
+	walk(file, "")
+
+	var sorted []string
+	for route := range routes {
+		sorted = append(sorted, route)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// stringValue returns the value of a string literal, of the http.MethodGet and
+// http.MethodPost constants, or of a variable holding a string.
+func stringValue(expr ast.Expr, variables map[string]string) (string, bool) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind != token.STRING {
+			return "", false
+		}
+		value, err := strconv.Unquote(expr.Value)
+		return value, err == nil
+	case *ast.SelectorExpr:
+		switch expr.Sel.Name {
+		case "MethodGet":
+			return "GET", true
+		case "MethodPost":
+			return "POST", true
+		}
+	case *ast.Ident:
+		value, ok := variables[expr.Name]
+		return value, ok
+	}
+	return "", false
+}
+
+func TestClientCoversHorizonRoutes(t *testing.T) {
+	const (
+		accountID = "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+		txHash    = "1534f6507420c6871b557cc2fc800c29fb1ed1e012e694993ffe7a39c824056e"
+	)
+	submit := submitRequest{endpoint: "transactions", transactionXdr: "AAAA"}
+
+	// the requests sent by the client for every route of horizon
+	requests := map[string]HorizonRequest{
+		"GET /":                                   nil,
+		"GET /metrics":                            metricsRequest{endpoint: "metrics"},
+		"GET /fee_stats":                          feeStatsRequest{endpoint: "fee_stats"},
+		"GET /ledgers":                            LedgerRequest{},
+		"GET /ledgers/{ledger_id}":                LedgerRequest{forSequence: 1},
+		"GET /ledgers/{ledger_id}/transactions":   TransactionRequest{ForLedger: 1},
+		"GET /ledgers/{ledger_id}/operations":     OperationRequest{ForLedger: 1, endpoint: "operations"},
+		"GET /ledgers/{ledger_id}/payments":       OperationRequest{ForLedger: 1, endpoint: "payments"},
+		"GET /ledgers/{ledger_id}/effects":        EffectRequest{ForLedger: "1"},
+		"GET /accounts":                           AccountsRequest{Signer: accountID},
+		"GET /accounts/{account_id}":              AccountRequest{AccountID: accountID},
+		"GET /accounts/{account_id}/balances":     AccountRequest{AccountID: accountID, Ledger: 1},
+		"GET /accounts/{account_id}/transactions": TransactionRequest{ForAccount: accountID},
+		"GET /accounts/{account_id}/operations":   OperationRequest{ForAccount: accountID, endpoint: "operations"},
+		"GET /accounts/{account_id}/payments":     OperationRequest{ForAccount: accountID, endpoint: "payments"},
+		"GET /accounts/{account_id}/effects":      EffectRequest{ForAccount: accountID},
+		"GET /accounts/{account_id}/trades":       TradeRequest{ForAccount: accountID},
+		"GET /accounts/{account_id}/data/{key}":   AccountRequest{AccountID: accountID, DataKey: "key"},
+		"GET /accounts/{account_id}/offers":       OfferRequest{ForAccount: accountID},
+		"GET /transactions":                       TransactionRequest{},
+		"POST /transactions":                      submit,
+		"POST /transactions_async":                submitRequest{endpoint: "transactions_async", transactionXdr: "AAAA", async: true},
+		"GET /transactions_async/{tx_id}":         asyncTransactionStatusRequest{hash: txHash},
+		"GET /transactions/{tx_id}":               TransactionRequest{forTransactionHash: txHash},
+		"GET /transactions/{tx_id}/operations":    OperationRequest{ForTransaction: txHash, endpoint: "operations"},
+		"GET /transactions/{tx_id}/payments":      OperationRequest{ForTransaction: txHash, endpoint: "payments"},
+		"GET /transactions/{tx_id}/effects":       EffectRequest{ForTransaction: txHash},
+		"GET /operations":                         OperationRequest{endpoint: "operations"},
+		"GET /operations/{id}":                    OperationRequest{forOperationID: "1", endpoint: "operations"},
+		"GET /operations/{op_id}/effects":         EffectRequest{ForOperation: "1"},
+		"GET /payments":                           OperationRequest{endpoint: "payments"},
+		"GET /effects":                            EffectRequest{},
+		"GET /trades":                             TradeRequest{},
+		"GET /trade_aggregations":                 TradeAggregationRequest{},
+		"GET /offers":                             OfferRequest{},
+		"GET /offers/{id}":                        OfferRequest{forOfferID: "1"},
+		"GET /offers/{offer_id}/trades":           TradeRequest{ForOfferID: "1"},
+		"GET /order_book":                         OrderBookRequest{},
+		"GET /paths":                              PathsRequest{},
+		"GET /paths/strict-receive":               nil,
+		"GET /paths/strict-send":                  StrictSendPathsRequest{},
+		"GET /assets":                             AssetRequest{},
+		"GET /friendbot":                          nil,
+		"POST /friendbot":                         nil,
+		"GET /graphql":                            nil,
+		"POST /graphql":                           nil,
+	}
+	// Routes served by the client without a HorizonRequest: the root by
+	// Root, /paths/strict-receive by Paths through its /paths alias, and
+	// friendbot by Fund. /graphql is left to GraphQL clients.
+
+	var expected []string
+	for route := range requests {
+		expected = append(expected, route)
+	}
+	sort.Strings(expected)
+	assert.Equal(t, expected, horizonRoutes(t), "the client doesn't cover the routes of horizon")
+
+	for route, request := range requests {
+		if request == nil {
+			continue
+		}
+		endpoint, err := request.BuildURL()
+		require.NoError(t, err, route)
+		u, err := url.Parse(endpoint)
+		require.NoError(t, err, route)
+
+		parts := strings.SplitN(route, " ", 2)
+		pattern := regexp.MustCompile(`\{[a-z_]+\}`).ReplaceAllString(parts[1], `[^/]+`)
+		assert.Regexp(t, "^"+pattern+"$", "/"+u.Path, route)
+	}
+}
@@ -0,0 +1,35 @@
+package horizonclient
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// BuildURL creates the endpoint to be queried based on the data in the StrictSendPathsRequest struct.
+func (pr StrictSendPathsRequest) BuildURL() (endpoint string, err error) {
+	endpoint = "paths/strict-send"
+
+	// add the parameters to a map here so it is easier for addQueryParams to populate the parameter list
+	// We can't use assetCode and assetIssuer types here because the paremeter names are different
+	paramMap := make(map[string]string)
+	paramMap["destination_account"] = pr.DestinationAccount
+	paramMap["destination_assets"] = pr.DestinationAssets
+	paramMap["source_asset_type"] = string(pr.SourceAssetType)
+	paramMap["source_asset_code"] = pr.SourceAssetCode
+	paramMap["source_asset_issuer"] = pr.SourceAssetIssuer
+	paramMap["source_amount"] = pr.SourceAmount
+
+	queryParams := addQueryParams(paramMap)
+	if queryParams != "" {
+		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
+	}
+
+	_, err = url.Parse(endpoint)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse endpoint")
+	}
+
+	return endpoint, err
+}
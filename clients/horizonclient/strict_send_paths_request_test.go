@@ -0,0 +1,51 @@
+package horizonclient
+
+import (
+	"testing"
+
+	"github.com/paydex-core/paydex-go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStrictSendPathsRequestBuildUrl(t *testing.T) {
+	endpoint, err := StrictSendPathsRequest{}.BuildURL()
+
+	// Horizon will return an error though because there are no parameters
+	require.NoError(t, err)
+	assert.Equal(t, "paths/strict-send", endpoint)
+
+	endpoint, err = StrictSendPathsRequest{
+		DestinationAssets: "native,EUR:GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+		SourceAssetType:   AssetType4,
+		SourceAssetCode:   "USD",
+		SourceAssetIssuer: "GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM",
+		SourceAmount:      "20",
+	}.BuildURL()
+	require.NoError(t, err)
+	assert.Equal(t, "paths/strict-send?destination_assets=native%2CEUR%3AGDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN&source_amount=20&source_asset_code=USD&source_asset_issuer=GDZST3XVCDTUJ76ZAV2HA72KYQODXXZ5PTMAPZGDHZ6CS7RO7MGG3DBM&source_asset_type=credit_alphanum4", endpoint)
+}
+
+func TestStrictSendPathsRequest(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		HorizonURL: "https://localhost/",
+		HTTP:       hmock,
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/paths/strict-send?destination_account=GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU&source_amount=30&source_asset_type=native",
+	).ReturnString(200, pathsResponse)
+
+	paths, err := client.StrictSendPaths(StrictSendPathsRequest{
+		DestinationAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		SourceAssetType:    AssetTypeNative,
+		SourceAmount:       "30",
+	})
+	if assert.NoError(t, err) {
+		record := paths.Embedded.Records[0]
+		assert.Equal(t, "20.0000000", record.DestinationAmount)
+		assert.Equal(t, "30.0000000", record.SourceAmount)
+	}
+}
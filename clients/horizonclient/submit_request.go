@@ -19,3 +19,13 @@ func (sr submitRequest) BuildURL() (endpoint string, err error) {
 	endpoint = fmt.Sprintf("%s?%s", sr.endpoint, query.Encode())
 	return endpoint, err
 }
+
+// BuildURL returns the url for the status of a transaction submitted
+// asynchronously to a running horizon instance
+func (sr asyncTransactionStatusRequest) BuildURL() (endpoint string, err error) {
+	if sr.hash == "" {
+		return endpoint, errors.New("invalid request: no transaction hash provided")
+	}
+
+	return "transactions_async/" + sr.hash, nil
+}
@@ -35,7 +35,8 @@ func (tr TradeRequest) BuildURL() (endpoint string, err error) {
 	var queryParams string
 
 	if endpoint != "trades" {
-		queryParams = addQueryParams(cursor(tr.Cursor), limit(tr.Limit), tr.Order)
+		queryParams = addQueryParams(cursor(tr.Cursor), limit(tr.Limit), tr.Order,
+			startTime(tr.StartTime), endTime(tr.EndTime), asset(tr.Asset))
 	} else {
 		// add the parameters for all trades endpoint
 		paramMap := make(map[string]string)
@@ -47,7 +48,8 @@ func (tr TradeRequest) BuildURL() (endpoint string, err error) {
 		paramMap["counter_asset_issuer"] = tr.CounterAssetIssuer
 		paramMap["offer_id"] = tr.ForOfferID
 
-		queryParams = addQueryParams(paramMap, cursor(tr.Cursor), limit(tr.Limit), tr.Order)
+		queryParams = addQueryParams(paramMap, cursor(tr.Cursor), limit(tr.Limit), tr.Order,
+			startTime(tr.StartTime), endTime(tr.EndTime), asset(tr.Asset))
 	}
 
 	if queryParams != "" {
@@ -77,7 +79,7 @@ func (tr TradeRequest) StreamTrades(ctx context.Context, client *Client,
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
 
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{tr.CursorStore, tr.OnGap}, func(data []byte) error {
 		var trade hProtocol.Trade
 		err = json.Unmarshal(data, &trade)
 		if err != nil {
@@ -3,6 +3,7 @@ package horizonclient
 import (
 	"context"
 	"testing"
+	"time"
 
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/support/http/httptest"
@@ -55,6 +56,26 @@ func TestTradeRequestBuildUrl(t *testing.T) {
 
 }
 
+func TestTradeRequestBuildUrlWithFilters(t *testing.T) {
+	tr := TradeRequest{
+		ForAccount: "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		EndTime:    time.Unix(1585699200, 0),
+		Asset:      "native",
+	}
+	endpoint, err := tr.BuildURL()
+
+	// It should return valid account trades endpoint with the filters and no errors
+	require.NoError(t, err)
+	assert.Equal(t, "accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/trades?asset=native&end_time=1585699200000", endpoint)
+
+	tr = TradeRequest{StartTime: time.Unix(1583020800, 0)}
+	endpoint, err = tr.BuildURL()
+
+	// It should return valid all trades endpoint with the filters and no errors
+	require.NoError(t, err)
+	assert.Equal(t, "trades?start_time=1583020800000", endpoint)
+}
+
 func TestTradesRequest(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
@@ -19,6 +19,10 @@ func (tr TransactionRequest) BuildURL() (endpoint string, err error) {
 		return endpoint, errors.New("invalid request: too many parameters")
 	}
 
+	if (tr.Memo != "" || tr.MemoType != "") && tr.ForAccount == "" {
+		return endpoint, errors.New("invalid request: memo requires an account")
+	}
+
 	endpoint = "transactions"
 	if tr.ForAccount != "" {
 		endpoint = fmt.Sprintf("accounts/%s/transactions", tr.ForAccount)
@@ -31,7 +35,8 @@ func (tr TransactionRequest) BuildURL() (endpoint string, err error) {
 	}
 
 	queryParams := addQueryParams(cursor(tr.Cursor), limit(tr.Limit), tr.Order,
-		includeFailed(tr.IncludeFailed))
+		includeFailed(tr.IncludeFailed), startTime(tr.StartTime), endTime(tr.EndTime),
+		types(tr.OperationTypes), asset(tr.Asset), memo(tr.Memo), memoType(tr.MemoType))
 	if queryParams != "" {
 		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams)
 	}
@@ -58,7 +63,7 @@ func (tr TransactionRequest) StreamTransactions(ctx context.Context, client *Cli
 
 	url := fmt.Sprintf("%s%s", client.fixHorizonURL(), endpoint)
 
-	return client.stream(ctx, url, func(data []byte) error {
+	return client.stream(ctx, url, streamCursor{tr.CursorStore, tr.OnGap}, func(data []byte) error {
 		var transaction hProtocol.Transaction
 		err = json.Unmarshal(data, &transaction)
 		if err != nil {
@@ -3,6 +3,7 @@ package horizonclient
 import (
 	"context"
 	"testing"
+	"time"
 
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/support/http/httptest"
@@ -55,6 +56,47 @@ func TestTransactionRequestBuildUrl(t *testing.T) {
 
 }
 
+func TestTransactionRequestBuildUrlWithFilters(t *testing.T) {
+	tr := TransactionRequest{
+		ForAccount:     "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU",
+		StartTime:      time.Unix(1583020800, 0),
+		EndTime:        time.Unix(1585699200, 0),
+		OperationTypes: []string{"payment"},
+		IncludeFailed:  true,
+	}
+	endpoint, err := tr.BuildURL()
+
+	// It should return valid account transactions endpoint with the filters and no errors
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/transactions?"+
+			"end_time=1585699200000&include_failed=true&start_time=1583020800000&type=payment",
+		endpoint,
+	)
+}
+
+func TestTransactionRequestBuildUrlWithMemo(t *testing.T) {
+	tr := TransactionRequest{Memo: "1234", MemoType: "id"}
+	_, err := tr.BuildURL()
+
+	// error case: memo without an account
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid request: memo requires an account")
+	}
+
+	tr.ForAccount = "GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU"
+	endpoint, err := tr.BuildURL()
+
+	// It should return valid account transactions endpoint with the memo and no errors
+	require.NoError(t, err)
+	assert.Equal(
+		t,
+		"accounts/GCLWGQPMKXQSPF776IU33AH4PZNOOWNAWGGKVTBQMIC5IMKUNP3E6NVU/transactions?memo=1234&memo_type=id",
+		endpoint,
+	)
+}
+
 func TestNextTransactionsPage(t *testing.T) {
 	hmock := httptest.NewClient()
 	client := &Client{
@@ -0,0 +1,381 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// WebSocket is a connection to the WebSocket transport of the streams of a
+// Horizon server, over which several streams are subscribed at once. Unlike
+// Server-Sent Events streams, the streams are requested again by the server
+// when they end, so the connection stays open until it is closed.
+//
+// Streams are subscribed to with Subscribe, or one of the typed methods such
+// as SubscribePayments, and their events are passed to the handlers by Run.
+type WebSocket struct {
+	// OnSubscriptionError is called when the server ends a subscription with
+	// an error, for example because its account doesn't exist. When it is
+	// nil, Run returns the error instead.
+	OnSubscriptionError func(id string, err error)
+
+	client *Client
+	conn   *websocket.Conn
+
+	writeMutex    sync.Mutex // serializes the messages sent to the server
+	mutex         sync.Mutex // protects subscriptions
+	subscriptions map[string]*webSocketSubscription
+}
+
+type webSocketSubscription struct {
+	info    StreamInfo
+	handler func(data []byte) error
+}
+
+// DialWebSocket opens a WebSocket connection to the Horizon server of the
+// client. The connection is closed by Close, or once Run returns.
+func (c *Client) DialWebSocket() (*WebSocket, error) {
+	horizonURL := c.fixHorizonURL()
+	u, err := url.Parse(horizonURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing horizon url")
+	}
+	switch u.Scheme {
+	case "http":
+		u.Scheme = "ws"
+	case "https":
+		u.Scheme = "wss"
+	default:
+		return nil, errors.Errorf("unsupported horizon url scheme %s", u.Scheme)
+	}
+
+	config, err := websocket.NewConfig(u.String(), horizonURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating websocket config")
+	}
+	if c.horizonTimeOut == 0 {
+		c.horizonTimeOut = HorizonTimeOut
+	}
+	config.Dialer = &net.Dialer{Timeout: time.Second * c.horizonTimeOut}
+	config.Header.Set("X-Client-Name", "go-paydex-sdk")
+	config.Header.Set("X-Client-Version", c.Version())
+	config.Header.Set("X-App-Name", c.AppName)
+	config.Header.Set("X-App-Version", c.AppVersion)
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening websocket connection")
+	}
+
+	return &WebSocket{
+		client:        c,
+		conn:          conn,
+		subscriptions: map[string]*webSocketSubscription{},
+	}, nil
+}
+
+// Close closes the connection.
+func (ws *WebSocket) Close() error {
+	return ws.conn.Close()
+}
+
+func (ws *WebSocket) send(msg hProtocol.WebSocketMessage) error {
+	ws.writeMutex.Lock()
+	defer ws.writeMutex.Unlock()
+	return errors.Wrap(websocket.JSON.Send(ws.conn, msg), "error sending message")
+}
+
+// Subscribe subscribes to the stream of request, which is identified by id in
+// Unsubscribe and OnSubscriptionError. Like with the Stream methods of Client,
+// the stream starts from the cursor of request or, when it is not set, with
+// the new records. handler is called by Run with the JSON resource of every
+// event of the stream. Run stops with the error of handler if it returns one.
+func (ws *WebSocket) Subscribe(id string, request HorizonRequest, handler func(data []byte) error) error {
+	if id == "" {
+		return errors.New("no subscription id provided")
+	}
+
+	endpoint, err := request.BuildURL()
+	if err != nil {
+		return errors.Wrap(err, "unable to build endpoint")
+	}
+	u, err := url.Parse("/" + strings.TrimLeft(endpoint, "/"))
+	if err != nil {
+		return errors.Wrap(err, "error parsing endpoint")
+	}
+	query := u.Query()
+	if query.Get("cursor") == "" {
+		query.Set("cursor", "now")
+	}
+	u.RawQuery = query.Encode()
+
+	streamURL := ws.client.fixHorizonURL() + strings.TrimLeft(u.String(), "/")
+	info := StreamInfo{URL: streamURL, Route: route(streamURL), Cursor: query.Get("cursor")}
+
+	ws.mutex.Lock()
+	if _, ok := ws.subscriptions[id]; ok {
+		ws.mutex.Unlock()
+		return errors.Errorf("already subscribed with id %s", id)
+	}
+	ws.subscriptions[id] = &webSocketSubscription{info: info, handler: handler}
+	ws.mutex.Unlock()
+
+	err = ws.send(hProtocol.WebSocketMessage{
+		Type: hProtocol.WebSocketSubscribe,
+		ID:   id,
+		Path: u.String(),
+	})
+	if err != nil {
+		ws.removeSubscription(id)
+	}
+	return err
+}
+
+// Unsubscribe ends the subscription identified by id, its handler is no
+// longer called once Unsubscribe returns.
+func (ws *WebSocket) Unsubscribe(id string) error {
+	if ws.removeSubscription(id) == nil {
+		return errors.Errorf("no subscription with id %s", id)
+	}
+
+	return ws.send(hProtocol.WebSocketMessage{
+		Type: hProtocol.WebSocketUnsubscribe,
+		ID:   id,
+	})
+}
+
+func (ws *WebSocket) subscription(id string) *webSocketSubscription {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	return ws.subscriptions[id]
+}
+
+func (ws *WebSocket) removeSubscription(id string) *webSocketSubscription {
+	ws.mutex.Lock()
+	defer ws.mutex.Unlock()
+	sub := ws.subscriptions[id]
+	delete(ws.subscriptions, id)
+	return sub
+}
+
+// Run reads the messages of the connection and calls the handlers of the
+// subscriptions, until ctx is done or an error occurs. The connection is
+// closed when Run returns.
+func (ws *WebSocket) Run(ctx context.Context) error {
+	done := make(chan struct{})
+	defer close(done)
+	defer ws.conn.Close()
+	go func() {
+		select {
+		case <-ctx.Done():
+			// unblocks the read of the next message
+			ws.conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		var msg hProtocol.WebSocketMessage
+		if err := websocket.JSON.Receive(ws.conn, &msg); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "error reading message")
+		}
+
+		if err := ws.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+func (ws *WebSocket) handle(msg hProtocol.WebSocketMessage) error {
+	hooks := ws.client.Hooks
+
+	switch msg.Type {
+	case hProtocol.WebSocketSubscribed:
+		if sub := ws.subscription(msg.ID); sub != nil && hooks != nil {
+			hooks.StreamConnected(sub.info)
+		}
+
+	case hProtocol.WebSocketEvent:
+		// events can still be received after unsubscribing
+		sub := ws.subscription(msg.ID)
+		if sub == nil {
+			return nil
+		}
+		if err := sub.handler(msg.Data); err != nil {
+			err = errors.Wrap(err, "handler error")
+			if hooks != nil {
+				hooks.StreamError(sub.info, err)
+			}
+			return err
+		}
+		if hooks != nil {
+			info := sub.info
+			if msg.Cursor != "" {
+				info.Cursor = msg.Cursor
+			}
+			hooks.StreamEvent(info)
+		}
+
+	case hProtocol.WebSocketError:
+		var err error = errors.New("websocket error")
+		if msg.Error != nil {
+			err = Error{Problem: *msg.Error}
+		}
+		if msg.ID == "" {
+			return err
+		}
+
+		sub := ws.removeSubscription(msg.ID)
+		if sub == nil {
+			return nil
+		}
+		if hooks != nil {
+			hooks.StreamError(sub.info, err)
+		}
+		if ws.OnSubscriptionError == nil {
+			return errors.Wrapf(err, "subscription %s failed", msg.ID)
+		}
+		ws.OnSubscriptionError(msg.ID, err)
+	}
+
+	// heartbeats and unsubscribed messages need no handling
+	return nil
+}
+
+// SubscribeAccount subscribes to the state of an account, see StreamAccount.
+func (ws *WebSocket) SubscribeAccount(id string, request AccountRequest, handler AccountHandler) error {
+	if request.AccountID == "" {
+		return errors.New("no account ID provided")
+	}
+	if request.DataKey != "" {
+		return errors.New("account data can't be streamed")
+	}
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var account hProtocol.Account
+		if err := json.Unmarshal(data, &account); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for account request")
+		}
+		handler(account)
+		return nil
+	})
+}
+
+// SubscribeTransactions subscribes to processed transactions, see
+// StreamTransactions.
+func (ws *WebSocket) SubscribeTransactions(id string, request TransactionRequest, handler TransactionHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var transaction hProtocol.Transaction
+		if err := json.Unmarshal(data, &transaction); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for transaction request")
+		}
+		handler(transaction)
+		return nil
+	})
+}
+
+// SubscribeEffects subscribes to effects, see StreamEffects.
+func (ws *WebSocket) SubscribeEffects(id string, request EffectRequest, handler EffectHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var baseEffect effects.Base
+		if err := json.Unmarshal(data, &baseEffect); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for effects request")
+		}
+		effect, err := effects.UnmarshalEffect(baseEffect.GetType(), data)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling to the correct effect type")
+		}
+		handler(effect)
+		return nil
+	})
+}
+
+// SubscribeOperations subscribes to operations, see StreamOperations.
+func (ws *WebSocket) SubscribeOperations(id string, request OperationRequest, handler OperationHandler) error {
+	return ws.subscribeOperations(id, *request.SetOperationsEndpoint(), handler)
+}
+
+// SubscribePayments subscribes to payments, see StreamPayments.
+func (ws *WebSocket) SubscribePayments(id string, request OperationRequest, handler OperationHandler) error {
+	return ws.subscribeOperations(id, *request.SetPaymentsEndpoint(), handler)
+}
+
+func (ws *WebSocket) subscribeOperations(id string, request OperationRequest, handler OperationHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var baseRecord operations.Base
+		if err := json.Unmarshal(data, &baseRecord); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for operation request")
+		}
+		op, err := operations.UnmarshalOperation(baseRecord.GetTypeI(), data)
+		if err != nil {
+			return errors.Wrap(err, "unmarshaling to the correct operation type")
+		}
+		handler(op)
+		return nil
+	})
+}
+
+// SubscribeOffers subscribes to the offers of an account, see StreamOffers.
+func (ws *WebSocket) SubscribeOffers(id string, request OfferRequest, handler OfferHandler) error {
+	if request.ForAccount == "" {
+		return errors.New(`parameter "ForAccount" required`)
+	}
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var offer hProtocol.Offer
+		if err := json.Unmarshal(data, &offer); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for offers request")
+		}
+		handler(offer)
+		return nil
+	})
+}
+
+// SubscribeLedgers subscribes to ledgers, see StreamLedgers.
+func (ws *WebSocket) SubscribeLedgers(id string, request LedgerRequest, handler LedgerHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var ledger hProtocol.Ledger
+		if err := json.Unmarshal(data, &ledger); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for ledger request")
+		}
+		handler(ledger)
+		return nil
+	})
+}
+
+// SubscribeTrades subscribes to trades, see StreamTrades.
+func (ws *WebSocket) SubscribeTrades(id string, request TradeRequest, handler TradeHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var trade hProtocol.Trade
+		if err := json.Unmarshal(data, &trade); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for trade request")
+		}
+		handler(trade)
+		return nil
+	})
+}
+
+// SubscribeOrderBooks subscribes to the order book of an asset pair, see
+// StreamOrderBooks.
+func (ws *WebSocket) SubscribeOrderBooks(id string, request OrderBookRequest, handler OrderBookHandler) error {
+	return ws.Subscribe(id, request, func(data []byte) error {
+		var orderBook hProtocol.OrderBookSummary
+		if err := json.Unmarshal(data, &orderBook); err != nil {
+			return errors.Wrap(err, "error unmarshaling data for orderbook request")
+		}
+		handler(orderBook)
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package horizonclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// newWebSocketServer returns a server answering every subscribe message with
+// a subscribed message followed by the messages returned by respond.
+func newWebSocketServer(
+	t *testing.T,
+	received chan<- hProtocol.WebSocketMessage,
+	respond func(hProtocol.WebSocketMessage) []hProtocol.WebSocketMessage,
+) *httptest.Server {
+	return httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		assert.Equal(t, "go-paydex-sdk", conn.Request().Header.Get("X-Client-Name"))
+		for {
+			var msg hProtocol.WebSocketMessage
+			if err := websocket.JSON.Receive(conn, &msg); err != nil {
+				return
+			}
+			received <- msg
+			for _, response := range respond(msg) {
+				if err := websocket.JSON.Send(conn, response); err != nil {
+					return
+				}
+			}
+		}
+	}))
+}
+
+func TestWebSocketSubscribe(t *testing.T) {
+	received := make(chan hProtocol.WebSocketMessage, 10)
+	server := newWebSocketServer(t, received, func(msg hProtocol.WebSocketMessage) []hProtocol.WebSocketMessage {
+		if msg.Type != hProtocol.WebSocketSubscribe {
+			return nil
+		}
+		return []hProtocol.WebSocketMessage{
+			{Type: hProtocol.WebSocketSubscribed, ID: msg.ID},
+			{Type: hProtocol.WebSocketHeartbeat},
+			{
+				Type:   hProtocol.WebSocketEvent,
+				ID:     msg.ID,
+				Cursor: "12884905985",
+				Data:   json.RawMessage(`{"id":"12884905985","paging_token":"12884905985","type":"payment","type_i":1,"amount":"10.0000000"}`),
+			},
+		}
+	})
+	defer server.Close()
+
+	client := &Client{HorizonURL: server.URL}
+	ws, err := client.DialWebSocket()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = ws.SubscribePayments(
+		"payments",
+		OperationRequest{ForAccount: "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR"},
+		func(op operations.Operation) {
+			payment, ok := op.(operations.Payment)
+			if assert.True(t, ok) {
+				assert.Equal(t, "10.0000000", payment.Amount)
+			}
+			cancel()
+		},
+	)
+	require.NoError(t, err)
+	assert.EqualError(
+		t,
+		ws.Subscribe("payments", (&OperationRequest{}).SetPaymentsEndpoint(), nil),
+		"already subscribed with id payments",
+	)
+
+	done := make(chan error)
+	go func() { done <- ws.Run(ctx) }()
+	select {
+	case err = <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler was not called")
+	}
+
+	msg := <-received
+	assert.Equal(t, hProtocol.WebSocketMessage{
+		Type: hProtocol.WebSocketSubscribe,
+		ID:   "payments",
+		Path: "/accounts/GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR/payments?cursor=now",
+	}, msg)
+}
+
+func TestWebSocketSubscriptionError(t *testing.T) {
+	received := make(chan hProtocol.WebSocketMessage, 10)
+	server := newWebSocketServer(t, received, func(msg hProtocol.WebSocketMessage) []hProtocol.WebSocketMessage {
+		switch msg.Type {
+		case hProtocol.WebSocketSubscribe:
+			return []hProtocol.WebSocketMessage{{Type: hProtocol.WebSocketError, ID: msg.ID, Error: &problem.NotFound}}
+		case hProtocol.WebSocketUnsubscribe:
+			return []hProtocol.WebSocketMessage{{Type: hProtocol.WebSocketUnsubscribed, ID: msg.ID}}
+		}
+		return nil
+	})
+	defer server.Close()
+
+	client := &Client{HorizonURL: server.URL}
+
+	t.Run("returned by run", func(t *testing.T) {
+		ws, err := client.DialWebSocket()
+		require.NoError(t, err)
+		require.NoError(t, ws.SubscribeLedgers("ledgers", LedgerRequest{Cursor: "8589934592"}, func(hProtocol.Ledger) {}))
+
+		err = ws.Run(context.Background())
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "subscription ledgers failed")
+		}
+		assert.Equal(t, "/ledgers?cursor=8589934592", (<-received).Path)
+	})
+
+	t.Run("passed to the handler", func(t *testing.T) {
+		ws, err := client.DialWebSocket()
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ws.OnSubscriptionError = func(id string, err error) {
+			assert.Equal(t, "ledgers", id)
+			herr, ok := err.(Error)
+			if assert.True(t, ok) {
+				assert.Equal(t, http.StatusNotFound, herr.Problem.Status)
+			}
+			cancel()
+		}
+		require.NoError(t, ws.SubscribeLedgers("ledgers", LedgerRequest{}, func(hProtocol.Ledger) {}))
+		assert.NoError(t, ws.Run(ctx))
+		<-received
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		ws, err := client.DialWebSocket()
+		require.NoError(t, err)
+		defer ws.Close()
+
+		assert.EqualError(t, ws.Unsubscribe("ledgers"), "no subscription with id ledgers")
+		require.NoError(t, ws.SubscribeLedgers("ledgers", LedgerRequest{}, func(hProtocol.Ledger) {}))
+		<-received
+		require.NoError(t, ws.Unsubscribe("ledgers"))
+		assert.Equal(t, hProtocol.WebSocketMessage{Type: hProtocol.WebSocketUnsubscribe, ID: "ledgers"}, <-received)
+	})
+}
@@ -40,6 +40,9 @@ type Response struct {
 	FederationServer string `toml:"FEDERATION_SERVER"`
 	EncryptionKey    string `toml:"ENCRYPTION_KEY"`
 	SigningKey       string `toml:"SIGNING_KEY"`
+	// URIRequestSigningKey is the key which signs the SEP 7 URIs created
+	// by the domain.
+	URIRequestSigningKey string `toml:"URI_REQUEST_SIGNING_KEY"`
 }
 
 // GetPaydexToml returns paydex.toml file for a given domain
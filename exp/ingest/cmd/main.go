@@ -68,7 +68,7 @@ func useAdapter() {
 
 		b := lt.Envelope
 		fmt.Println("TransactionEnvelope:")
-		fmt.Println("b", b.Tx)
+		fmt.Println("b", b.Transaction())
 
 		c := lt.Meta
 		fmt.Println("TransactionMeta:")
@@ -125,7 +125,7 @@ func useAdapter() {
 
 // 	b := ledgerCloseMeta.TransactionEnvelope[0]
 // 	fmt.Println("TransactionEnvelope:", b)
-// 	fmt.Println("b", b.Tx)
+// 	fmt.Println("b", b.Transaction())
 
 // 	c := ledgerCloseMeta.TransactionMeta[0]
 // 	fmt.Println("TransactionMeta", c.Operations)
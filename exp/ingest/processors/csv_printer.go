@@ -204,14 +204,15 @@ func (p *CSVPrinter) ProcessLedger(ctx context.Context, store *pipeline.Store, r
 			}
 		}
 
+		sourceAccount := transaction.Envelope.SourceAccount()
 		fmt.Fprintf(
 			f,
 			"%d,%t,%s,%d,%d\n",
 			transaction.Index,
-			transaction.Result.Result.Result.Code == xdr.TransactionResultCodeTxSuccess,
-			transaction.Envelope.Tx.SourceAccount.Address(),
-			len(transaction.Envelope.Tx.Operations),
-			transaction.Envelope.Tx.Fee,
+			transaction.Result.Result.Successful(),
+			sourceAccount.Address(),
+			len(transaction.Envelope.Operations()),
+			transaction.Envelope.Fee(),
 		)
 
 		select {
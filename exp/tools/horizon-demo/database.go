@@ -6,7 +6,6 @@ import (
 
 	"github.com/paydex-core/paydex-go/exp/ingest/io"
 	"github.com/paydex-core/paydex-go/support/db"
-	"github.com/paydex-core/paydex-go/xdr"
 )
 
 /* Schema:
@@ -73,13 +72,14 @@ func NewDatabase(uri string) (*Database, error) {
 // InsertTransaction adds a new transaction to the database. It probably
 // shouldn't use `io.LedgerTransaction` but it's just a demo code.
 func (d *Database) InsertTransaction(ledger uint32, tx io.LedgerTransaction) (sql.Result, error) {
+	sourceAccount := tx.Envelope.SourceAccount()
 	row := &transaction{
 		Hash:       hex.EncodeToString(tx.Result.TransactionHash[:]),
-		Success:    tx.Result.Result.Result.Code == xdr.TransactionResultCodeTxSuccess,
+		Success:    tx.Result.Result.Successful(),
 		Ledger:     ledger,
-		Source:     tx.Envelope.Tx.SourceAccount.Address(),
-		FeePaid:    uint32(tx.Envelope.Tx.Fee),
-		Operations: len(tx.Envelope.Tx.Operations),
+		Source:     sourceAccount.Address(),
+		FeePaid:    tx.Envelope.Fee(),
+		Operations: len(tx.Envelope.Operations()),
 	}
 	return d.Session.GetTable("transactions").Insert(row).Exec()
 }
@@ -65,7 +65,9 @@ require (
 	github.com/yudai/golcs v0.0.0-20170316035057-ecda9a501e82 // indirect
 	github.com/yudai/pp v2.0.1+incompatible // indirect
 	golang.org/x/crypto v0.0.0-20200323165209-0ec3e9974c59
+	golang.org/x/net v0.0.0-20200202094626-16171245cfb2
 	gopkg.in/gavv/httpexpect.v1 v1.1.1
 	gopkg.in/tylerb/graceful.v1 v1.2.15
+	gopkg.in/yaml.v2 v2.2.5
 	moul.io/http2curl v1.0.0 // indirect
 )
@@ -26,11 +26,47 @@ func ID(passphrase string) [32]byte {
 	return hash.Hash([]byte(passphrase))
 }
 
+// HashTransactionInEnvelope derives the network specific hash for the transaction
+// contained in the provided envelope using the network identified by the supplied passphrase.
+// The resulting hash is the value that can be signed by paydex secret key to
+// authorize the transaction identified by the hash to paydex validators.
+func HashTransactionInEnvelope(envelope xdr.TransactionEnvelope, passphrase string) ([32]byte, error) {
+	switch envelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		return HashTransactionV0(&envelope.V0.Tx, passphrase)
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return HashTransaction(&envelope.V1.Tx, passphrase)
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return HashFeeBumpTransaction(&envelope.FeeBump.Tx, passphrase)
+	default:
+		return [32]byte{}, errors.New("invalid transaction type")
+	}
+}
+
 // HashTransaction derives the network specific hash for the provided
 // transaction using the network identified by the supplied passphrase.  The
 // resulting hash is the value that can be signed by paydex secret key to
 // authorize the transaction identified by the hash to paydex validators.
 func HashTransaction(tx *xdr.Transaction, passphrase string) ([32]byte, error) {
+	return hashTx(tx, xdr.EnvelopeTypeEnvelopeTypeTx, passphrase)
+}
+
+// HashTransactionV0 derives the network specific hash for the provided
+// legacy transaction using the network identified by the supplied passphrase.
+// V0 transactions are signed as if they were V1 transactions, so the
+// resulting hash is the same as the hash of the equivalent xdr.Transaction.
+func HashTransactionV0(tx *xdr.TransactionV0, passphrase string) ([32]byte, error) {
+	v1 := tx.ToTransaction()
+	return HashTransaction(&v1, passphrase)
+}
+
+// HashFeeBumpTransaction derives the network specific hash for the provided
+// fee bump transaction using the network identified by the supplied passphrase.
+func HashFeeBumpTransaction(tx *xdr.FeeBumpTransaction, passphrase string) ([32]byte, error) {
+	return hashTx(tx, xdr.EnvelopeTypeEnvelopeTypeTxFeeBump, passphrase)
+}
+
+func hashTx(tx interface{}, envelopeType xdr.EnvelopeType, passphrase string) ([32]byte, error) {
 	var txBytes bytes.Buffer
 
 	if strings.TrimSpace(passphrase) == "" {
@@ -43,7 +79,7 @@ func HashTransaction(tx *xdr.Transaction, passphrase string) ([32]byte, error) {
 		return [32]byte{}, errors.Wrap(err, "fprint network id failed")
 	}
 
-	_, err = xdr.Marshal(&txBytes, xdr.EnvelopeTypeEnvelopeTypeTx)
+	_, err = xdr.Marshal(&txBytes, envelopeType)
 	if err != nil {
 		return [32]byte{}, errors.Wrap(err, "marshal type failed")
 	}
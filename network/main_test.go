@@ -22,14 +22,66 @@ func TestHashTransaction(t *testing.T) {
 		0x0d, 0x78, 0x6b, 0x6e, 0x0a, 0x00, 0xfd, 0x74,
 	}
 
-	actual, err := HashTransaction(&txe.Tx, TestNetworkPassphrase)
+	actual, err := HashTransactionV0(&txe.V0.Tx, TestNetworkPassphrase)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, actual)
+	}
+
+	tx := txe.V0.Tx.ToTransaction()
+	actual, err = HashTransaction(&tx, TestNetworkPassphrase)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, actual)
+	}
+
+	actual, err = HashTransactionInEnvelope(txe, TestNetworkPassphrase)
 	if assert.NoError(t, err) {
 		assert.Equal(t, expected, actual)
 	}
 
 	// sadpath: empty passphrase
-	_, err = HashTransaction(&txe.Tx, "")
+	_, err = HashTransaction(&tx, "")
 	if assert.Error(t, err) {
 		assert.Contains(t, err.Error(), "empty network passphrase")
 	}
 }
+
+func TestHashFeeBumpTransaction(t *testing.T) {
+	var txe xdr.TransactionEnvelope
+
+	err := xdr.SafeUnmarshalBase64("AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAACgAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAEAKZ7IPj/46PuWU6ZOtyMosctNAkXRNX9WCAI5RnfRk+AyxDLoDZP/9l3NvsxQtWj9juQOuoBlFLnWu8intgxQA", &txe)
+	require.NoError(t, err)
+
+	feeBumpTx := xdr.FeeBumpTransaction{
+		FeeSource: txe.SourceAccount(),
+		Fee:       200,
+		InnerTx: xdr.FeeBumpTransactionInnerTx{
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx:         txe.V0.Tx.ToTransaction(),
+				Signatures: txe.V0.Signatures,
+			},
+		},
+	}
+	feeBump := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: feeBumpTx,
+		},
+	}
+
+	innerHash, err := HashTransactionInEnvelope(txe, TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	outerHash, err := HashFeeBumpTransaction(&feeBumpTx, TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, innerHash, outerHash)
+
+	envelopeHash, err := HashTransactionInEnvelope(feeBump, TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, outerHash, envelopeHash)
+
+	// the fee bump hash depends on the network
+	publicHash, err := HashFeeBumpTransaction(&feeBumpTx, PublicNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, outerHash, publicHash)
+}
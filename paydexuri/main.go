@@ -0,0 +1,274 @@
+// Package paydexuri builds and parses SEP 7 URIs, which wallets use to receive
+// requests to sign a transaction or to make a payment. See
+// https://github.com/paydex-core/paydex-protocol/blob/master/ecosystem/sep-0007.md
+// for the specification.
+//
+// A URI is either a transaction request (web+paydex:tx?xdr=...), represented
+// by TxRequest, or a pay request (web+paydex:pay?destination=...), represented
+// by PayRequest. Requests may be signed by the domain which created them, in
+// which case wallets verify the signature against the URI_REQUEST_SIGNING_KEY
+// of the domain's paydex.toml file.
+package paydexuri
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/paydex-core/paydex-go/clients/paydextoml"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// Scheme is the scheme of SEP 7 URIs.
+const Scheme = "web+paydex"
+
+const (
+	// OperationTx is the operation of URIs requesting a transaction to be
+	// signed.
+	OperationTx = "tx"
+	// OperationPay is the operation of URIs requesting a payment.
+	OperationPay = "pay"
+)
+
+// MsgMaxLength is the maximum number of characters of the msg parameter.
+const MsgMaxLength = 300
+
+// callbackPrefix is the prefix of callback URLs.
+const callbackPrefix = "url:"
+
+// Request is a SEP 7 request, either a *TxRequest or a *PayRequest.
+type Request interface {
+	// String returns the request encoded as a URI.
+	String() string
+	// Sign signs the request on behalf of its origin domain, with the
+	// keypair of the domain's URI_REQUEST_SIGNING_KEY.
+	Sign(kp *keypair.Full) error
+	// VerifySignature verifies the signature of the request using the
+	// URI_REQUEST_SIGNING_KEY of its origin domain.
+	VerifySignature(toml PaydexTOML) error
+	// VerifySignatureWithKey verifies the signature of the request using
+	// the given signing key.
+	VerifySignatureWithKey(signingKey string) error
+}
+
+// PaydexTOML represents a client that can resolve a given domain name to its
+// paydex.toml file. The URI_REQUEST_SIGNING_KEY of the file is used to verify
+// the signatures of requests.
+type PaydexTOML interface {
+	GetPaydexToml(domain string) (*paydextoml.Response, error)
+}
+
+// confirm interface conformity
+var _ PaydexTOML = paydextoml.DefaultClient
+var _ Request = &TxRequest{}
+var _ Request = &PayRequest{}
+
+// Params contains the parameters shared by transaction and pay requests.
+type Params struct {
+	// Callback is the URL the signed transaction is posted to instead of
+	// being submitted to the network.
+	Callback string
+	// Msg is a message shown to the user, of up to MsgMaxLength characters.
+	Msg string
+	// NetworkPassphrase is the passphrase of the network the request is
+	// meant for. It is omitted for the public network.
+	NetworkPassphrase string
+	// OriginDomain is the fully qualified domain name of the service which
+	// created the request. It is required when the request is signed.
+	OriginDomain string
+	// Signature is the base64 encoded signature of the request, made with
+	// the URI_REQUEST_SIGNING_KEY of OriginDomain.
+	Signature string
+
+	// signedFields is the URI, without signature, of the parameters of the
+	// request when it was signed or parsed. Once the parameters change,
+	// Signature doesn't cover them anymore and is left out of String.
+	signedFields string
+	// uri is the signed URI the request was parsed from, and signedURI its
+	// part which Signature was made for. As long as the request is
+	// unchanged, they are used so that signatures are verified against,
+	// and String returns, the exact URI the signer created, whatever the
+	// order and encoding of its parameters.
+	uri       string
+	signedURI string
+}
+
+// Network returns the network passphrase of the request, which defaults to the
+// passphrase of the public network.
+func (p *Params) Network() string {
+	if p.NetworkPassphrase == "" {
+		return network.PublicNetworkPassphrase
+	}
+	return p.NetworkPassphrase
+}
+
+// Parse parses a SEP 7 URI. The result is either a *TxRequest or a
+// *PayRequest.
+func Parse(uri string) (Request, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse uri")
+	}
+	if u.Scheme != Scheme {
+		return nil, errors.Errorf("uri scheme must be %s", Scheme)
+	}
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse uri parameters")
+	}
+
+	var request Request
+	var params *Params
+	var unsignedString func() string
+	switch u.Opaque {
+	case OperationTx:
+		r := &TxRequest{}
+		err = r.decode(values)
+		request, params, unsignedString = r, &r.Params, r.unsignedString
+	case OperationPay:
+		r := &PayRequest{}
+		err = r.decode(values)
+		request, params, unsignedString = r, &r.Params, r.unsignedString
+	default:
+		return nil, errors.Errorf("unknown operation %q", u.Opaque)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Signature != "" {
+		i := strings.LastIndex(uri, "&signature=")
+		if i < 0 || strings.Contains(uri[i+1:], "&") {
+			return nil, errors.New("signature must be the last parameter of the uri")
+		}
+		params.signedFields = unsignedString()
+		params.uri = uri
+		params.signedURI = uri[:i]
+	}
+
+	return request, nil
+}
+
+// ParseTx parses a SEP 7 URI which requests a transaction to be signed.
+func ParseTx(uri string) (*TxRequest, error) {
+	request, err := Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := request.(*TxRequest)
+	if !ok {
+		return nil, errors.Errorf("uri operation must be %s", OperationTx)
+	}
+	return r, nil
+}
+
+// ParsePay parses a SEP 7 URI which requests a payment.
+func ParsePay(uri string) (*PayRequest, error) {
+	request, err := Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	r, ok := request.(*PayRequest)
+	if !ok {
+		return nil, errors.Errorf("uri operation must be %s", OperationPay)
+	}
+	return r, nil
+}
+
+// decode sets the shared parameters from the query of a URI.
+func (p *Params) decode(values url.Values) error {
+	if callback := values.Get("callback"); callback != "" {
+		if !strings.HasPrefix(callback, callbackPrefix) {
+			return errors.Errorf("callback must start with %s", callbackPrefix)
+		}
+		p.Callback = strings.TrimPrefix(callback, callbackPrefix)
+	}
+	p.Msg = values.Get("msg")
+	p.NetworkPassphrase = values.Get("network_passphrase")
+	p.OriginDomain = values.Get("origin_domain")
+	p.Signature = values.Get("signature")
+	return p.validate()
+}
+
+// validate checks the shared parameters.
+func (p *Params) validate() error {
+	if p.Callback != "" {
+		if _, err := url.ParseRequestURI(p.Callback); err != nil {
+			return errors.Wrap(err, "callback is not a valid url")
+		}
+	}
+	if len([]rune(p.Msg)) > MsgMaxLength {
+		return errors.Errorf("msg can't be longer than %d characters", MsgMaxLength)
+	}
+	if p.OriginDomain != "" && !govalidator.IsDNSName(p.OriginDomain) {
+		return errors.New("origin_domain must be a fully qualified domain name")
+	}
+	if p.Signature != "" && p.OriginDomain == "" {
+		return errors.New("origin_domain is required when the uri is signed")
+	}
+	return nil
+}
+
+// encode adds the shared parameters, except the signature, to the query of a
+// URI.
+func (p *Params) encode(values url.Values) {
+	if p.Callback != "" {
+		values.Set("callback", callbackPrefix+p.Callback)
+	}
+	if p.Msg != "" {
+		values.Set("msg", p.Msg)
+	}
+	if p.NetworkPassphrase != "" {
+		values.Set("network_passphrase", p.NetworkPassphrase)
+	}
+	if p.OriginDomain != "" {
+		values.Set("origin_domain", p.OriginDomain)
+	}
+}
+
+// unsignedURI returns the URI of an operation with the given parameters,
+// without the signature of the request.
+func (p *Params) unsignedURI(operation string, values url.Values) string {
+	p.encode(values)
+	// SEP 7 URIs percent-encode spaces
+	return Scheme + ":" + operation + "?" + strings.Replace(values.Encode(), "+", "%20", -1)
+}
+
+// signedString appends the signature of the request, if any, to the unsigned
+// URI of its parameters. The signature is always the last parameter of a URI.
+// It is left out when the parameters changed since the request was signed or
+// parsed, as it doesn't cover them anymore.
+func (p *Params) signedString(unsignedURI string) string {
+	if p.Signature == "" || (p.signedFields != "" && unsignedURI != p.signedFields) {
+		return unsignedURI
+	}
+	if p.signedURI != "" {
+		if p.Signature == p.parsedSignature() {
+			return p.uri
+		}
+		return p.signedURI + "&signature=" + url.QueryEscape(p.Signature)
+	}
+	return unsignedURI + "&signature=" + url.QueryEscape(p.Signature)
+}
+
+// coveredURI returns the URI the signature of the request is made for, given
+// the unsigned URI of its parameters: the part of the parsed URI before its
+// signature, unless the parameters changed since the URI was parsed.
+func (p *Params) coveredURI(unsignedURI string) string {
+	if p.signedURI != "" && unsignedURI == p.signedFields {
+		return p.signedURI
+	}
+	return unsignedURI
+}
+
+// parsedSignature returns the signature of the URI the request was parsed
+// from.
+func (p *Params) parsedSignature() string {
+	values, err := url.ParseQuery(p.uri[len(p.signedURI)+1:])
+	if err != nil {
+		return ""
+	}
+	return values.Get("signature")
+}
@@ -0,0 +1,207 @@
+package paydexuri
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/clients/paydextoml"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	destination = "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	issuer      = "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z"
+)
+
+func buildTransaction(t *testing.T) *txnbuild.Transaction {
+	sourceAccount := txnbuild.NewSimpleAccount(destination, 1)
+	tx := &txnbuild.Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: issuer, Amount: "10", Asset: txnbuild.NativeAsset{}},
+		},
+		Timebounds: txnbuild.NewInfiniteTimeout(),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    txnbuild.MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+	return tx
+}
+
+func TestTxRequest(t *testing.T) {
+	tx := buildTransaction(t)
+	r, err := NewTxRequest(tx)
+	require.NoError(t, err)
+	r.Pubkey = destination
+	r.Callback = "https://example.com/callback"
+	r.Msg = "order number 24"
+	assert.Equal(t, network.TestNetworkPassphrase, r.NetworkPassphrase)
+
+	uri := r.String()
+	assert.Contains(t, uri, "web+paydex:tx?callback=url%3Ahttps%3A%2F%2Fexample.com%2Fcallback&msg=order%20number%2024")
+
+	parsed, err := Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, r, parsed)
+
+	decoded, err := parsed.(*TxRequest).Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, network.TestNetworkPassphrase, decoded.Network)
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	decodedHash, err := decoded.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, decodedHash)
+
+	_, err = ParsePay(uri)
+	assert.EqualError(t, err, "uri operation must be pay")
+}
+
+func TestPayRequest(t *testing.T) {
+	uri := "web+paydex:pay?destination=" + destination + "&amount=120.1234567&asset_code=USD&asset_issuer=" + issuer +
+		"&memo=skdjfasf&msg=pay%20me%20with%20lumens"
+	r, err := ParsePay(uri)
+	require.NoError(t, err)
+	assert.Equal(t, &PayRequest{
+		Destination: destination,
+		Amount:      "120.1234567",
+		AssetCode:   "USD",
+		AssetIssuer: issuer,
+		Memo:        "skdjfasf",
+		Params:      Params{Msg: "pay me with lumens"},
+	}, r)
+	assert.Equal(t, network.PublicNetworkPassphrase, r.Network())
+
+	memo, err := r.TxMemo()
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.MemoText("skdjfasf"), memo)
+
+	payment, err := r.Payment()
+	require.NoError(t, err)
+	assert.Equal(t, &txnbuild.Payment{
+		Destination: destination,
+		Amount:      "120.1234567",
+		Asset:       txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+	}, payment)
+
+	pathPayment, err := r.PathPayment(txnbuild.NativeAsset{}, "1000")
+	require.NoError(t, err)
+	assert.Equal(t, &txnbuild.PathPayment{
+		SendAsset:   txnbuild.NativeAsset{},
+		SendMax:     "1000",
+		Destination: destination,
+		DestAsset:   txnbuild.CreditAsset{Code: "USD", Issuer: issuer},
+		DestAmount:  "120.1234567",
+	}, pathPayment)
+
+	reparsed, err := ParsePay(r.String())
+	require.NoError(t, err)
+	assert.Equal(t, r, reparsed)
+
+	// the user chooses the amount
+	r, err = ParsePay("web+paydex:pay?destination=" + destination + "&memo=MTIzNDU2Nzg5MDEyMzQ1Njc4OTAxMjM0NTY3ODkwMTI=&memo_type=MEMO_HASH")
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.NativeAsset{}, r.Asset())
+	memo, err = r.TxMemo()
+	require.NoError(t, err)
+	assert.IsType(t, txnbuild.MemoHash{}, memo)
+	_, err = r.Payment()
+	assert.EqualError(t, err, "amount is required to build a payment")
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		uri string
+		err string
+	}{
+		{"https://paydex.org", "uri scheme must be web+paydex"},
+		{"web+paydex:sign?xdr=AAAA", `unknown operation "sign"`},
+		{"web+paydex:tx", "xdr is required"},
+		{"web+paydex:pay?destination=GBAD", "destination is not a valid account id"},
+		{"web+paydex:pay?destination=" + destination + "&asset_code=USD", "asset_code and asset_issuer must be set together"},
+		{"web+paydex:pay?destination=" + destination + "&memo=abc&memo_type=MEMO_ID", `memo is not a valid id: strconv.ParseUint: parsing "abc": invalid syntax`},
+		{"web+paydex:pay?destination=" + destination + "&callback=https://example.com", "callback must start with url:"},
+		{"web+paydex:pay?destination=" + destination + "&signature=abc", "origin_domain is required when the uri is signed"},
+		{"web+paydex:pay?destination=" + destination + "&origin_domain=example.com&signature=abc&msg=hi", "signature must be the last parameter of the uri"},
+	} {
+		t.Run(testCase.uri, func(t *testing.T) {
+			_, err := Parse(testCase.uri)
+			assert.EqualError(t, err, testCase.err)
+		})
+	}
+}
+
+func TestSignature(t *testing.T) {
+	signingKP := keypair.MustRandom()
+	r := &PayRequest{
+		Destination: destination,
+		Amount:      "10",
+		Params:      Params{OriginDomain: "someDomain.com", Msg: "order 42"},
+	}
+	require.NoError(t, r.Sign(signingKP))
+	assert.NotEmpty(t, r.Signature)
+	require.NoError(t, r.VerifySignatureWithKey(signingKP.Address()))
+
+	toml := &paydextoml.MockClient{}
+	toml.On("GetPaydexToml", "someDomain.com").
+		Return(&paydextoml.Response{URIRequestSigningKey: signingKP.Address()}, nil)
+
+	parsed, err := Parse(r.String())
+	require.NoError(t, err)
+	require.NoError(t, parsed.VerifySignature(toml))
+
+	// a tampered uri
+	tampered, err := ParsePay(r.String())
+	require.NoError(t, err)
+	tampered.Amount = "1000"
+	assert.EqualError(t, tampered.VerifySignature(toml), "signature is invalid")
+	assert.NotContains(t, tampered.String(), "signature=")
+
+	// a signature made by another key
+	assert.EqualError(t, parsed.VerifySignatureWithKey(keypair.MustRandom().Address()), "signature is invalid")
+
+	toml = &paydextoml.MockClient{}
+	toml.On("GetPaydexToml", "someDomain.com").Return(&paydextoml.Response{}, nil)
+	assert.EqualError(t, parsed.VerifySignature(toml), "paydex.toml of someDomain.com has no URI_REQUEST_SIGNING_KEY")
+
+	toml = &paydextoml.MockClient{}
+	toml.On("GetPaydexToml", "someDomain.com").Return((*paydextoml.Response)(nil), errors.New("http request failed with non-200 status code"))
+	assert.EqualError(t, parsed.VerifySignature(toml), "failed to get paydex.toml of someDomain.com: http request failed with non-200 status code")
+
+	unsigned := &TxRequest{XDR: "AAAA"}
+	assert.EqualError(t, unsigned.VerifySignature(toml), "uri is not signed")
+	assert.EqualError(t, unsigned.Sign(signingKP), "origin_domain is required to sign the uri")
+}
+
+func TestSignatureParameterOrder(t *testing.T) {
+	signingKP := keypair.MustRandom()
+	// the parameters aren't in the order String encodes them
+	unsignedURI := "web+paydex:pay?origin_domain=someDomain.com&msg=order%2042&destination=" + destination + "&amount=10"
+	signature, err := signingKP.Sign(signaturePayload(unsignedURI))
+	require.NoError(t, err)
+	uri := unsignedURI + "&signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+
+	r, err := ParsePay(uri)
+	require.NoError(t, err)
+	require.NoError(t, r.VerifySignatureWithKey(signingKP.Address()))
+	assert.Equal(t, uri, r.String())
+
+	// once changed, the request is no longer signed
+	r.Amount = "1000"
+	assert.EqualError(t, r.VerifySignatureWithKey(signingKP.Address()), "signature is invalid")
+	assert.Equal(t, "web+paydex:pay?amount=1000&destination="+destination+"&msg=order%2042&origin_domain=someDomain.com", r.String())
+
+	// until it is signed again
+	require.NoError(t, r.Sign(signingKP))
+	require.NoError(t, r.VerifySignatureWithKey(signingKP.Address()))
+	reparsed, err := ParsePay(r.String())
+	require.NoError(t, err)
+	require.NoError(t, reparsed.VerifySignatureWithKey(signingKP.Address()))
+	assert.Equal(t, "1000", reparsed.Amount)
+}
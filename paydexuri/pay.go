@@ -0,0 +1,191 @@
+package paydexuri
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strconv"
+
+	"github.com/paydex-core/paydex-go/amount"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/strkey"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+)
+
+// Memo types of pay requests.
+const (
+	MemoTypeText   = "MEMO_TEXT"
+	MemoTypeID     = "MEMO_ID"
+	MemoTypeHash   = "MEMO_HASH"
+	MemoTypeReturn = "MEMO_RETURN"
+)
+
+// PayRequest is a request to make a payment
+// (web+paydex:pay?destination=...).
+type PayRequest struct {
+	// Destination is the account which receives the payment.
+	Destination string
+	// Amount is the amount to pay. If it is empty the user chooses the
+	// amount.
+	Amount string
+	// AssetCode and AssetIssuer identify the asset to pay. The native
+	// asset is paid when both are empty.
+	AssetCode   string
+	AssetIssuer string
+	// Memo is the memo of the payment transaction. Hash and return memos
+	// are base64 encoded.
+	Memo string
+	// MemoType is the type of Memo, one of the MemoType constants. It
+	// defaults to MemoTypeText.
+	MemoType string
+
+	Params
+}
+
+// Asset returns the asset to pay.
+func (r *PayRequest) Asset() txnbuild.Asset {
+	if r.AssetCode == "" {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: r.AssetCode, Issuer: r.AssetIssuer}
+}
+
+// TxMemo returns the memo the payment transaction must have, or nil if the
+// request has no memo.
+func (r *PayRequest) TxMemo() (txnbuild.Memo, error) {
+	if r.Memo == "" {
+		return nil, nil
+	}
+
+	switch r.MemoType {
+	case "", MemoTypeText:
+		if len(r.Memo) > txnbuild.MemoTextMaxLength {
+			return nil, errors.Errorf("memo can't be longer than %d bytes", txnbuild.MemoTextMaxLength)
+		}
+		return txnbuild.MemoText(r.Memo), nil
+	case MemoTypeID:
+		id, err := strconv.ParseUint(r.Memo, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "memo is not a valid id")
+		}
+		return txnbuild.MemoID(id), nil
+	case MemoTypeHash, MemoTypeReturn:
+		raw, err := base64.StdEncoding.DecodeString(r.Memo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode memo")
+		}
+		var hash [32]byte
+		if len(raw) != len(hash) {
+			return nil, errors.Errorf("memo must be %d bytes long", len(hash))
+		}
+		copy(hash[:], raw)
+		if r.MemoType == MemoTypeHash {
+			return txnbuild.MemoHash(hash), nil
+		}
+		return txnbuild.MemoReturn(hash), nil
+	default:
+		return nil, errors.Errorf("unknown memo_type %s", r.MemoType)
+	}
+}
+
+// Payment returns the payment operation requested. The request must have an
+// amount.
+func (r *PayRequest) Payment() (*txnbuild.Payment, error) {
+	if r.Amount == "" {
+		return nil, errors.New("amount is required to build a payment")
+	}
+	return &txnbuild.Payment{
+		Destination: r.Destination,
+		Amount:      r.Amount,
+		Asset:       r.Asset(),
+	}, nil
+}
+
+// PathPayment returns a path payment operation which pays the amount requested
+// by sending at most sendMax of sendAsset through the given path. The request
+// must have an amount.
+func (r *PayRequest) PathPayment(sendAsset txnbuild.Asset, sendMax string, path ...txnbuild.Asset) (*txnbuild.PathPayment, error) {
+	if r.Amount == "" {
+		return nil, errors.New("amount is required to build a path payment")
+	}
+	return &txnbuild.PathPayment{
+		SendAsset:   sendAsset,
+		SendMax:     sendMax,
+		Destination: r.Destination,
+		DestAsset:   r.Asset(),
+		DestAmount:  r.Amount,
+		Path:        path,
+	}, nil
+}
+
+// String returns the request encoded as a URI.
+func (r *PayRequest) String() string {
+	return r.signedString(r.unsignedString())
+}
+
+// Sign signs the request on behalf of its origin domain, with the keypair of
+// the domain's URI_REQUEST_SIGNING_KEY.
+func (r *PayRequest) Sign(kp *keypair.Full) error {
+	return r.sign(r.unsignedString(), kp)
+}
+
+// VerifySignature verifies the signature of the request using the
+// URI_REQUEST_SIGNING_KEY of its origin domain.
+func (r *PayRequest) VerifySignature(toml PaydexTOML) error {
+	return r.verifySignature(r.unsignedString(), toml)
+}
+
+// VerifySignatureWithKey verifies the signature of the request using the given
+// signing key.
+func (r *PayRequest) VerifySignatureWithKey(signingKey string) error {
+	return r.verifySignatureWithKey(r.unsignedString(), signingKey)
+}
+
+func (r *PayRequest) unsignedString() string {
+	values := url.Values{}
+	values.Set("destination", r.Destination)
+	if r.Amount != "" {
+		values.Set("amount", r.Amount)
+	}
+	if r.AssetCode != "" {
+		values.Set("asset_code", r.AssetCode)
+	}
+	if r.AssetIssuer != "" {
+		values.Set("asset_issuer", r.AssetIssuer)
+	}
+	if r.Memo != "" {
+		values.Set("memo", r.Memo)
+	}
+	if r.MemoType != "" {
+		values.Set("memo_type", r.MemoType)
+	}
+	return r.unsignedURI(OperationPay, values)
+}
+
+func (r *PayRequest) decode(values url.Values) error {
+	r.Destination = values.Get("destination")
+	r.Amount = values.Get("amount")
+	r.AssetCode = values.Get("asset_code")
+	r.AssetIssuer = values.Get("asset_issuer")
+	r.Memo = values.Get("memo")
+	r.MemoType = values.Get("memo_type")
+
+	if !strkey.IsValidEd25519PublicKey(r.Destination) && !strkey.IsValidMuxedAccountEd25519PublicKey(r.Destination) {
+		return errors.New("destination is not a valid account id")
+	}
+	if r.Amount != "" {
+		if _, err := amount.Parse(r.Amount); err != nil {
+			return errors.Wrap(err, "amount is invalid")
+		}
+	}
+	if (r.AssetCode == "") != (r.AssetIssuer == "") {
+		return errors.New("asset_code and asset_issuer must be set together")
+	}
+	if r.AssetIssuer != "" && !strkey.IsValidEd25519PublicKey(r.AssetIssuer) {
+		return errors.New("asset_issuer is not a valid account id")
+	}
+	if _, err := r.TxMemo(); err != nil {
+		return err
+	}
+	return r.Params.decode(values)
+}
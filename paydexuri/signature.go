@@ -0,0 +1,75 @@
+package paydexuri
+
+import (
+	"encoding/base64"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// signaturePrefix is prepended to URIs before they are signed, so that
+// signatures of URIs can't be mistaken for signatures of anything else. It is
+// made of 35 zero bytes, a byte with the value 4 and the name of the scheme.
+var signaturePrefix = append(
+	append(make([]byte, 35), 4),
+	[]byte("paydex.sep.7 - URI Scheme")...,
+)
+
+// signaturePayload returns the bytes which are signed for an unsigned URI.
+func signaturePayload(unsignedURI string) []byte {
+	payload := make([]byte, 0, len(signaturePrefix)+len(unsignedURI))
+	payload = append(payload, signaturePrefix...)
+	return append(payload, unsignedURI...)
+}
+
+// sign signs an unsigned URI with kp and sets the signature of the request.
+func (p *Params) sign(unsignedURI string, kp *keypair.Full) error {
+	if p.OriginDomain == "" {
+		return errors.New("origin_domain is required to sign the uri")
+	}
+	signature, err := kp.Sign(signaturePayload(unsignedURI))
+	if err != nil {
+		return errors.Wrap(err, "failed to sign uri")
+	}
+	p.Signature = base64.StdEncoding.EncodeToString(signature)
+	p.signedFields = unsignedURI
+	p.uri = ""
+	p.signedURI = ""
+	return nil
+}
+
+// verifySignature fetches the paydex.toml file of the origin domain and
+// verifies the signature of the request with its URI_REQUEST_SIGNING_KEY.
+func (p *Params) verifySignature(unsignedURI string, toml PaydexTOML) error {
+	if p.Signature == "" {
+		return errors.New("uri is not signed")
+	}
+	resp, err := toml.GetPaydexToml(p.OriginDomain)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get paydex.toml of %s", p.OriginDomain)
+	}
+	if resp.URIRequestSigningKey == "" {
+		return errors.Errorf("paydex.toml of %s has no URI_REQUEST_SIGNING_KEY", p.OriginDomain)
+	}
+	return p.verifySignatureWithKey(unsignedURI, resp.URIRequestSigningKey)
+}
+
+// verifySignatureWithKey verifies the signature of the request with the given
+// signing key.
+func (p *Params) verifySignatureWithKey(unsignedURI, signingKey string) error {
+	if p.Signature == "" {
+		return errors.New("uri is not signed")
+	}
+	kp, err := keypair.Parse(signingKey)
+	if err != nil {
+		return errors.Wrap(err, "invalid signing key")
+	}
+	signature, err := base64.StdEncoding.DecodeString(p.Signature)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signature")
+	}
+	if err := kp.Verify(signaturePayload(p.coveredURI(unsignedURI)), signature); err != nil {
+		return errors.New("signature is invalid")
+	}
+	return nil
+}
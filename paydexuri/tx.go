@@ -0,0 +1,109 @@
+package paydexuri
+
+import (
+	"net/url"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/strkey"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// TxRequest is a request to sign a transaction
+// (web+paydex:tx?xdr=...).
+type TxRequest struct {
+	// XDR is the base64 encoded transaction envelope to sign.
+	XDR string
+	// Replace lists the fields of the transaction the wallet should
+	// replace, in the Txrep format of SEP 11.
+	Replace string
+	// Pubkey is the account which should sign the transaction.
+	Pubkey string
+	// Chain is the URI of a previous request this request was created
+	// from.
+	Chain string
+
+	Params
+}
+
+// NewTxRequest returns a request to sign tx, which must have been built.
+func NewTxRequest(tx *txnbuild.Transaction) (*TxRequest, error) {
+	txeB64, err := tx.Base64()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode transaction")
+	}
+	r := &TxRequest{XDR: txeB64}
+	if tx.Network != network.PublicNetworkPassphrase {
+		r.NetworkPassphrase = tx.Network
+	}
+	return r, nil
+}
+
+// Transaction decodes the transaction of the request.
+func (r *TxRequest) Transaction() (txnbuild.Transaction, error) {
+	tx, err := txnbuild.TransactionFromXDR(r.XDR)
+	if err != nil {
+		return tx, errors.Wrap(err, "failed to decode transaction")
+	}
+	tx.Network = r.Network()
+	return tx, nil
+}
+
+// String returns the request encoded as a URI.
+func (r *TxRequest) String() string {
+	return r.signedString(r.unsignedString())
+}
+
+// Sign signs the request on behalf of its origin domain, with the keypair of
+// the domain's URI_REQUEST_SIGNING_KEY.
+func (r *TxRequest) Sign(kp *keypair.Full) error {
+	return r.sign(r.unsignedString(), kp)
+}
+
+// VerifySignature verifies the signature of the request using the
+// URI_REQUEST_SIGNING_KEY of its origin domain.
+func (r *TxRequest) VerifySignature(toml PaydexTOML) error {
+	return r.verifySignature(r.unsignedString(), toml)
+}
+
+// VerifySignatureWithKey verifies the signature of the request using the given
+// signing key.
+func (r *TxRequest) VerifySignatureWithKey(signingKey string) error {
+	return r.verifySignatureWithKey(r.unsignedString(), signingKey)
+}
+
+func (r *TxRequest) unsignedString() string {
+	values := url.Values{}
+	values.Set("xdr", r.XDR)
+	if r.Replace != "" {
+		values.Set("replace", r.Replace)
+	}
+	if r.Pubkey != "" {
+		values.Set("pubkey", r.Pubkey)
+	}
+	if r.Chain != "" {
+		values.Set("chain", r.Chain)
+	}
+	return r.unsignedURI(OperationTx, values)
+}
+
+func (r *TxRequest) decode(values url.Values) error {
+	r.XDR = values.Get("xdr")
+	r.Replace = values.Get("replace")
+	r.Pubkey = values.Get("pubkey")
+	r.Chain = values.Get("chain")
+
+	if r.XDR == "" {
+		return errors.New("xdr is required")
+	}
+	var envelope xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(r.XDR, &envelope); err != nil {
+		return errors.Wrap(err, "xdr is not a valid transaction envelope")
+	}
+	if r.Pubkey != "" && !strkey.IsValidEd25519PublicKey(r.Pubkey) {
+		return errors.New("pubkey is not a valid account id")
+	}
+	return r.Params.decode(values)
+}
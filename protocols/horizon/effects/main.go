@@ -148,9 +148,13 @@ type Base struct {
 		Precedes  hal.Link `json:"precedes"`
 	} `json:"_links"`
 
-	ID              string    `json:"id"`
-	PT              string    `json:"paging_token"`
-	Account         string    `json:"account"`
+	ID      string `json:"id"`
+	PT      string `json:"paging_token"`
+	Account string `json:"account"`
+	// AccountMuxed is the multiplexed (M...) address of the account, if the
+	// operation which produced the effect referred to it by one.
+	AccountMuxed    string    `json:"account_muxed,omitempty"`
+	AccountMuxedID  uint64    `json:"account_muxed_id,omitempty,string"`
 	Type            string    `json:"type"`
 	TypeI           int32     `json:"type_i"`
 	LedgerCloseTime time.Time `json:"created_at"`
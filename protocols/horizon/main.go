@@ -13,6 +13,7 @@ import (
 	"github.com/paydex-core/paydex-go/strkey"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/problem"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -128,6 +129,15 @@ func (a *Account) GetData(key string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(a.Data[key])
 }
 
+// SignerSummary returns a map of signer's keys to weights.
+func (a Account) SignerSummary() map[string]int32 {
+	m := map[string]int32{}
+	for _, s := range a.Signers {
+		m[s.Key] = s.Weight
+	}
+	return m
+}
+
 // AccountSigner is the account signer information.
 type AccountSigner struct {
 	Links struct {
@@ -425,22 +435,39 @@ type Transaction struct {
 	LedgerCloseTime time.Time `json:"created_at"`
 	Account         string    `json:"source_account"`
 	AccountSequence string    `json:"source_account_sequence"`
+	FeeAccount      string    `json:"fee_account"`
 	// Action needed in release: horizon-v0.25.0
 	// Action needed in release: horizonclient-v2.0.0
 	// Remove this field.
-	FeePaid        int32    `json:"fee_paid"`
-	FeeCharged     int32    `json:"fee_charged"`
-	MaxFee         int32    `json:"max_fee"`
-	OperationCount int32    `json:"operation_count"`
-	EnvelopeXdr    string   `json:"envelope_xdr"`
-	ResultXdr      string   `json:"result_xdr"`
-	ResultMetaXdr  string   `json:"result_meta_xdr"`
-	FeeMetaXdr     string   `json:"fee_meta_xdr"`
-	MemoType       string   `json:"memo_type"`
-	Memo           string   `json:"memo,omitempty"`
-	Signatures     []string `json:"signatures"`
-	ValidAfter     string   `json:"valid_after,omitempty"`
-	ValidBefore    string   `json:"valid_before,omitempty"`
+	FeePaid            int32               `json:"fee_paid"`
+	FeeCharged         int64               `json:"fee_charged"`
+	MaxFee             int64               `json:"max_fee"`
+	OperationCount     int32               `json:"operation_count"`
+	EnvelopeXdr        string              `json:"envelope_xdr"`
+	ResultXdr          string              `json:"result_xdr"`
+	ResultMetaXdr      string              `json:"result_meta_xdr"`
+	FeeMetaXdr         string              `json:"fee_meta_xdr"`
+	MemoType           string              `json:"memo_type"`
+	Memo               string              `json:"memo,omitempty"`
+	Signatures         []string            `json:"signatures"`
+	ValidAfter         string              `json:"valid_after,omitempty"`
+	ValidBefore        string              `json:"valid_before,omitempty"`
+	FeeBumpTransaction *FeeBumpTransaction `json:"fee_bump_transaction,omitempty"`
+	InnerTransaction   *InnerTransaction   `json:"inner_transaction,omitempty"`
+}
+
+// FeeBumpTransaction contains information about a fee bump transaction
+type FeeBumpTransaction struct {
+	Hash       string   `json:"hash"`
+	Signatures []string `json:"signatures"`
+}
+
+// InnerTransaction contains information about the inner transaction contained
+// within a fee bump transaction
+type InnerTransaction struct {
+	Hash       string   `json:"hash"`
+	Signatures []string `json:"signatures"`
+	MaxFee     int64    `json:"max_fee"`
 }
 
 // MarshalJSON implements a custom marshaler for Transaction.
@@ -498,6 +525,59 @@ func (resp TransactionSuccess) TransactionSuccessToString() (s string) {
 	return
 }
 
+// AsyncTransactionSubmissionResponse represents the response of paydex-core to
+// a transaction submitted to the asynchronous submission endpoint.
+type AsyncTransactionSubmissionResponse struct {
+	Links struct {
+		Status hal.Link `json:"status"`
+	} `json:"_links"`
+	Hash           string `json:"hash"`
+	TxStatus       string `json:"tx_status"`
+	ErrorResultXDR string `json:"error_result_xdr,omitempty"`
+}
+
+// Values of AsyncTransactionSubmissionResponse.TxStatus
+const (
+	// SubmissionStatusPending means paydex-core accepted the transaction and
+	// will try to include it in a ledger.
+	SubmissionStatusPending = "PENDING"
+	// SubmissionStatusDuplicate means the transaction was already submitted.
+	SubmissionStatusDuplicate = "DUPLICATE"
+	// SubmissionStatusTryAgainLater means paydex-core did not accept the
+	// transaction because its queue is full, it can be submitted again.
+	SubmissionStatusTryAgainLater = "TRY_AGAIN_LATER"
+	// SubmissionStatusError means paydex-core rejected the transaction, the
+	// reason is in ErrorResultXDR.
+	SubmissionStatusError = "ERROR"
+)
+
+// AsyncTransactionStatus represents the status of a transaction submitted to
+// the asynchronous submission endpoint.
+type AsyncTransactionStatus struct {
+	Hash          string `json:"hash"`
+	Status        string `json:"status"`
+	Ledger        int32  `json:"ledger,omitempty"`
+	EnvelopeXDR   string `json:"envelope_xdr,omitempty"`
+	ResultXDR     string `json:"result_xdr,omitempty"`
+	ResultMetaXDR string `json:"result_meta_xdr,omitempty"`
+}
+
+// Values of AsyncTransactionStatus.Status
+const (
+	// TransactionStatusPending means the transaction was submitted and is
+	// waiting to be included in a ledger.
+	TransactionStatusPending = "PENDING"
+	// TransactionStatusSuccess means the transaction was included in a ledger
+	// and succeeded.
+	TransactionStatusSuccess = "SUCCESS"
+	// TransactionStatusFailed means the transaction was included in a ledger
+	// and failed.
+	TransactionStatusFailed = "FAILED"
+	// TransactionStatusNotFound means the transaction is neither pending nor
+	// in a ledger known to horizon.
+	TransactionStatusNotFound = "NOT_FOUND"
+)
+
 // KeyTypeFromAddress converts the version byte of the provided strkey encoded
 // value (for example an account id or a signer key) and returns the appropriate
 // horizon-specific type name.
@@ -546,6 +626,14 @@ type TradesPage struct {
 	} `json:"_embedded"`
 }
 
+// AccountsPage returns a list of accounts
+type AccountsPage struct {
+	Links    hal.Links `json:"_links"`
+	Embedded struct {
+		Records []Account `json:"records"`
+	} `json:"_embedded"`
+}
+
 // OffersPage returns a list of offers
 type OffersPage struct {
 	Links    hal.Links `json:"_links"`
@@ -686,3 +774,41 @@ type PathsPage struct {
 		Records []Path
 	} `json:"_embedded"`
 }
+
+// The types of the messages of the WebSocket transport of the streams.
+const (
+	// WebSocketSubscribe is sent by the client to subscribe to a stream.
+	WebSocketSubscribe = "subscribe"
+	// WebSocketUnsubscribe is sent by the client to end a subscription.
+	WebSocketUnsubscribe = "unsubscribe"
+	// WebSocketSubscribed is sent once the stream of a subscription started.
+	WebSocketSubscribed = "subscribed"
+	// WebSocketEvent contains an event of the stream of a subscription.
+	WebSocketEvent = "event"
+	// WebSocketUnsubscribed is sent once a subscription ended after an
+	// unsubscribe message.
+	WebSocketUnsubscribed = "unsubscribed"
+	// WebSocketError contains the problem which ended a subscription or, when
+	// it has no id, the problem of an invalid message.
+	WebSocketError = "error"
+	// WebSocketHeartbeat is sent periodically to keep the connection alive.
+	WebSocketHeartbeat = "heartbeat"
+)
+
+// WebSocketMessage is a message of the WebSocket transport of the streams,
+// over which a client subscribes to several streams.
+type WebSocketMessage struct {
+	Type string `json:"type"`
+	// ID identifies the subscription of the message, it is chosen by the
+	// client when subscribing.
+	ID string `json:"id,omitempty"`
+	// Path is the path and query of the stream of a subscribe message, for
+	// example `/accounts/{account_id}/payments?cursor=now`.
+	Path string `json:"path,omitempty"`
+	// Cursor is the paging token of an event. In a subscribe message, the
+	// stream starts after it, like with the Last-Event-ID header of the
+	// Server-Sent Events.
+	Cursor string          `json:"cursor,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  *problem.P      `json:"error,omitempty"`
+}
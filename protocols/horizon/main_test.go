@@ -50,6 +50,20 @@ func TestAccount_MustGetData(t *testing.T) {
 }
 
 // Transaction Tests
+func TestAccount_SignerSummary(t *testing.T) {
+	account := Account{
+		AccountID: "GABQGHD3LCZ2JBNXTOYPVZJKIWHUGAP6GIJ4X3TGXRS4OPDV3ZDFHCTE",
+		Signers: []Signer{
+			{Key: "GABQGHD3LCZ2JBNXTOYPVZJKIWHUGAP6GIJ4X3TGXRS4OPDV3ZDFHCTE", Weight: 1},
+			{Key: "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", Weight: 2},
+		},
+	}
+	assert.Equal(t, map[string]int32{
+		"GABQGHD3LCZ2JBNXTOYPVZJKIWHUGAP6GIJ4X3TGXRS4OPDV3ZDFHCTE": 1,
+		"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU": 2,
+	}, account.SignerSummary())
+}
+
 func TestTransactionJSONMarshal(t *testing.T) {
 	transaction := Transaction{
 		ID:       "12345",
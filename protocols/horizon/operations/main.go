@@ -50,11 +50,15 @@ type Base struct {
 	PT string `json:"paging_token"`
 	// TransactionSuccessful defines if this operation is part of
 	// successful transaction.
-	TransactionSuccessful bool      `json:"transaction_successful"`
-	SourceAccount         string    `json:"source_account"`
-	Type                  string    `json:"type"`
-	TypeI                 int32     `json:"type_i"`
-	LedgerCloseTime       time.Time `json:"created_at"`
+	TransactionSuccessful bool   `json:"transaction_successful"`
+	SourceAccount         string `json:"source_account"`
+	// SourceAccountMuxed is the multiplexed (M...) address of the source
+	// account, if the operation or its transaction used one.
+	SourceAccountMuxed   string    `json:"source_account_muxed,omitempty"`
+	SourceAccountMuxedID uint64    `json:"source_account_muxed_id,omitempty,string"`
+	Type                 string    `json:"type"`
+	TypeI                int32     `json:"type_i"`
+	LedgerCloseTime      time.Time `json:"created_at"`
 	// TransactionHash is the hash of the transaction which created the operation
 	// Note that the Transaction field below is not always present in the Operation response.
 	// If the Transaction field is present TransactionHash is redundant since the same information
@@ -82,6 +86,8 @@ type CreateAccount struct {
 	Base
 	StartingBalance string `json:"starting_balance"`
 	Funder          string `json:"funder"`
+	FunderMuxed     string `json:"funder_muxed,omitempty"`
+	FunderMuxedID   uint64 `json:"funder_muxed_id,omitempty,string"`
 	Account         string `json:"account"`
 }
 
@@ -90,9 +96,13 @@ type CreateAccount struct {
 type Payment struct {
 	Base
 	base.Asset
-	From   string `json:"from"`
-	To     string `json:"to"`
-	Amount string `json:"amount"`
+	From        string `json:"from"`
+	FromMuxed   string `json:"from_muxed,omitempty"`
+	FromMuxedID uint64 `json:"from_muxed_id,omitempty,string"`
+	To          string `json:"to"`
+	ToMuxed     string `json:"to_muxed,omitempty"`
+	ToMuxedID   uint64 `json:"to_muxed_id,omitempty,string"`
+	Amount      string `json:"amount"`
 }
 
 // PathPayment is the json resource representing a single operation whose type
@@ -189,9 +199,11 @@ type SetOptions struct {
 type ChangeTrust struct {
 	Base
 	base.Asset
-	Limit   string `json:"limit"`
-	Trustee string `json:"trustee"`
-	Trustor string `json:"trustor"`
+	Limit          string `json:"limit"`
+	Trustee        string `json:"trustee"`
+	Trustor        string `json:"trustor"`
+	TrustorMuxed   string `json:"trustor_muxed,omitempty"`
+	TrustorMuxedID uint64 `json:"trustor_muxed_id,omitempty,string"`
 }
 
 // AllowTrust is the json resource representing a single operation whose type is
@@ -199,17 +211,23 @@ type ChangeTrust struct {
 type AllowTrust struct {
 	Base
 	base.Asset
-	Trustee   string `json:"trustee"`
-	Trustor   string `json:"trustor"`
-	Authorize bool   `json:"authorize"`
+	Trustee        string `json:"trustee"`
+	TrusteeMuxed   string `json:"trustee_muxed,omitempty"`
+	TrusteeMuxedID uint64 `json:"trustee_muxed_id,omitempty,string"`
+	Trustor        string `json:"trustor"`
+	Authorize      bool   `json:"authorize"`
 }
 
 // AccountMerge is the json resource representing a single operation whose type
 // is AccountMerge.
 type AccountMerge struct {
 	Base
-	Account string `json:"account"`
-	Into    string `json:"into"`
+	Account        string `json:"account"`
+	AccountMuxed   string `json:"account_muxed,omitempty"`
+	AccountMuxedID uint64 `json:"account_muxed_id,omitempty,string"`
+	Into           string `json:"into"`
+	IntoMuxed      string `json:"into_muxed,omitempty"`
+	IntoMuxedID    uint64 `json:"into_muxed_id,omitempty,string"`
 }
 
 // Inflation is the json resource representing a single operation whose type is
@@ -140,9 +140,10 @@ func (ts *TransactionSubmitter) SignAndSubmitRawTransaction(paymentID *string, s
 		return
 	}
 
-	envelopeXdr := xdr.TransactionEnvelope{
-		Tx:         *tx,
-		Signatures: []xdr.DecoratedSignature{sig},
+	envelopeXdr, err := xdr.NewTransactionV0Envelope(*tx, []xdr.DecoratedSignature{sig})
+	if err != nil {
+		ts.log.WithFields(logrus.Fields{"err": err}).Error("Cannot build transaction envelope")
+		return
 	}
 
 	txeB64, err := xdr.MarshalBase64(envelopeXdr)
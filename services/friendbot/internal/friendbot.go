@@ -1,36 +1,64 @@
 package internal
 
 import (
-	"log"
-	"sync"
+	"context"
+	"fmt"
 
+	"github.com/paydex-core/paydex-go/clients/horizonclient"
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/paydex-core/paydex-go/txnbuild/channels"
 )
 
-// Bot represents the friendbot subsystem and primarily delegates work
-// to its Minions.
-type Bot struct {
-	Minions         []Minion
-	nextMinionIndex int
-	indexMux        sync.Mutex
-}
+const createAccountAlreadyExistXDR = "AAAAAAAAAGT/////AAAAAQAAAAAAAAAA/////AAAAAA="
 
-// SubmitResult is the result from the asynchronous tx submission.
-type SubmitResult struct {
-	maybeTransactionSuccess *hProtocol.TransactionSuccess
-	maybeErr                error
+var ErrAccountExists error = errors.New(fmt.Sprintf("createAccountAlreadyExist (%s)", createAccountAlreadyExistXDR))
+
+// Bot represents the friendbot subsystem. Accounts are created by the bot
+// account, using the channel accounts (minions) of its pool as the source
+// accounts of the transactions.
+type Bot struct {
+	Pool            *channels.Pool
+	StartingBalance string
 }
 
 // Pay funds the account at `destAddress`.
 func (bot *Bot) Pay(destAddress string) (*hProtocol.TransactionSuccess, error) {
-	bot.indexMux.Lock()
-	log.Printf("Selecting minion at index %d of max length %d", bot.nextMinionIndex, len(bot.Minions))
-	minion := bot.Minions[bot.nextMinionIndex]
-	bot.nextMinionIndex = (bot.nextMinionIndex + 1) % len(bot.Minions)
-	bot.indexMux.Unlock()
-	resultChan := make(chan SubmitResult)
-	go minion.Run(destAddress, resultChan)
-	maybeSubmitResult := <-resultChan
-	close(resultChan)
-	return maybeSubmitResult.maybeTransactionSuccess, maybeSubmitResult.maybeErr
+	tx := txnbuild.Transaction{
+		Operations: []txnbuild.Operation{
+			&txnbuild.CreateAccount{
+				Destination:   destAddress,
+				SourceAccount: bot.Pool.Funder(),
+				Amount:        bot.StartingBalance,
+			},
+		},
+		Timebounds: txnbuild.NewInfiniteTimeout(),
+	}
+
+	result, err := bot.Pool.Submit(context.Background(), tx)
+	if err != nil {
+		return nil, submitError(err)
+	}
+	return &result, nil
+}
+
+// submitError adds the result of a failed transaction to the error returned by
+// horizon, and converts it to ErrAccountExists when the account to fund
+// already exists.
+func submitError(err error) error {
+	errStr := "submitting tx to horizon"
+	switch e := err.(type) {
+	case *horizonclient.Error:
+		resStr, resErr := e.ResultString()
+		if resErr != nil {
+			errStr += ": error getting horizon error code: " + resErr.Error()
+		} else if resStr == createAccountAlreadyExistXDR {
+			return errors.Wrap(ErrAccountExists, errStr)
+		} else {
+			errStr += ": horizon error string: " + resStr
+		}
+		return errors.New(errStr)
+	}
+	return errors.Wrap(err, errStr)
 }
@@ -7,23 +7,29 @@ import (
 	"github.com/paydex-core/paydex-go/clients/horizonclient"
 	"github.com/paydex-core/paydex-go/keypair"
 	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/txnbuild/channels"
 	"github.com/stretchr/testify/assert"
 )
 
-func TestFriendbot_Pay(t *testing.T) {
-	mockSubmitTransaction := func(minion *Minion, hclient *horizonclient.Client, tx string) (*hProtocol.TransactionSuccess, error) {
-		// Instead of submitting the tx, we emulate a success.
-		txSuccess := hProtocol.TransactionSuccess{Env: tx}
-		return &txSuccess, nil
-	}
+// mockHorizon emulates the submission of transactions.
+type mockHorizon struct{}
+
+func (mockHorizon) AccountDetail(request horizonclient.AccountRequest) (hProtocol.Account, error) {
+	return hProtocol.Account{}, horizonclient.Error{}
+}
 
+func (mockHorizon) SubmitTransactionXDR(tx string) (hProtocol.TransactionSuccess, error) {
+	// Instead of submitting the tx, we emulate a success.
+	return hProtocol.TransactionSuccess{Env: tx}, nil
+}
+
+func TestFriendbot_Pay(t *testing.T) {
 	// Public key: GD25B4QI6KWVDWXDW25CIM7EKR6A6PBSWE2RCNSAC4NJQDQJXZJYMMKR
 	botSeed := "SCWNLYELENPBXN46FHYXETT5LJCYBZD5VUQQVW4KZPHFO2YTQJUWT4D5"
 	botKeypair, err := keypair.Parse(botSeed)
 	if !assert.NoError(t, err) {
 		return
 	}
-	botAccount := Account{AccountID: botKeypair.Address()}
 
 	// Public key: GD4AGPPDFFHKK3Z2X4XZDRXX6GZQKP4FMLVQ5T55NDEYGG3GIP7BQUHM
 	minionSeed := "SDTNSEERJPJFUE2LSDNYBFHYGVTPIWY7TU2IOJZQQGLWO2THTGB7NU5A"
@@ -32,19 +38,9 @@ func TestFriendbot_Pay(t *testing.T) {
 		return
 	}
 
-	minion := Minion{
-		Account: Account{
-			AccountID: minionKeypair.Address(),
-			Sequence:  1,
-		},
-		Keypair:           minionKeypair.(*keypair.Full),
-		BotAccount:        botAccount,
-		BotKeypair:        botKeypair.(*keypair.Full),
-		Network:           "Test SDF Network ; September 2015",
-		StartingBalance:   "10000.00",
-		SubmitTransaction: mockSubmitTransaction,
-	}
-	fb := &Bot{Minions: []Minion{minion}}
+	pool := channels.NewPool(mockHorizon{}, botKeypair.(*keypair.Full), "Test SDF Network ; September 2015", 0)
+	pool.AddChannel(minionKeypair.(*keypair.Full), 1)
+	fb := &Bot{Pool: pool, StartingBalance: "10000.00"}
 
 	recipientAddress := "GDJIN6W6PLTPKLLM57UW65ZH4BITUXUMYQHIMAZFYXF45PZVAWDBI77Z"
 	txSuccess, err := fb.Pay(recipientAddress)
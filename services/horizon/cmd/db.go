@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/schema"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ingest"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/util"
@@ -60,6 +61,47 @@ var dbBackfillCmd = &cobra.Command{
 	},
 }
 
+// memoBackfillBatchSize is the number of ledgers whose transaction memos are
+// backfilled in a single statement by dbBackfillMemosCmd.
+const memoBackfillBatchSize = 10000
+
+var dbBackfillMemosCmd = &cobra.Command{
+	Use:   "backfill-memos",
+	Short: "copies the memos of ingested transactions to their participants",
+	Long: "backfill-memos copies the memo of the transactions ingested before memos were indexed " +
+		"to their participants, so that they can be looked up by memo. It can run while horizon is running.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		q := &history.Q{Session: hdb}
+
+		var elder, latest int32
+		if err = q.ElderLedger(&elder); err != nil {
+			log.Fatal(err)
+		}
+		if err = q.LatestLedger(&latest); err != nil {
+			log.Fatal(err)
+		}
+
+		var total int64
+		for start := elder; start <= latest; start += memoBackfillBatchSize {
+			end := start + memoBackfillBatchSize
+			updated, err := q.BackfillTransactionMemos(start, end)
+			if err != nil {
+				log.Fatal(err)
+			}
+			total += updated
+			log.Printf("Backfilled the memos of ledgers %d to %d (%d participants)\n", start, end-1, updated)
+		}
+
+		log.Printf("Backfilled the memos of %d participants.\n", total)
+	},
+}
+
 var dbInitAssetStatsCmd = &cobra.Command{
 	Use:   "init-asset-stats",
 	Short: "initializes values for assets stats",
@@ -279,6 +321,7 @@ func init() {
 		dbInitCmd,
 		dbInitAssetStatsCmd,
 		dbBackfillCmd,
+		dbBackfillMemosCmd,
 		dbClearCmd,
 		dbMigrateCmd,
 		dbReapCmd,
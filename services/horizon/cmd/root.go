@@ -366,6 +366,71 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: false,
 		Usage:       "experimental ingestion system runs a verification routing to compare state in local database with history buckets, this can be disabled however it's not recommended",
 	},
+	&support.ConfigOption{
+		Name:        "enable-graphql",
+		ConfigKey:   &config.EnableGraphQL,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "exposes the `/graphql` endpoint",
+	},
+	&support.ConfigOption{
+		Name:        "graphql-max-depth",
+		ConfigKey:   &config.GraphQLMaxDepth,
+		OptType:     types.Int,
+		FlagDefault: 10,
+		Usage:       "maximum depth of the queries accepted by the `/graphql` endpoint, 0 disables the limit",
+	},
+	&support.ConfigOption{
+		Name:        "graphql-max-cost",
+		ConfigKey:   &config.GraphQLMaxCost,
+		OptType:     types.Int,
+		FlagDefault: 10000,
+		Usage:       "maximum estimated number of fields resolved by a query sent to the `/graphql` endpoint, 0 disables the limit",
+	},
+	&support.ConfigOption{
+		Name:           "graphql-query-timeout",
+		ConfigKey:      &config.GraphQLQueryTimeout,
+		OptType:        types.Int,
+		FlagDefault:    30,
+		CustomSetValue: support.SetDuration,
+		Usage:          "maximum duration of a query sent to the `/graphql` endpoint (in seconds), 0 disables the limit",
+	},
+	&support.ConfigOption{
+		Name:        "enable-webhooks",
+		ConfigKey:   &config.EnableWebhooks,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "enables webhook subscriptions and exposes their admin API at `/admin/webhooks`, events are only delivered by instances ingesting history",
+	},
+	&support.ConfigOption{
+		Name:        "webhooks-admin-token",
+		ConfigKey:   &config.WebhooksAdminToken,
+		OptType:     types.String,
+		FlagDefault: "",
+		Usage:       "bearer token required by the webhooks admin API",
+	},
+	&support.ConfigOption{
+		Name:        "webhooks-max-attempts",
+		ConfigKey:   &config.WebhooksMaxAttempts,
+		OptType:     types.Int,
+		FlagDefault: 12,
+		Usage:       "number of attempts of a webhook delivery before it is moved to the dead state",
+	},
+	&support.ConfigOption{
+		Name:           "websocket-heartbeat-interval",
+		ConfigKey:      &config.WebSocketHeartbeatInterval,
+		OptType:        types.Int,
+		FlagDefault:    15,
+		CustomSetValue: support.SetDuration,
+		Usage:          "interval between two heartbeats sent over the WebSocket connections of the streams (in seconds)",
+	},
+	&support.ConfigOption{
+		Name:        "websocket-max-subscriptions",
+		ConfigKey:   &config.WebSocketMaxSubscriptions,
+		OptType:     types.Int,
+		FlagDefault: 50,
+		Usage:       "maximum number of streams subscribed over a WebSocket connection, 0 disables the limit",
+	},
 	&support.ConfigOption{
 		Name:        "apply-migrations",
 		ConfigKey:   &config.ApplyMigrations,
@@ -423,6 +488,16 @@ func initConfig() {
 	// Configure log level
 	log.DefaultLogger.Logger.SetLevel(config.LogLevel)
 
+	if config.EnableWebhooks && config.WebhooksAdminToken == "" {
+		stdLog.Fatal("--webhooks-admin-token must be set when --enable-webhooks is set")
+	}
+	if config.WebhooksMaxAttempts < 1 {
+		stdLog.Fatal("--webhooks-max-attempts must be positive")
+	}
+	if config.WebSocketHeartbeatInterval <= 0 {
+		stdLog.Fatal("--websocket-heartbeat-interval must be positive")
+	}
+
 	if config.IngestStateReaderTempSet != "memory" && config.IngestStateReaderTempSet != "postgres" {
 		log.Fatal("Invalid `ingest-state-reader-temp-set` value: " + config.IngestStateReaderTempSet)
 	}
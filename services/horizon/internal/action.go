@@ -13,6 +13,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/httpx"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
@@ -164,6 +165,7 @@ type indexActionQueryParams struct {
 	LedgerID         int32
 	PagingParams     db2.PageQuery
 	IncludeFailedTxs bool
+	Filters          actions.TransactionFilters
 	Signer           string
 }
 
@@ -209,7 +211,7 @@ func (w *web) getTransactionPage(ctx context.Context, qp *indexActionQueryParams
 		return nil, errors.Wrap(err, "getting horizon db session")
 	}
 
-	return actions.TransactionPage(ctx, &history.Q{horizonSession}, qp.AccountID, qp.LedgerID, qp.IncludeFailedTxs, qp.PagingParams)
+	return actions.TransactionPage(ctx, &history.Q{horizonSession}, qp.AccountID, qp.LedgerID, qp.IncludeFailedTxs, qp.Filters, qp.PagingParams)
 }
 
 // getTransactionRecord returns a single transaction resource.
@@ -229,5 +231,13 @@ func (w *web) streamTransactions(ctx context.Context, s *sse.Stream, qp *indexAc
 		return errors.Wrap(err, "getting horizon db session")
 	}
 
-	return actions.StreamTransactions(ctx, s, &history.Q{horizonSession}, qp.AccountID, qp.LedgerID, qp.IncludeFailedTxs, qp.PagingParams)
+	return actions.StreamTransactions(ctx, s, &history.Q{horizonSession}, qp.AccountID, qp.LedgerID, qp.IncludeFailedTxs, qp.Filters, qp.PagingParams)
+}
+
+// liveTransactions returns the events of the transaction records of a
+// published ledger for a stream of the transactions of an account or of every
+// transaction.
+func (w *web) liveTransactions(ctx context.Context, l *pubsub.Ledger, lastEventID string, qp *indexActionQueryParams) ([]sse.Event, error) {
+	cursor := liveCursor(lastEventID, qp.PagingParams)
+	return actions.LiveTransactions(ctx, l, cursor, qp.IncludeFailedTxs), nil
 }
@@ -0,0 +1,177 @@
+package actions
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// AccountStateQuery query struct for the historical account state end-points
+type AccountStateQuery struct {
+	AccountID string `schema:"account_id" valid:"accountID,required"`
+	Ledger    string `schema:"ledger" valid:"-"`
+	At        string `schema:"at" valid:"-"`
+}
+
+var invalidAccountStateParams = problem.P{
+	Type:   "invalid_account_state_params",
+	Title:  "Invalid Account State Parameters",
+	Status: http.StatusBadRequest,
+	Detail: "A point in history is required. Please ensure that you are including either a ledger or a timestamp (at).",
+}
+
+// Validate runs custom validations.
+func (q AccountStateQuery) Validate() error {
+	if len(q.Ledger) == 0 && len(q.At) == 0 {
+		return invalidAccountStateParams
+	}
+
+	if len(q.Ledger) > 0 && len(q.At) > 0 {
+		return problem.MakeInvalidFieldProblem(
+			"at",
+			errors.New("you can't request a ledger and a timestamp at the same time"),
+		)
+	}
+
+	if len(q.Ledger) > 0 {
+		if _, err := q.LedgerSequence(); err != nil {
+			return problem.MakeInvalidFieldProblem(
+				"ledger",
+				errors.New("Ledger must be a positive ledger sequence"),
+			)
+		}
+	}
+
+	if len(q.At) > 0 {
+		if _, err := q.Time(); err != nil {
+			return problem.MakeInvalidFieldProblem(
+				"at",
+				errors.New("Timestamp must be in RFC 3339 format, for example 2020-01-02T15:04:05Z"),
+			)
+		}
+	}
+
+	return nil
+}
+
+// LedgerSequence returns the ledger requested.
+func (q AccountStateQuery) LedgerSequence() (uint32, error) {
+	sequence, err := strconv.ParseUint(q.Ledger, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if sequence == 0 {
+		return 0, errors.New("ledger sequence is zero")
+	}
+	return uint32(sequence), nil
+}
+
+// Time returns the timestamp requested.
+func (q AccountStateQuery) Time() (time.Time, error) {
+	return time.Parse(time.RFC3339, q.At)
+}
+
+// GetAccountStateHandler is the action handler for the /accounts/{id}?ledger={sequence}
+// and /accounts/{id}/balances?at={timestamp} endpoints
+type GetAccountStateHandler struct {
+}
+
+// GetResource returns an account as it was at a ledger, or at the last ledger
+// closed by a time.
+func (handler GetAccountStateHandler) GetResource(
+	w HeaderWriter,
+	r *http.Request,
+) (hal.Pageable, error) {
+	ctx := r.Context()
+	qp := AccountStateQuery{}
+	err := GetParams(&qp, r)
+	if err != nil {
+		return nil, err
+	}
+
+	historyQ, err := historyQFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	field := "ledger"
+	var sequence uint32
+	if len(qp.At) > 0 {
+		field = "at"
+		at, _ := qp.Time()
+		closed, err := historyQ.LedgerSequenceAt(at)
+		if historyQ.NoRows(err) {
+			return nil, hProblem.BeforeHistory
+		} else if err != nil {
+			return nil, errors.Wrap(err, "loading ledger closed at timestamp")
+		}
+		sequence = uint32(closed)
+	} else {
+		sequence, _ = qp.LedgerSequence()
+	}
+
+	elder, err := historyQ.StateHistoryElder()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading state history elder")
+	}
+	if historyElder := ledger.CurrentState().HistoryElder; uint32(historyElder) > elder {
+		elder = uint32(historyElder)
+	}
+	if sequence < elder {
+		return nil, hProblem.BeforeHistory
+	}
+
+	latest, err := historyQ.GetLastLedgerExpIngestNonBlocking()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading last ingested ledger")
+	}
+	if sequence > latest {
+		return nil, problem.MakeInvalidFieldProblem(
+			field,
+			errors.New("the ledger has not been ingested yet"),
+		)
+	}
+
+	return AccountStateAt(ctx, historyQ, qp.AccountID, sequence)
+}
+
+// AccountStateAt returns the information about an account identified by addr
+// as it was at the ledger with the given sequence. Its data entries are not
+// part of the state history so the resource has no data.
+func AccountStateAt(ctx context.Context, hq *history.Q, addr string, sequence uint32) (protocol.Account, error) {
+	var resource protocol.Account
+
+	state, err := hq.AccountStateAt(addr, sequence)
+	if err != nil {
+		return resource, errors.Wrap(err, "getting account state")
+	}
+
+	trustLines, err := hq.TrustLinesAt(addr, sequence)
+	if err != nil {
+		return resource, errors.Wrap(err, "getting trust line states")
+	}
+
+	err = resourceadapter.PopulateAccountEntry(
+		ctx,
+		&resource,
+		state.AccountEntry,
+		nil,
+		state.Signers.AccountSigners(addr),
+		trustLines,
+	)
+	if err != nil {
+		return resource, errors.Wrap(err, "populating account entry")
+	}
+
+	return resource, nil
+}
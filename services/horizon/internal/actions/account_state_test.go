@@ -0,0 +1,87 @@
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+func TestAccountStateQueryValidate(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		query                AccountStateQuery
+		expectedInvalidField string
+		expectedErr          string
+		isInvalidParams      bool
+	}{
+		{
+			desc:            "no point in history",
+			query:           AccountStateQuery{AccountID: accountOne},
+			isInvalidParams: true,
+		},
+		{
+			desc: "ledger and timestamp",
+			query: AccountStateQuery{
+				AccountID: accountOne,
+				Ledger:    "10",
+				At:        "2020-01-02T15:04:05Z",
+			},
+			expectedInvalidField: "at",
+			expectedErr:          "you can't request a ledger and a timestamp at the same time",
+		},
+		{
+			desc:                 "zero ledger",
+			query:                AccountStateQuery{AccountID: accountOne, Ledger: "0"},
+			expectedInvalidField: "ledger",
+			expectedErr:          "Ledger must be a positive ledger sequence",
+		},
+		{
+			desc:                 "negative ledger",
+			query:                AccountStateQuery{AccountID: accountOne, Ledger: "-3"},
+			expectedInvalidField: "ledger",
+			expectedErr:          "Ledger must be a positive ledger sequence",
+		},
+		{
+			desc:                 "invalid timestamp",
+			query:                AccountStateQuery{AccountID: accountOne, At: "1577977445"},
+			expectedInvalidField: "at",
+			expectedErr:          "Timestamp must be in RFC 3339 format, for example 2020-01-02T15:04:05Z",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tt := assert.New(t)
+			err := tc.query.Validate()
+			tt.Error(err)
+			if tc.isInvalidParams {
+				tt.Equal(invalidAccountStateParams, err)
+				return
+			}
+			if tt.IsType(&problem.P{}, err) {
+				p := err.(*problem.P)
+				tt.Equal("bad_request", p.Type)
+				tt.Equal(tc.expectedInvalidField, p.Extras["invalid_field"])
+				tt.Equal(tc.expectedErr, p.Extras["reason"])
+			}
+		})
+	}
+}
+
+func TestAccountStateQueryPointInHistory(t *testing.T) {
+	tt := assert.New(t)
+
+	q := AccountStateQuery{AccountID: accountOne, Ledger: "1234"}
+	tt.NoError(q.Validate())
+	sequence, err := q.LedgerSequence()
+	tt.NoError(err)
+	tt.Equal(uint32(1234), sequence)
+
+	q = AccountStateQuery{AccountID: accountOne, At: "2020-01-02T15:04:05Z"}
+	tt.NoError(q.Validate())
+	at, err := q.Time()
+	tt.NoError(err)
+	tt.True(at.Equal(time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)))
+}
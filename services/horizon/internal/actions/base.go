@@ -10,6 +10,7 @@ import (
 
 	horizonContext "github.com/paydex-core/paydex-go/services/horizon/internal/context"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render"
 	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
@@ -67,14 +68,58 @@ func (base *Base) Execute(action interface{}) {
 			goto NotAcceptable
 		}
 
-		stream := sse.NewStream(ctx, base.W)
+		base.stream(action)
+	case render.MimeRaw:
+		action, ok := action.(RawDataResponder)
+		if !ok {
+			goto NotAcceptable
+		}
 
-		var oldHash [32]byte
-		for {
-			lastLedgerState := ledger.CurrentState()
+		err := action.Raw()
+		if err != nil {
+			problem.Render(ctx, base.W, err)
+			return
+		}
+	default:
+		goto NotAcceptable
+	}
+	return
 
-			// Rate limit the request if it's a call to stream since it queries the DB every second.
-			app := base.R.Context().Value(&horizonContext.AppContextKey)
+NotAcceptable:
+	problem.Render(ctx, base.W, hProblem.NotAcceptable)
+}
+
+// stream sends the events of action until the stream is done. The events are
+// loaded again whenever a ledger is closed, or whenever a ledger with records
+// of its topic is published when action is a TopicSubscriber. The events of a
+// LiveEventStreamer are only loaded from the database to catch up from the
+// cursor of the request.
+func (base *Base) stream(action interface{}) {
+	ctx := base.R.Context()
+	stream := sse.NewStream(ctx, base.W)
+	app := ctx.Value(&horizonContext.AppContextKey)
+
+	var ledgers <-chan *pubsub.Ledger
+	if subscriber, ok := action.(TopicSubscriber); ok {
+		// Subscribe before loading the events so that no ledger ingested in
+		// between is missed.
+		if topic, ok := subscriber.Topic(); ok {
+			if provider, ok := app.(StreamHubProvider); ok && provider.GetStreamHub() != nil {
+				subscription := provider.GetStreamHub().Subscribe(topic)
+				defer subscription.Unsubscribe()
+				ledgers = subscription.Ledgers()
+			}
+		}
+	}
+	live, isLive := action.(LiveEventStreamer)
+
+	var oldHash [32]byte
+	load := true
+	for {
+		lastLedgerState := ledger.CurrentState()
+
+		if load {
+			// Rate limit the request if it's a call to stream since it queries the DB.
 			rateLimiter := app.(RateLimiterProvider).GetRateLimiter()
 			if rateLimiter != nil {
 				limited, _, err := rateLimiter.RateLimiter.RateLimit(rateLimiter.VaryBy.Key(base.R), 1)
@@ -117,33 +162,41 @@ func (base *Base) Execute(action interface{}) {
 				stream.SetLimit(10)
 				stream.Send(newEvent)
 			}
+		}
 
-			// Manually send the preamble in case there are no data events in SSE to trigger a stream.Send call.
-			// This method is called every iteration of the loop, but is protected by a sync.Once variable so it's
-			// only executed once.
-			stream.Init()
+		// Manually send the preamble in case there are no data events in SSE to trigger a stream.Send call.
+		// This method is called every iteration of the loop, but is protected by a sync.Once variable so it's
+		// only executed once.
+		stream.Init()
 
-			if stream.IsDone() {
-				return
-			}
+		if stream.IsDone() {
+			return
+		}
 
-			// Make sure this is buffered channel of size 1. Otherwise, the go routine below
-			// will never return if `newLedgers` channel is not read. From Effective Go:
-			// > If the channel is unbuffered, the sender blocks until the receiver has received the value.
-			newLedgers := make(chan bool, 1)
-			go func() {
-				for {
-					time.Sleep(base.sseUpdateFrequency)
-					currentLedgerState := ledger.CurrentState()
-					if currentLedgerState.HistoryLatest >= lastLedgerState.HistoryLatest+1 {
-						newLedgers <- true
-						return
-					}
+		if ledgers != nil {
+			select {
+			case l, ok := <-ledgers:
+				if !ok {
+					// The subscription fell behind, the client catches up
+					// from the database when reconnecting.
+					break
+				}
+				if !isLive {
+					continue
 				}
-			}()
 
-			select {
-			case <-newLedgers:
+				load = false
+				events, err := live.LiveEvents(l, stream.LastEventID())
+				if err != nil {
+					stream.Err(err)
+					return
+				}
+				for _, event := range events {
+					if stream.IsDone() {
+						break
+					}
+					stream.Send(event)
+				}
 				continue
 			case <-ctx.Done():
 			case <-base.appCtx.Done():
@@ -152,24 +205,32 @@ func (base *Base) Execute(action interface{}) {
 			stream.Done()
 			return
 		}
-	case render.MimeRaw:
-		action, ok := action.(RawDataResponder)
-		if !ok {
-			goto NotAcceptable
-		}
 
-		err := action.Raw()
-		if err != nil {
-			problem.Render(ctx, base.W, err)
-			return
+		// Make sure this is buffered channel of size 1. Otherwise, the go routine below
+		// will never return if `newLedgers` channel is not read. From Effective Go:
+		// > If the channel is unbuffered, the sender blocks until the receiver has received the value.
+		newLedgers := make(chan bool, 1)
+		go func() {
+			for {
+				time.Sleep(base.sseUpdateFrequency)
+				currentLedgerState := ledger.CurrentState()
+				if currentLedgerState.HistoryLatest >= lastLedgerState.HistoryLatest+1 {
+					newLedgers <- true
+					return
+				}
+			}
+		}()
+
+		select {
+		case <-newLedgers:
+			continue
+		case <-ctx.Done():
+		case <-base.appCtx.Done():
 		}
-	default:
-		goto NotAcceptable
-	}
-	return
 
-NotAcceptable:
-	problem.Render(ctx, base.W, hProblem.NotAcceptable)
+		stream.Done()
+		return
+	}
 }
 
 // Do executes the provided func iff there is no current error for the action.
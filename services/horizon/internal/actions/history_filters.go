@@ -0,0 +1,269 @@
+package actions
+
+import (
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/support/time"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// These are the query string param names of the filters of the history
+// endpoints.
+const (
+	// ParamStartTime is a query string param name
+	ParamStartTime = "start_time"
+	// ParamEndTime is a query string param name
+	ParamEndTime = "end_time"
+	// ParamType is a query string param name
+	ParamType = "type"
+	// ParamAsset is a query string param name
+	ParamAsset = "asset"
+	// ParamMemo is a query string param name
+	ParamMemo = "memo"
+	// ParamMemoType is a query string param name
+	ParamMemoType = "memo_type"
+)
+
+// GetTimeMillis retrieves a TimeMillis from the action parameter of the
+// given name. Returns a nil TimeMillis if the parameter is missing.
+func GetTimeMillis(r *http.Request, name string) (time.Millis, error) {
+	asStr, err := GetString(r, name)
+	if err != nil {
+		return 0, err
+	}
+	if asStr == "" {
+		return 0, nil
+	}
+
+	millis, err := time.MillisFromString(asStr)
+	if err != nil || millis < 0 {
+		return 0, problem.MakeInvalidFieldProblem(
+			name,
+			errors.New("must be a positive number of milliseconds since epoch"),
+		)
+	}
+
+	return millis, nil
+}
+
+// GetTimeRange retrieves the start_time and end_time params used to filter
+// the records of the history endpoints by the close time of their ledger.
+// Either time is nil when its param is missing.
+func GetTimeRange(r *http.Request) (time.Millis, time.Millis, error) {
+	start, err := GetTimeMillis(r, ParamStartTime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := GetTimeMillis(r, ParamEndTime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if !start.IsNil() && !end.IsNil() && end <= start {
+		return 0, 0, problem.MakeInvalidFieldProblem(
+			ParamEndTime,
+			errors.New("must be greater than start_time"),
+		)
+	}
+
+	return start, end, nil
+}
+
+// getTypeNames returns the type names of the param with the given name. The
+// param can be repeated and each value can be a comma separated list.
+func getTypeNames(r *http.Request, name string) ([]string, error) {
+	var names []string
+	for _, value := range r.URL.Query()[name] {
+		if err := checkUTF8(name, value); err != nil {
+			return nil, err
+		}
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				names = append(names, part)
+			}
+		}
+	}
+	return names, nil
+}
+
+// GetOperationTypes retrieves the operation types named in the param with
+// the given name, for example `type=payment,path_payment_strict_send`.
+func GetOperationTypes(r *http.Request, name string) ([]xdr.OperationType, error) {
+	names, err := getTypeNames(r, name)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	byName := map[string]xdr.OperationType{}
+	for typ, typeName := range operations.TypeNames {
+		byName[typeName] = typ
+	}
+
+	types := make([]xdr.OperationType, 0, len(names))
+	for _, typeName := range names {
+		typ, ok := byName[typeName]
+		if !ok {
+			return nil, problem.MakeInvalidFieldProblem(
+				name,
+				errors.Errorf("%s is not a valid operation type", typeName),
+			)
+		}
+		types = append(types, typ)
+	}
+
+	return types, nil
+}
+
+// GetEffectTypes retrieves the effect types named in the param with the
+// given name, for example `type=account_credited,account_debited`.
+func GetEffectTypes(r *http.Request, name string) ([]history.EffectType, error) {
+	names, err := getTypeNames(r, name)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+
+	byName := map[string]history.EffectType{}
+	for typ, typeName := range resourceadapter.EffectTypeNames {
+		byName[typeName] = typ
+	}
+
+	types := make([]history.EffectType, 0, len(names))
+	for _, typeName := range names {
+		typ, ok := byName[typeName]
+		if !ok {
+			return nil, problem.MakeInvalidFieldProblem(
+				name,
+				errors.Errorf("%s is not a valid effect type", typeName),
+			)
+		}
+		types = append(types, typ)
+	}
+
+	return types, nil
+}
+
+// MaybeGetAssetFilter retrieves a single asset, in the format defined by
+// SEP-0011 ("native" or "Code:Issuer"), from the param with the given name.
+// The returned boolean is false if the param is missing.
+func MaybeGetAssetFilter(r *http.Request, name string) (xdr.Asset, bool, error) {
+	s, err := GetString(r, name)
+	if err != nil || s == "" {
+		return xdr.Asset{}, false, err
+	}
+
+	assets, err := xdr.BuildAssets(s)
+	if err != nil || len(assets) != 1 {
+		return xdr.Asset{}, false, problem.MakeInvalidFieldProblem(
+			name,
+			errors.New("must be `native` or `Code:Issuer`"),
+		)
+	}
+
+	return assets[0], true, nil
+}
+
+// GetMemo retrieves the memo and memo_type params used to look up
+// transactions by memo. The memo is given as it is in transaction resources:
+// a text, a decimal id, or a base64 encoded hash. The memo type is optional
+// but requires a memo.
+func GetMemo(r *http.Request) (string, string, error) {
+	memo, err := GetString(r, ParamMemo)
+	if err != nil {
+		return "", "", err
+	}
+
+	memoType, err := GetString(r, ParamMemoType)
+	if err != nil {
+		return "", "", err
+	}
+
+	if memo == "" {
+		if memoType != "" {
+			return "", "", problem.MakeInvalidFieldProblem(
+				ParamMemo,
+				errors.New("must be set with memo_type"),
+			)
+		}
+		return "", "", nil
+	}
+
+	var reason string
+	switch memoType {
+	case "":
+	case "text":
+		if len(memo) > 28 {
+			reason = "must be at most 28 bytes long for a text memo"
+		}
+	case "id":
+		if _, err := strconv.ParseUint(memo, 10, 64); err != nil {
+			reason = "must be an unsigned 64-bit integer for an id memo"
+		}
+	case "hash", "return":
+		if hash, err := base64.StdEncoding.DecodeString(memo); err != nil || len(hash) != 32 {
+			reason = "must be a base64 encoded 32 byte hash for a hash or return memo"
+		}
+	default:
+		return "", "", problem.MakeInvalidFieldProblem(
+			ParamMemoType,
+			errors.New("must be one of text, id, hash or return"),
+		)
+	}
+	if reason != "" {
+		return "", "", problem.MakeInvalidFieldProblem(ParamMemo, errors.New(reason))
+	}
+
+	return memoType, memo, nil
+}
+
+// GetTimeRange retrieves the start_time and end_time params. Populates err
+// if either time is invalid.
+func (base *Base) GetTimeRange() (start time.Millis, end time.Millis) {
+	if base.Err != nil {
+		return
+	}
+
+	start, end, base.Err = GetTimeRange(base.R)
+	return
+}
+
+// GetOperationTypes retrieves the operation types named in the param with
+// the given name. Populates err if a type is unknown.
+func (base *Base) GetOperationTypes(name string) (types []xdr.OperationType) {
+	if base.Err != nil {
+		return nil
+	}
+
+	types, base.Err = GetOperationTypes(base.R, name)
+	return types
+}
+
+// GetEffectTypes retrieves the effect types named in the param with the
+// given name. Populates err if a type is unknown.
+func (base *Base) GetEffectTypes(name string) (types []history.EffectType) {
+	if base.Err != nil {
+		return nil
+	}
+
+	types, base.Err = GetEffectTypes(base.R, name)
+	return types
+}
+
+// MaybeGetAssetFilter retrieves a single asset from the param with the given
+// name. Populates err if the asset is invalid.
+func (base *Base) MaybeGetAssetFilter(name string) (asset xdr.Asset, ok bool) {
+	if base.Err != nil {
+		return xdr.Asset{}, false
+	}
+
+	asset, ok, base.Err = MaybeGetAssetFilter(base.R, name)
+	return asset, ok
+}
@@ -0,0 +1,208 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/support/time"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+func TestGetTransactionFilters(t *testing.T) {
+	tt := assert.New(t)
+
+	filters, err := GetTransactionFilters(makeRequest(t, map[string]string{}, map[string]string{}, nil))
+	tt.NoError(err)
+	tt.True(filters.IsZero())
+
+	filters, err = GetTransactionFilters(makeRequest(
+		t,
+		map[string]string{
+			"start_time": "1582156800000",
+			"end_time":   "1582243200000",
+			"type":       "payment, create_account",
+			"asset":      "USD:GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD",
+		},
+		map[string]string{},
+		nil,
+	))
+	tt.NoError(err)
+	tt.False(filters.IsZero())
+	tt.Equal(time.MillisFromInt64(1582156800000), filters.StartTime)
+	tt.Equal(time.MillisFromInt64(1582243200000), filters.EndTime)
+	tt.Equal(
+		[]xdr.OperationType{xdr.OperationTypePayment, xdr.OperationTypeCreateAccount},
+		filters.OperationTypes,
+	)
+	if tt.NotNil(filters.Asset) {
+		tt.Equal(xdr.AssetTypeAssetTypeCreditAlphanum4, filters.Asset.Type)
+	}
+}
+
+func TestGetTransactionFiltersInvalid(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		params               map[string]string
+		expectedInvalidField string
+		expectedErr          string
+	}{
+		{
+			desc:                 "invalid start time",
+			params:               map[string]string{"start_time": "yesterday"},
+			expectedInvalidField: "start_time",
+			expectedErr:          "must be a positive number of milliseconds since epoch",
+		},
+		{
+			desc:                 "negative end time",
+			params:               map[string]string{"end_time": "-1"},
+			expectedInvalidField: "end_time",
+			expectedErr:          "must be a positive number of milliseconds since epoch",
+		},
+		{
+			desc: "end time before start time",
+			params: map[string]string{
+				"start_time": "1582243200000",
+				"end_time":   "1582156800000",
+			},
+			expectedInvalidField: "end_time",
+			expectedErr:          "must be greater than start_time",
+		},
+		{
+			desc:                 "unknown operation type",
+			params:               map[string]string{"type": "payment,account_credited"},
+			expectedInvalidField: "type",
+			expectedErr:          "account_credited is not a valid operation type",
+		},
+		{
+			desc:                 "invalid asset",
+			params:               map[string]string{"asset": "USD"},
+			expectedInvalidField: "asset",
+			expectedErr:          "must be `native` or `Code:Issuer`",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tt := assert.New(t)
+			_, err := GetTransactionFilters(makeRequest(t, tc.params, map[string]string{}, nil))
+			if tt.IsType(&problem.P{}, err) {
+				p := err.(*problem.P)
+				tt.Equal("bad_request", p.Type)
+				tt.Equal(tc.expectedInvalidField, p.Extras["invalid_field"])
+				tt.Equal(tc.expectedErr, p.Extras["reason"])
+			}
+		})
+	}
+}
+
+func TestGetEffectTypes(t *testing.T) {
+	tt := assert.New(t)
+
+	types, err := GetEffectTypes(makeRequest(
+		t,
+		map[string]string{"type": "account_credited,account_debited"},
+		map[string]string{},
+		nil,
+	), ParamType)
+	tt.NoError(err)
+	tt.Equal(
+		[]history.EffectType{history.EffectAccountCredited, history.EffectAccountDebited},
+		types,
+	)
+
+	_, err = GetEffectTypes(makeRequest(
+		t,
+		map[string]string{"type": "payment"},
+		map[string]string{},
+		nil,
+	), ParamType)
+	if tt.IsType(&problem.P{}, err) {
+		p := err.(*problem.P)
+		tt.Equal("type", p.Extras["invalid_field"])
+		tt.Equal("payment is not a valid effect type", p.Extras["reason"])
+	}
+}
+
+func TestGetMemo(t *testing.T) {
+	testCases := []struct {
+		desc                 string
+		params               map[string]string
+		expectedMemoType     string
+		expectedMemo         string
+		expectedInvalidField string
+		expectedErr          string
+	}{
+		{
+			desc: "no memo",
+		},
+		{
+			desc:         "memo of any type",
+			params:       map[string]string{"memo": "deposit 42"},
+			expectedMemo: "deposit 42",
+		},
+		{
+			desc:             "id memo",
+			params:           map[string]string{"memo": "1234", "memo_type": "id"},
+			expectedMemoType: "id",
+			expectedMemo:     "1234",
+		},
+		{
+			desc: "hash memo",
+			params: map[string]string{
+				"memo":      "ZKA6c8Ssz3kkpqSiZ8zvfEAAsDP4TjxODm9Nre6XFeI=",
+				"memo_type": "hash",
+			},
+			expectedMemoType: "hash",
+			expectedMemo:     "ZKA6c8Ssz3kkpqSiZ8zvfEAAsDP4TjxODm9Nre6XFeI=",
+		},
+		{
+			desc:                 "memo type without memo",
+			params:               map[string]string{"memo_type": "text"},
+			expectedInvalidField: "memo",
+			expectedErr:          "must be set with memo_type",
+		},
+		{
+			desc:                 "unknown memo type",
+			params:               map[string]string{"memo": "1234", "memo_type": "number"},
+			expectedInvalidField: "memo_type",
+			expectedErr:          "must be one of text, id, hash or return",
+		},
+		{
+			desc:                 "invalid id memo",
+			params:               map[string]string{"memo": "-1", "memo_type": "id"},
+			expectedInvalidField: "memo",
+			expectedErr:          "must be an unsigned 64-bit integer for an id memo",
+		},
+		{
+			desc:                 "long text memo",
+			params:               map[string]string{"memo": "a text longer than 28 bytes...", "memo_type": "text"},
+			expectedInvalidField: "memo",
+			expectedErr:          "must be at most 28 bytes long for a text memo",
+		},
+		{
+			desc:                 "invalid return memo",
+			params:               map[string]string{"memo": "deadbeef", "memo_type": "return"},
+			expectedInvalidField: "memo",
+			expectedErr:          "must be a base64 encoded 32 byte hash for a hash or return memo",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			tt := assert.New(t)
+			memoType, memo, err := GetMemo(makeRequest(t, tc.params, map[string]string{}, nil))
+			if tc.expectedInvalidField == "" {
+				tt.NoError(err)
+				tt.Equal(tc.expectedMemoType, memoType)
+				tt.Equal(tc.expectedMemo, memo)
+				return
+			}
+			if tt.IsType(&problem.P{}, err) {
+				p := err.(*problem.P)
+				tt.Equal(tc.expectedInvalidField, p.Extras["invalid_field"])
+				tt.Equal(tc.expectedErr, p.Extras["reason"])
+			}
+		})
+	}
+}
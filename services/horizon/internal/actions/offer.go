@@ -7,6 +7,7 @@ import (
 
 	"github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/render/hal"
@@ -164,6 +165,15 @@ func (handler GetAccountOffersHandler) GetResourcePage(
 	return offers, nil
 }
 
+// Topic returns the topic of the ledgers changing the offers of the account.
+func (handler GetAccountOffersHandler) Topic(r *http.Request) (pubsub.Topic, error) {
+	qp := AccountOffersQuery{}
+	if err := GetParams(&qp, r); err != nil {
+		return pubsub.Topic{}, err
+	}
+	return pubsub.AccountTopic(pubsub.Accounts, qp.AccountID), nil
+}
+
 func getOffersPage(ctx context.Context, historyQ *history.Q, query history.OffersQuery) ([]hal.Pageable, error) {
 	records, err := historyQ.GetOffers(query)
 	if err != nil {
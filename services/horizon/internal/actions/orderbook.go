@@ -9,6 +9,7 @@ import (
 	"github.com/paydex-core/paydex-go/amount"
 	"github.com/paydex-core/paydex-go/exp/orderbook"
 	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/render/problem"
 	"github.com/paydex-core/paydex-go/xdr"
@@ -148,6 +149,19 @@ func (handler GetOrderbookHandler) orderBookSummary(
 	return response, lastLedger, nil
 }
 
+// Topic returns the topic of the ledgers changing the order book.
+func (handler GetOrderbookHandler) Topic(r *http.Request) (pubsub.Topic, error) {
+	selling, err := GetAsset(r, "selling_")
+	if err != nil {
+		return pubsub.Topic{}, invalidOrderBook
+	}
+	buying, err := GetAsset(r, "buying_")
+	if err != nil {
+		return pubsub.Topic{}, invalidOrderBook
+	}
+	return pubsub.AssetPairTopic(pubsub.OrderBooks, selling, buying), nil
+}
+
 // GetResource implements the /order_book endpoint
 func (handler GetOrderbookHandler) GetResource(w HeaderWriter, r *http.Request) (StreamableObjectResponse, error) {
 	selling, err := GetAsset(r, "selling_")
@@ -1,8 +1,17 @@
 package actions
 
-import "github.com/paydex-core/go-throttled"
+import (
+	"github.com/paydex-core/go-throttled"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
+)
 
 // RateLimiterProvider is an interface that provides access to the type's HTTPRateLimiter.
 type RateLimiterProvider interface {
 	GetRateLimiter() *throttled.HTTPRateLimiter
 }
+
+// StreamHubProvider is an interface that provides access to the hub the
+// ingested ledgers are published to.
+type StreamHubProvider interface {
+	GetStreamHub() *pubsub.Hub
+}
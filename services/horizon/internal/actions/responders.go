@@ -1,6 +1,9 @@
 package actions
 
-import "github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
+import (
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
+)
 
 // JSONer implementors can respond to a request whose response type was negotiated
 // to be MimeHal or MimeJSON.
@@ -26,3 +29,24 @@ type EventStreamer interface {
 type SingleObjectStreamer interface {
 	LoadEvent() (sse.Event, error)
 }
+
+// TopicSubscriber implementors can respond to a request whose response type
+// was negotiated to be MimeEventStream by subscribing to a topic of the
+// published ledgers, instead of loading their events again whenever a ledger
+// is closed. Topic returns false if no published ledger can hold records of
+// the stream, in which case the stream falls back to polling.
+type TopicSubscriber interface {
+	Topic() (pubsub.Topic, bool)
+}
+
+// LiveEventStreamer implementors are EventStreamers which build the events of
+// the records of the ledgers published to their topic without querying the
+// database. SSE is only called once, to catch up from the cursor of the
+// request.
+type LiveEventStreamer interface {
+	EventStreamer
+	TopicSubscriber
+	// LiveEvents returns the events of the records of l coming after the
+	// id of the last event sent, which is empty if no event was sent.
+	LiveEvents(l *pubsub.Ledger, lastEventID string) ([]sse.Event, error)
+}
@@ -2,22 +2,82 @@ package actions
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/paydex-core/paydex-go/clients/horizon"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
+// TransactionFilters are the optional filters of the transaction records, on
+// top of the account or ledger they belong to.
+type TransactionFilters struct {
+	// StartTime and EndTime limit the records to the ledgers closed in
+	// [StartTime, EndTime). A nil time leaves the range open on its side.
+	StartTime time.Millis
+	EndTime   time.Millis
+	// OperationTypes limits the records to the transactions containing an
+	// operation of one of the types.
+	OperationTypes []xdr.OperationType
+	// Asset, if not nil, limits the records to the transactions containing
+	// an operation moving or trusting the asset.
+	Asset *xdr.Asset
+	// Memo, if not empty, limits the records to the transactions with the
+	// memo, of type MemoType if it is set. It requires an account.
+	MemoType string
+	Memo     string
+}
+
+// IsZero returns true if no filter is set.
+func (f TransactionFilters) IsZero() bool {
+	return f.StartTime.IsNil() && f.EndTime.IsNil() && len(f.OperationTypes) == 0 && f.Asset == nil &&
+		f.Memo == ""
+}
+
+// GetTransactionFilters retrieves the start_time, end_time, type, asset, memo
+// and memo_type params of a request for transaction records.
+func GetTransactionFilters(r *http.Request) (TransactionFilters, error) {
+	var filters TransactionFilters
+
+	start, end, err := GetTimeRange(r)
+	if err != nil {
+		return filters, err
+	}
+	filters.StartTime, filters.EndTime = start, end
+
+	filters.OperationTypes, err = GetOperationTypes(r, ParamType)
+	if err != nil {
+		return filters, err
+	}
+
+	asset, ok, err := MaybeGetAssetFilter(r, ParamAsset)
+	if err != nil {
+		return filters, err
+	}
+	if ok {
+		filters.Asset = &asset
+	}
+
+	filters.MemoType, filters.Memo, err = GetMemo(r)
+	if err != nil {
+		return filters, err
+	}
+
+	return filters, nil
+}
+
 // TransactionPage returns a page containing the transaction records of an
-// account/ledger identified by accountID/ledgerID into a page based on pq and
-// includeFailedTx.
-func TransactionPage(ctx context.Context, hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, pq db2.PageQuery) (hal.Page, error) {
-	records, err := loadTransactionRecords(hq, accountID, ledgerID, includeFailedTx, pq)
+// account/ledger identified by accountID/ledgerID into a page based on pq,
+// includeFailedTx and filters.
+func TransactionPage(ctx context.Context, hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, filters TransactionFilters, pq db2.PageQuery) (hal.Page, error) {
+	records, err := loadTransactionRecords(hq, accountID, ledgerID, includeFailedTx, filters, pq)
 	if err != nil {
 		return hal.Page{}, errors.Wrap(err, "loading transaction records")
 	}
@@ -41,12 +101,15 @@ func TransactionPage(ctx context.Context, hq *history.Q, accountID string, ledge
 }
 
 // loadTransactionRecords returns a slice of transaction records of an
-// account/ledger identified by accountID/ledgerID based on pq and
-// includeFailedTx.
-func loadTransactionRecords(hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, pq db2.PageQuery) ([]history.Transaction, error) {
+// account/ledger identified by accountID/ledgerID based on pq,
+// includeFailedTx and filters.
+func loadTransactionRecords(hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, filters TransactionFilters, pq db2.PageQuery) ([]history.Transaction, error) {
 	if accountID != "" && ledgerID != 0 {
 		return nil, errors.New("conflicting exclusive fields are present: account_id and ledger_id")
 	}
+	if filters.Memo != "" && accountID == "" {
+		return nil, errors.New("the memo filter requires an account_id")
+	}
 
 	var records []history.Transaction
 
@@ -62,6 +125,22 @@ func loadTransactionRecords(hq *history.Q, accountID string, ledgerID int32, inc
 		txs.IncludeFailed()
 	}
 
+	if len(filters.OperationTypes) > 0 {
+		txs.WithOperationTypes(filters.OperationTypes)
+	}
+
+	if filters.Asset != nil {
+		txs.ForAsset(*filters.Asset)
+	}
+
+	if !filters.StartTime.IsNil() || !filters.EndTime.IsNil() {
+		txs.ForTimeRange(filters.StartTime, filters.EndTime)
+	}
+
+	if filters.Memo != "" {
+		txs.ForMemo(filters.MemoType, filters.Memo)
+	}
+
 	err := txs.Page(pq).Select(&records)
 	if err != nil {
 		return nil, errors.Wrap(err, "executing transaction records query")
@@ -89,9 +168,9 @@ func loadTransactionRecords(hq *history.Q, accountID string, ledgerID int32, inc
 }
 
 // StreamTransactions streams transaction records of an account/ledger
-// identified by accountID/ledgerID based on pq and includeFailedTx.
-func StreamTransactions(ctx context.Context, s *sse.Stream, hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, pq db2.PageQuery) error {
-	allRecords, err := loadTransactionRecords(hq, accountID, ledgerID, includeFailedTx, pq)
+// identified by accountID/ledgerID based on pq, includeFailedTx and filters.
+func StreamTransactions(ctx context.Context, s *sse.Stream, hq *history.Q, accountID string, ledgerID int32, includeFailedTx bool, filters TransactionFilters, pq db2.PageQuery) error {
+	allRecords, err := loadTransactionRecords(hq, accountID, ledgerID, includeFailedTx, filters, pq)
 	if err != nil {
 		return errors.Wrap(err, "loading transaction records")
 	}
@@ -107,6 +186,22 @@ func StreamTransactions(ctx context.Context, s *sse.Stream, hq *history.Q, accou
 	return nil
 }
 
+// LiveTransactions returns the events of the transaction records of a
+// published ledger coming after cursor, based on includeFailedTx.
+func LiveTransactions(ctx context.Context, l *pubsub.Ledger, cursor string, includeFailedTx bool) []sse.Event {
+	var events []sse.Event
+	for _, record := range l.Transactions {
+		if !pubsub.After(record.PagingToken(), cursor) || (!includeFailedTx && !record.IsSuccessful()) {
+			continue
+		}
+
+		var res horizon.Transaction
+		resourceadapter.PopulateTransaction(ctx, &res, record)
+		events = append(events, sse.Event{ID: res.PagingToken(), Data: res})
+	}
+	return events
+}
+
 // TransactionResource returns a single transaction resource identified by txHash.
 func TransactionResource(ctx context.Context, hq *history.Q, txHash string) (horizon.Transaction, error) {
 	var (
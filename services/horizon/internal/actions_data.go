@@ -3,6 +3,7 @@ package horizon
 import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/support/render/hal"
 )
@@ -11,6 +12,7 @@ import (
 var _ actions.JSONer = (*DataShowAction)(nil)
 var _ actions.RawDataResponder = (*DataShowAction)(nil)
 var _ actions.EventStreamer = (*DataShowAction)(nil)
+var _ actions.TopicSubscriber = (*DataShowAction)(nil)
 
 // DataShowAction renders a account summary found by its address.
 type DataShowAction struct {
@@ -60,6 +62,15 @@ func (action *DataShowAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *DataShowAction) Topic() (pubsub.Topic, bool) {
+	action.loadParams()
+	if action.Err != nil {
+		return pubsub.Topic{}, false
+	}
+	return pubsub.AccountTopic(pubsub.Accounts, action.Address), true
+}
+
 func (action *DataShowAction) loadParams() {
 	action.Address = action.GetAddress("account_id", actions.RequiredParam)
 	action.Key = action.GetString("key")
@@ -7,11 +7,14 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/render/hal"
 	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/support/time"
+	"github.com/paydex-core/paydex-go/xdr"
 )
 
 // This file contains the actions:
@@ -21,18 +24,24 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*EffectIndexAction)(nil)
 var _ actions.EventStreamer = (*EffectIndexAction)(nil)
+var _ actions.LiveEventStreamer = (*EffectIndexAction)(nil)
 
 var effectsCursorRegexp = regexp.MustCompile(`now|\d+(-\d+)?`)
 
 // EffectIndexAction renders a page of effect resources, identified by
 // a normal page query and optionally filtered by an account, ledger,
-// transaction, or operation.
+// transaction, or operation, and by time range, effect types and asset.
 type EffectIndexAction struct {
 	Action
 	AccountFilter     string
 	LedgerFilter      int32
 	TransactionFilter string
 	OperationFilter   int64
+	StartTimeFilter   time.Millis
+	EndTimeFilter     time.Millis
+	TypesFilter       []history.EffectType
+	AssetFilter       xdr.Asset
+	HasAssetFilter    bool
 
 	PagingParams db2.PageQuery
 	Records      []history.Effect
@@ -93,6 +102,46 @@ func (action *EffectIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *EffectIndexAction) Topic() (pubsub.Topic, bool) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+	)
+	switch {
+	case action.Err != nil,
+		action.PagingParams.Order != db2.OrderAscending,
+		action.LedgerFilter > 0,
+		action.TransactionFilter != "",
+		action.OperationFilter > 0,
+		action.hasHistoryFilters():
+		return pubsub.Topic{}, false
+	case action.AccountFilter != "":
+		return pubsub.AccountTopic(pubsub.Effects, action.AccountFilter), true
+	default:
+		return pubsub.AllTopic(pubsub.Effects), true
+	}
+}
+
+// LiveEvents is a method for actions.LiveEventStreamer
+func (action *EffectIndexAction) LiveEvents(l *pubsub.Ledger, lastEventID string) ([]sse.Event, error) {
+	cursor := liveCursor(lastEventID, action.PagingParams)
+	var events []sse.Event
+	for _, record := range l.Effects {
+		if !pubsub.After(record.PagingToken(), cursor) {
+			continue
+		}
+
+		res, err := resourceadapter.NewEffect(action.R.Context(), record, l.Ledger)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, sse.Event{ID: res.PagingToken(), Data: res})
+	}
+	return events, nil
+}
+
 // loadLedgers populates the ledger cache for this action
 func (action *EffectIndexAction) loadLedgers() {
 	action.Ledgers = &history.LedgerCache{}
@@ -111,6 +160,9 @@ func (action *EffectIndexAction) loadParams() {
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
 	action.OperationFilter = action.GetInt64("op_id")
+	action.StartTimeFilter, action.EndTimeFilter = action.GetTimeRange()
+	action.TypesFilter = action.GetEffectTypes(actions.ParamType)
+	action.AssetFilter, action.HasAssetFilter = action.MaybeGetAssetFilter(actions.ParamAsset)
 
 	filters, err := countNonEmpty(
 		action.AccountFilter,
@@ -130,6 +182,15 @@ func (action *EffectIndexAction) loadParams() {
 	}
 }
 
+// hasHistoryFilters returns true if the effects are filtered by time range,
+// effect types or asset.
+func (action *EffectIndexAction) hasHistoryFilters() bool {
+	return !action.StartTimeFilter.IsNil() ||
+		!action.EndTimeFilter.IsNil() ||
+		len(action.TypesFilter) > 0 ||
+		action.HasAssetFilter
+}
+
 // loadRecords populates action.Records
 func (action *EffectIndexAction) loadRecords() {
 	effects := action.HistoryQ().Effects()
@@ -145,6 +206,18 @@ func (action *EffectIndexAction) loadRecords() {
 		effects.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypesFilter) > 0 {
+		effects.OfTypes(action.TypesFilter)
+	}
+
+	if action.HasAssetFilter {
+		effects.ForAsset(action.AssetFilter)
+	}
+
+	if !action.StartTimeFilter.IsNil() || !action.EndTimeFilter.IsNil() {
+		effects.ForTimeRange(action.StartTimeFilter, action.EndTimeFilter)
+	}
+
 	action.Err = effects.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -6,6 +6,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
@@ -20,6 +21,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*LedgerIndexAction)(nil)
 var _ actions.EventStreamer = (*LedgerIndexAction)(nil)
+var _ actions.LiveEventStreamer = (*LedgerIndexAction)(nil)
 
 // LedgerIndexAction renders a page of ledger resources, identified by
 // a normal page query.
@@ -66,6 +68,30 @@ func (action *LedgerIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *LedgerIndexAction) Topic() (pubsub.Topic, bool) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+	)
+	if action.Err != nil || action.PagingParams.Order != db2.OrderAscending {
+		return pubsub.Topic{}, false
+	}
+	return pubsub.AllTopic(pubsub.Ledgers), true
+}
+
+// LiveEvents is a method for actions.LiveEventStreamer
+func (action *LedgerIndexAction) LiveEvents(l *pubsub.Ledger, lastEventID string) ([]sse.Event, error) {
+	if !pubsub.After(l.Ledger.PagingToken(), liveCursor(lastEventID, action.PagingParams)) {
+		return nil, nil
+	}
+
+	var res horizon.Ledger
+	resourceadapter.PopulateLedger(action.R.Context(), &res, l.Ledger)
+	return []sse.Event{{ID: res.PagingToken(), Data: res}}, nil
+}
+
 func (action *LedgerIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.PagingParams = action.GetPageQuery()
@@ -6,6 +6,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/render/hal"
@@ -16,6 +17,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*OffersByAccountAction)(nil)
 var _ actions.EventStreamer = (*OffersByAccountAction)(nil)
+var _ actions.TopicSubscriber = (*OffersByAccountAction)(nil)
 
 // OffersByAccountAction renders a page of offer resources, for a given
 // account.  These offers are present in the ledger as of the latest validated
@@ -74,6 +76,15 @@ func (action *OffersByAccountAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *OffersByAccountAction) Topic() (pubsub.Topic, bool) {
+	action.loadParams()
+	if action.Err != nil {
+		return pubsub.Topic{}, false
+	}
+	return pubsub.AccountTopic(pubsub.Accounts, action.Address), true
+}
+
 func (action *OffersByAccountAction) loadParams() {
 	action.PageQuery = action.GetPageQuery()
 	action.Address = action.GetAddress("account_id")
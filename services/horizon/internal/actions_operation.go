@@ -8,6 +8,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
@@ -15,6 +16,7 @@ import (
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/render/hal"
 	supportProblem "github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -26,6 +28,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*OperationIndexAction)(nil)
 var _ actions.EventStreamer = (*OperationIndexAction)(nil)
+var _ actions.LiveEventStreamer = (*OperationIndexAction)(nil)
 
 const (
 	joinTransactions = "transactions"
@@ -33,12 +36,17 @@ const (
 
 // OperationIndexAction renders a page of operations resources, identified by
 // a normal page query and optionally filtered by an account, ledger, or
-// transaction.
+// transaction, and by time range, operation types and asset.
 type OperationIndexAction struct {
 	Action
 	LedgerFilter        int32
 	AccountFilter       string
 	TransactionFilter   string
+	StartTimeFilter     time.Millis
+	EndTimeFilter       time.Millis
+	TypesFilter         []xdr.OperationType
+	AssetFilter         xdr.Asset
+	HasAssetFilter      bool
 	PagingParams        db2.PageQuery
 	OperationRecords    []history.Operation
 	TransactionRecords  []history.Transaction
@@ -109,6 +117,56 @@ func (action *OperationIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *OperationIndexAction) Topic() (pubsub.Topic, bool) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+	)
+	switch {
+	case action.Err != nil,
+		action.PagingParams.Order != db2.OrderAscending,
+		action.LedgerFilter > 0,
+		action.TransactionFilter != "",
+		action.hasHistoryFilters():
+		return pubsub.Topic{}, false
+	case action.AccountFilter != "":
+		return pubsub.AccountTopic(pubsub.Operations, action.AccountFilter), true
+	default:
+		return pubsub.AllTopic(pubsub.Operations), true
+	}
+}
+
+// LiveEvents is a method for actions.LiveEventStreamer
+func (action *OperationIndexAction) LiveEvents(l *pubsub.Ledger, lastEventID string) ([]sse.Event, error) {
+	cursor := liveCursor(lastEventID, action.PagingParams)
+	var events []sse.Event
+	for _, operationRecord := range l.Operations {
+		switch {
+		case !pubsub.After(operationRecord.PagingToken(), cursor),
+			action.OnlyPayments && !operationRecord.IsPayment(),
+			!action.IncludeFailed && !operationRecord.IsTransactionSuccessful():
+			continue
+		}
+
+		var transactionRecord *history.Transaction
+		if action.IncludeTransactions {
+			transactionRecord = l.Transaction(operationRecord.TransactionID)
+			if transactionRecord == nil {
+				return nil, errors.Errorf("could not find transaction for operation %d", operationRecord.ID)
+			}
+		}
+
+		res, err := resourceadapter.NewOperation(action.R.Context(), operationRecord, transactionRecord, l.Ledger)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, sse.Event{ID: res.PagingToken(), Data: res})
+	}
+	return events, nil
+}
+
 func parseJoinField(action *actions.Base) (map[string]bool, error) {
 	join := action.GetString("join")
 	validJoins := map[string]bool{}
@@ -134,6 +192,9 @@ func (action *OperationIndexAction) loadParams() {
 	action.TransactionFilter = action.GetStringFromURLParam("tx_id")
 	action.PagingParams = action.GetPageQuery()
 	action.IncludeFailed = action.GetBool("include_failed")
+	action.StartTimeFilter, action.EndTimeFilter = action.GetTimeRange()
+	action.TypesFilter = action.GetOperationTypes(actions.ParamType)
+	action.AssetFilter, action.HasAssetFilter = action.MaybeGetAssetFilter(actions.ParamAsset)
 	parsed, err := parseJoinField(&action.Action.Base)
 	if err != nil {
 		action.Err = err
@@ -232,6 +293,18 @@ func (action *OperationIndexAction) loadRecords() {
 		ops.OnlyPayments()
 	}
 
+	if len(action.TypesFilter) > 0 {
+		ops.OfTypes(action.TypesFilter)
+	}
+
+	if action.HasAssetFilter {
+		ops.ForAsset(action.AssetFilter)
+	}
+
+	if !action.StartTimeFilter.IsNil() || !action.EndTimeFilter.IsNil() {
+		ops.ForTimeRange(action.StartTimeFilter, action.EndTimeFilter)
+	}
+
 	action.OperationRecords, action.TransactionRecords, action.Err = ops.Page(action.PagingParams).Fetch()
 	if action.Err != nil {
 		return
@@ -270,6 +343,15 @@ func (action *OperationIndexAction) loadRecords() {
 	}
 }
 
+// hasHistoryFilters returns true if the operations are filtered by time
+// range, operation types or asset.
+func (action *OperationIndexAction) hasHistoryFilters() bool {
+	return !action.StartTimeFilter.IsNil() ||
+		!action.EndTimeFilter.IsNil() ||
+		len(action.TypesFilter) > 0 ||
+		action.HasAssetFilter
+}
+
 // loadLedgers populates the ledger cache for this action
 func (action *OperationIndexAction) loadLedgers() {
 	action.Ledgers = &history.LedgerCache{}
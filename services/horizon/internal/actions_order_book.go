@@ -6,6 +6,7 @@ import (
 	"github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/render/hal"
@@ -16,6 +17,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*OrderBookShowAction)(nil)
 var _ actions.SingleObjectStreamer = (*OrderBookShowAction)(nil)
+var _ actions.TopicSubscriber = (*OrderBookShowAction)(nil)
 
 // OrderBookShowAction renders a account summary found by its address.
 type OrderBookShowAction struct {
@@ -79,6 +81,15 @@ func (action *OrderBookShowAction) JSON() error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *OrderBookShowAction) Topic() (pubsub.Topic, bool) {
+	action.LoadQuery()
+	if action.Err != nil {
+		return pubsub.Topic{}, false
+	}
+	return pubsub.AssetPairTopic(pubsub.OrderBooks, action.Selling, action.Buying), true
+}
+
 func (action *OrderBookShowAction) LoadEvent() (sse.Event, error) {
 	action.Do(action.LoadQuery, action.LoadRecord, action.LoadResource)
 	return sse.Event{Data: action.Resource}, action.Err
@@ -8,6 +8,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
 	"github.com/paydex-core/paydex-go/support/errors"
@@ -19,6 +20,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*TradeIndexAction)(nil)
 var _ actions.EventStreamer = (*TradeIndexAction)(nil)
+var _ actions.LiveEventStreamer = (*TradeIndexAction)(nil)
 
 type TradeIndexAction struct {
 	Action
@@ -28,6 +30,10 @@ type TradeIndexAction struct {
 	HasCounterAssetFilter bool
 	OfferFilter           int64
 	AccountFilter         string
+	AssetFilter           xdr.Asset
+	HasAssetFilter        bool
+	StartTimeFilter       time.Millis
+	EndTimeFilter         time.Millis
 	PagingParams          db2.PageQuery
 	Records               []history.Trade
 	Page                  hal.Page
@@ -71,6 +77,70 @@ func (action *TradeIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// Topic is a method for actions.TopicSubscriber
+func (action *TradeIndexAction) Topic() (pubsub.Topic, bool) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+	)
+	switch {
+	case action.Err != nil,
+		action.PagingParams.Order != db2.OrderAscending,
+		action.HasAssetFilter,
+		!action.StartTimeFilter.IsNil(),
+		!action.EndTimeFilter.IsNil():
+		return pubsub.Topic{}, false
+	case action.HasBaseAssetFilter:
+		return pubsub.AssetPairTopic(pubsub.Trades, action.BaseAssetFilter, action.CounterAssetFilter), true
+	case action.AccountFilter != "":
+		return pubsub.AccountTopic(pubsub.Trades, action.AccountFilter), true
+	default:
+		return pubsub.AllTopic(pubsub.Trades), true
+	}
+}
+
+// LiveEvents is a method for actions.LiveEventStreamer
+func (action *TradeIndexAction) LiveEvents(l *pubsub.Ledger, lastEventID string) ([]sse.Event, error) {
+	cursor := liveCursor(lastEventID, action.PagingParams)
+	var events []sse.Event
+	for _, record := range l.Trades {
+		if !pubsub.After(record.PagingToken(), cursor) {
+			continue
+		}
+		if action.OfferFilter != 0 && !tradeHasOffer(record, action.OfferFilter) {
+			continue
+		}
+
+		// the trades of an asset pair are oriented like the pair requested
+		if action.HasBaseAssetFilter && !tradeHasBaseAsset(record, action.BaseAssetFilter) {
+			record = record.Reversed()
+		}
+
+		var res horizon.Trade
+		resourceadapter.PopulateTrade(action.R.Context(), &res, record)
+		events = append(events, sse.Event{ID: res.PagingToken(), Data: res})
+	}
+	return events, nil
+}
+
+// tradeHasOffer returns true if the offer with the given id is one of the
+// offers of trade.
+func tradeHasOffer(trade history.Trade, id int64) bool {
+	return (trade.BaseOfferID != nil && *trade.BaseOfferID == id) ||
+		(trade.CounterOfferID != nil && *trade.CounterOfferID == id)
+}
+
+// tradeHasBaseAsset returns true if asset is the base asset of trade.
+func tradeHasBaseAsset(trade history.Trade, asset xdr.Asset) bool {
+	var assetType, code, issuer string
+	if err := asset.Extract(&assetType, &code, &issuer); err != nil {
+		return false
+	}
+	return trade.BaseAssetType == assetType &&
+		trade.BaseAssetCode == code &&
+		trade.BaseAssetIssuer == issuer
+}
+
 // loadParams sets action.Query from the request params
 func (action *TradeIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
@@ -78,6 +148,8 @@ func (action *TradeIndexAction) loadParams() {
 	action.CounterAssetFilter, action.HasCounterAssetFilter = action.MaybeGetAsset("counter_")
 	action.OfferFilter = action.GetInt64("offer_id")
 	action.AccountFilter = action.GetAddress("account_id")
+	action.AssetFilter, action.HasAssetFilter = action.MaybeGetAssetFilter(actions.ParamAsset)
+	action.StartTimeFilter, action.EndTimeFilter = action.GetTimeRange()
 
 	if (!action.HasBaseAssetFilter && action.HasCounterAssetFilter) ||
 		(action.HasBaseAssetFilter && !action.HasCounterAssetFilter) {
@@ -119,6 +191,22 @@ func (action *TradeIndexAction) loadRecords() {
 		trades = trades.ForOffer(action.OfferFilter)
 	}
 
+	if action.HasAssetFilter {
+		assetID, err := action.HistoryQ().GetAssetID(action.AssetFilter)
+		if action.HistoryQ().NoRows(err) {
+			// the asset was never traded
+			return
+		} else if err != nil {
+			action.Err = err
+			return
+		}
+		trades = trades.ForAsset(assetID)
+	}
+
+	if !action.StartTimeFilter.IsNil() || !action.EndTimeFilter.IsNil() {
+		trades = trades.ForTimeRange(action.StartTimeFilter, action.EndTimeFilter)
+	}
+
 	action.Err = trades.Page(action.PagingParams).Select(&action.Records)
 }
 
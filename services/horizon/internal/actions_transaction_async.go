@@ -0,0 +1,151 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/httpx"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// Interface verifications
+var _ actions.JSONer = (*TransactionAsyncCreateAction)(nil)
+var _ actions.JSONer = (*TransactionAsyncStatusAction)(nil)
+
+// asyncSubmissionStatusCodes maps the status returned by paydex-core for an
+// asynchronous submission to the status code of the response.
+var asyncSubmissionStatusCodes = map[string]int{
+	horizon.SubmissionStatusPending:       http.StatusCreated,
+	horizon.SubmissionStatusDuplicate:     http.StatusConflict,
+	horizon.SubmissionStatusTryAgainLater: http.StatusServiceUnavailable,
+	horizon.SubmissionStatusError:         http.StatusBadRequest,
+}
+
+// TransactionAsyncCreateAction submits a transaction to the paydex-core
+// network on behalf of the requesting client and responds with the status
+// returned by paydex-core, without waiting for the transaction to be included
+// in a ledger.
+type TransactionAsyncCreateAction struct {
+	Action
+	TX       string
+	Result   txsub.AsyncResult
+	Resource horizon.AsyncTransactionSubmissionResponse
+}
+
+// JSON format action handler
+func (action *TransactionAsyncCreateAction) JSON() error {
+	action.Do(
+		action.loadTX,
+		action.loadResult,
+		action.loadResource,
+		func() {
+			hal.RenderStatus(action.W, asyncSubmissionStatusCodes[action.Resource.TxStatus], action.Resource)
+		},
+	)
+	return action.Err
+}
+
+func (action *TransactionAsyncCreateAction) loadTX() {
+	action.ValidateBodyType()
+	action.TX = action.GetString("tx")
+}
+
+func (action *TransactionAsyncCreateAction) loadResult() {
+	result, err := action.App.submitter.SubmitAsync(action.R.Context(), action.TX)
+	if err == nil {
+		action.Result = result
+		return
+	}
+
+	switch err := err.(type) {
+	case *txsub.MalformedTransactionError:
+		action.Err = &problem.P{
+			Type:   "transaction_malformed",
+			Title:  "Transaction Malformed",
+			Status: http.StatusBadRequest,
+			Detail: "Horizon could not decode the transaction envelope in this " +
+				"request. A transaction should be an XDR TransactionEnvelope struct " +
+				"encoded using base64.  The envelope read from this request is " +
+				"echoed in the `extras.envelope_xdr` field of this response for your " +
+				"convenience.",
+			Extras: map[string]interface{}{
+				"envelope_xdr": err.EnvelopeXDR,
+			},
+		}
+	default:
+		action.Err = err
+	}
+}
+
+func (action *TransactionAsyncCreateAction) loadResource() {
+	if _, ok := asyncSubmissionStatusCodes[action.Result.Status]; !ok {
+		action.Err = errors.Errorf("unexpected paydex-core status: %s", action.Result.Status)
+		return
+	}
+
+	action.Resource.Hash = action.Result.Hash
+	action.Resource.TxStatus = action.Result.Status
+	action.Resource.ErrorResultXDR = action.Result.ErrorResultXDR
+
+	lb := hal.LinkBuilder{httpx.BaseURL(action.R.Context())}
+	action.Resource.Links.Status = lb.Link("/transactions_async", action.Result.Hash)
+}
+
+// TransactionAsyncStatusAction renders the status of a transaction submitted
+// with TransactionAsyncCreateAction.
+type TransactionAsyncStatusAction struct {
+	Action
+	Hash     string
+	Resource horizon.AsyncTransactionStatus
+}
+
+// JSON format action handler
+func (action *TransactionAsyncStatusAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *TransactionAsyncStatusAction) loadParams() {
+	action.Hash = action.GetStringFromURLParam("tx_id")
+	if action.Err == nil && !isValidTransactionHash(action.Hash) {
+		action.Err = problem.MakeInvalidFieldProblem("tx_id", errors.New("Invalid transaction hash"))
+	}
+}
+
+func (action *TransactionAsyncStatusAction) loadResource() {
+	result, pending := action.App.submitter.Status(action.R.Context(), action.Hash)
+	action.Resource.Hash = action.Hash
+
+	switch err := result.Err.(type) {
+	case nil:
+		action.Resource.Status = horizon.TransactionStatusSuccess
+		action.Resource.ResultXDR = result.ResultXDR
+	case *txsub.FailedTransactionError:
+		action.Resource.Status = horizon.TransactionStatusFailed
+		action.Resource.ResultXDR = err.ResultXDR
+	default:
+		if err != txsub.ErrNoResults {
+			action.Err = err
+			return
+		}
+
+		if pending {
+			action.Resource.Status = horizon.TransactionStatusPending
+		} else {
+			action.Resource.Status = horizon.TransactionStatusNotFound
+		}
+		return
+	}
+
+	action.Resource.Ledger = result.LedgerSequence
+	action.Resource.EnvelopeXDR = result.EnvelopeXDR
+	action.Resource.ResultMetaXDR = result.ResultMetaXDR
+}
@@ -0,0 +1,110 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub"
+)
+
+func TestTransactionAsyncActions_Post(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	form := url.Values{"tx": []string{"AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"}}
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+
+	// existing transaction
+	w := ht.Post("/transactions_async", form)
+	if ht.Assert.Equal(409, w.Code) {
+		var actual horizon.AsyncTransactionSubmissionResponse
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(hash, actual.Hash)
+		ht.Assert.Equal(horizon.SubmissionStatusDuplicate, actual.TxStatus)
+		ht.Assert.Equal("http://localhost/transactions_async/"+hash, actual.Links.Status.Href)
+	}
+
+	// accepted by paydex-core
+	ht.App.submitter.Results = &txsub.MockResultProvider{}
+	ht.App.submitter.Submitter = &txsub.MockSubmitter{
+		R: txsub.SubmissionResult{Status: "PENDING"},
+	}
+	w = ht.Post("/transactions_async", form)
+	if ht.Assert.Equal(201, w.Code) {
+		var actual horizon.AsyncTransactionSubmissionResponse
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(horizon.SubmissionStatusPending, actual.TxStatus)
+	}
+
+	// rejected by paydex-core
+	ht.App.submitter.Submitter = &txsub.MockSubmitter{
+		R: txsub.SubmissionResult{
+			Status: "ERROR",
+			Err:    &txsub.FailedTransactionError{ResultXDR: "AAAAAAAAAGT////7AAAAAA=="},
+		},
+	}
+	w = ht.Post("/transactions_async", form)
+	if ht.Assert.Equal(400, w.Code) {
+		var actual horizon.AsyncTransactionSubmissionResponse
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(horizon.SubmissionStatusError, actual.TxStatus)
+		ht.Assert.Equal("AAAAAAAAAGT////7AAAAAA==", actual.ErrorResultXDR)
+	}
+
+	// queue full
+	ht.App.submitter.Submitter = &txsub.MockSubmitter{
+		R: txsub.SubmissionResult{Status: "TRY_AGAIN_LATER"},
+	}
+	w = ht.Post("/transactions_async", form)
+	ht.Assert.Equal(503, w.Code)
+
+	// malformed envelope
+	w = ht.Post("/transactions_async", url.Values{"tx": []string{"AAAA"}})
+	ht.Assert.Equal(400, w.Code)
+	ht.Assert.Contains(w.Body.String(), "transaction_malformed")
+}
+
+func TestTransactionAsyncActions_Status(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// transaction in history
+	w := ht.Get("/transactions_async/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.AsyncTransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(horizon.TransactionStatusSuccess, actual.Status)
+		ht.Assert.NotZero(actual.Ledger)
+		ht.Assert.NotEmpty(actual.ResultXDR)
+	}
+
+	// unknown transaction
+	unknown := "ab2a0a1b26b53d9ed5e4ac42bb8a38c1d1fbb2c7ab1e8a9f5e3fc6c9f6b0b2b5"
+	w = ht.Get("/transactions_async/" + unknown)
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.AsyncTransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(horizon.TransactionStatusNotFound, actual.Status)
+	}
+
+	// pending transaction
+	ht.Require.NoError(ht.App.submitter.Pending.Add(ht.Ctx, unknown, make(chan txsub.Result, 1)))
+	w = ht.Get("/transactions_async/" + unknown)
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.AsyncTransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(horizon.TransactionStatusPending, actual.Status)
+	}
+
+	// invalid hash
+	w = ht.Get("/transactions_async/not_real")
+	ht.Assert.Equal(400, w.Code)
+}
@@ -23,8 +23,10 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/logmetrics"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/operationfeestats"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/paths"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/reap"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/webhooks"
 	"github.com/paydex-core/paydex-go/support/app"
 	"github.com/paydex-core/paydex-go/support/db"
 	"github.com/paydex-core/paydex-go/support/errors"
@@ -51,6 +53,9 @@ type App struct {
 	ingester                     *ingest.System
 	expingester                  *expingest.System
 	reaper                       *reap.System
+	webhooks                     *webhooks.System
+	streams                      *pubsub.Hub
+	publisher                    *pubsub.Publisher
 	ticks                        *time.Ticker
 
 	// metrics
@@ -98,6 +103,7 @@ func (a *App) Serve() {
 	log.Infof("Starting horizon on %s (ingest: %v)", addr, a.config.Ingest)
 
 	go a.run()
+	go a.publisher.Run(a.ctx)
 
 	// WaitGroup for all go routines. Makes sure that DB is closed when
 	// all services gracefully shutdown.
@@ -218,6 +224,10 @@ func (a *App) UpdateLedgerState() {
 	}
 
 	ledger.SetState(next)
+
+	// the instances which don't ingest publish the ledgers ingested by
+	// another instance
+	a.publisher.Notify(next.HistoryLatest)
 }
 
 // UpdateFeeStatsState triggers a refresh of several operation fee metrics.
@@ -409,6 +419,11 @@ func (a *App) Tick() {
 		go a.ingester.Tick()
 	}
 
+	// webhook events are delivered by the instances ingesting history
+	if a.webhooks != nil && a.config.Ingest {
+		go a.webhooks.Tick()
+	}
+
 	wg.Add(2)
 	go func() { a.reaper.Tick(); wg.Done() }()
 	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
@@ -442,6 +457,9 @@ func (a *App) init() {
 	mustInitHorizonDB(a)
 	mustInitCoreDB(a)
 
+	// streams
+	initStreams(a)
+
 	// ingester
 	initIngester(a)
 
@@ -461,12 +479,18 @@ func (a *App) init() {
 	// reaper
 	a.reaper = reap.New(a.config.HistoryRetentionCount, a.HorizonSession(context.Background()))
 
+	// webhooks
+	initWebhooks(a)
+
 	// web.init
 	a.web = mustInitWeb(a.ctx, a.historyQ, a.coreQ, a.config.SSEUpdateFrequency, a.config.StaleThreshold, a.config.IngestFailedTransactions)
 
 	// web.rate-limiter
 	a.web.rateLimiter = maybeInitWebRateLimiter(a.config.RateQuota)
 
+	// web.streams
+	a.web.streams = a.streams
+
 	// web.middleware
 	// Note that we passed in `a` here for putting the whole App in the context.
 	// This parameter will be removed soon.
@@ -483,7 +507,7 @@ func (a *App) init() {
 		},
 	}
 	// web.actions
-	a.web.mustInstallActions(a.config, a.paths, orderBookGraph, requiresExperimentalIngestion)
+	a.web.mustInstallActions(a.config, a.paths, orderBookGraph, requiresExperimentalIngestion, a.webhooks)
 
 	// metrics and log.metrics
 	a.metrics = metrics.NewRegistry()
@@ -531,6 +555,11 @@ func (a *App) GetRateLimiter() *throttled.HTTPRateLimiter {
 	return a.web.rateLimiter
 }
 
+// GetStreamHub returns the hub the ingested ledgers are published to.
+func (a *App) GetStreamHub() *pubsub.Hub {
+	return a.streams
+}
+
 // AppFromContext returns the set app, if one has been set, from the
 // provided context returns nil if no app has been set.
 func AppFromContext(ctx context.Context) *App {
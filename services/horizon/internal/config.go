@@ -79,6 +79,33 @@ type Config struct {
 	// IngestDisableStateVerification disables state verification
 	// `System.verifyState()` when set to `true`.
 	IngestDisableStateVerification bool
+	// EnableGraphQL exposes the `/graphql` endpoint.
+	EnableGraphQL bool
+	// GraphQLMaxDepth is the maximum depth of the queries accepted by the
+	// `/graphql` endpoint, 0 disables the limit.
+	GraphQLMaxDepth int
+	// GraphQLMaxCost is the maximum estimated number of fields resolved by a
+	// query sent to the `/graphql` endpoint, 0 disables the limit.
+	GraphQLMaxCost int
+	// GraphQLQueryTimeout is the maximum duration of a query sent to the
+	// `/graphql` endpoint, 0 disables the limit.
+	GraphQLQueryTimeout time.Duration
+	// EnableWebhooks enables webhook subscriptions and exposes their admin API
+	// at `/admin/webhooks`. Events are only delivered by instances ingesting
+	// history.
+	EnableWebhooks bool
+	// WebhooksAdminToken is the bearer token required by the webhooks admin
+	// API.
+	WebhooksAdminToken string
+	// WebhooksMaxAttempts is the number of attempts of a webhook delivery
+	// before it is moved to the dead state.
+	WebhooksMaxAttempts int
+	// WebSocketHeartbeatInterval is the interval between two heartbeats sent
+	// over the WebSocket connections of the streams.
+	WebSocketHeartbeatInterval time.Duration
+	// WebSocketMaxSubscriptions is the maximum number of streams subscribed
+	// over a WebSocket connection, 0 disables the limit.
+	WebSocketMaxSubscriptions int
 	// ApplyMigrations will apply pending migrations to the horizon database
 	// before starting the horizon service
 	ApplyMigrations bool
@@ -2,6 +2,7 @@ package core
 
 import (
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 
 	"strings"
@@ -9,14 +10,26 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/utf8"
-	"github.com/paydex-core/paydex-go/strkey"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
 // Base64Signatures returns a slice of strings where each element is a base64
-// encoded representation of a signature attached to this transaction.
+// encoded representation of a signature attached to this transaction. For fee
+// bump transactions the signatures of the fee bump envelope are returned.
 func (tx *Transaction) Base64Signatures() []string {
-	raw := tx.Envelope.Signatures
+	if tx.Envelope.IsFeeBump() {
+		return base64Signatures(tx.Envelope.FeeBumpSignatures())
+	}
+	return base64Signatures(tx.Envelope.Signatures())
+}
+
+// InnerBase64Signatures returns the base64 encoded signatures of the inner
+// transaction wrapped by a fee bump transaction.
+func (tx *Transaction) InnerBase64Signatures() []string {
+	return base64Signatures(tx.Envelope.Signatures())
+}
+
+func base64Signatures(raw []xdr.DecoratedSignature) []string {
 	results := make([]string, len(raw))
 
 	for i := range raw {
@@ -34,9 +47,43 @@ func (tx *Transaction) EnvelopeXDR() string {
 	return out
 }
 
-// Fee returns the max fee that was set for `tx`
+// MaxFee returns the max fee that was set for `tx`. For fee bump
+// transactions this is the max fee of the inner transaction.
 func (tx *Transaction) MaxFee() int32 {
-	return int32(tx.Envelope.Tx.Fee)
+	return int32(tx.Envelope.Fee())
+}
+
+// IsFeeBump returns true when `tx` is a fee bump transaction.
+func (tx *Transaction) IsFeeBump() bool {
+	return tx.Envelope.IsFeeBump()
+}
+
+// NewMaxFee returns the max fee set by the fee bump transaction wrapping `tx`.
+func (tx *Transaction) NewMaxFee() null.Int {
+	if !tx.Envelope.IsFeeBump() {
+		return null.Int{}
+	}
+	return null.IntFrom(tx.Envelope.FeeBumpFee())
+}
+
+// FeeAccount returns the strkey-encoded account id that paid the fee for a
+// fee bump transaction. A muxed fee source resolves to its underlying account.
+func (tx *Transaction) FeeAccount() null.String {
+	if !tx.Envelope.IsFeeBump() {
+		return null.String{}
+	}
+	feeAccount := tx.Envelope.FeeBumpAccount().ToAccountId()
+	return null.StringFrom(feeAccount.Address())
+}
+
+// InnerTransactionHash returns the hash of the transaction wrapped by a fee
+// bump transaction.
+func (tx *Transaction) InnerTransactionHash() null.String {
+	innerResult, ok := tx.Result.Result.Result.GetInnerResultPair()
+	if !ok {
+		return null.String{}
+	}
+	return null.StringFrom(hex.EncodeToString(innerResult.TransactionHash[:]))
 }
 
 // FeeCharged returns the fee that was actually charged for `tx`
@@ -46,7 +93,7 @@ func (tx *Transaction) FeeCharged() int32 {
 
 // IsSuccessful returns true when the transaction was successful.
 func (tx *Transaction) IsSuccessful() bool {
-	return tx.Result.Result.Result.Code == xdr.TransactionResultCodeTxSuccess
+	return tx.Result.Result.Successful()
 }
 
 // Memo returns the memo for this transaction, if there is one.
@@ -55,27 +102,28 @@ func (tx *Transaction) Memo() null.String {
 		value string
 		valid bool
 	)
-	switch tx.Envelope.Tx.Memo.Type {
+	memo := tx.Envelope.Memo()
+	switch memo.Type {
 	case xdr.MemoTypeMemoNone:
 		value, valid = "", false
 	case xdr.MemoTypeMemoText:
-		scrubbed := utf8.Scrub(tx.Envelope.Tx.Memo.MustText())
+		scrubbed := utf8.Scrub(memo.MustText())
 		notnull := strings.Join(strings.Split(scrubbed, "\x00"), "")
 		value, valid = notnull, true
 	case xdr.MemoTypeMemoId:
-		value, valid = fmt.Sprintf("%d", tx.Envelope.Tx.Memo.MustId()), true
+		value, valid = fmt.Sprintf("%d", memo.MustId()), true
 	case xdr.MemoTypeMemoHash:
-		hash := tx.Envelope.Tx.Memo.MustHash()
+		hash := memo.MustHash()
 		value, valid =
 			base64.StdEncoding.EncodeToString(hash[:]),
 			true
 	case xdr.MemoTypeMemoReturn:
-		hash := tx.Envelope.Tx.Memo.MustRetHash()
+		hash := memo.MustRetHash()
 		value, valid =
 			base64.StdEncoding.EncodeToString(hash[:]),
 			true
 	default:
-		panic(fmt.Errorf("invalid memo type: %v", tx.Envelope.Tx.Memo.Type))
+		panic(fmt.Errorf("invalid memo type: %v", memo.Type))
 	}
 
 	return null.NewString(value, valid)
@@ -83,7 +131,8 @@ func (tx *Transaction) Memo() null.String {
 
 // MemoType returns the memo type for this transaction
 func (tx *Transaction) MemoType() string {
-	switch tx.Envelope.Tx.Memo.Type {
+	memo := tx.Envelope.Memo()
+	switch memo.Type {
 	case xdr.MemoTypeMemoNone:
 		return "none"
 	case xdr.MemoTypeMemoText:
@@ -95,7 +144,7 @@ func (tx *Transaction) MemoType() string {
 	case xdr.MemoTypeMemoReturn:
 		return "return"
 	default:
-		panic(fmt.Errorf("invalid memo type: %v", tx.Envelope.Tx.Memo.Type))
+		panic(fmt.Errorf("invalid memo type: %v", memo.Type))
 	}
 }
 
@@ -119,17 +168,16 @@ func (tx *Transaction) ResultMetaXDR() string {
 
 // Sequence returns the sequence number for `tx`
 func (tx *Transaction) Sequence() int64 {
-	return int64(tx.Envelope.Tx.SeqNum)
+	return tx.Envelope.SeqNum()
 }
 
 // SourceAddress returns the strkey-encoded account id that paid the fee for
-// `tx`.
+// `tx`. For fee bump transactions this is the source account of the inner
+// transaction, see FeeAccount for the account that paid the fee. A muxed
+// source account resolves to its underlying account.
 func (tx *Transaction) SourceAddress() string {
-	sa := tx.Envelope.Tx.SourceAccount
-	pubkey := sa.MustEd25519()
-	raw := make([]byte, 32)
-	copy(raw, pubkey[:])
-	return strkey.MustEncode(strkey.VersionByteAccountID, raw)
+	sa := tx.Envelope.SourceAccount().ToAccountId()
+	return sa.Address()
 }
 
 // TransactionByHashAfterLedger is a query that loads a single row from the `txhistory`.
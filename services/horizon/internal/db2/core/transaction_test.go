@@ -5,6 +5,7 @@ import (
 
 	"github.com/paydex-core/paydex-go/services/horizon/internal/test"
 	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestTransactionsQueries(t *testing.T) {
@@ -60,3 +61,44 @@ func TestSignatures(t *testing.T) {
 	tt.Assert.Equal("8qkkeKaKfsbgInyIkzXJhqJE5/Ufxri2LdxmyKkgkT6I3sPmvrs5cPWQSzEQyhV750IW2ds97xTHqTpOfuZCAg==", signatures[0])
 	tt.Assert.Equal("", signatures[1])
 }
+
+func TestMuxedAccounts(t *testing.T) {
+	tt := assert.New(t)
+	address := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	var muxed xdr.MuxedAccount
+	tt.NoError(muxed.SetAddress(address))
+	muxed = xdr.MuxedAccount{
+		Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+		Med25519: &xdr.MuxedAccountMed25519{
+			Id:      123,
+			Ed25519: muxed.MustEd25519(),
+		},
+	}
+
+	inner := xdr.TransactionV1Envelope{
+		Tx: xdr.Transaction{SourceAccount: muxed, Fee: 100, SeqNum: 1},
+	}
+	tx := Transaction{Envelope: xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &inner,
+	}}
+	tt.Equal(address, tx.SourceAddress())
+	tt.False(tx.FeeAccount().Valid)
+
+	// the fee account fits in history_transactions.fee_account
+	tx.Envelope = xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: xdr.FeeBumpTransaction{
+				FeeSource: muxed,
+				Fee:       200,
+				InnerTx: xdr.FeeBumpTransactionInnerTx{
+					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+					V1:   &inner,
+				},
+			},
+		},
+	}
+	tt.Equal(address, tx.SourceAddress())
+	tt.Equal(address, tx.FeeAccount().String)
+}
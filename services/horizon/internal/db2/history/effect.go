@@ -9,6 +9,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
 	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -98,11 +99,11 @@ func (q *EffectsQ) ForOperation(id int64) *EffectsQ {
 // ForOrderBook filters the query to only effects whose details indicate that
 // the effect is for a specific asset pair.
 func (q *EffectsQ) ForOrderBook(selling, buying xdr.Asset) *EffectsQ {
-	q.orderBookFilter(selling, "sold_")
+	q.assetFilter(selling, "sold_")
 	if q.Err != nil {
 		return q
 	}
-	q.orderBookFilter(buying, "bought_")
+	q.assetFilter(buying, "bought_")
 	if q.Err != nil {
 		return q
 	}
@@ -137,6 +138,45 @@ func (q *EffectsQ) OfType(typ EffectType) *EffectsQ {
 	return q
 }
 
+// OfTypes filters the query to only effects of one of the given types.
+func (q *EffectsQ) OfTypes(types []EffectType) *EffectsQ {
+	q.sql = q.sql.Where(sq.Eq{"heff.type": types})
+	return q
+}
+
+// ForAsset filters the query to only effects whose details indicate that the
+// effect is for a specific asset, like the credits, debits and trust line
+// changes of the asset.
+func (q *EffectsQ) ForAsset(asset xdr.Asset) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+	q.assetFilter(asset, "")
+	return q
+}
+
+// ForTimeRange filters the query to only effects in the ledgers closed at or
+// after start and before end. A nil start or end leaves the range open on
+// that side.
+func (q *EffectsQ) ForTimeRange(start, end time.Millis) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.timeRangeIDs(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(
+		"heff.history_operation_id >= ? AND heff.history_operation_id < ?",
+		from,
+		to,
+	)
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *EffectsQ) Page(page db2.PageQuery) *EffectsQ {
 	if q.Err != nil {
@@ -192,28 +232,15 @@ func (q *EffectsQ) Select(dest interface{}) error {
 	return q.Err
 }
 
-// OfType filters the query to only effects of the given type.
-func (q *EffectsQ) orderBookFilter(a xdr.Asset, prefix string) {
-	var typ, code, iss string
-	q.Err = a.Extract(&typ, &code, &iss)
+// assetFilter filters the query to only effects whose details contain the
+// asset in the fields named with prefix.
+func (q *EffectsQ) assetFilter(a xdr.Asset, prefix string) {
+	var clause sq.Sqlizer
+	clause, q.Err = assetDetailsClause("heff", prefix, a)
 	if q.Err != nil {
 		return
 	}
-
-	if a.Type == xdr.AssetTypeAssetTypeNative {
-		clause := fmt.Sprintf(`
-				(heff.details->>'%sasset_type' = ?
-		AND heff.details ?? '%sasset_code' = false
-		AND heff.details ?? '%sasset_issuer' = false)`, prefix, prefix, prefix)
-		q.sql = q.sql.Where(clause, typ)
-		return
-	}
-
-	clause := fmt.Sprintf(`
-		(heff.details->>'%sasset_type' = ?
-	AND heff.details->>'%sasset_code' = ?
-	AND heff.details->>'%sasset_issuer' = ?)`, prefix, prefix, prefix)
-	q.sql = q.sql.Where(clause, typ, code, iss)
+	q.sql = q.sql.Where(clause)
 }
 
 var selectEffect = sq.
@@ -0,0 +1,84 @@
+package history
+
+import (
+	"fmt"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/time"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// timeRangeIDs returns the range [from, to) of the total order ids of the
+// records in the ledgers closed at or after start and before end. A nil start
+// or end leaves the range open on that side.
+func (q *Q) timeRangeIDs(start, end time.Millis) (from int64, to int64, err error) {
+	to = math.MaxInt64
+
+	if !start.IsNil() {
+		seq, found, err := q.firstLedgerClosedAt(start)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "loading the first ledger of the time range")
+		}
+		if !found {
+			// no ledger closed after start so nothing can match
+			return math.MaxInt64, math.MaxInt64, nil
+		}
+		from = toid.New(seq, 0, 0).ToInt64()
+	}
+
+	if !end.IsNil() {
+		seq, found, err := q.firstLedgerClosedAt(end)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "loading the last ledger of the time range")
+		}
+		if found {
+			to = toid.New(seq, 0, 0).ToInt64()
+		}
+	}
+
+	return from, to, nil
+}
+
+// firstLedgerClosedAt returns the sequence of the first ledger closed at or
+// after t.
+func (q *Q) firstLedgerClosedAt(t time.Millis) (int32, bool, error) {
+	var seq []int32
+	sql := sq.Select("hl.sequence").
+		From("history_ledgers hl").
+		Where(sq.GtOrEq{"hl.closed_at": t.ToTime()}).
+		OrderBy("hl.closed_at asc, hl.sequence asc").
+		Limit(1)
+
+	err := q.Select(&seq, sql)
+	if err != nil || len(seq) == 0 {
+		return 0, false, err
+	}
+	return seq[0], true, nil
+}
+
+// assetDetailsClause returns a condition matching the rows whose details,
+// in the `details` jsonb column of the table aliased as alias, contain asset
+// in the fields named with prefix.
+func assetDetailsClause(alias, prefix string, a xdr.Asset) (sq.Sqlizer, error) {
+	var typ, code, iss string
+	if err := a.Extract(&typ, &code, &iss); err != nil {
+		return nil, err
+	}
+
+	if a.Type == xdr.AssetTypeAssetTypeNative {
+		clause := fmt.Sprintf(`
+				(%[1]s.details->>'%[2]sasset_type' = ?
+		AND %[1]s.details ?? '%[2]sasset_code' = false
+		AND %[1]s.details ?? '%[2]sasset_issuer' = false)`, alias, prefix)
+		return sq.Expr(clause, typ), nil
+	}
+
+	clause := fmt.Sprintf(`
+		(%[1]s.details->>'%[2]sasset_type' = ?
+	AND %[1]s.details->>'%[2]sasset_code' = ?
+	AND %[1]s.details->>'%[2]sasset_issuer' = ?)`, alias, prefix)
+	return sq.Expr(clause, typ, code, iss), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
+	"github.com/lib/pq"
 
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/support/db"
@@ -116,6 +117,8 @@ var ExperimentalIngestionTables = []string{
 	"accounts_data",
 	"accounts_signers",
 	"exp_asset_stats",
+	"history_account_states",
+	"history_trust_line_states",
 	"offers",
 	"trust_lines",
 }
@@ -187,6 +190,20 @@ type accountSignersBatchInsertBuilder struct {
 	builder db.BatchInsertBuilder
 }
 
+// AccountState is a row of data from the `history_account_states` table, the
+// state of an account from LedgerSequence until its next row.
+type AccountState struct {
+	AccountEntry
+	LedgerSequence uint32 `db:"ledger_sequence"`
+	// Deleted is true if the account was merged in the ledger.
+	Deleted bool          `db:"deleted"`
+	Signers SignerWeights `db:"signers"`
+}
+
+// SignerWeights maps the signers of an account to their weights, the master key
+// included if its weight is not zero.
+type SignerWeights map[string]int32
+
 // Data is a row of data from the `account_data` table
 type Data struct {
 	AccountID          string           `db:"account_id"`
@@ -532,6 +549,11 @@ type Transaction struct {
 	// otherwise all existing transactions would be interpreted as failed until
 	// ledger is reingested.
 	Successful *bool `db:"successful"`
+	// The following fields are only set for fee bump transactions.
+	FeeAccount           null.String `db:"fee_account"`
+	InnerTransactionHash null.String `db:"inner_transaction_hash"`
+	NewMaxFee            null.Int    `db:"new_max_fee"`
+	InnerSignatureString null.String `db:"inner_signatures"`
 }
 
 // TransactionsQ is a helper struct to aid in configuring queries that loads
@@ -575,6 +597,67 @@ type trustLinesBatchInsertBuilder struct {
 	builder db.BatchInsertBuilder
 }
 
+// TrustLineState is a row of data from the `history_trust_line_states` table,
+// the state of a trust line from LedgerSequence until its next row.
+type TrustLineState struct {
+	TrustLine
+	LedgerSequence uint32 `db:"ledger_sequence"`
+	// Deleted is true if the trust line was removed in the ledger.
+	Deleted bool `db:"deleted"`
+}
+
+// QStateHistory defines account and trust line state history related queries.
+type QStateHistory interface {
+	NewStateHistoryBatchInsertBuilder(maxBatchSize int) StateHistoryBatchInsertBuilder
+}
+
+// StateHistoryBatchInsertBuilder adds the states accounts and trust lines
+// were left in by a ledger to the state history.
+type StateHistoryBatchInsertBuilder interface {
+	AddAccount(account xdr.AccountEntry, lastModifiedLedger, ledger xdr.Uint32) error
+	RemoveAccount(accountID xdr.AccountId, ledger xdr.Uint32) error
+	AddTrustLine(trustLine xdr.TrustLineEntry, lastModifiedLedger, ledger xdr.Uint32) error
+	RemoveTrustLine(key xdr.LedgerKeyTrustLine, ledger xdr.Uint32) error
+	Exec() error
+}
+
+// stateHistoryBatchInsertBuilder is a simple wrapper around the
+// db.BatchInsertBuilders of the state history tables
+type stateHistoryBatchInsertBuilder struct {
+	accounts   db.BatchInsertBuilder
+	trustLines db.BatchInsertBuilder
+}
+
+// WebhookDelivery is a row of data from the `webhook_deliveries` table
+type WebhookDelivery struct {
+	ID             int64       `db:"id"`
+	SubscriptionID int64       `db:"subscription_id"`
+	LedgerSequence int32       `db:"ledger_sequence"`
+	OperationID    int64       `db:"operation_id"`
+	Payload        string      `db:"payload"`
+	State          string      `db:"state"`
+	Attempts       int32       `db:"attempts"`
+	NextAttemptAt  time.Time   `db:"next_attempt_at"`
+	LastError      null.String `db:"last_error"`
+	CreatedAt      time.Time   `db:"created_at"`
+	DeliveredAt    null.Time   `db:"delivered_at"`
+}
+
+// WebhookSubscription is a row of data from the `webhook_subscriptions` table
+type WebhookSubscription struct {
+	ID     int64  `db:"id"`
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+	// Accounts, Asset and OperationTypes filter the operations delivered to
+	// the subscription. Empty filters match every operation.
+	Accounts       pq.StringArray `db:"accounts"`
+	Asset          null.String    `db:"asset"`
+	OperationTypes pq.Int64Array  `db:"operation_types"`
+	// LastLedger is the last ledger checked for matching operations.
+	LastLedger int32     `db:"last_ledger"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
 func (q *Q) NewAccountsBatchInsertBuilder(maxBatchSize int) AccountsBatchInsertBuilder {
 	return &accountsBatchInsertBuilder{
 		builder: db.BatchInsertBuilder{
@@ -620,6 +703,19 @@ func (q *Q) NewTrustLinesBatchInsertBuilder(maxBatchSize int) TrustLinesBatchIns
 	}
 }
 
+func (q *Q) NewStateHistoryBatchInsertBuilder(maxBatchSize int) StateHistoryBatchInsertBuilder {
+	return &stateHistoryBatchInsertBuilder{
+		accounts: db.BatchInsertBuilder{
+			Table:        q.GetTable("history_account_states"),
+			MaxBatchSize: maxBatchSize,
+		},
+		trustLines: db.BatchInsertBuilder{
+			Table:        q.GetTable("history_trust_line_states"),
+			MaxBatchSize: maxBatchSize,
+		},
+	}
+}
+
 // ElderLedger loads the oldest ledger known to the history database
 func (q *Q) ElderLedger(dest interface{}) error {
 	return q.GetRaw(dest, `SELECT COALESCE(MIN(sequence), 0) FROM history_ledgers`)
@@ -0,0 +1,15 @@
+package history
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQStateHistory is a mock implementation of the QStateHistory interface
+type MockQStateHistory struct {
+	mock.Mock
+}
+
+func (m *MockQStateHistory) NewStateHistoryBatchInsertBuilder(maxBatchSize int) StateHistoryBatchInsertBuilder {
+	a := m.Called(maxBatchSize)
+	return a.Get(0).(StateHistoryBatchInsertBuilder)
+}
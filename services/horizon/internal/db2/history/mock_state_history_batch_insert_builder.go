@@ -0,0 +1,35 @@
+package history
+
+import (
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStateHistoryBatchInsertBuilder struct {
+	mock.Mock
+}
+
+func (m *MockStateHistoryBatchInsertBuilder) AddAccount(account xdr.AccountEntry, lastModifiedLedger, ledger xdr.Uint32) error {
+	a := m.Called(account, lastModifiedLedger, ledger)
+	return a.Error(0)
+}
+
+func (m *MockStateHistoryBatchInsertBuilder) RemoveAccount(accountID xdr.AccountId, ledger xdr.Uint32) error {
+	a := m.Called(accountID, ledger)
+	return a.Error(0)
+}
+
+func (m *MockStateHistoryBatchInsertBuilder) AddTrustLine(trustLine xdr.TrustLineEntry, lastModifiedLedger, ledger xdr.Uint32) error {
+	a := m.Called(trustLine, lastModifiedLedger, ledger)
+	return a.Error(0)
+}
+
+func (m *MockStateHistoryBatchInsertBuilder) RemoveTrustLine(key xdr.LedgerKeyTrustLine, ledger xdr.Uint32) error {
+	a := m.Called(key, ledger)
+	return a.Error(0)
+}
+
+func (m *MockStateHistoryBatchInsertBuilder) Exec() error {
+	a := m.Called()
+	return a.Error(0)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
+	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -178,16 +179,83 @@ func (q *OperationsQ) ForTransaction(hash string) *OperationsQ {
 // are in the "payment" class of operations:  CreateAccountOps, Payments, and
 // PathPayments.
 func (q *OperationsQ) OnlyPayments() *OperationsQ {
-	q.sql = q.sql.Where(sq.Eq{"hop.type": []xdr.OperationType{
-		xdr.OperationTypeCreateAccount,
-		xdr.OperationTypePayment,
-		xdr.OperationTypePathPaymentStrictReceive,
-		xdr.OperationTypePathPaymentStrictSend,
-		xdr.OperationTypeAccountMerge,
-	}})
+	q.sql = q.sql.Where(sq.Eq{"hop.type": PaymentOperationTypes})
 	return q
 }
 
+// OfTypes filters the query being built to only include operations of one of
+// the given types.
+func (q *OperationsQ) OfTypes(types []xdr.OperationType) *OperationsQ {
+	q.sql = q.sql.Where(sq.Eq{"hop.type": types})
+	return q
+}
+
+// ForAsset filters the query being built to only include operations moving
+// or trusting a specific asset: the asset of payments, path payments (sent or
+// received), change trust and allow trust operations.
+func (q *OperationsQ) ForAsset(asset xdr.Asset) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	received, err := assetDetailsClause("hop", "", asset)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+	sent, err := assetDetailsClause("hop", "source_", asset)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	q.sql = q.sql.Where(sq.Or{received, sent})
+	return q
+}
+
+// ForTimeRange filters the query being built to only include operations in
+// the ledgers closed at or after start and before end. A nil start or end
+// leaves the range open on that side.
+func (q *OperationsQ) ForTimeRange(start, end time.Millis) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.timeRangeIDs(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(
+		q.opIdCol+" >= ? AND "+q.opIdCol+" < ?",
+		from,
+		to,
+	)
+	return q
+}
+
+// PaymentOperationTypes are the types of the operations in the "payment"
+// class of operations.
+var PaymentOperationTypes = []xdr.OperationType{
+	xdr.OperationTypeCreateAccount,
+	xdr.OperationTypePayment,
+	xdr.OperationTypePathPaymentStrictReceive,
+	xdr.OperationTypePathPaymentStrictSend,
+	xdr.OperationTypeAccountMerge,
+}
+
+// IsPayment returns true if the operation is in the "payment" class of
+// operations.
+func (r *Operation) IsPayment() bool {
+	for _, t := range PaymentOperationTypes {
+		if r.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 // IncludeFailed changes the query to include failed transactions.
 func (q *OperationsQ) IncludeFailed() *OperationsQ {
 	q.includeFailed = true
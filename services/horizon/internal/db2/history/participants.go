@@ -0,0 +1,61 @@
+package history
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
+)
+
+// participant is a row of data from the `history_operation_participants` or
+// `history_transaction_participants` tables, joined with the address of the
+// account.
+type participant struct {
+	ID      int64  `db:"id"`
+	Address string `db:"address"`
+}
+
+// OperationParticipantsForLedger returns the addresses of the participants of
+// the operations of a ledger, by operation id.
+func (q *Q) OperationParticipantsForLedger(seq int32) (map[int64][]string, error) {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+	sql := sq.Select("hopp.history_operation_id AS id", "hacc.address").
+		From("history_operation_participants hopp").
+		Join("history_accounts hacc ON hacc.id = hopp.history_account_id").
+		Where(
+			"hopp.history_operation_id >= ? AND hopp.history_operation_id < ?",
+			start.ToInt64(),
+			end.ToInt64(),
+		)
+
+	return q.participants(sql)
+}
+
+// TransactionParticipantsForLedger returns the addresses of the participants
+// of the transactions of a ledger, by transaction id.
+func (q *Q) TransactionParticipantsForLedger(seq int32) (map[int64][]string, error) {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+	sql := sq.Select("htp.history_transaction_id AS id", "hacc.address").
+		From("history_transaction_participants htp").
+		Join("history_accounts hacc ON hacc.id = htp.history_account_id").
+		Where(
+			"htp.history_transaction_id >= ? AND htp.history_transaction_id < ?",
+			start.ToInt64(),
+			end.ToInt64(),
+		)
+
+	return q.participants(sql)
+}
+
+func (q *Q) participants(sql sq.SelectBuilder) (map[int64][]string, error) {
+	var rows []participant
+	if err := q.Select(&rows, sql); err != nil {
+		return nil, err
+	}
+
+	participants := map[int64][]string{}
+	for _, row := range rows {
+		participants[row.ID] = append(participants[row.ID], row.Address)
+	}
+	return participants, nil
+}
@@ -0,0 +1,65 @@
+package history
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"sort"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+var _ driver.Valuer = (*SignerWeights)(nil)
+var _ sql.Scanner = (*SignerWeights)(nil)
+
+// Scan decodes a jsonb object into SignerWeights
+func (t *SignerWeights) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case []byte:
+		data = src
+	case string:
+		data = []byte(src)
+	default:
+		return errors.Errorf("cannot scan %T into SignerWeights", src)
+	}
+
+	weights := SignerWeights{}
+	if err := json.Unmarshal(data, &weights); err != nil {
+		return errors.Wrap(err, "could not unmarshal signer weights")
+	}
+
+	*t = weights
+	return nil
+}
+
+// Value implements driver.Valuer
+func (value SignerWeights) Value() (driver.Value, error) {
+	if value == nil {
+		value = SignerWeights{}
+	}
+
+	data, err := json.Marshal(map[string]int32(value))
+	if err != nil {
+		return nil, err
+	}
+	return driver.Value(data), nil
+}
+
+// AccountSigners returns the signers of account as `accounts_signers` rows,
+// ordered by signer.
+func (value SignerWeights) AccountSigners(account string) []AccountSigner {
+	signers := make([]AccountSigner, 0, len(value))
+	for signer, weight := range value {
+		signers = append(signers, AccountSigner{
+			Account: account,
+			Signer:  signer,
+			Weight:  weight,
+		})
+	}
+
+	sort.Slice(signers, func(i, j int) bool {
+		return signers[i].Signer < signers[j].Signer
+	})
+	return signers
+}
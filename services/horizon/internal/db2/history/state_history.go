@@ -0,0 +1,190 @@
+package history
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// AddAccount adds the state `account` was left in by `ledger` to the batch.
+func (i *stateHistoryBatchInsertBuilder) AddAccount(account xdr.AccountEntry, lastModifiedLedger, ledger xdr.Uint32) error {
+	return i.accounts.Row(accountStateToMap(account, lastModifiedLedger, ledger, false))
+}
+
+// RemoveAccount adds the removal of an account in `ledger` to the batch.
+func (i *stateHistoryBatchInsertBuilder) RemoveAccount(accountID xdr.AccountId, ledger xdr.Uint32) error {
+	return i.accounts.Row(accountStateToMap(xdr.AccountEntry{AccountId: accountID}, 0, ledger, true))
+}
+
+// AddTrustLine adds the state `trustLine` was left in by `ledger` to the batch.
+func (i *stateHistoryBatchInsertBuilder) AddTrustLine(trustLine xdr.TrustLineEntry, lastModifiedLedger, ledger xdr.Uint32) error {
+	return i.trustLines.Row(trustLineStateToMap(trustLine, lastModifiedLedger, ledger, false))
+}
+
+// RemoveTrustLine adds the removal of a trust line in `ledger` to the batch.
+func (i *stateHistoryBatchInsertBuilder) RemoveTrustLine(key xdr.LedgerKeyTrustLine, ledger xdr.Uint32) error {
+	trustLine := xdr.TrustLineEntry{AccountId: key.AccountId, Asset: key.Asset}
+	return i.trustLines.Row(trustLineStateToMap(trustLine, 0, ledger, true))
+}
+
+func (i *stateHistoryBatchInsertBuilder) Exec() error {
+	if err := i.accounts.Exec(); err != nil {
+		return err
+	}
+	return i.trustLines.Exec()
+}
+
+func accountStateToMap(account xdr.AccountEntry, lastModifiedLedger, ledger xdr.Uint32, deleted bool) map[string]interface{} {
+	m := accountToMap(account, lastModifiedLedger)
+	m["ledger_sequence"] = ledger
+	m["deleted"] = deleted
+	m["signers"] = SignerWeights(account.SignerSummary())
+	return m
+}
+
+func trustLineStateToMap(trustLine xdr.TrustLineEntry, lastModifiedLedger, ledger xdr.Uint32, deleted bool) map[string]interface{} {
+	m := trustLineToMap(trustLine, lastModifiedLedger)
+	m["ledger_sequence"] = ledger
+	m["deleted"] = deleted
+	return m
+}
+
+// AccountStateAt loads the state of an account at `ledger`: its latest row in
+// the `history_account_states` table at or before the ledger. It returns
+// sql.ErrNoRows if the account did not exist at the ledger.
+func (q *Q) AccountStateAt(accountID string, ledger uint32) (AccountState, error) {
+	var state AccountState
+	query := selectAccountStates.
+		Where(sq.Eq{"account_id": accountID}).
+		Where("ledger_sequence <= ?", ledger).
+		OrderBy("ledger_sequence desc").
+		Limit(1)
+
+	if err := q.Get(&state, query); err != nil {
+		return state, err
+	}
+
+	if state.Deleted {
+		return AccountState{}, sql.ErrNoRows
+	}
+	return state, nil
+}
+
+// TrustLinesAt loads the trust lines of an account at `ledger` from the
+// `history_trust_line_states` table.
+func (q *Q) TrustLinesAt(accountID string, ledger uint32) ([]TrustLine, error) {
+	latest := selectTrustLineStates.
+		Options("DISTINCT ON (asset_type, asset_issuer, asset_code)").
+		Where(sq.Eq{"account_id": accountID}).
+		Where("ledger_sequence <= ?", ledger).
+		OrderBy("asset_type, asset_issuer, asset_code, ledger_sequence desc")
+
+	var states []TrustLineState
+	if err := q.Select(&states, latest); err != nil {
+		return nil, errors.Wrap(err, "could not run select query")
+	}
+
+	trustLines := make([]TrustLine, 0, len(states))
+	for _, state := range states {
+		if !state.Deleted {
+			trustLines = append(trustLines, state.TrustLine)
+		}
+	}
+	return trustLines, nil
+}
+
+// StateHistoryElder loads the oldest ledger the state history starts at, the
+// checkpoint the experimental ingestion system started from. It returns 0 if
+// the state history is empty.
+func (q *Q) StateHistoryElder() (uint32, error) {
+	var elder uint32
+	err := q.GetRaw(&elder, `SELECT COALESCE(MIN(ledger_sequence), 0) FROM history_account_states`)
+	return elder, err
+}
+
+// LedgerSequenceAt loads the sequence of the last ledger closed at or before
+// `at`. It returns sql.ErrNoRows if no known ledger was closed by then.
+func (q *Q) LedgerSequenceAt(at time.Time) (int32, error) {
+	var sequence int32
+	query := sq.Select("sequence").
+		From("history_ledgers").
+		Where("closed_at <= ?", at.UTC()).
+		OrderBy("sequence desc").
+		Limit(1)
+
+	err := q.Get(&sequence, query)
+	return sequence, err
+}
+
+// ReapStateHistory removes the rows of the state history which are not needed
+// to load the states at `elder` and after: the rows older than `elder` which
+// are followed by another row of the same entry at or before `elder`, and the
+// removals older than `elder`.
+func (q *Q) ReapStateHistory(elder uint32) error {
+	_, err := q.ExecRaw(`
+		DELETE FROM history_account_states s
+		WHERE s.ledger_sequence < $1 AND (s.deleted OR EXISTS (
+			SELECT 1 FROM history_account_states n
+			WHERE n.account_id = s.account_id
+			AND n.ledger_sequence > s.ledger_sequence
+			AND n.ledger_sequence <= $1
+		))`, elder)
+	if err != nil {
+		return errors.Wrap(err, "could not reap account states")
+	}
+
+	_, err = q.ExecRaw(`
+		DELETE FROM history_trust_line_states s
+		WHERE s.ledger_sequence < $1 AND (s.deleted OR EXISTS (
+			SELECT 1 FROM history_trust_line_states n
+			WHERE n.account_id = s.account_id
+			AND n.asset_type = s.asset_type
+			AND n.asset_issuer = s.asset_issuer
+			AND n.asset_code = s.asset_code
+			AND n.ledger_sequence > s.ledger_sequence
+			AND n.ledger_sequence <= $1
+		))`, elder)
+	if err != nil {
+		return errors.Wrap(err, "could not reap trust line states")
+	}
+
+	return nil
+}
+
+var selectAccountStates = sq.Select(`
+	account_id,
+	ledger_sequence,
+	deleted,
+	balance,
+	buying_liabilities,
+	selling_liabilities,
+	sequence_number,
+	num_subentries,
+	inflation_destination,
+	flags,
+	home_domain,
+	master_weight,
+	threshold_low,
+	threshold_medium,
+	threshold_high,
+	signers,
+	last_modified_ledger
+`).From("history_account_states")
+
+var selectTrustLineStates = sq.Select(`
+	account_id,
+	asset_type,
+	asset_issuer,
+	asset_code,
+	ledger_sequence,
+	deleted,
+	balance,
+	trust_line_limit,
+	buying_liabilities,
+	selling_liabilities,
+	flags,
+	last_modified_ledger
+`).From("history_trust_line_states")
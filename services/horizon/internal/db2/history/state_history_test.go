@@ -0,0 +1,153 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/test"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccountStateAt(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	updated := account1
+	updated.Balance = 10000
+	updated.Signers = []xdr.Signer{
+		{Key: xdr.MustSigner("GCT2NQM5KJJEF55NPMY444C6M6CA7T33HRNCMA6ZFBIIXKNCRO6J25K7"), Weight: 5},
+	}
+
+	batch := q.NewStateHistoryBatchInsertBuilder(0)
+	assert.NoError(t, batch.AddAccount(account1, 5, 10))
+	assert.NoError(t, batch.AddAccount(updated, 20, 20))
+	assert.NoError(t, batch.RemoveAccount(account1.AccountId, 30))
+	assert.NoError(t, batch.Exec())
+
+	address := account1.AccountId.Address()
+
+	_, err := q.AccountStateAt(address, 9)
+	assert.True(t, q.NoRows(err))
+
+	state, err := q.AccountStateAt(address, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), state.LedgerSequence)
+	assert.Equal(t, int64(20000), state.Balance)
+	assert.Equal(t, uint32(5), state.LastModifiedLedger)
+	assert.Equal(t, byte(2), state.ThresholdLow)
+	assert.Equal(t, SignerWeights{address: 1}, state.Signers)
+
+	state, err = q.AccountStateAt(address, 29)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(20), state.LedgerSequence)
+	assert.Equal(t, int64(10000), state.Balance)
+	assert.Equal(t, []AccountSigner{
+		{Account: address, Signer: address, Weight: 1},
+		{Account: address, Signer: "GCT2NQM5KJJEF55NPMY444C6M6CA7T33HRNCMA6ZFBIIXKNCRO6J25K7", Weight: 5},
+	}, state.Signers.AccountSigners(address))
+
+	_, err = q.AccountStateAt(address, 30)
+	assert.True(t, q.NoRows(err))
+
+	elder, err := q.StateHistoryElder()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), elder)
+}
+
+func TestTrustLinesAt(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	usd := usdTrustLine
+	usd.AccountId = eurTrustLine.AccountId
+	updated := eurTrustLine
+	updated.Balance = 1
+
+	var eurKey xdr.LedgerKey
+	assert.NoError(t, eurKey.SetTrustline(eurTrustLine.AccountId, eurTrustLine.Asset))
+
+	batch := q.NewStateHistoryBatchInsertBuilder(0)
+	assert.NoError(t, batch.AddTrustLine(eurTrustLine, 10, 10))
+	assert.NoError(t, batch.AddTrustLine(usd, 15, 15))
+	assert.NoError(t, batch.AddTrustLine(updated, 16, 16))
+	assert.NoError(t, batch.RemoveTrustLine(*eurKey.TrustLine, 20))
+	assert.NoError(t, batch.Exec())
+
+	address := eurTrustLine.AccountId.Address()
+
+	trustLines, err := q.TrustLinesAt(address, 9)
+	assert.NoError(t, err)
+	assert.Len(t, trustLines, 0)
+
+	trustLines, err = q.TrustLinesAt(address, 12)
+	assert.NoError(t, err)
+	if assert.Len(t, trustLines, 1) {
+		assert.Equal(t, "EUR", trustLines[0].AssetCode)
+		assert.Equal(t, int64(20000), trustLines[0].Balance)
+	}
+
+	trustLines, err = q.TrustLinesAt(address, 16)
+	assert.NoError(t, err)
+	if assert.Len(t, trustLines, 2) {
+		assert.Equal(t, "EUR", trustLines[0].AssetCode)
+		assert.Equal(t, int64(1), trustLines[0].Balance)
+		assert.Equal(t, "USDUSD", trustLines[1].AssetCode)
+	}
+
+	trustLines, err = q.TrustLinesAt(address, 20)
+	assert.NoError(t, err)
+	if assert.Len(t, trustLines, 1) {
+		assert.Equal(t, "USDUSD", trustLines[0].AssetCode)
+	}
+}
+
+func TestReapStateHistory(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	test.ResetHorizonDB(t, tt.HorizonDB)
+	q := &Q{tt.HorizonSession()}
+
+	updated := account1
+	updated.Balance = 10000
+
+	batch := q.NewStateHistoryBatchInsertBuilder(0)
+	assert.NoError(t, batch.AddAccount(account1, 10, 10))
+	assert.NoError(t, batch.AddAccount(updated, 20, 20))
+	assert.NoError(t, batch.AddAccount(account1, 30, 30))
+	assert.NoError(t, batch.AddAccount(account2, 10, 10))
+	assert.NoError(t, batch.RemoveAccount(account2.AccountId, 15))
+	assert.NoError(t, batch.Exec())
+
+	assert.NoError(t, q.ReapStateHistory(25))
+
+	var sequences []uint32
+	err := q.SelectRaw(&sequences, `SELECT ledger_sequence FROM history_account_states ORDER BY ledger_sequence`)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{20, 30}, sequences)
+
+	state, err := q.AccountStateAt(account1.AccountId.Address(), 25)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), state.Balance)
+
+	_, err = q.AccountStateAt(account2.AccountId.Address(), 25)
+	assert.True(t, q.NoRows(err))
+}
+
+func TestSignerWeightsValue(t *testing.T) {
+	weights := SignerWeights{"GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB": 1}
+
+	value, err := weights.Value()
+	assert.NoError(t, err)
+
+	var scanned SignerWeights
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, weights, scanned)
+
+	value, err = SignerWeights(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("{}"), value)
+}
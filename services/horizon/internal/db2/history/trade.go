@@ -6,6 +6,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
@@ -16,6 +17,21 @@ func (r *Trade) PagingToken() string {
 	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
 }
 
+// Reversed returns the trade with its base and counter swapped, like the
+// trades loaded by ReverseTrades.
+func (r Trade) Reversed() Trade {
+	reversed := r
+	reversed.BaseOfferID, reversed.CounterOfferID = r.CounterOfferID, r.BaseOfferID
+	reversed.BaseAccount, reversed.CounterAccount = r.CounterAccount, r.BaseAccount
+	reversed.BaseAssetType, reversed.CounterAssetType = r.CounterAssetType, r.BaseAssetType
+	reversed.BaseAssetCode, reversed.CounterAssetCode = r.CounterAssetCode, r.BaseAssetCode
+	reversed.BaseAssetIssuer, reversed.CounterAssetIssuer = r.CounterAssetIssuer, r.BaseAssetIssuer
+	reversed.BaseAmount, reversed.CounterAmount = r.CounterAmount, r.BaseAmount
+	reversed.BaseIsSeller = !r.BaseIsSeller
+	reversed.PriceN, reversed.PriceD = r.PriceD, r.PriceN
+	return reversed
+}
+
 // HasPrice returns true if the trade has non-null price data
 func (r *Trade) HasPrice() bool {
 	return r.PriceN.Valid && r.PriceD.Valid
@@ -60,6 +76,48 @@ func (q *TradesQ) ForOffer(id int64) *TradesQ {
 	return q
 }
 
+// ForLedger filters the query to only trades in a specific ledger, specified
+// by its sequence.
+func (q *TradesQ) ForLedger(seq int32) *TradesQ {
+	start := toid.ID{LedgerSequence: seq}
+	end := toid.ID{LedgerSequence: seq + 1}
+	q.sql = q.sql.Where(
+		"htrd.history_operation_id >= ? AND htrd.history_operation_id < ?",
+		start.ToInt64(),
+		end.ToInt64(),
+	)
+	return q
+}
+
+// ForAsset filters the query results to the trades of the asset with the
+// given id, either as the base or the counter asset.
+func (q *TradesQ) ForAsset(assetID int64) *TradesQ {
+	q.sql = q.sql.Where("(htrd.base_asset_id = ? OR htrd.counter_asset_id = ?)", assetID, assetID)
+	return q
+}
+
+// ForTimeRange filters the query to only trades in the ledgers closed at or
+// after start and before end. A nil start or end leaves the range open on
+// that side.
+func (q *TradesQ) ForTimeRange(start, end time.Millis) *TradesQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.timeRangeIDs(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(
+		"htrd.history_operation_id >= ? AND htrd.history_operation_id < ?",
+		from,
+		to,
+	)
+	return q
+}
+
 //Filter by asset pair. This function is private to ensure that correct order and proper select statement are coupled
 func (q *TradesQ) forAssetPair(baseAssetId int64, counterAssetId int64) *TradesQ {
 	q.sql = q.sql.Where(sq.Eq{"base_asset_id": baseAssetId, "counter_asset_id": counterAssetId})
@@ -6,6 +6,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	. "github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/test"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -68,4 +69,20 @@ func TestTradeQueries(t *testing.T) {
 	tt.Assert.Equal(xdr.Int64(1000000000), trades[0].BaseAmount)
 	tt.Assert.Equal(xdr.Int64(2000000000), trades[0].CounterAmount)
 	tt.Assert.Equal(false, trades[0].BaseIsSeller)
+
+	var reversed []Trade
+	err = q.TradesForAssetPair(lumen, assetUSD).Select(&reversed)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(trades[0], reversed[0].Reversed())
+
+	// trades of a ledger
+	var ledgerTrades []Trade
+	seq := toid.Parse(trades[0].HistoryOperationID).LedgerSequence
+	err = q.Trades().ForLedger(seq).Select(&ledgerTrades)
+	tt.Require.NoError(err)
+	if tt.Assert.NotEmpty(ledgerTrades) {
+		for _, trade := range ledgerTrades {
+			tt.Assert.Equal(seq, toid.Parse(trade.HistoryOperationID).LedgerSequence)
+		}
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
 	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/time"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
@@ -17,11 +18,13 @@ func (t *Transaction) IsSuccessful() bool {
 }
 
 // TransactionByHash is a query that loads a single row from the
-// `history_transactions` table based upon the provided hash.
+// `history_transactions` table based upon the provided hash. The hash of the
+// inner transaction of a fee bump transaction also matches the fee bump
+// transaction.
 func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	sql := selectTransaction.
 		Limit(1).
-		Where("ht.transaction_hash = ?", hash)
+		Where("(ht.transaction_hash = ? OR ht.inner_transaction_hash = ?)", hash, hash)
 
 	return q.Get(dest, sql)
 }
@@ -76,6 +79,19 @@ func (q *TransactionsQ) ForAccount(aid string) *TransactionsQ {
 	return q
 }
 
+// ForMemo filters the query to only transactions with the given memo. An empty
+// memoType matches the memo of any type. It must be used with ForAccount:
+// memos are looked up in the transaction participants of the account, so that
+// the query only scans the transactions of the account with the memo.
+func (q *TransactionsQ) ForMemo(memoType, memo string) *TransactionsQ {
+	q.sql = q.sql.Where("htp.memo = ?", memo)
+	if memoType != "" {
+		q.sql = q.sql.Where("htp.memo_type = ?", memoType)
+	}
+
+	return q
+}
+
 // ForLedger filters the query to a only transactions in a specific ledger,
 // specified by its sequence.
 func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
@@ -96,6 +112,95 @@ func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
 	return q
 }
 
+// WithOperationTypes filters the query to only transactions containing an
+// operation of one of the given types.
+func (q *TransactionsQ) WithOperationTypes(types []xdr.OperationType) *TransactionsQ {
+	return q.withOperations(sq.Eq{"hop.type": types})
+}
+
+// ForAsset filters the query to only transactions containing an operation
+// moving or trusting a specific asset, as matched by OperationsQ.ForAsset.
+func (q *TransactionsQ) ForAsset(asset xdr.Asset) *TransactionsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	received, err := assetDetailsClause("hop", "", asset)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+	sent, err := assetDetailsClause("hop", "source_", asset)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	return q.withOperations(sq.Or{received, sent})
+}
+
+// ForTimeRange filters the query to only transactions in the ledgers closed
+// at or after start and before end. A nil start or end leaves the range open
+// on that side.
+func (q *TransactionsQ) ForTimeRange(start, end time.Millis) *TransactionsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.timeRangeIDs(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where("ht.id >= ? AND ht.id < ?", from, to)
+	return q
+}
+
+// withOperations filters the query to only transactions containing an
+// operation matching cond.
+func (q *TransactionsQ) withOperations(cond sq.Sqlizer) *TransactionsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	sql, args, err := sq.Select("1").
+		From("history_operations hop").
+		Where("hop.transaction_id = ht.id").
+		Where(cond).
+		ToSql()
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	q.sql = q.sql.Where("EXISTS ("+sql+")", args...)
+	return q
+}
+
+// BackfillTransactionMemos copies the memo of the transactions in the ledgers
+// [start, end) to their participants which were ingested before memos were,
+// and returns the number of participants updated.
+func (q *Q) BackfillTransactionMemos(start, end int32) (int64, error) {
+	from := toid.ID{LedgerSequence: start}
+	to := toid.ID{LedgerSequence: end}
+	result, err := q.ExecRaw(`
+		UPDATE history_transaction_participants htp
+		SET memo_type = ht.memo_type, memo = ht.memo
+		FROM history_transactions ht
+		WHERE ht.id = htp.history_transaction_id
+		AND htp.memo_type IS NULL
+		AND htp.history_transaction_id >= $1 AND htp.history_transaction_id < $2`,
+		from.ToInt64(),
+		to.ToInt64(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 // IncludeFailed changes the query to include failed transactions.
 func (q *TransactionsQ) IncludeFailed() *TransactionsQ {
 	q.includeFailed = true
@@ -187,6 +292,10 @@ var selectTransaction = sq.Select(
 		"ht.memo, " +
 		"lower(ht.time_bounds) AS valid_after, " +
 		"upper(ht.time_bounds) AS valid_before, " +
-		"hl.closed_at AS ledger_close_time").
+		"hl.closed_at AS ledger_close_time, " +
+		"ht.fee_account, " +
+		"ht.inner_transaction_hash, " +
+		"ht.new_max_fee, " +
+		"array_to_string(ht.inner_signatures, ',') AS inner_signatures").
 	From("history_transactions ht").
 	LeftJoin("history_ledgers hl ON ht.ledger_sequence = hl.sequence")
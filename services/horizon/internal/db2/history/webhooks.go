@@ -0,0 +1,268 @@
+package history
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+const (
+	// WebhookDeliveryPending is the state of a delivery waiting for its next
+	// attempt.
+	WebhookDeliveryPending = "pending"
+	// WebhookDeliveryDelivered is the state of a delivery acknowledged by the
+	// subscriber.
+	WebhookDeliveryDelivered = "delivered"
+	// WebhookDeliveryDead is the state of a delivery which failed too many
+	// times and will not be attempted again.
+	WebhookDeliveryDead = "dead"
+)
+
+// webhookAssetDetailPrefixes are the prefixes of the asset fields in the
+// details of the operations.
+var webhookAssetDetailPrefixes = []string{"", "source_", "selling_", "buying_"}
+
+// InsertWebhookSubscription creates a row in the webhook_subscriptions table
+// and returns its id.
+func (q *Q) InsertWebhookSubscription(subscription WebhookSubscription) (int64, error) {
+	// pq encodes nil arrays as NULL
+	if subscription.Accounts == nil {
+		subscription.Accounts = pq.StringArray{}
+	}
+	if subscription.OperationTypes == nil {
+		subscription.OperationTypes = pq.Int64Array{}
+	}
+
+	sql := sq.Insert("webhook_subscriptions").SetMap(map[string]interface{}{
+		"url":             subscription.URL,
+		"secret":          subscription.Secret,
+		"accounts":        subscription.Accounts,
+		"asset":           subscription.Asset,
+		"operation_types": subscription.OperationTypes,
+		"last_ledger":     subscription.LastLedger,
+		"created_at":      subscription.CreatedAt,
+	}).Suffix("RETURNING id")
+
+	var id int64
+	err := q.Get(&id, sql)
+	return id, err
+}
+
+// WebhookSubscriptions loads all the rows from the webhook_subscriptions table.
+func (q *Q) WebhookSubscriptions() ([]WebhookSubscription, error) {
+	var subscriptions []WebhookSubscription
+	err := q.Select(&subscriptions, selectWebhookSubscription.OrderBy("id ASC"))
+	return subscriptions, err
+}
+
+// WebhookSubscriptionByID loads a row from the webhook_subscriptions table.
+func (q *Q) WebhookSubscriptionByID(dest *WebhookSubscription, id int64) error {
+	return q.Get(dest, selectWebhookSubscription.Where("id = ?", id))
+}
+
+// DeleteWebhookSubscription removes a row from the webhook_subscriptions
+// table, together with its deliveries. Returns number of rows affected and
+// error.
+func (q *Q) DeleteWebhookSubscription(id int64) (int64, error) {
+	result, err := q.Exec(sq.Delete("webhook_subscriptions").Where("id = ?", id))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// UpdateWebhookSubscriptionLastLedger sets the last ledger checked for
+// operations matching a subscription. Returns number of rows affected and
+// error.
+func (q *Q) UpdateWebhookSubscriptionLastLedger(id int64, lastLedger int32) (int64, error) {
+	sql := sq.Update("webhook_subscriptions").
+		Set("last_ledger", lastLedger).
+		Where("id = ?", id)
+	result, err := q.Exec(sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// AdvanceWebhookSubscription sets the last ledger checked for operations
+// matching a subscription, unless it was changed since it was `previous`, for
+// example by a replay. Returns number of rows affected and error.
+func (q *Q) AdvanceWebhookSubscription(id int64, previous, lastLedger int32) (int64, error) {
+	sql := sq.Update("webhook_subscriptions").
+		Set("last_ledger", lastLedger).
+		Where("id = ? AND last_ledger = ?", id, previous)
+	result, err := q.Exec(sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// WebhookOperations loads the operations of successful transactions matching
+// the filters of a subscription, in the ledgers from `from` to `to`
+// (inclusive).
+func (q *Q) WebhookOperations(
+	subscription WebhookSubscription,
+	from, to int32,
+) ([]Operation, error) {
+	startID := toid.ID{LedgerSequence: from}
+	endID := toid.ID{LedgerSequence: to + 1}
+	start, end := startID.ToInt64(), endID.ToInt64()
+
+	sql := selectOperation.
+		Where("hop.id >= ? AND hop.id < ?", start, end).
+		Where("(ht.successful = true OR ht.successful IS NULL)").
+		OrderBy("hop.id ASC")
+
+	if len(subscription.Accounts) > 0 {
+		sql = sql.Where(`hop.id IN (
+			SELECT hopp.history_operation_id
+			FROM history_operation_participants hopp
+			JOIN history_accounts ha ON ha.id = hopp.history_account_id
+			WHERE ha.address = ANY(?)
+			AND hopp.history_operation_id >= ? AND hopp.history_operation_id < ?
+		)`, subscription.Accounts, start, end)
+	}
+
+	if len(subscription.OperationTypes) > 0 {
+		sql = sql.Where("hop.type = ANY(?)", subscription.OperationTypes)
+	}
+
+	if subscription.Asset.Valid {
+		assets, err := xdr.BuildAssets(subscription.Asset.String)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid subscription asset")
+		}
+		if len(assets) != 1 {
+			return nil, errors.Errorf("invalid subscription asset: %s", subscription.Asset.String)
+		}
+
+		var typ, code, issuer string
+		if err = assets[0].Extract(&typ, &code, &issuer); err != nil {
+			return nil, errors.Wrap(err, "invalid subscription asset")
+		}
+
+		or := sq.Or{}
+		for _, prefix := range webhookAssetDetailPrefixes {
+			if assets[0].Type == xdr.AssetTypeAssetTypeNative {
+				or = append(or, sq.Expr("hop.details->>'"+prefix+"asset_type' = ?", typ))
+				continue
+			}
+			or = append(or, sq.Expr(
+				"(hop.details->>'"+prefix+"asset_code' = ? AND hop.details->>'"+prefix+"asset_issuer' = ?)",
+				code,
+				issuer,
+			))
+		}
+		sql = sql.Where(or)
+	}
+
+	var operations []Operation
+	err := q.Select(&operations, sql)
+	return operations, err
+}
+
+// InsertWebhookDelivery creates a pending delivery, to be attempted at
+// `delivery.NextAttemptAt`.
+func (q *Q) InsertWebhookDelivery(delivery WebhookDelivery) error {
+	sql := sq.Insert("webhook_deliveries").SetMap(map[string]interface{}{
+		"subscription_id": delivery.SubscriptionID,
+		"ledger_sequence": delivery.LedgerSequence,
+		"operation_id":    delivery.OperationID,
+		"payload":         delivery.Payload,
+		"state":           WebhookDeliveryPending,
+		"attempts":        0,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"created_at":      delivery.CreatedAt,
+	})
+	_, err := q.Exec(sql)
+	return err
+}
+
+// PendingWebhookDeliveries loads up to `limit` pending deliveries due at
+// `now`, the ones due the longest first.
+func (q *Q) PendingWebhookDeliveries(now time.Time, limit uint64) ([]WebhookDelivery, error) {
+	sql := selectWebhookDelivery.
+		Where("state = ?", WebhookDeliveryPending).
+		Where("next_attempt_at <= ?", now).
+		OrderBy("next_attempt_at ASC", "id ASC").
+		Limit(limit)
+
+	var deliveries []WebhookDelivery
+	err := q.Select(&deliveries, sql)
+	return deliveries, err
+}
+
+// WebhookDeliveries loads a page of the deliveries of a subscription. When
+// `state` is not empty only the deliveries in this state are loaded.
+func (q *Q) WebhookDeliveries(
+	subscriptionID int64,
+	state string,
+	page db2.PageQuery,
+) ([]WebhookDelivery, error) {
+	sql := selectWebhookDelivery.Where("subscription_id = ?", subscriptionID)
+	if state != "" {
+		sql = sql.Where("state = ?", state)
+	}
+
+	sql, err := page.ApplyTo(sql, "id")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not apply query to page")
+	}
+
+	var deliveries []WebhookDelivery
+	err = q.Select(&deliveries, sql)
+	return deliveries, err
+}
+
+// UpdateWebhookDelivery records the outcome of a delivery attempt. Returns
+// number of rows affected and error.
+func (q *Q) UpdateWebhookDelivery(delivery WebhookDelivery) (int64, error) {
+	sql := sq.Update("webhook_deliveries").SetMap(map[string]interface{}{
+		"state":           delivery.State,
+		"attempts":        delivery.Attempts,
+		"next_attempt_at": delivery.NextAttemptAt,
+		"last_error":      delivery.LastError,
+		"delivered_at":    delivery.DeliveredAt,
+	}).Where("id = ?", delivery.ID)
+	result, err := q.Exec(sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+var selectWebhookSubscription = sq.Select(
+	"id, " +
+		"url, " +
+		"secret, " +
+		"accounts, " +
+		"asset, " +
+		"operation_types, " +
+		"last_ledger, " +
+		"created_at").
+	From("webhook_subscriptions")
+
+var selectWebhookDelivery = sq.Select(
+	"id, " +
+		"subscription_id, " +
+		"ledger_sequence, " +
+		"operation_id, " +
+		"payload, " +
+		"state, " +
+		"attempts, " +
+		"next_attempt_at, " +
+		"last_error, " +
+		"created_at, " +
+		"delivered_at").
+	From("webhook_deliveries")
@@ -0,0 +1,182 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/test"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+func TestWebhookSubscriptionQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	createdAt := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	id, err := q.InsertWebhookSubscription(WebhookSubscription{
+		URL:        "https://example.com/hook",
+		Secret:     "s3cr3t",
+		Asset:      null.StringFrom("native"),
+		LastLedger: 1,
+		CreatedAt:  createdAt,
+	})
+	tt.Assert.NoError(err)
+
+	var subscription WebhookSubscription
+	tt.Assert.NoError(q.WebhookSubscriptionByID(&subscription, id))
+	tt.Assert.Equal("https://example.com/hook", subscription.URL)
+	tt.Assert.Equal("s3cr3t", subscription.Secret)
+	tt.Assert.Len(subscription.Accounts, 0)
+	tt.Assert.Len(subscription.OperationTypes, 0)
+	tt.Assert.Equal(int32(1), subscription.LastLedger)
+	tt.Assert.True(createdAt.Equal(subscription.CreatedAt))
+
+	rows, err := q.UpdateWebhookSubscriptionLastLedger(id, 3)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(1), rows)
+
+	rows, err = q.AdvanceWebhookSubscription(id, 2, 5)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(0), rows)
+
+	subscriptions, err := q.WebhookSubscriptions()
+	tt.Assert.NoError(err)
+	if tt.Assert.Len(subscriptions, 1) {
+		tt.Assert.Equal(int32(3), subscriptions[0].LastLedger)
+	}
+
+	rows, err = q.AdvanceWebhookSubscription(id, 3, 5)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(1), rows)
+
+	rows, err = q.DeleteWebhookSubscription(id)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(1), rows)
+
+	err = q.WebhookSubscriptionByID(&subscription, id)
+	tt.Assert.True(q.NoRows(err))
+}
+
+func TestWebhookOperations(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var latest int32
+	tt.Assert.NoError(q.LatestLedger(&latest))
+
+	address := "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	expected, _, err := q.Operations().ForAccount(address).Fetch()
+	tt.Assert.NoError(err)
+
+	ops, err := q.WebhookOperations(WebhookSubscription{
+		Accounts: pq.StringArray{address},
+	}, 1, latest)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, len(expected))
+
+	// ledger range
+	expected, _, err = q.Operations().ForLedger(2).Fetch()
+	tt.Assert.NoError(err)
+	ops, err = q.WebhookOperations(WebhookSubscription{}, 2, 2)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, len(expected))
+
+	// operation types
+	ops, err = q.WebhookOperations(WebhookSubscription{
+		OperationTypes: pq.Int64Array{int64(xdr.OperationTypeManageData)},
+	}, 1, latest)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, 0)
+
+	// asset
+	ops, err = q.WebhookOperations(WebhookSubscription{
+		Asset: null.StringFrom("USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"),
+	}, 1, latest)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(ops, 0)
+
+	_, err = q.WebhookOperations(WebhookSubscription{
+		Asset: null.StringFrom("USD"),
+	}, 1, latest)
+	tt.Assert.Error(err)
+}
+
+func TestWebhookDeliveryQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	now := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	id, err := q.InsertWebhookSubscription(WebhookSubscription{
+		URL:        "https://example.com/hook",
+		Secret:     "s3cr3t",
+		LastLedger: 1,
+		CreatedAt:  now,
+	})
+	tt.Assert.NoError(err)
+
+	for i, due := range []time.Time{now.Add(time.Minute), now, now.Add(-time.Minute)} {
+		err = q.InsertWebhookDelivery(WebhookDelivery{
+			SubscriptionID: id,
+			LedgerSequence: 2,
+			OperationID:    int64(i + 1),
+			Payload:        `{"id":"1"}`,
+			NextAttemptAt:  due,
+			CreatedAt:      now,
+		})
+		tt.Assert.NoError(err)
+	}
+
+	pending, err := q.PendingWebhookDeliveries(now, 10)
+	tt.Assert.NoError(err)
+	if tt.Assert.Len(pending, 2) {
+		tt.Assert.Equal(int64(3), pending[0].OperationID)
+		tt.Assert.Equal(int64(2), pending[1].OperationID)
+		tt.Assert.Equal(WebhookDeliveryPending, pending[0].State)
+		tt.Assert.JSONEq(`{"id":"1"}`, pending[0].Payload)
+	}
+
+	delivered := pending[0]
+	delivered.State = WebhookDeliveryDelivered
+	delivered.Attempts = 1
+	delivered.DeliveredAt = null.TimeFrom(now)
+	rows, err := q.UpdateWebhookDelivery(delivered)
+	tt.Assert.NoError(err)
+	tt.Assert.Equal(int64(1), rows)
+
+	dead := pending[1]
+	dead.State = WebhookDeliveryDead
+	dead.Attempts = 10
+	dead.LastError = null.StringFrom("unexpected status 500")
+	_, err = q.UpdateWebhookDelivery(dead)
+	tt.Assert.NoError(err)
+
+	pending, err = q.PendingWebhookDeliveries(now, 10)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(pending, 0)
+
+	page := db2.PageQuery{Order: "asc", Limit: 10}
+	all, err := q.WebhookDeliveries(id, "", page)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(all, 3)
+
+	deadLetters, err := q.WebhookDeliveries(id, WebhookDeliveryDead, page)
+	tt.Assert.NoError(err)
+	if tt.Assert.Len(deadLetters, 1) {
+		tt.Assert.Equal(dead.ID, deadLetters[0].ID)
+		tt.Assert.Equal(int32(10), deadLetters[0].Attempts)
+		tt.Assert.Equal("unexpected status 500", deadLetters[0].LastError.String)
+	}
+
+	// deliveries are removed with their subscription
+	_, err = q.DeleteWebhookSubscription(id)
+	tt.Assert.NoError(err)
+	all, err = q.WebhookDeliveries(id, "", page)
+	tt.Assert.NoError(err)
+	tt.Assert.Len(all, 0)
+}
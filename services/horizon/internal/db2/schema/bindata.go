@@ -18,7 +18,12 @@
 // migrations/24_accounts.sql (1.402kB)
 // migrations/25_expingest_rename_columns.sql (641B)
 // migrations/26_exp_history_ledgers.sql (209B)
+// migrations/27_fee_bump_transactions.sql (702B)
+// migrations/28_webhooks.sql (1.441kB)
+// migrations/29_account_state_history.sql (2.24kB)
 // migrations/2_index_participants_by_toid.sql (277B)
+// migrations/30_history_filters_indexes.sql (948B)
+// migrations/31_transaction_participant_memos.sql (740B)
 // migrations/3_use_sequence_in_history_accounts.sql (447B)
 // migrations/4_add_protocol_version.sql (188B)
 // migrations/5_create_trades_table.sql (1.1kB)
@@ -455,6 +460,66 @@ func migrations26_exp_history_ledgersSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations27_fee_bump_transactionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x92\x51\x4b\xc3\x30\x14\x85\xdf\xf3\x2b\xee\xe3\x8a\xeb\x9b\x0c\x24\x4f\x75\x09\x5a\x88\xa9\x74\x2d\x0a\x32\x42\x5a\x62\x9b\x87\xa5\x92\x64\xce\xfe\x7b\xcb\x44\x8d\x6b\x86\xcb\xeb\xbd\xf7\x3b\xe7\xdc\x9b\x34\x85\xab\x9d\xee\xac\xf4\x0a\xea\x37\x84\x32\x56\xd1\x12\xaa\xec\x96\x51\xe8\xb5\xf3\x83\x1d\x85\xb7\xd2\x38\xd9\x7a\x3d\x18\x87\x60\x7a\x19\x21\xf0\xaa\x94\x90\x6d\x3b\xec\x8d\x87\xb6\x97\x76\xaa\x2b\x0b\xef\xd2\x8e\xda\x74\x8b\xd5\x75\xb2\xfc\x69\xd5\xc6\x28\x1b\x52\x44\x2f\x5d\xff\xdf\x94\x51\x07\xb1\x93\x1f\x62\x12\x82\x46\x77\xda\xf8\x53\xa2\xd3\x9d\x91\x7e\x6f\x95\x8b\xb0\x6e\x56\xc9\xcb\x16\x23\xb4\x2e\x69\x56\x51\xc8\x39\xa1\xcf\xd0\x8c\xe2\x6b\xf4\x68\xa0\xe0\xd1\x88\x50\x6f\x72\x7e\x07\x8d\xb7\x93\xf2\x22\x6e\x3e\x81\xa7\x7b\x5a\xd2\x73\xd1\xf2\x0d\xf0\xa2\x02\x5e\x33\x86\x67\x0e\xc2\xcd\x5d\x64\x21\x18\xf8\xd6\x0d\x19\x7f\xc4\x50\x1a\x1c\x94\x0c\x07\x83\x10\x29\x8b\xc7\x58\x7e\x7c\x52\x09\x98\xf8\xc2\x8f\x70\x04\xac\x0b\x56\x3f\xf0\xd0\xd2\x72\x56\x8c\xef\x69\xde\x17\x1c\xfd\x1c\xe4\xf7\xea\x18\x7d\x02\x3f\xcd\xe6\xbf\xbe\x02\x00\x00")
+
+func migrations27_fee_bump_transactionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations27_fee_bump_transactionsSql,
+		"migrations/27_fee_bump_transactions.sql",
+	)
+}
+
+func migrations27_fee_bump_transactionsSql() (*asset, error) {
+	bytes, err := migrations27_fee_bump_transactionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/27_fee_bump_transactions.sql", size: 702, mode: os.FileMode(0644), modTime: time.Unix(1792300237, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x32, 0xbc, 0x4e, 0x55, 0xab, 0xa0, 0xe7, 0x60, 0x2a, 0x75, 0xcc, 0x6f, 0x2c, 0x95, 0x18, 0xde, 0xb1, 0xea, 0x5f, 0x72, 0x31, 0x2e, 0x1a, 0x45, 0xd2, 0x2, 0x85, 0xee, 0xb1, 0x2d, 0x2d, 0xe9}}
+	return a, nil
+}
+
+var _migrations28_webhooksSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x94\x41\x8f\xda\x30\x10\x85\xef\xf9\x15\x73\x5b\xa2\x82\xd4\xae\x5a\xd4\x0a\xf5\x90\x05\xb7\x45\xa5\x61\x15\x40\xed\xaa\xaa\x22\xc7\x99\x12\x77\x83\x9d\xda\x66\x69\xfa\xeb\x6b\x62\x14\x42\x20\x5a\x54\x8e\xf6\x9b\xf7\x66\x3c\x5f\x18\x0c\xe0\xc5\x86\xaf\x15\x35\x08\xab\xc2\xf3\xc6\x11\x09\x96\x04\x96\xc1\xdd\x8c\xc0\x0e\x93\x4c\xca\xc7\x58\x6f\x13\xcd\x14\x2f\x0c\x97\x42\x43\xcf\x03\xfb\xe3\x29\x24\x7c\xad\x51\x71\x9a\xc3\x7d\x34\xfd\x12\x44\x0f\xf0\x99\x3c\xf4\xab\xdb\xad\xca\x81\x65\x54\x51\x66\x50\xc1\x13\x55\x25\x17\xeb\xde\xed\xcb\xd7\x6f\x7d\x08\xe7\x4b\x08\x57\xb3\x99\x53\x6a\x64\x0a\xcd\x05\xf1\xab\xdb\x33\xed\x60\x00\xb8\x29\x4c\x09\x54\x29\x5a\x6a\xa0\x22\x05\x5a\xdd\x03\xd5\xda\xba\x6c\xa8\x61\x19\xe0\x13\xaa\x12\x64\x81\x76\x2a\xdb\x71\x55\x4a\x19\x93\x5b\x61\xf4\x85\xa0\x37\x43\xff\xfb\x8f\x56\x92\xb3\x3b\xd7\x0e\xdf\xf9\x4e\x50\xbb\xc7\xa6\x2c\x50\x03\x17\x06\xd7\xa8\xce\x8c\x6c\xcb\x39\xd5\x26\xce\x31\xb5\xd7\xc0\x35\x98\x0c\xab\x23\x38\x1c\xb1\x0c\xd9\x23\xa6\xf0\x53\x2a\xd7\xbf\xcd\x39\xda\xeb\xca\xe5\xc4\xc2\x25\xb5\x72\xec\x23\xda\x15\xa6\x31\x35\x60\xf8\x06\xb5\xa1\x9b\x02\x76\xdc\x64\x72\xeb\x4e\xe0\xaf\x14\x58\x17\x79\xfe\xa8\xde\xf5\x34\x9c\x90\x6f\x97\x77\x1d\x27\x65\xdc\xcc\x9e\x87\x1d\x4c\xac\x16\xd3\xf0\x23\xdc\x2d\x23\x42\x7a\x8d\x82\x46\xca\x29\x51\x29\xe6\xdc\x6e\x89\xe3\x75\x38\x35\xc3\x62\x27\xb5\xcf\x50\x4f\x03\x11\xf9\x40\x22\x12\x8e\xc9\xa2\x8b\x59\x9e\xfa\xfb\xee\x27\x64\x46\x6c\x33\xe3\x60\x31\x0e\x26\xc4\x99\xbb\x56\x63\x8d\xbf\xb7\x28\x18\x76\x3c\xf0\x71\xe1\xe7\xf9\x4e\x51\xd0\x32\x97\x34\x85\x5f\x5a\x8a\xa4\x4d\xb9\xd9\x7f\x60\x17\x20\x1f\xb6\x19\xa7\xc6\xec\x19\xd7\x1d\x6d\x08\xfc\x63\xe2\x83\xe6\xda\x65\xf7\x8f\x0c\xa1\x52\x16\x33\x63\x4d\xfe\x0b\x1b\x57\x74\x58\xde\xb3\x65\xdd\x90\x1d\xd7\x1f\x17\x28\xd2\x3d\xf1\x0d\xb4\x1a\x70\x34\xb9\x6a\x8d\xee\xc3\xd7\x4f\x76\xe9\x87\xa7\x7d\x0f\x37\x07\xa7\x9b\xd1\xb3\x99\x96\xea\x26\x1e\x57\x64\xb7\x00\xec\x5b\x5e\xf7\xc3\x0d\x1a\xff\x9e\x13\xb9\x13\x9e\x37\x89\xe6\xf7\xdd\xac\x33\xaa\x19\x4d\x71\x74\x49\x76\x0a\x6c\xad\xfc\x07\x9b\xcd\xad\x0f\xa1\x05\x00\x00")
+
+func migrations28_webhooksSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations28_webhooksSql,
+		"migrations/28_webhooks.sql",
+	)
+}
+
+func migrations28_webhooksSql() (*asset, error) {
+	bytes, err := migrations28_webhooksSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/28_webhooks.sql", size: 1441, mode: os.FileMode(0644), modTime: time.Unix(1792305889, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xbd, 0x28, 0x9, 0x7d, 0x68, 0xc1, 0xa9, 0xac, 0x43, 0xaa, 0xbf, 0xc9, 0x66, 0x4d, 0xb, 0xd2, 0x57, 0xdf, 0xe7, 0x51, 0xe1, 0xa0, 0xa, 0xe5, 0xdb, 0xb8, 0xc0, 0xcd, 0x43, 0x60, 0x9f, 0x18}}
+	return a, nil
+}
+
+var _migrations29_account_state_historySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x56\x51\x6f\xc3\x34\x10\x7e\xcf\xaf\xb8\xb7\x75\xa2\x9d\x60\x08\x5e\xfa\xd4\xd1\x80\x26\x4a\x37\x95\x56\x62\x4f\xd6\x25\xbe\x24\x07\x8e\x3d\x7c\xce\xaa\x0a\xf1\xdf\x91\xd3\x64\x74\x6d\xd7\xa8\x08\x21\xde\x62\xfb\xbb\xef\xbe\x3b\xdf\xe7\x76\x32\x81\x2f\x6a\x2e\x3d\x06\x82\xcd\x6b\x92\x4c\x26\x50\xb1\x04\xe7\x77\x0a\xf3\xdc\x35\x36\x28\x09\x18\x48\x00\xad\x7e\x3f\x0a\xbe\x91\xa0\x0c\x5b\xea\x4f\x2b\x67\x34\x20\x78\xb7\x85\xc2\x79\xa0\x37\xf2\xbb\x48\x66\x48\x97\xe4\x01\x2d\x74\x74\xe0\x3c\x20\xb4\x04\x10\x09\x20\xaf\xd0\x96\xa4\x81\xed\x1d\xac\x2b\x82\x96\x10\x5c\x11\x63\xc8\x06\xbf\x03\x0c\x80\x07\x5c\x2c\xc0\x41\xc0\x44\x55\xa1\xcd\x88\x2d\x6b\x46\x85\xf3\x04\xa1\xa2\x0e\x39\x8e\x82\xa8\x76\x6f\xa4\x3b\xa6\x0a\x25\xee\xb9\x6d\xa4\xdb\x72\xa8\x40\x93\xa1\x40\x1a\x84\xc2\x5d\xf2\xdd\x2a\x9d\xad\x53\x58\xcf\x1e\x16\xe9\x67\x6d\x18\x25\x00\xd0\x17\xa3\x58\x47\xfd\x1e\xf3\x40\x1e\xde\xd0\xef\xd8\x96\xa3\x6f\xbe\xbd\x85\xe5\xd3\x1a\x96\x9b\xc5\x62\xdc\xc2\xf7\x7a\x94\xd0\xef\x0d\xd9\x9c\x80\x6d\xa0\xd8\x96\x8f\xa8\x5e\x4b\xe6\x9c\x21\xb4\xef\xa7\x30\x4f\xbf\x9f\x6d\x16\x6b\x28\xd0\x08\xed\xb1\x19\x1a\x8c\x4c\x19\x97\x6c\xc3\x29\xf4\xcb\x0e\xd6\x44\x45\xca\x30\x66\x6c\x38\x30\xc9\x50\x84\x90\x31\x57\x87\xec\xcb\x52\xb6\xa9\x33\xf2\x43\x70\xdb\xd4\x4a\x9a\x2c\xde\x48\x24\xbf\x04\x65\x5b\x18\x0c\xec\xac\xd2\x24\x81\x6d\xfb\x3d\xd0\xf1\x77\x8e\x9b\x9b\x3d\x49\x61\xb0\xbc\x9c\xa6\x72\x35\x29\xed\x6a\xe4\x73\xe4\x5f\xdf\x5f\x20\xaf\x51\x02\x79\xb5\x25\x2e\xab\x00\x52\xa3\x31\x97\x32\x85\xca\x93\x44\xaf\x28\xe3\xb6\xd7\xc0\x6b\xd2\xdc\xd4\xd7\x44\x54\x5c\x56\xc3\xf8\xc9\x04\x84\x4b\x4b\x5e\xa0\xc6\x57\x69\xdd\xd3\x6f\xb8\xa2\x5d\x76\xb3\x0e\xc1\xc5\x25\x7b\xd8\x17\x2b\x63\x30\xfc\x1b\xf5\x2c\x1d\x4a\x54\x1f\xad\x1d\xc9\x5d\x7b\xda\xef\xfc\x2a\xce\x66\xa7\x4a\x6e\xfe\xf8\xb3\x6b\xa6\x41\x09\xaa\x76\x9a\x0b\x26\xad\x7a\xbb\x1f\x79\xe5\xb8\x82\xe7\xd5\xe3\x4f\xb3\xd5\x0b\xfc\x98\xbe\xc0\xa8\x53\xa1\x58\x8f\x8f\x4d\x77\x9b\xdc\x4e\x93\xf3\x16\x3f\x7d\xce\xfe\x91\xcb\x51\x84\x82\x0a\xbb\x57\xfa\x30\x6d\x87\x87\x2c\xd2\x90\xbf\x82\x2d\x77\x9a\xce\xc0\xbf\xba\xff\xdf\x3c\x31\x07\xcd\x33\x5c\x73\x18\xc2\xff\x27\x4f\xd2\xb0\xe7\xff\xdd\x59\xfb\xfb\xea\xfb\xef\xfd\x4d\xf7\xab\x78\x8d\x97\x27\xf2\x71\x39\x4f\x7f\xf9\xe4\x47\x47\x65\xbb\x5e\xe1\xd3\xf2\x13\x0c\x6c\x7e\x7e\x5c\xfe\x00\x0f\xeb\x55\x9a\x8e\x8e\x13\x4d\xcf\x67\x39\x99\xfb\xf3\x89\x4e\x60\x03\xb9\x92\xc3\x3f\x15\x73\xb7\xb5\x49\x32\x5f\x3d\x3d\x0f\x9a\x2e\x47\xc9\x51\xd3\xf4\x1c\xfa\xa8\xd8\x1c\x25\x47\x4d\xd3\xe4\xaf\x01\x00\x14\xc5\x1a\x12\xc0\x08\x00\x00")
+
+func migrations29_account_state_historySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations29_account_state_historySql,
+		"migrations/29_account_state_history.sql",
+	)
+}
+
+func migrations29_account_state_historySql() (*asset, error) {
+	bytes, err := migrations29_account_state_historySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/29_account_state_history.sql", size: 2240, mode: os.FileMode(0644), modTime: time.Unix(1792310183, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xc1, 0xe, 0xa6, 0x2d, 0x6f, 0x9d, 0xb0, 0xd4, 0xa5, 0x6d, 0x3b, 0x3e, 0x3f, 0x15, 0x45, 0xf2, 0xe0, 0x5e, 0xe2, 0xac, 0xbf, 0xf2, 0xc8, 0x60, 0xc1, 0xd0, 0xdb, 0xbd, 0x8, 0x7f, 0x80, 0xcf}}
+	return a, nil
+}
+
 var _migrations2_index_participants_by_toidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xb1\xca\xc2\x50\x0c\x46\xf7\x3c\x45\xc6\xff\x47\xfa\x04\x9d\xc4\x16\xe9\xd2\x4a\xb5\xe0\x76\x49\xdb\x8b\xcd\xe0\xcd\x25\x37\x20\x7d\x7b\x41\x07\x5b\xbb\xb8\x86\x8f\x73\x72\xb2\x0c\x77\x77\xbe\x29\x99\xc7\x2e\x02\x1c\xda\x72\x7f\x29\xb1\xaa\x8b\xf2\x8a\x93\x44\xd7\xcf\x6e\x12\x1e\xb1\xa9\x71\xe2\x64\xa2\xb3\x93\xe8\x95\x8c\x25\xb8\x48\x6a\x3c\x70\xa4\x60\x09\xbb\x73\x55\x1f\xb1\x37\xf5\x1e\xff\xb6\x5b\x1e\xff\xf3\x2f\xbc\xbd\xf1\xb6\xc6\x9b\x52\x48\x34\xfc\x28\x58\xae\x5f\x0a\x58\x26\x15\xf2\x08\x00\x45\xdb\x9c\xb6\x49\xf9\xea\xfe\xf9\x25\x87\x67\x00\x00\x00\xff\xff\x33\xec\x54\x7a\x15\x01\x00\x00")
 
 func migrations2_index_participants_by_toidSqlBytes() ([]byte, error) {
@@ -475,6 +540,46 @@ func migrations2_index_participants_by_toidSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations30_history_filters_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x93\xb1\x6a\xc3\x30\x10\x86\x77\x3d\xc5\x91\x25\x36\xb5\x5f\x20\x86\x40\xa9\x4d\xc9\xe2\x94\xb4\x81\x6e\x42\xb1\xce\x8d\xa0\xb5\x8c\xee\x42\xeb\xb7\x2f\xf1\x50\xe4\x46\x6a\x4c\x87\xcc\xf7\x9d\xf4\x7f\xfa\x51\x9e\xc3\xdd\x87\x79\x73\x8a\x11\xf6\xbd\x10\x0f\xbb\xea\xfe\xa5\x82\x4d\x5d\x56\xaf\x70\xb4\xbd\x3c\x0c\x92\x87\x1e\xa5\xea\xb4\x34\x1a\xb6\x35\x1c\x0d\xb1\x75\x83\xb4\x3d\x3a\xc5\xc6\x76\x04\xfb\xe7\x4d\xfd\x08\x07\x76\x88\x90\x9c\xf1\x0c\x8c\x4e\x8b\xe0\x69\x8a\x08\x79\xce\x39\x49\xa2\x91\x95\x79\x27\xc8\xd7\x6b\x58\x8e\x7b\x63\x96\xe5\x6a\xc5\xf8\xc5\x69\x9a\x41\x90\x69\xac\xbe\xca\x18\xa2\x13\x3a\x8f\x8a\xe6\x25\x7b\x72\x0d\xfe\x3b\xb6\xbf\x7e\x25\xfd\x04\xfd\x5b\x62\x82\xce\x71\xc1\xb6\x9d\x54\xf9\x93\xde\x57\xc2\xb6\xc5\x86\x7f\xf9\x9c\x57\xb2\x4b\x6b\x69\x74\x06\x0b\xeb\x34\xba\x45\xec\xb6\x8b\x37\x0b\x5e\x70\xdb\x9e\x43\x22\x69\x21\x84\xff\x11\x4a\xfb\xd9\x09\x51\xee\xb6\x4f\xd1\x8f\x50\x04\xc6\xa3\x6e\x68\xe0\xb7\x35\x9d\x47\x6b\x09\x62\x8a\x08\xb9\x10\xdf\x03\x00\x54\x2c\xef\xdf\xb4\x03\x00\x00")
+
+func migrations30_history_filters_indexesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations30_history_filters_indexesSql,
+		"migrations/30_history_filters_indexes.sql",
+	)
+}
+
+func migrations30_history_filters_indexesSql() (*asset, error) {
+	bytes, err := migrations30_history_filters_indexesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/30_history_filters_indexes.sql", size: 948, mode: os.FileMode(0644), modTime: time.Unix(1792310957, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x66, 0x6b, 0x25, 0xfd, 0xca, 0x24, 0xf1, 0x46, 0x12, 0x3d, 0x81, 0xd0, 0xd8, 0x6c, 0x53, 0x17, 0x5b, 0x73, 0x51, 0xbe, 0x8b, 0x6a, 0xd4, 0xe, 0xcb, 0xc4, 0x6e, 0x8e, 0x3b, 0xce, 0xbd, 0x48}}
+	return a, nil
+}
+
+var _migrations31_transaction_participant_memosSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x92\xc1\x8e\xd3\x30\x10\x86\xef\x7e\x8a\xff\x08\xa2\xdd\x17\xe8\xa9\x6c\x22\x58\x29\xa4\x28\x9b\x0a\x6e\xd9\x89\x33\xad\x47\x9b\xda\x91\x3d\x6d\x09\x4f\x8f\x92\x65\x45\x04\x15\x88\xb3\xff\xf9\xe6\x9b\x5f\x5e\xaf\xf1\xee\x24\xc7\x48\xca\xd8\x0f\xc6\xac\xd7\xa8\x1d\xe3\xc4\xa7\x80\x70\x00\x5f\x38\x8e\xd0\x48\x3e\x91\x55\x09\x1e\x92\x60\xc3\x20\xdc\x41\x03\x44\x13\x06\x8a\x2a\x56\x06\xf2\x9a\x90\x02\xd4\x91\x42\x1d\x4f\xa8\xc5\x60\x9a\x70\xe4\x41\xd6\x86\xb3\x57\x58\xf2\x68\x19\x7d\x08\xcf\xdc\xe1\x3c\xa0\x1d\x5f\xb6\x5e\x45\x1d\x08\x49\xfc\xb1\x67\x88\xef\xf8\xdb\x84\x4a\x96\xfc\x1d\xaa\x70\x4d\x10\x7f\xe4\xa4\xdc\xa1\xe5\x43\x88\x0c\x75\x92\xf0\x72\xc4\x64\xe8\xe8\xc2\x20\x94\xfb\xa2\x98\x89\x8d\x8e\x03\xe3\xec\x55\xfa\xc9\x6b\x9c\x68\x14\x19\x07\xe9\xfb\x09\x32\xe2\xc9\x85\x28\xdf\x83\x47\xd7\xa2\x25\xfb\x3c\xbd\xac\xa7\xd1\xf4\x74\x67\xb6\x45\x9d\x57\xa8\xb7\xef\x8b\x1c\x4e\x92\x86\x38\x36\x8b\xbb\x9a\xe5\xfd\x06\x00\xb6\x59\x86\xfb\x5d\xb1\xff\x54\x2e\xd6\x5b\x47\x91\xac\x72\xc4\x85\xe2\x28\xfe\xb8\xba\x95\xfd\x33\xb6\x31\xe6\xbe\xca\xb7\x75\x8e\x87\x32\xcb\xbf\xc2\xe9\xd0\xb4\x63\xf3\xb3\xc5\x86\x7c\xd7\xcc\x83\xbb\xf2\x9f\x72\xd8\x3f\x3e\x94\x1f\xd0\x6a\x64\xc6\x9b\xd7\xf4\x2b\x49\xba\xd5\xac\xbb\xfa\x25\xbd\xba\x89\x94\xee\x2d\xbe\x7c\xcc\xab\x7c\x0e\xe2\xe1\x11\xe5\xae\x9e\xdb\xde\x18\xb3\xfc\x4e\x59\xb8\x7a\x63\xb2\x6a\xf7\xf9\xef\xee\x1b\xf3\xff\x1d\xcf\xd4\xdf\x4b\x5e\xdd\x7c\xda\x98\x1f\x03\x00\xc6\x56\x2e\x90\xe4\x02\x00\x00")
+
+func migrations31_transaction_participant_memosSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations31_transaction_participant_memosSql,
+		"migrations/31_transaction_participant_memos.sql",
+	)
+}
+
+func migrations31_transaction_participant_memosSql() (*asset, error) {
+	bytes, err := migrations31_transaction_participant_memosSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/31_transaction_participant_memos.sql", size: 740, mode: os.FileMode(0644), modTime: time.Unix(1792311522, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x1e, 0x10, 0xeb, 0x2a, 0xdc, 0x3f, 0x47, 0xcd, 0xa9, 0xb2, 0xcd, 0xd2, 0x6a, 0xb4, 0x6b, 0x56, 0x60, 0x34, 0x8a, 0xcb, 0x7f, 0x66, 0x76, 0xd6, 0xe3, 0xf4, 0xaf, 0xd0, 0x40, 0xe1, 0x8, 0x17}}
+	return a, nil
+}
+
 var _migrations3_use_sequence_in_history_accountsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x91\x4d\x6b\xb3\x40\x14\x85\xf7\xf3\x2b\xce\x2e\xca\xfb\x66\x91\x6d\x5c\x4d\xc6\x1b\x22\x8c\x63\x3b\x5e\xdb\x64\x25\xa2\x43\x3a\x90\x6a\xeb\xd8\xaf\x7f\x5f\x48\xd3\x0f\x08\x6d\xa1\xcb\x73\x78\xe0\x39\xdc\x3b\x9f\xe3\xdf\xad\xdf\x8f\xcd\xe4\x50\xdd\x09\x65\x49\x32\xa1\xa4\xcb\x8a\x8c\x22\xdc\xf8\x30\x0d\xe3\x4b\xdd\xb4\xed\xf0\xd0\x4f\xa1\xf6\x5d\x1d\xdc\xbd\x00\x80\x92\xa5\x65\x5c\x67\xbc\xc1\xe2\x58\x64\x46\x59\xca\xc9\x30\x56\xbb\x53\x65\x0a\xe4\x99\xb9\x92\xba\xa2\x8f\x2c\xb7\x9f\x59\x49\xb5\x21\x2c\x12\x51\x92\x26\xc5\x08\x6e\x7a\x6c\x0e\xd1\xec\x1b\xef\xec\x3f\xa2\x13\x99\xcb\x6d\xe4\xbb\x18\x6b\x5b\xe4\x67\x33\xe3\x38\x11\x52\x33\x59\xb0\x5c\x69\x42\x61\xf4\xee\x0c\xc2\x1b\xa1\x0a\x5d\xe5\x06\xbe\x43\x49\x8c\x94\xd6\xb2\xd2\x8c\xde\x3d\xff\xbc\x64\xb9\x1c\xdd\xbe\x3d\x34\x21\xc4\x89\x10\x5f\xcf\x98\x0e\x4f\xfd\x1f\xec\xa9\x2d\x2e\xde\xf5\x89\x38\xa6\xdf\xde\x90\x88\xd7\x00\x00\x00\xff\xff\x55\xe2\xdd\x2c\xbf\x01\x00\x00")
 
 func migrations3_use_sequence_in_history_accountsSqlBytes() ([]byte, error) {
@@ -762,8 +867,18 @@ var _bindata = map[string]func() (*asset, error){
 
 	"migrations/26_exp_history_ledgers.sql": migrations26_exp_history_ledgersSql,
 
+	"migrations/27_fee_bump_transactions.sql": migrations27_fee_bump_transactionsSql,
+
+	"migrations/28_webhooks.sql": migrations28_webhooksSql,
+
+	"migrations/29_account_state_history.sql": migrations29_account_state_historySql,
+
 	"migrations/2_index_participants_by_toid.sql": migrations2_index_participants_by_toidSql,
 
+	"migrations/30_history_filters_indexes.sql": migrations30_history_filters_indexesSql,
+
+	"migrations/31_transaction_participant_memos.sql": migrations31_transaction_participant_memosSql,
+
 	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
 
 	"migrations/4_add_protocol_version.sql": migrations4_add_protocol_versionSql,
@@ -841,7 +956,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		"24_accounts.sql":                              &bintree{migrations24_accountsSql, map[string]*bintree{}},
 		"25_expingest_rename_columns.sql":              &bintree{migrations25_expingest_rename_columnsSql, map[string]*bintree{}},
 		"26_exp_history_ledgers.sql":                   &bintree{migrations26_exp_history_ledgersSql, map[string]*bintree{}},
+		"27_fee_bump_transactions.sql":                 &bintree{migrations27_fee_bump_transactionsSql, map[string]*bintree{}},
+		"28_webhooks.sql":                              &bintree{migrations28_webhooksSql, map[string]*bintree{}},
+		"29_account_state_history.sql":                 &bintree{migrations29_account_state_historySql, map[string]*bintree{}},
 		"2_index_participants_by_toid.sql":             &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
+		"30_history_filters_indexes.sql":               &bintree{migrations30_history_filters_indexesSql, map[string]*bintree{}},
+		"31_transaction_participant_memos.sql":         &bintree{migrations31_transaction_participant_memosSql, map[string]*bintree{}},
 		"3_use_sequence_in_history_accounts.sql":       &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
 		"4_add_protocol_version.sql":                   &bintree{migrations4_add_protocol_versionSql, map[string]*bintree{}},
 		"5_create_trades_table.sql":                    &bintree{migrations5_create_trades_tableSql, map[string]*bintree{}},
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+ALTER TABLE history_transactions
+    ADD fee_account character varying(64),
+    ADD inner_transaction_hash character varying(64),
+    ADD new_max_fee bigint,
+    ADD inner_signatures character varying(96)[];
+
+CREATE INDEX by_inner_hash ON history_transactions USING btree (inner_transaction_hash) WHERE inner_transaction_hash IS NOT NULL;
+CREATE INDEX by_fee_account ON history_transactions USING btree (fee_account) WHERE fee_account IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX by_inner_hash;
+DROP INDEX by_fee_account;
+
+ALTER TABLE history_transactions
+    DROP COLUMN fee_account,
+    DROP COLUMN inner_transaction_hash,
+    DROP COLUMN new_max_fee,
+    DROP COLUMN inner_signatures;
@@ -0,0 +1,38 @@
+-- +migrate Up
+
+CREATE TABLE webhook_subscriptions (
+    id bigserial PRIMARY KEY,
+    url character varying(2048) NOT NULL,
+    secret character varying(128) NOT NULL,
+    -- empty arrays and a NULL asset match every operation
+    accounts character varying(56)[] NOT NULL,
+    asset character varying(69),
+    operation_types integer[] NOT NULL,
+    -- last_ledger is the last ledger checked for matching operations
+    last_ledger integer NOT NULL,
+    created_at timestamp without time zone NOT NULL
+);
+
+CREATE INDEX webhook_subscriptions_by_last_ledger ON webhook_subscriptions USING BTREE(last_ledger);
+
+CREATE TABLE webhook_deliveries (
+    id bigserial PRIMARY KEY,
+    subscription_id bigint NOT NULL REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    ledger_sequence integer NOT NULL,
+    operation_id bigint NOT NULL,
+    payload jsonb NOT NULL,
+    state character varying(16) NOT NULL,
+    attempts integer NOT NULL,
+    next_attempt_at timestamp without time zone NOT NULL,
+    last_error text,
+    created_at timestamp without time zone NOT NULL,
+    delivered_at timestamp without time zone
+);
+
+CREATE INDEX webhook_deliveries_pending ON webhook_deliveries USING BTREE(next_attempt_at) WHERE state = 'pending';
+CREATE INDEX webhook_deliveries_by_subscription ON webhook_deliveries USING BTREE(subscription_id, id);
+
+-- +migrate Down
+
+DROP TABLE webhook_deliveries cascade;
+DROP TABLE webhook_subscriptions cascade;
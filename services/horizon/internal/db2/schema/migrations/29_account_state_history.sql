@@ -0,0 +1,52 @@
+-- +migrate Up
+
+-- history_account_states and history_trust_line_states hold a row for every
+-- ledger an account or a trust line changed in. The state of an entry at a
+-- ledger is its latest row at or before the ledger, a removed entry has a row
+-- with deleted set.
+CREATE TABLE history_account_states (
+    account_id character varying(56) NOT NULL,
+    ledger_sequence integer NOT NULL,
+    deleted boolean NOT NULL DEFAULT false,
+    balance bigint NOT NULL DEFAULT 0,
+    buying_liabilities bigint NOT NULL DEFAULT 0,
+    selling_liabilities bigint NOT NULL DEFAULT 0,
+    sequence_number bigint NOT NULL DEFAULT 0,
+    num_subentries int NOT NULL DEFAULT 0,
+    inflation_destination character varying(56) NOT NULL DEFAULT '',
+    flags int NOT NULL DEFAULT 0,
+    home_domain character varying(32) NOT NULL DEFAULT '',
+    master_weight smallint NOT NULL DEFAULT 0,
+    threshold_low smallint NOT NULL DEFAULT 0,
+    threshold_medium smallint NOT NULL DEFAULT 0,
+    threshold_high smallint NOT NULL DEFAULT 0,
+    -- signers maps the signers of the account to their weights, like
+    -- accounts_signers does.
+    signers jsonb NOT NULL DEFAULT '{}',
+    last_modified_ledger integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (account_id, ledger_sequence)
+);
+
+CREATE TABLE history_trust_line_states (
+    account_id character varying(56) NOT NULL,
+    asset_type int NOT NULL,
+    asset_issuer character varying(56) NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    ledger_sequence integer NOT NULL,
+    deleted boolean NOT NULL DEFAULT false,
+    balance bigint NOT NULL DEFAULT 0,
+    trust_line_limit bigint NOT NULL DEFAULT 0,
+    buying_liabilities bigint NOT NULL DEFAULT 0,
+    selling_liabilities bigint NOT NULL DEFAULT 0,
+    flags int NOT NULL DEFAULT 0,
+    last_modified_ledger integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (account_id, asset_type, asset_issuer, asset_code, ledger_sequence)
+);
+
+CREATE INDEX history_account_states_by_ledger ON history_account_states USING BTREE(ledger_sequence);
+CREATE INDEX history_trust_line_states_by_ledger ON history_trust_line_states USING BTREE(ledger_sequence);
+
+-- +migrate Down
+
+DROP TABLE history_trust_line_states cascade;
+DROP TABLE history_account_states cascade;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+CREATE INDEX hop_by_type_and_id ON history_operations USING btree (type, id);
+CREATE INDEX hop_by_asset ON history_operations USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), id);
+CREATE INDEX hop_by_source_asset ON history_operations USING btree (((details ->> 'source_asset_type'::text)), ((details ->> 'source_asset_code'::text)), ((details ->> 'source_asset_issuer'::text)), id);
+CREATE INDEX heff_by_type_and_operation ON history_effects USING btree (type, history_operation_id, "order");
+CREATE INDEX heff_by_asset ON history_effects USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), history_operation_id);
+
+-- +migrate Down
+
+DROP INDEX hop_by_type_and_id;
+DROP INDEX hop_by_asset;
+DROP INDEX hop_by_source_asset;
+DROP INDEX heff_by_type_and_operation;
+DROP INDEX heff_by_asset;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+-- The memo of every transaction is copied to its participants so that the
+-- transactions of an account can be looked up by memo with a single index
+-- scan. Rows ingested before this migration have a NULL memo_type until they
+-- are filled by `horizon db backfill-memos`.
+ALTER TABLE history_transaction_participants
+    ADD COLUMN memo_type character varying,
+    ADD COLUMN memo character varying;
+
+CREATE INDEX htp_by_account_and_memo ON history_transaction_participants USING btree (history_account_id, memo, memo_type, history_transaction_id) WHERE memo IS NOT NULL;
+
+-- +migrate Down
+
+DROP INDEX htp_by_account_and_memo;
+
+ALTER TABLE history_transaction_participants
+    DROP COLUMN memo_type,
+    DROP COLUMN memo;
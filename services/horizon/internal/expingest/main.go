@@ -42,7 +42,8 @@ const (
 	//      trustlines.
 	// - 10: Fixes a bug in meta processing (fees are now processed before
 	//      everything else).
-	CurrentVersion = 10
+	// - 11: Added account and trust line state history.
+	CurrentVersion = 11
 )
 
 var log = logpkg.DefaultLogger.WithField("service", "expingest")
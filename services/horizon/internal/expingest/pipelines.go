@@ -86,6 +86,14 @@ func trustLinesDBStateNode(q *history.Q) *supportPipeline.PipelineNode {
 		)
 }
 
+func stateHistoryDBStateNode(q *history.Q) *supportPipeline.PipelineNode {
+	return pipeline.StateNode(&horizonProcessors.DatabaseProcessor{
+		StateHistoryQ: q,
+		Action:        horizonProcessors.StateHistory,
+		IngestVersion: CurrentVersion,
+	})
+}
+
 func buildStatePipeline(historyQ *history.Q, graph *orderbook.OrderBookGraph) *pipeline.StatePipeline {
 	statePipeline := &pipeline.StatePipeline{}
 
@@ -97,6 +105,7 @@ func buildStatePipeline(historyQ *history.Q, graph *orderbook.OrderBookGraph) *p
 				orderBookDBStateNode(historyQ),
 				orderBookGraphStateNode(graph),
 				trustLinesDBStateNode(historyQ),
+				stateHistoryDBStateNode(historyQ),
 			),
 	)
 
@@ -125,6 +134,7 @@ func buildLedgerPipeline(historyQ *history.Q, graph *orderbook.OrderBookGraph) *
 							SignersQ:      historyQ,
 							TrustLinesQ:   historyQ,
 							AssetStatsQ:   historyQ,
+							StateHistoryQ: historyQ,
 							LedgersQ:      historyQ,
 							Action:        horizonProcessors.All,
 							IngestVersion: CurrentVersion,
@@ -192,6 +192,7 @@ func (s *AccountsDataProcessorTestSuiteLedger) TestNewAccount() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -217,6 +218,7 @@ func (s *AccountsDataProcessorTestSuiteLedger) TestNewAccount() {
 	}
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -270,6 +272,7 @@ func (s *AccountsDataProcessorTestSuiteLedger) TestNewAccount() {
 func (s *AccountsDataProcessorTestSuiteLedger) TestRemoveAccount() {
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -337,6 +340,7 @@ func (s *AccountsDataProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
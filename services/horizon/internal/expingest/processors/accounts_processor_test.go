@@ -190,6 +190,7 @@ func (s *AccountsProcessorTestSuiteLedger) TestNewAccount() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -279,6 +280,7 @@ func (s *AccountsProcessorTestSuiteLedger) TestRemoveAccount() {
 	// add offer
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -340,6 +342,7 @@ func (s *AccountsProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -414,6 +417,7 @@ func (s *AccountsProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 func (s *AccountsProcessorTestSuiteLedger) TestFeeProcessedBeforeEverythingElse() {
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -448,6 +452,7 @@ func (s *AccountsProcessorTestSuiteLedger) TestFeeProcessedBeforeEverythingElse(
 
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			FeeChanges: []xdr.LedgerEntryChange{
 				xdr.LedgerEntryChange{
 					Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
@@ -215,6 +215,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestNewAccount() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -262,6 +263,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestNewSigner() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -356,6 +358,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestSignerRemoved() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -551,6 +554,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestRemoveAccount() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -606,6 +610,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestNewAccountNoRowsAffected()
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -658,6 +663,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestRemoveAccountNoRowsAffected
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -722,6 +728,7 @@ func (s *AccountsSignerProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 	s.mockLedgerReader.
 		On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -867,3 +874,10 @@ func createTransactionMeta(opMeta []xdr.OperationMeta) xdr.TransactionMeta {
 		},
 	}
 }
+
+// emptyTransactionEnvelope is a valid envelope with no operations so that
+// ProcessLedger can count operations of transactions built in these tests.
+var emptyTransactionEnvelope = xdr.TransactionEnvelope{
+	Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+	V1:   &xdr.TransactionV1Envelope{},
+}
@@ -31,6 +31,7 @@ func (p *DatabaseProcessor) ProcessState(ctx context.Context, store *pipeline.St
 		accountSignerBatch history.AccountSignersBatchInsertBuilder
 		offersBatch        history.OffersBatchInsertBuilder
 		trustLinesBatch    history.TrustLinesBatchInsertBuilder
+		stateHistoryBatch  history.StateHistoryBatchInsertBuilder
 	)
 	assetStats := AssetStatSet{}
 
@@ -45,6 +46,8 @@ func (p *DatabaseProcessor) ProcessState(ctx context.Context, store *pipeline.St
 		offersBatch = p.OffersQ.NewOffersBatchInsertBuilder(maxBatchSize)
 	case TrustLines:
 		trustLinesBatch = p.TrustLinesQ.NewTrustLinesBatchInsertBuilder(maxBatchSize)
+	case StateHistory:
+		stateHistoryBatch = p.StateHistoryQ.NewStateHistoryBatchInsertBuilder(maxBatchSize)
 	default:
 		return errors.Errorf("Invalid action type (%s)", p.Action)
 	}
@@ -140,6 +143,31 @@ func (p *DatabaseProcessor) ProcessState(ctx context.Context, store *pipeline.St
 			if err != nil {
 				return errors.Wrap(err, "Error adding row to trustLinesBatch")
 			}
+		case StateHistory:
+			// The state at the checkpoint is where the state history starts
+			state := entryChange.MustState()
+			checkpoint := xdr.Uint32(r.GetSequence())
+
+			switch entryChange.EntryType() {
+			case xdr.LedgerEntryTypeAccount:
+				err = stateHistoryBatch.AddAccount(
+					state.Data.MustAccount(),
+					state.LastModifiedLedgerSeq,
+					checkpoint,
+				)
+			case xdr.LedgerEntryTypeTrustline:
+				err = stateHistoryBatch.AddTrustLine(
+					state.Data.MustTrustLine(),
+					state.LastModifiedLedgerSeq,
+					checkpoint,
+				)
+			default:
+				// We're interested in accounts and trust lines only
+				continue
+			}
+			if err != nil {
+				return errors.Wrap(err, "Error adding row to stateHistoryBatch")
+			}
 		default:
 			return errors.New("Unknown action")
 		}
@@ -168,6 +196,8 @@ func (p *DatabaseProcessor) ProcessState(ctx context.Context, store *pipeline.St
 		if err == nil {
 			err = p.AssetStatsQ.InsertAssetStats(assetStats.All(), maxBatchSize)
 		}
+	case StateHistory:
+		err = stateHistoryBatch.Exec()
 	default:
 		return errors.Errorf("Invalid action type (%s)", p.Action)
 	}
@@ -194,19 +224,27 @@ func (p *DatabaseProcessor) ProcessLedger(ctx context.Context, store *pipeline.S
 	ledgerCache := io.NewLedgerEntryChangeCache()
 	p.AssetStatSet = AssetStatSet{}
 
+	var stateHistoryBatch history.StateHistoryBatchInsertBuilder
+	if p.Action == All || p.Action == StateHistory {
+		stateHistoryBatch = p.StateHistoryQ.NewStateHistoryBatchInsertBuilder(maxBatchSize)
+	}
+
 	actionHandlers := map[DatabaseProcessorActionType]func(change io.Change) error{
 		Accounts:          p.processLedgerAccounts,
 		AccountsForSigner: p.processLedgerAccountSigners,
 		Data:              p.processLedgerAccountData,
 		Offers:            p.processLedgerOffers,
 		TrustLines:        p.processLedgerTrustLines,
+		StateHistory: func(change io.Change) error {
+			return p.processLedgerStateHistory(stateHistoryBatch, change, xdr.Uint32(r.GetSequence()))
+		},
 	}
 
 	actions := []DatabaseProcessorActionType{}
 
 	if p.Action == All {
 		actions = []DatabaseProcessorActionType{
-			Accounts, AccountsForSigner, Data, Offers, TrustLines,
+			Accounts, AccountsForSigner, Data, Offers, TrustLines, StateHistory,
 		}
 	} else if p.Action != Ledgers {
 		actions = append(actions, p.Action)
@@ -226,9 +264,9 @@ func (p *DatabaseProcessor) ProcessLedger(ctx context.Context, store *pipeline.S
 			}
 		}
 
-		if transaction.Result.Result.Result.Code == xdr.TransactionResultCodeTxSuccess {
+		if transaction.Result.Result.Successful() {
 			successTxCount++
-			opCount += len(transaction.Envelope.Tx.Operations)
+			opCount += len(transaction.Envelope.Operations())
 		} else {
 			failedTxCount++
 		}
@@ -325,6 +363,12 @@ func (p *DatabaseProcessor) ProcessLedger(ctx context.Context, store *pipeline.S
 		}
 	}
 
+	if stateHistoryBatch != nil {
+		if err := stateHistoryBatch.Exec(); err != nil {
+			return errors.Wrap(err, "Error batch inserting state history")
+		}
+	}
+
 	// Asset stats
 	if p.Action == All || p.Action == TrustLines {
 		assetStatsDeltas := p.AssetStatSet.All()
@@ -817,6 +861,37 @@ func (p *DatabaseProcessor) processLedgerTrustLines(change io.Change) error {
 	return nil
 }
 
+// processLedgerStateHistory adds the state an account or a trust line was left
+// in by the ledger to the state history.
+func (p *DatabaseProcessor) processLedgerStateHistory(
+	batch history.StateHistoryBatchInsertBuilder,
+	change io.Change,
+	ledger xdr.Uint32,
+) error {
+	switch change.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if change.Post == nil {
+			// Removed
+			return batch.RemoveAccount(change.Pre.Data.MustAccount().AccountId, ledger)
+		}
+		return batch.AddAccount(change.Post.Data.MustAccount(), change.Post.LastModifiedLedgerSeq, ledger)
+	case xdr.LedgerEntryTypeTrustline:
+		if change.Post == nil {
+			// Removed
+			var ledgerKey xdr.LedgerKey
+			trustLine := change.Pre.Data.MustTrustLine()
+			err := ledgerKey.SetTrustline(trustLine.AccountId, trustLine.Asset)
+			if err != nil {
+				return errors.Wrap(err, "Error creating ledger key")
+			}
+			return batch.RemoveTrustLine(*ledgerKey.TrustLine, ledger)
+		}
+		return batch.AddTrustLine(change.Post.Data.MustTrustLine(), change.Post.LastModifiedLedgerSeq, ledger)
+	default:
+		return nil
+	}
+}
+
 func (p *DatabaseProcessor) Name() string {
 	return fmt.Sprintf("DatabaseProcessor (%s)", p.Action)
 }
@@ -52,8 +52,11 @@ func createTransaction(successful bool, numOps int) io.LedgerTransaction {
 			},
 		},
 		Envelope: xdr.TransactionEnvelope{
-			Tx: xdr.Transaction{
-				Operations: operations,
+			Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+			V1: &xdr.TransactionV1Envelope{
+				Tx: xdr.Transaction{
+					Operations: operations,
+				},
 			},
 		},
 	}
@@ -19,6 +19,7 @@ const (
 	Data              DatabaseProcessorActionType = "Data"
 	Offers            DatabaseProcessorActionType = "Offers"
 	TrustLines        DatabaseProcessorActionType = "TrustLines"
+	StateHistory      DatabaseProcessorActionType = "StateHistory"
 	Ledgers           DatabaseProcessorActionType = "Ledgers"
 	All               DatabaseProcessorActionType = "All"
 )
@@ -35,6 +36,7 @@ type DatabaseProcessor struct {
 	OffersQ       history.QOffers
 	TrustLinesQ   history.QTrustLines
 	AssetStatsQ   history.QAssetStats
+	StateHistoryQ history.QStateHistory
 	LedgersQ      history.QExpLedgers
 	Action        DatabaseProcessorActionType
 	IngestVersion int
@@ -139,6 +139,7 @@ func (s *OffersProcessorTestSuiteLedger) TearDownTest() {
 
 func (s *OffersProcessorTestSuiteLedger) TestInsertOffer() {
 	accountTransaction := io.LedgerTransaction{
+		Envelope: emptyTransactionEnvelope,
 		Meta: createTransactionMeta([]xdr.OperationMeta{
 			xdr.OperationMeta{
 				Changes: []xdr.LedgerEntryChange{
@@ -172,6 +173,7 @@ func (s *OffersProcessorTestSuiteLedger) TestInsertOffer() {
 	lastModifiedLedgerSeq := xdr.Uint32(1234)
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -198,6 +200,7 @@ func (s *OffersProcessorTestSuiteLedger) TestInsertOffer() {
 	}
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -264,6 +267,7 @@ func (s *OffersProcessorTestSuiteLedger) TestUpdateOfferNoRowsAffected() {
 	}
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -317,6 +321,7 @@ func (s *OffersProcessorTestSuiteLedger) TestRemoveOffer() {
 	// add offer
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -380,6 +385,7 @@ func (s *OffersProcessorTestSuiteLedger) TestProcessUpgradeChange() {
 	lastModifiedLedgerSeq := xdr.Uint32(1234)
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -457,6 +463,7 @@ func (s *OffersProcessorTestSuiteLedger) TestRemoveOfferNoRowsAffected() {
 	// add offer
 	s.mockLedgerReader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -135,6 +135,7 @@ func TestProcessOrderBookLedger(t *testing.T) {
 	// should be ignored because it's not an offer type
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -189,6 +190,7 @@ func TestProcessOrderBookLedger(t *testing.T) {
 	// add offer
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -213,6 +215,7 @@ func TestProcessOrderBookLedger(t *testing.T) {
 	// add another 2 offers
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -248,6 +251,7 @@ func TestProcessOrderBookLedger(t *testing.T) {
 	// update an offer
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -284,6 +288,7 @@ func TestProcessOrderBookLedger(t *testing.T) {
 
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
@@ -358,6 +363,7 @@ func TestProcessOrderBookLedgerProcessUpgradeChanges(t *testing.T) {
 	// add offer
 	reader.On("Read").
 		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
 			Meta: createTransactionMeta([]xdr.OperationMeta{
 				xdr.OperationMeta{
 					Changes: []xdr.LedgerEntryChange{
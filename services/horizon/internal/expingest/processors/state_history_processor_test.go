@@ -0,0 +1,255 @@
+package processors
+
+import (
+	"context"
+	stdio "io"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/exp/ingest/io"
+	supportPipeline "github.com/paydex-core/paydex-go/exp/support/pipeline"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestStateHistoryProcessorTestSuiteState(t *testing.T) {
+	suite.Run(t, new(StateHistoryProcessorTestSuiteState))
+}
+
+type StateHistoryProcessorTestSuiteState struct {
+	suite.Suite
+	processor              *DatabaseProcessor
+	mockQ                  *history.MockQStateHistory
+	mockBatchInsertBuilder *history.MockStateHistoryBatchInsertBuilder
+	mockStateReader        *io.MockStateReader
+	mockStateWriter        *io.MockStateWriter
+}
+
+func (s *StateHistoryProcessorTestSuiteState) SetupTest() {
+	s.mockQ = &history.MockQStateHistory{}
+	s.mockBatchInsertBuilder = &history.MockStateHistoryBatchInsertBuilder{}
+	s.mockStateReader = &io.MockStateReader{}
+	s.mockStateWriter = &io.MockStateWriter{}
+
+	s.processor = &DatabaseProcessor{
+		Action:        StateHistory,
+		StateHistoryQ: s.mockQ,
+	}
+
+	// Reader and Writer should be always closed and once
+	s.mockStateReader.On("Close").Return(nil).Once()
+	s.mockStateWriter.On("Close").Return(nil).Once()
+
+	s.mockQ.
+		On("NewStateHistoryBatchInsertBuilder", maxBatchSize).
+		Return(s.mockBatchInsertBuilder).Once()
+}
+
+func (s *StateHistoryProcessorTestSuiteState) TearDownTest() {
+	s.mockQ.AssertExpectations(s.T())
+	s.mockBatchInsertBuilder.AssertExpectations(s.T())
+	s.mockStateReader.AssertExpectations(s.T())
+	s.mockStateWriter.AssertExpectations(s.T())
+}
+
+func (s *StateHistoryProcessorTestSuiteState) TestAddsCheckpointState() {
+	account := xdr.AccountEntry{
+		AccountId:  xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+		Thresholds: [4]byte{1, 1, 1, 1},
+	}
+	trustLine := xdr.TrustLineEntry{
+		AccountId: xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+		Asset:     xdr.MustNewCreditAsset("EUR", trustLineIssuer.Address()),
+	}
+	offer := xdr.OfferEntry{
+		SellerId: xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+		OfferId:  xdr.Int64(1),
+	}
+
+	for _, data := range []xdr.LedgerEntryData{
+		{Type: xdr.LedgerEntryTypeAccount, Account: &account},
+		{Type: xdr.LedgerEntryTypeTrustline, TrustLine: &trustLine},
+		{Type: xdr.LedgerEntryTypeOffer, Offer: &offer},
+	} {
+		s.mockStateReader.
+			On("Read").Return(
+			xdr.LedgerEntryChange{
+				Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+				State: &xdr.LedgerEntry{
+					Data:                  data,
+					LastModifiedLedgerSeq: xdr.Uint32(123),
+				},
+			},
+			nil,
+		).Once()
+	}
+
+	s.mockStateReader.
+		On("Read").
+		Return(xdr.LedgerEntryChange{}, stdio.EOF).Once()
+	s.mockStateReader.On("GetSequence").Return(uint32(127))
+
+	s.mockBatchInsertBuilder.
+		On("AddAccount", account, xdr.Uint32(123), xdr.Uint32(127)).Return(nil).Once()
+	s.mockBatchInsertBuilder.
+		On("AddTrustLine", trustLine, xdr.Uint32(123), xdr.Uint32(127)).Return(nil).Once()
+	s.mockBatchInsertBuilder.On("Exec").Return(nil).Once()
+
+	err := s.processor.ProcessState(
+		context.Background(),
+		&supportPipeline.Store{},
+		s.mockStateReader,
+		s.mockStateWriter,
+	)
+
+	s.Assert().NoError(err)
+}
+
+func TestStateHistoryProcessorTestSuiteLedger(t *testing.T) {
+	suite.Run(t, new(StateHistoryProcessorTestSuiteLedger))
+}
+
+type StateHistoryProcessorTestSuiteLedger struct {
+	suite.Suite
+	processor              *DatabaseProcessor
+	mockQ                  *history.MockQStateHistory
+	mockBatchInsertBuilder *history.MockStateHistoryBatchInsertBuilder
+	mockLedgerReader       *io.MockLedgerReader
+	mockLedgerWriter       *io.MockLedgerWriter
+}
+
+func (s *StateHistoryProcessorTestSuiteLedger) SetupTest() {
+	s.mockQ = &history.MockQStateHistory{}
+	s.mockBatchInsertBuilder = &history.MockStateHistoryBatchInsertBuilder{}
+	s.mockLedgerReader = &io.MockLedgerReader{}
+	s.mockLedgerWriter = &io.MockLedgerWriter{}
+
+	s.processor = &DatabaseProcessor{
+		Action:        StateHistory,
+		StateHistoryQ: s.mockQ,
+	}
+
+	// Reader and Writer should be always closed and once
+	s.mockLedgerReader.
+		On("ReadUpgradeChange").
+		Return(io.Change{}, stdio.EOF).Once()
+
+	s.mockLedgerReader.
+		On("Close").
+		Return(nil).Once()
+
+	s.mockLedgerWriter.
+		On("Close").
+		Return(nil).Once()
+
+	s.mockLedgerReader.On("GetSequence").Return(uint32(20))
+
+	s.mockQ.
+		On("NewStateHistoryBatchInsertBuilder", maxBatchSize).
+		Return(s.mockBatchInsertBuilder).Once()
+}
+
+func (s *StateHistoryProcessorTestSuiteLedger) TearDownTest() {
+	s.mockQ.AssertExpectations(s.T())
+	s.mockBatchInsertBuilder.AssertExpectations(s.T())
+	s.mockLedgerReader.AssertExpectations(s.T())
+	s.mockLedgerWriter.AssertExpectations(s.T())
+}
+
+func (s *StateHistoryProcessorTestSuiteLedger) TestAddsLedgerChanges() {
+	account := xdr.AccountEntry{
+		AccountId:  xdr.MustAddress("GC3C4AKRBQLHOJ45U4XG35ESVWRDECWO5XLDGYADO6DPR3L7KIDVUMML"),
+		Thresholds: [4]byte{1, 1, 1, 1},
+	}
+	updatedAccount := account
+	updatedAccount.Balance = 100
+	trustLine := xdr.TrustLineEntry{
+		AccountId: xdr.MustAddress("GAOQJGUAB7NI7K7I62ORBXMN3J4SSWQUQ7FOEPSDJ322W2HMCNWPHXFB"),
+		Asset:     xdr.MustNewCreditAsset("EUR", trustLineIssuer.Address()),
+	}
+
+	s.mockLedgerReader.On("Read").
+		Return(io.LedgerTransaction{
+			Envelope: emptyTransactionEnvelope,
+			Meta: createTransactionMeta([]xdr.OperationMeta{
+				xdr.OperationMeta{
+					Changes: []xdr.LedgerEntryChange{
+						// Created and updated in the same ledger
+						xdr.LedgerEntryChange{
+							Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+							Created: &xdr.LedgerEntry{
+								LastModifiedLedgerSeq: xdr.Uint32(20),
+								Data: xdr.LedgerEntryData{
+									Type:    xdr.LedgerEntryTypeAccount,
+									Account: &account,
+								},
+							},
+						},
+						xdr.LedgerEntryChange{
+							Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+							State: &xdr.LedgerEntry{
+								LastModifiedLedgerSeq: xdr.Uint32(20),
+								Data: xdr.LedgerEntryData{
+									Type:    xdr.LedgerEntryTypeAccount,
+									Account: &account,
+								},
+							},
+						},
+						xdr.LedgerEntryChange{
+							Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+							Updated: &xdr.LedgerEntry{
+								LastModifiedLedgerSeq: xdr.Uint32(20),
+								Data: xdr.LedgerEntryData{
+									Type:    xdr.LedgerEntryTypeAccount,
+									Account: &updatedAccount,
+								},
+							},
+						},
+						// Removed
+						xdr.LedgerEntryChange{
+							Type: xdr.LedgerEntryChangeTypeLedgerEntryState,
+							State: &xdr.LedgerEntry{
+								LastModifiedLedgerSeq: xdr.Uint32(10),
+								Data: xdr.LedgerEntryData{
+									Type:      xdr.LedgerEntryTypeTrustline,
+									TrustLine: &trustLine,
+								},
+							},
+						},
+						xdr.LedgerEntryChange{
+							Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved,
+							Removed: &xdr.LedgerKey{
+								Type: xdr.LedgerEntryTypeTrustline,
+								TrustLine: &xdr.LedgerKeyTrustLine{
+									AccountId: trustLine.AccountId,
+									Asset:     trustLine.Asset,
+								},
+							},
+						},
+					},
+				},
+			}),
+		}, nil).Once()
+
+	s.mockLedgerReader.
+		On("Read").
+		Return(io.LedgerTransaction{}, stdio.EOF).Once()
+
+	s.mockBatchInsertBuilder.
+		On("AddAccount", updatedAccount, xdr.Uint32(20), xdr.Uint32(20)).Return(nil).Once()
+	s.mockBatchInsertBuilder.
+		On("RemoveTrustLine", xdr.LedgerKeyTrustLine{
+			AccountId: trustLine.AccountId,
+			Asset:     trustLine.Asset,
+		}, xdr.Uint32(20)).Return(nil).Once()
+	s.mockBatchInsertBuilder.On("Exec").Return(nil).Once()
+
+	err := s.processor.ProcessLedger(
+		context.Background(),
+		&supportPipeline.Store{},
+		s.mockLedgerReader,
+		s.mockLedgerWriter,
+	)
+
+	s.Assert().NoError(err)
+}
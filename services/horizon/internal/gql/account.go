@@ -0,0 +1,159 @@
+package gql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// account represents a Paydex account, with its balances, signers and data
+// entries.
+type account struct {
+	ID                   string
+	Sequence             string
+	SubentryCount        int32
+	InflationDestination string
+	HomeDomain           string
+	LastModifiedLedger   int32
+	Thresholds           thresholds
+	Flags                horizon.AccountFlags
+	Balances             []balance
+	Signers              []horizon.Signer
+	Data                 []dataEntry
+
+	r *resolver
+}
+
+// thresholds represents the thresholds of an account, with some type
+// adaptations to match the GraphQL type system
+type thresholds struct {
+	LowThreshold  int32
+	MedThreshold  int32
+	HighThreshold int32
+}
+
+// balance represents a balance of an account, native or held in a
+// trustline
+type balance struct {
+	Asset              asset
+	Balance            string
+	Limit              string
+	BuyingLiabilities  string
+	SellingLiabilities string
+	IsAuthorized       *bool
+	LastModifiedLedger int32
+}
+
+// dataEntry represents a data entry of an account
+type dataEntry struct {
+	Key   string
+	Value string
+}
+
+func (r *resolver) newAccount(res *horizon.Account) *account {
+	a := &account{
+		ID:                   res.ID,
+		Sequence:             res.Sequence,
+		SubentryCount:        res.SubentryCount,
+		InflationDestination: res.InflationDestination,
+		HomeDomain:           res.HomeDomain,
+		LastModifiedLedger:   int32(res.LastModifiedLedger),
+		Thresholds: thresholds{
+			LowThreshold:  int32(res.Thresholds.LowThreshold),
+			MedThreshold:  int32(res.Thresholds.MedThreshold),
+			HighThreshold: int32(res.Thresholds.HighThreshold),
+		},
+		Flags:   res.Flags,
+		Signers: res.Signers,
+		r:       r,
+	}
+	for _, b := range res.Balances {
+		a.Balances = append(a.Balances, balance{
+			Asset:              asset(b.Asset),
+			Balance:            b.Balance,
+			Limit:              b.Limit,
+			BuyingLiabilities:  b.BuyingLiabilities,
+			SellingLiabilities: b.SellingLiabilities,
+			IsAuthorized:       b.IsAuthorized,
+			LastModifiedLedger: int32(b.LastModifiedLedger),
+		})
+	}
+	for key, value := range res.Data {
+		a.Data = append(a.Data, dataEntry{Key: key, Value: value})
+	}
+	sort.Slice(a.Data, func(i, j int) bool {
+		return a.Data[i].Key < a.Data[j].Key
+	})
+	return a
+}
+
+// Account resolves the account query.
+func (r *resolver) Account(ctx context.Context, args struct{ ID string }) (*account, error) {
+	cq, err := coreQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the history is only needed to compare the accounts of both ingestion
+	// systems
+	var hq *history.Q
+	if r.config.EnableExperimentalIngestion {
+		if hq, err = historyQ(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := actions.AccountInfo(ctx, cq, hq, args.ID, r.config.EnableExperimentalIngestion)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading account")
+	}
+	return r.newAccount(res), nil
+}
+
+// Transactions resolves the transactions of the account.
+func (a *account) Transactions(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*transactionConnection, error) {
+	return a.r.loadTransactions(ctx, a.ID, 0, args.IncludeFailed, args.pageArgs)
+}
+
+// Operations resolves the operations of the account.
+func (a *account) Operations(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	filter := operationFilter{account: a.ID, includeFailed: args.IncludeFailed}
+	return a.r.loadOperations(ctx, filter, args.pageArgs)
+}
+
+// Payments resolves the payments of the account.
+func (a *account) Payments(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	filter := operationFilter{account: a.ID, includeFailed: args.IncludeFailed, onlyPayments: true}
+	return a.r.loadOperations(ctx, filter, args.pageArgs)
+}
+
+// Effects resolves the effects of the account.
+func (a *account) Effects(ctx context.Context, args pageArgs) (*effectConnection, error) {
+	return a.r.loadEffects(ctx, effectFilter{account: a.ID}, args)
+}
+
+// Offers resolves the offers of the account.
+func (a *account) Offers(ctx context.Context, args pageArgs) (*offerConnection, error) {
+	return a.r.loadOffers(ctx, offerFilter{seller: a.ID}, args)
+}
+
+// Trades resolves the trades of the account.
+func (a *account) Trades(ctx context.Context, args pageArgs) (*tradeConnection, error) {
+	return a.r.loadTrades(ctx, tradeFilter{account: a.ID}, args)
+}
@@ -0,0 +1,29 @@
+package gql
+
+import (
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// asset represents a Paydex asset. Code and Issuer are empty for the native
+// asset.
+type asset horizon.Asset
+
+// assetInput represents the AssetInput type of the schema
+type assetInput struct {
+	Type   string
+	Code   *string
+	Issuer *string
+}
+
+// xdrAsset returns the asset described by the input.
+func (in *assetInput) xdrAsset() (xdr.Asset, error) {
+	var code, issuer string
+	if in.Code != nil {
+		code = *in.Code
+	}
+	if in.Issuer != nil {
+		issuer = *in.Issuer
+	}
+	return xdr.BuildAsset(in.Type, issuer, code)
+}
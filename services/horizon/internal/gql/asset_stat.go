@@ -0,0 +1,125 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/assets"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// assetStat represents the statistics of an asset
+type assetStat struct {
+	Asset       asset
+	PagingToken string
+	Amount      string
+	NumAccounts int32
+	Flags       horizon.AccountFlags
+	Toml        string
+}
+
+type assetStatConnection struct {
+	Edges    []assetStatEdge
+	PageInfo pageInfo
+}
+
+type assetStatEdge struct {
+	Cursor string
+	Node   *assetStat
+}
+
+// Assets resolves the assets query. The stats are loaded from the state
+// ingested by the experimental ingestion system when it is enabled, or else
+// from the asset stats of the legacy ingestion system.
+func (r *resolver) Assets(ctx context.Context, args struct {
+	pageArgs
+	Code   *string
+	Issuer *string
+}) (*assetStatConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+	var code, issuer string
+	if args.Code != nil {
+		code = *args.Code
+	}
+	if args.Issuer != nil {
+		issuer = *args.Issuer
+	}
+
+	var stats []horizon.AssetStat
+	switch {
+	case r.config.EnableExperimentalIngestion:
+		rows, err := hq.GetAssetStats(code, issuer, pq)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading asset stats")
+		}
+		var issuers []string
+		for _, row := range rows {
+			issuers = append(issuers, row.AssetIssuer)
+		}
+		accounts, err := hq.GetAccountsByIDs(issuers)
+		if err != nil {
+			return nil, errors.Wrap(err, "loading issuers")
+		}
+		accountsByID := map[string]history.AccountEntry{}
+		for _, entry := range accounts {
+			accountsByID[entry.AccountID] = entry
+		}
+		for _, row := range rows {
+			var res horizon.AssetStat
+			err = resourceadapter.PopulateExpAssetStat(ctx, &res, row, accountsByID[row.AssetIssuer])
+			if err != nil {
+				return nil, err
+			}
+			stats = append(stats, res)
+		}
+	case r.config.EnableAssetStats:
+		sql, err := assets.AssetStatsQ{
+			AssetCode:   &code,
+			AssetIssuer: &issuer,
+			PageQuery:   &pq,
+		}.GetSQL()
+		if err != nil {
+			return nil, err
+		}
+		var rows []assets.AssetStatsR
+		if err = hq.Select(&rows, sql); err != nil {
+			return nil, errors.Wrap(err, "loading asset stats")
+		}
+		for _, row := range rows {
+			var res horizon.AssetStat
+			if err = resourceadapter.PopulateAssetStat(ctx, &res, row); err != nil {
+				return nil, err
+			}
+			stats = append(stats, res)
+		}
+	default:
+		return nil, errors.New("asset stats are disabled")
+	}
+
+	n, hasNextPage := args.pageLength(len(stats))
+	connection := &assetStatConnection{}
+	var cursors []string
+	for _, res := range stats[:n] {
+		node := &assetStat{
+			Asset:       asset(res.Asset),
+			PagingToken: res.PT,
+			Amount:      res.Amount,
+			NumAccounts: res.NumAccounts,
+			Flags:       res.Flags,
+			Toml:        res.Links.Toml.Href,
+		}
+		connection.Edges = append(connection.Edges, assetStatEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
@@ -0,0 +1,646 @@
+package gql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+)
+
+// connectionDefaults matches the fields of the schema accepting a <first>
+// argument, with its default value.
+var connectionDefaults = regexp.MustCompile(`(\w+)\s*\(([^)]*?)\bfirst\s*:\s*Int\s*=\s*(\d+)`)
+
+// costAnalyzer estimates the cost of GraphQL queries before they are
+// executed, so that queries loading too many records can be rejected.
+//
+// Every field costs 1, plus the cost of its selections multiplied by the
+// number of records the field may return: the value of its <first> argument
+// for connections, or its default value when it is omitted. <first> is capped
+// at db2.MaxPageSize, the largest page a connection returns, and costs
+// saturate at math.MaxInt64 so that they can't overflow.
+type costAnalyzer struct {
+	// connections maps the names of the connection fields of the schema to
+	// the default value of their <first> argument.
+	connections map[string]int
+}
+
+// newCostAnalyzer returns an analyzer for the queries of schema.
+func newCostAnalyzer(schema string) *costAnalyzer {
+	connections := map[string]int{}
+	for _, match := range connectionDefaults.FindAllStringSubmatch(schema, -1) {
+		def, err := strconv.Atoi(match[3])
+		if err != nil {
+			continue
+		}
+		if def > connections[match[1]] {
+			connections[match[1]] = def
+		}
+	}
+	return &costAnalyzer{connections: connections}
+}
+
+// Cost returns the cost of the operation named operationName of query, or of
+// its only operation when operationName is empty. It returns an error if the
+// query cannot be parsed, in which case the error reported by the GraphQL
+// executor should be preferred.
+func (a *costAnalyzer) Cost(query, operationName string, variables map[string]interface{}) (int, error) {
+	doc, err := parseDocument(query)
+	if err != nil {
+		return 0, err
+	}
+
+	var op *queryOperation
+	for i := range doc.operations {
+		if operationName == "" || doc.operations[i].name == operationName {
+			op = &doc.operations[i]
+			break
+		}
+	}
+	if op == nil {
+		return 0, fmt.Errorf("no operation %q", operationName)
+	}
+
+	c := costContext{
+		analyzer:  a,
+		doc:       doc,
+		variables: map[string]interface{}{},
+		visiting:  map[string]bool{},
+	}
+	for name, value := range op.defaults {
+		c.variables[name] = value
+	}
+	for name, value := range variables {
+		c.variables[name] = value
+	}
+	return c.selectionsCost(op.selections), nil
+}
+
+type costContext struct {
+	analyzer  *costAnalyzer
+	doc       *queryDocument
+	variables map[string]interface{}
+	visiting  map[string]bool
+}
+
+func (c *costContext) selectionsCost(selections []querySelection) int {
+	total := 0
+	for _, s := range selections {
+		switch {
+		case s.field != nil:
+			total = addCost(total, c.fieldCost(s.field))
+		case s.spread != "":
+			// cycles between fragments are invalid, and reported by the
+			// executor
+			if c.visiting[s.spread] {
+				continue
+			}
+			c.visiting[s.spread] = true
+			total = addCost(total, c.selectionsCost(c.doc.fragments[s.spread]))
+			delete(c.visiting, s.spread)
+		default:
+			total = addCost(total, c.selectionsCost(s.inline))
+		}
+	}
+	return total
+}
+
+func (c *costContext) fieldCost(f *queryField) int {
+	if len(f.selections) == 0 {
+		return 1
+	}
+
+	multiplier := 1
+	if def, ok := c.analyzer.connections[f.name]; ok {
+		multiplier = def
+		if first, ok := c.intArgument(f.args["first"]); ok {
+			multiplier = first
+		}
+	}
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	if multiplier > db2.MaxPageSize {
+		multiplier = db2.MaxPageSize
+	}
+	return addCost(1, multiplyCost(multiplier, c.selectionsCost(f.selections)))
+}
+
+// addCost returns a+b for non-negative costs, saturating at math.MaxInt64.
+func addCost(a, b int) int {
+	if a > math.MaxInt64-b {
+		return math.MaxInt64
+	}
+	return a + b
+}
+
+// multiplyCost returns a*b for non-negative costs, saturating at
+// math.MaxInt64.
+func multiplyCost(a, b int) int {
+	if a != 0 && b > math.MaxInt64/a {
+		return math.MaxInt64
+	}
+	return a * b
+}
+
+// intArgument returns the integer value of an argument, resolving variables.
+func (c *costContext) intArgument(v interface{}) (int, bool) {
+	if name, ok := v.(variable); ok {
+		v = c.variables[string(name)]
+	}
+	switch n := v.(type) {
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case fmt.Stringer:
+		// json.Number
+		i, err := strconv.Atoi(n.String())
+		return i, err == nil
+	}
+	return 0, false
+}
+
+// The types below represent the parts of a GraphQL query document needed to
+// estimate its cost.
+
+type queryDocument struct {
+	operations []queryOperation
+	fragments  map[string][]querySelection
+}
+
+type queryOperation struct {
+	name       string
+	defaults   map[string]interface{}
+	selections []querySelection
+}
+
+// querySelection is either a field, a fragment spread or an inline fragment.
+type querySelection struct {
+	field  *queryField
+	spread string
+	inline []querySelection
+}
+
+type queryField struct {
+	name       string
+	args       map[string]interface{}
+	selections []querySelection
+}
+
+// variable is a reference to a variable in an argument value.
+type variable string
+
+// enumValue is an enum value, or a name, in an argument value.
+type enumValue string
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// lexer splits a GraphQL document into tokens, skipping white space, commas
+// and comments.
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',' {
+			l.pos++
+			continue
+		}
+		if c == '#' {
+			for l.pos < len(l.input) && l.input[l.pos] != '\n' && l.input[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		break
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case strings.HasPrefix(l.input[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunctuator, value: "..."}, nil
+	case strings.IndexByte("!$&()[]{}:=@|", c) >= 0:
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c)}, nil
+	case c == '_' || isLetter(c):
+		for l.pos < len(l.input) && (l.input[l.pos] == '_' || isLetter(l.input[l.pos]) || isDigit(l.input[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.input[start:l.pos]}, nil
+	case c == '-' || isDigit(c):
+		l.pos++
+		kind := tokenInt
+		for l.pos < len(l.input) {
+			d := l.input[l.pos]
+			if d == '.' || d == 'e' || d == 'E' || d == '+' || (d == '-' && kind == tokenFloat) {
+				kind = tokenFloat
+			} else if !isDigit(d) {
+				break
+			}
+			l.pos++
+		}
+		return token{kind: kind, value: l.input[start:l.pos]}, nil
+	case strings.HasPrefix(l.input[l.pos:], `"""`):
+		end := strings.Index(l.input[l.pos+3:], `"""`)
+		if end < 0 {
+			return token{}, fmt.Errorf("unterminated string at %d", start)
+		}
+		l.pos += end + 6
+		return token{kind: tokenString, value: l.input[start+3 : l.pos-3]}, nil
+	case c == '"':
+		l.pos++
+		for l.pos < len(l.input) && l.input[l.pos] != '"' {
+			if l.input[l.pos] == '\n' {
+				break
+			}
+			if l.input[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		if l.pos >= len(l.input) || l.input[l.pos] != '"' {
+			return token{}, fmt.Errorf("unterminated string at %d", start)
+		}
+		l.pos++
+		value, err := strconv.Unquote(l.input[start:l.pos])
+		if err != nil {
+			value = l.input[start+1 : l.pos-1]
+		}
+		return token{kind: tokenString, value: value}, nil
+	}
+	return token{}, fmt.Errorf("unexpected character %q at %d", c, start)
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser builds a document from the tokens of a lexer.
+type parser struct {
+	lexer lexer
+	tok   token
+}
+
+// parseDocument parses a GraphQL query document.
+func parseDocument(query string) (*queryDocument, error) {
+	p := &parser{lexer: lexer{input: query}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &queryDocument{fragments: map[string][]querySelection{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.is("{"):
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, queryOperation{selections: selections})
+		case p.tok.kind == tokenName && p.tok.value == "fragment":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			// type condition and directives
+			if err := p.skipUntil("{"); err != nil {
+				return nil, err
+			}
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.fragments[name] = selections
+		case p.tok.kind == tokenName:
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) is(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return fmt.Errorf("unexpected end of document")
+	}
+	return fmt.Errorf("unexpected %q at %d", p.tok.value, p.lexer.pos)
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.is(punctuator) {
+		return p.unexpected()
+	}
+	return p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+func (p *parser) skipUntil(punctuator string) error {
+	for !p.is(punctuator) {
+		if p.tok.kind == tokenEOF {
+			return p.unexpected()
+		}
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operation parses an operation with its type: query, mutation or
+// subscription.
+func (p *parser) operation() (queryOperation, error) {
+	op := queryOperation{defaults: map[string]interface{}{}}
+	if err := p.advance(); err != nil {
+		return op, err
+	}
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return op, err
+		}
+	}
+
+	if p.is("(") {
+		if err := p.advance(); err != nil {
+			return op, err
+		}
+		for !p.is(")") {
+			if err := p.expect("$"); err != nil {
+				return op, err
+			}
+			name, err := p.name()
+			if err != nil {
+				return op, err
+			}
+			if err := p.expect(":"); err != nil {
+				return op, err
+			}
+			// the type of the variable
+			for p.tok.kind == tokenName || p.is("[") || p.is("]") || p.is("!") {
+				if err := p.advance(); err != nil {
+					return op, err
+				}
+			}
+			if p.is("=") {
+				if err := p.advance(); err != nil {
+					return op, err
+				}
+				value, err := p.value()
+				if err != nil {
+					return op, err
+				}
+				op.defaults[name] = value
+			}
+			if err := p.directives(); err != nil {
+				return op, err
+			}
+		}
+		if err := p.advance(); err != nil {
+			return op, err
+		}
+	}
+
+	if err := p.directives(); err != nil {
+		return op, err
+	}
+	selections, err := p.selectionSet()
+	op.selections = selections
+	return op, err
+}
+
+func (p *parser) selectionSet() ([]querySelection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []querySelection
+	for !p.is("}") {
+		s, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, s)
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (querySelection, error) {
+	if p.is("...") {
+		if err := p.advance(); err != nil {
+			return querySelection{}, err
+		}
+		if p.tok.kind == tokenName && p.tok.value != "on" {
+			name := p.tok.value
+			if err := p.advance(); err != nil {
+				return querySelection{}, err
+			}
+			return querySelection{spread: name}, p.directives()
+		}
+		// inline fragment, with an optional type condition
+		if err := p.skipUntil("{"); err != nil {
+			return querySelection{}, err
+		}
+		inline, err := p.selectionSet()
+		return querySelection{inline: inline}, err
+	}
+
+	f := &queryField{args: map[string]interface{}{}}
+	name, err := p.name()
+	if err != nil {
+		return querySelection{}, err
+	}
+	if p.is(":") {
+		// name was an alias
+		if err := p.advance(); err != nil {
+			return querySelection{}, err
+		}
+		if name, err = p.name(); err != nil {
+			return querySelection{}, err
+		}
+	}
+	f.name = name
+
+	if p.is("(") {
+		if f.args, err = p.arguments(); err != nil {
+			return querySelection{}, err
+		}
+	}
+	if err := p.directives(); err != nil {
+		return querySelection{}, err
+	}
+	if p.is("{") {
+		if f.selections, err = p.selectionSet(); err != nil {
+			return querySelection{}, err
+		}
+	}
+	return querySelection{field: f}, nil
+}
+
+func (p *parser) arguments() (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	for !p.is(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if args[name], err = p.value(); err != nil {
+			return nil, err
+		}
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() error {
+	for p.is("@") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if p.is("(") {
+			if _, err := p.arguments(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *parser) value() (interface{}, error) {
+	tok := p.tok
+	switch {
+	case p.is("$"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return variable(name), err
+	case p.is("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var list []interface{}
+		for !p.is("]") {
+			v, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, p.advance()
+	case p.is("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		object := map[string]interface{}{}
+		for !p.is("}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+			if object[name], err = p.value(); err != nil {
+				return nil, err
+			}
+		}
+		return object, p.advance()
+	case tok.kind == tokenInt:
+		i, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return i, p.advance()
+	case tok.kind == tokenFloat:
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return f, p.advance()
+	case tok.kind == tokenString:
+		return tok.value, p.advance()
+	case tok.kind == tokenName:
+		var v interface{}
+		switch tok.value {
+		case "true":
+			v = true
+		case "false":
+			v = false
+		case "null":
+			v = nil
+		default:
+			v = enumValue(tok.value)
+		}
+		return v, p.advance()
+	}
+	return nil, p.unexpected()
+}
@@ -0,0 +1,148 @@
+package gql
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/gql/static"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostAnalyzerConnections(t *testing.T) {
+	a := newCostAnalyzer(static.Schema())
+	for _, name := range []string{"ledgers", "transactions", "operations", "payments", "effects", "offers", "trades", "assets"} {
+		assert.Equal(t, 10, a.connections[name], name)
+	}
+	assert.NotContains(t, a.connections, "orderBook")
+	assert.NotContains(t, a.connections, "account")
+}
+
+func TestCost(t *testing.T) {
+	a := newCostAnalyzer(static.Schema())
+	for _, testCase := range []struct {
+		name          string
+		query         string
+		operationName string
+		variables     string
+		expected      int
+	}{
+		{
+			name:     "scalar fields",
+			query:    `{ ledger(sequence: 1) { hash sequence } }`,
+			expected: 3,
+		},
+		{
+			name:     "default page size",
+			query:    `{ ledgers { edges { cursor node { hash } } } }`,
+			expected: 1 + 10*(1+1+2),
+		},
+		{
+			name: "nested connections",
+			query: `query {
+				account(id: "GA") {
+					id
+					payments(first: 5) { edges { node { id } } pageInfo { hasNextPage } }
+				}
+			}`,
+			expected: 1 + (1 + (1 + 5*(1+2+2))),
+		},
+		{
+			name:      "variables",
+			query:     `query Account($first: Int = 3, $id: String!) { account(id: $id) { effects(first: $first) { edges { cursor } } } }`,
+			variables: `{"id": "GA", "first": 20}`,
+			expected:  1 + 1 + 20*2,
+		},
+		{
+			name:     "variable defaults",
+			query:    `query Account($first: Int = 3) { account(id: "GA") { effects(first: $first) { edges { cursor } } } }`,
+			expected: 1 + 1 + 3*2,
+		},
+		{
+			name: "fragments and aliases",
+			query: `
+				# fragments are expanded where they are used
+				query Trades {
+					first: trades(first: 2) { ...tradeEdges }
+					second: trades(first: 4, order: DESC, baseAsset: {type: "native"}) {
+						... on TradeConnection { ...tradeEdges }
+					}
+				}
+				fragment tradeEdges on TradeConnection {
+					edges { node { id baseAsset { code } } }
+				}`,
+			expected: (1 + 2*5) + (1 + 4*5),
+		},
+		{
+			name: "operation name",
+			query: `
+				query Ledger { ledger(sequence: 1) { hash } }
+				query Ledgers { ledgers(first: 100) { edges { cursor } } }`,
+			operationName: "Ledgers",
+			expected:      1 + 100*2,
+		},
+		{
+			name:     "page size above the maximum",
+			query:    `{ ledgers(first: 1000) { edges { cursor } } }`,
+			expected: 1 + 200*2,
+		},
+		{
+			name:     "directives and strings",
+			query:    `{ transaction(hash: "a\"b") @include(if: true) { hash memo @skip(if: false) } }`,
+			expected: 3,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			var variables map[string]interface{}
+			if testCase.variables != "" {
+				require.NoError(t, json.Unmarshal([]byte(testCase.variables), &variables))
+			}
+			cost, err := a.Cost(testCase.query, testCase.operationName, variables)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, cost)
+		})
+	}
+}
+
+func TestCostOverflow(t *testing.T) {
+	a := newCostAnalyzer(static.Schema())
+	nested := func(first string) string {
+		return `ledgers(first: ` + first + `) { edges { node {
+			transactions(first: ` + first + `) { edges { node {
+				operations(first: ` + first + `) { edges { node { id } } }
+			} } }
+		} } }`
+	}
+
+	cost, err := a.Cost(`{ a: `+nested("200")+` }`, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 24120601, cost)
+
+	// without capping <first>, the aliased sibling would overflow the total
+	// to a negative cost
+	cost, err = a.Cost(`{ a: `+nested("200")+` b: `+nested("2147483647")+` }`, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2*24120601, cost)
+
+	assert.Equal(t, math.MaxInt64, addCost(math.MaxInt64-1, 2))
+	assert.Equal(t, math.MaxInt64, multiplyCost(200, math.MaxInt64/100))
+	assert.Equal(t, 0, multiplyCost(0, math.MaxInt64))
+}
+
+func TestCostInvalidQueries(t *testing.T) {
+	a := newCostAnalyzer(static.Schema())
+	for _, query := range []string{
+		`{ ledgers(first: 1) { edges `,
+		`{ ledger(sequence: ) { hash } }`,
+		`{ transaction(hash: "abc) { hash } }`,
+		`query Ledgers($first Int) { ledgers { edges { cursor } } }`,
+		`%`,
+	} {
+		_, err := a.Cost(query, "", nil)
+		assert.Error(t, err, query)
+	}
+
+	_, err := a.Cost(`query Ledger { ledger(sequence: 1) { hash } }`, "Ledgers", nil)
+	assert.EqualError(t, err, `no operation "Ledgers"`)
+}
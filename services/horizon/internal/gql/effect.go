@@ -0,0 +1,128 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon/effects"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// effect represents an effect of an operation. Details is the JSON
+// representation of the effect in the REST API, with the fields specific
+// to its type.
+type effect struct {
+	ID          string
+	PagingToken string
+	Type        string
+	TypeI       int32
+	Account     string
+	CreatedAt   graphql.Time
+	Details     string
+}
+
+type effectConnection struct {
+	Edges    []effectEdge
+	PageInfo pageInfo
+}
+
+type effectEdge struct {
+	Cursor string
+	Node   *effect
+}
+
+// effectFilter selects the effects of an account, a ledger, a transaction or
+// an operation, or every effect when it is empty.
+type effectFilter struct {
+	account     string
+	ledger      int32
+	transaction string
+	operation   int64
+}
+
+func newEffect(ctx context.Context, row history.Effect, l history.Ledger) (*effect, error) {
+	res, err := resourceadapter.NewEffect(ctx, row, l)
+	if err != nil {
+		return nil, errors.Wrap(err, "populating effect")
+	}
+	details, err := json.Marshal(res)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling effect")
+	}
+
+	var base effects.Base
+	resourceadapter.PopulateBaseEffect(ctx, &base, row, l)
+	return &effect{
+		ID:          base.ID,
+		PagingToken: base.PT,
+		Type:        base.Type,
+		TypeI:       base.TypeI,
+		Account:     base.Account,
+		CreatedAt:   graphql.Time{Time: base.LedgerCloseTime},
+		Details:     string(details),
+	}, nil
+}
+
+// loadEffects returns a page of the effects selected by filter.
+func (r *resolver) loadEffects(ctx context.Context, filter effectFilter, args pageArgs) (*effectConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	q := hq.Effects()
+	switch {
+	case filter.account != "":
+		q.ForAccount(filter.account)
+	case filter.ledger > 0:
+		q.ForLedger(filter.ledger)
+	case filter.operation > 0:
+		q.ForOperation(filter.operation)
+	case filter.transaction != "":
+		q.ForTransaction(filter.transaction)
+	}
+
+	var rows []history.Effect
+	if err = q.Page(pq).Select(&rows); err != nil {
+		return nil, errors.Wrap(err, "loading effects")
+	}
+
+	n, hasNextPage := args.pageLength(len(rows))
+	rows = rows[:n]
+	ledgers := &history.LedgerCache{}
+	for _, row := range rows {
+		ledgers.Queue(row.LedgerSequence())
+	}
+	if err = ledgers.Load(hq); err != nil {
+		return nil, errors.Wrap(err, "loading ledgers")
+	}
+
+	connection := &effectConnection{}
+	var cursors []string
+	for _, row := range rows {
+		l, found := ledgers.Records[row.LedgerSequence()]
+		if !found {
+			return nil, errors.Errorf("could not find ledger data for sequence %d", row.LedgerSequence())
+		}
+		node, err := newEffect(ctx, row, l)
+		if err != nil {
+			return nil, err
+		}
+		connection.Edges = append(connection.Edges, effectEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Effects resolves the effects query.
+func (r *resolver) Effects(ctx context.Context, args pageArgs) (*effectConnection, error) {
+	return r.loadEffects(ctx, effectFilter{}, args)
+}
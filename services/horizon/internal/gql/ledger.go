@@ -0,0 +1,148 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// ledger represents a ledger closed by the network
+type ledger struct {
+	Sequence                   int32
+	Hash                       string
+	PrevHash                   string
+	PagingToken                string
+	SuccessfulTransactionCount int32
+	FailedTransactionCount     *int32
+	OperationCount             int32
+	ClosedAt                   graphql.Time
+	TotalCoins                 string
+	FeePool                    string
+	BaseFee                    int32
+	BaseReserve                int32
+	MaxTxSetSize               int32
+	ProtocolVersion            int32
+	HeaderXdr                  string
+
+	r *resolver
+}
+
+type ledgerConnection struct {
+	Edges    []ledgerEdge
+	PageInfo pageInfo
+}
+
+type ledgerEdge struct {
+	Cursor string
+	Node   *ledger
+}
+
+func (r *resolver) newLedger(ctx context.Context, row history.Ledger) *ledger {
+	var res horizon.Ledger
+	resourceadapter.PopulateLedger(ctx, &res, row)
+	return &ledger{
+		Sequence:                   res.Sequence,
+		Hash:                       res.Hash,
+		PrevHash:                   res.PrevHash,
+		PagingToken:                res.PT,
+		SuccessfulTransactionCount: res.SuccessfulTransactionCount,
+		FailedTransactionCount:     res.FailedTransactionCount,
+		OperationCount:             res.OperationCount,
+		ClosedAt:                   graphql.Time{Time: res.ClosedAt},
+		TotalCoins:                 res.TotalCoins,
+		FeePool:                    res.FeePool,
+		BaseFee:                    res.BaseFee,
+		BaseReserve:                res.BaseReserve,
+		MaxTxSetSize:               res.MaxTxSetSize,
+		ProtocolVersion:            res.ProtocolVersion,
+		HeaderXdr:                  res.HeaderXDR,
+		r:                          r,
+	}
+}
+
+// loadLedger returns the ledger with the given sequence, or nil if it is
+// not in the history.
+func (r *resolver) loadLedger(ctx context.Context, sequence int32) (*ledger, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var row history.Ledger
+	err = hq.LedgerBySequence(&row, sequence)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading ledger")
+	}
+	return r.newLedger(ctx, row), nil
+}
+
+// Ledger resolves the ledger query.
+func (r *resolver) Ledger(ctx context.Context, args struct{ Sequence int32 }) (*ledger, error) {
+	return r.loadLedger(ctx, args.Sequence)
+}
+
+// Ledgers resolves the ledgers query.
+func (r *resolver) Ledgers(ctx context.Context, args pageArgs) (*ledgerConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []history.Ledger
+	if err = hq.Ledgers().Page(pq).Select(&rows); err != nil {
+		return nil, errors.Wrap(err, "loading ledgers")
+	}
+
+	n, hasNextPage := args.pageLength(len(rows))
+	connection := &ledgerConnection{}
+	var cursors []string
+	for _, row := range rows[:n] {
+		node := r.newLedger(ctx, row)
+		connection.Edges = append(connection.Edges, ledgerEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Transactions resolves the transactions of the ledger.
+func (l *ledger) Transactions(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*transactionConnection, error) {
+	return l.r.loadTransactions(ctx, "", l.Sequence, args.IncludeFailed, args.pageArgs)
+}
+
+// Operations resolves the operations of the ledger.
+func (l *ledger) Operations(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	filter := operationFilter{ledger: l.Sequence, includeFailed: args.IncludeFailed}
+	return l.r.loadOperations(ctx, filter, args.pageArgs)
+}
+
+// Payments resolves the payments of the ledger.
+func (l *ledger) Payments(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	filter := operationFilter{ledger: l.Sequence, includeFailed: args.IncludeFailed, onlyPayments: true}
+	return l.r.loadOperations(ctx, filter, args.pageArgs)
+}
+
+// Effects resolves the effects of the ledger.
+func (l *ledger) Effects(ctx context.Context, args pageArgs) (*effectConnection, error) {
+	return l.r.loadEffects(ctx, effectFilter{ledger: l.Sequence}, args)
+}
@@ -0,0 +1,224 @@
+package gql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/gql/static"
+	"github.com/paydex-core/paydex-go/support/db"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// maxRequestSize is the maximum size of the body of a GraphQL request.
+const maxRequestSize = 1 << 20
+
+// Config configures the GraphQL endpoint.
+type Config struct {
+	// HistorySession returns a session of the horizon database bound to ctx,
+	// or an error if the history is unavailable, e.g. because it is stale.
+	HistorySession func(ctx context.Context) (*db.Session, error)
+	// CoreSession returns a session of the paydex core database bound to
+	// ctx.
+	CoreSession func(ctx context.Context) *db.Session
+	// MaxDepth is the maximum depth of the selections of a query. It is
+	// unlimited when 0.
+	MaxDepth int
+	// MaxCost is the maximum cost of a query, see costAnalyzer. It is
+	// unlimited when 0.
+	MaxCost int
+	// QueryTimeout is the maximum duration of a query, after which its
+	// database queries are cancelled. It is unlimited when 0.
+	QueryTimeout time.Duration
+	// EnableExperimentalIngestion loads offers and asset stats from the
+	// state ingested by the experimental ingestion system.
+	EnableExperimentalIngestion bool
+	// EnableAssetStats enables the asset stats of the legacy ingestion
+	// system, used when the experimental ingestion is disabled.
+	EnableAssetStats bool
+}
+
+// resolver is the root resolver of the GraphQL schema.
+type resolver struct {
+	config Config
+}
+
+// Handler serves GraphQL queries over HTTP, with GET requests and the query
+// in the URL, or POST requests with a JSON body.
+type Handler struct {
+	schema *graphql.Schema
+	cost   *costAnalyzer
+	config Config
+}
+
+// New creates the handler of the GraphQL endpoint.
+func New(config Config) *Handler {
+	schema := static.Schema()
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	if config.MaxDepth > 0 {
+		opts = append(opts, graphql.MaxDepth(config.MaxDepth))
+	}
+	return &Handler{
+		schema: graphql.MustParseSchema(schema, &resolver{config: config}, opts...),
+		cost:   newCostAnalyzer(schema),
+		config: config,
+	}
+}
+
+// request is a GraphQL request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func readRequest(w http.ResponseWriter, r *http.Request) (request, error) {
+	var req request
+	if r.Method == http.MethodGet {
+		values := r.URL.Query()
+		req.Query = values.Get("query")
+		req.OperationName = values.Get("operationName")
+		if variables := values.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, errors.Wrap(err, "invalid variables")
+			}
+		}
+		return req, nil
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		return req, errors.Wrap(err, "reading request")
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return req, errors.Wrap(err, "invalid request")
+	}
+	return req, nil
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := readRequest(w, r)
+	if err == nil && req.Query == "" {
+		err = errors.New("missing query")
+	}
+	if err != nil {
+		writeResponse(w, http.StatusBadRequest, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("%s", err)},
+		})
+		return
+	}
+
+	if h.config.MaxCost > 0 {
+		cost, err := h.cost.Cost(req.Query, req.OperationName, req.Variables)
+		if err != nil {
+			// a query whose cost is unknown is rejected, with the errors
+			// of the executor when it can't parse the query either
+			errs := h.schema.Validate(req.Query)
+			if len(errs) == 0 {
+				errs = []*gqlerrors.QueryError{gqlerrors.Errorf("could not estimate the query cost: %s", err)}
+			}
+			writeResponse(w, http.StatusOK, &graphql.Response{Errors: errs})
+			return
+		}
+		if cost > h.config.MaxCost {
+			writeResponse(w, http.StatusOK, &graphql.Response{
+				Errors: []*gqlerrors.QueryError{
+					gqlerrors.Errorf("query cost %d exceeds the maximum cost of %d", cost, h.config.MaxCost),
+				},
+			})
+			return
+		}
+	}
+
+	ctx := r.Context()
+	if h.config.QueryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.config.QueryTimeout)
+		defer cancel()
+	}
+	ctx = context.WithValue(ctx, &sessionsContextKey, &sessions{ctx: ctx, config: &h.config})
+
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+	writeResponse(w, http.StatusOK, response)
+}
+
+func writeResponse(w http.ResponseWriter, status int, response *graphql.Response) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+type contextKey string
+
+var sessionsContextKey = contextKey("sessions")
+
+// sessions opens the database sessions of a query when they are first used.
+// The sessions are bound to the context of the query, so that they are
+// cancelled when it times out.
+type sessions struct {
+	ctx    context.Context
+	config *Config
+
+	historyOnce sync.Once
+	historyQ    *history.Q
+	historyErr  error
+
+	coreOnce sync.Once
+	coreQ    *core.Q
+}
+
+func sessionsFromContext(ctx context.Context) (*sessions, error) {
+	s, ok := ctx.Value(&sessionsContextKey).(*sessions)
+	if !ok {
+		return nil, errors.New("missing database sessions")
+	}
+	return s, nil
+}
+
+// historyQ returns the history queries of the query running with ctx.
+func historyQ(ctx context.Context) (*history.Q, error) {
+	s, err := sessionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.historyOnce.Do(func() {
+		session, err := s.config.HistorySession(s.ctx)
+		if err != nil {
+			s.historyErr = err
+			return
+		}
+		s.historyQ = &history.Q{Session: session}
+	})
+	return s.historyQ, s.historyErr
+}
+
+// coreQ returns the core queries of the query running with ctx.
+func coreQ(ctx context.Context) (*core.Q, error) {
+	s, err := sessionsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.coreOnce.Do(func() {
+		s.coreQ = &core.Q{Session: s.config.CoreSession(s.ctx)}
+	})
+	return s.coreQ, nil
+}
+
+// isNotFound returns true if err is returned for a missing record.
+func isNotFound(err error) bool {
+	return errors.Cause(err) == sql.ErrNoRows
+}
@@ -0,0 +1,98 @@
+package gql
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/gql/static"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchema(t *testing.T) {
+	r := resolver{}
+	opts := []graphql.SchemaOpt{graphql.UseFieldResolvers()}
+	graphql.MustParseSchema(static.Schema(), &r, opts...)
+}
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func serve(t *testing.T, h *Handler, r *http.Request) (int, response) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var res response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return w.Code, res
+}
+
+func TestHandlerInvalidRequests(t *testing.T) {
+	h := New(Config{})
+
+	status, res := serve(t, h, httptest.NewRequest("GET", "/graphql", nil))
+	assert.Equal(t, http.StatusBadRequest, status)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "missing query", res.Errors[0].Message)
+
+	status, res = serve(t, h, httptest.NewRequest("POST", "/graphql", strings.NewReader("{")))
+	assert.Equal(t, http.StatusBadRequest, status)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "invalid request")
+
+	query := url.Values{"query": {"{ ledger(sequence: 1) { hash } }"}, "variables": {"["}}
+	status, res = serve(t, h, httptest.NewRequest("GET", "/graphql?"+query.Encode(), nil))
+	assert.Equal(t, http.StatusBadRequest, status)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "invalid variables")
+
+	// syntax errors are reported by the executor
+	status, res = serve(t, h, httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "{ ledger("}`)))
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "syntax error")
+}
+
+func TestHandlerLimits(t *testing.T) {
+	h := New(Config{MaxDepth: 3, MaxCost: 100})
+
+	// 1 + 50 * (edges 1 + node 2)
+	body := `{
+		"query": "query Ledgers($first: Int) { ledgers(first: $first) { edges { node { hash } } } }",
+		"variables": {"first": 50}
+	}`
+	status, res := serve(t, h, httptest.NewRequest("POST", "/graphql", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "query cost 151 exceeds the maximum cost of 100", res.Errors[0].Message)
+	assert.Nil(t, res.Data)
+
+	query := url.Values{"query": {"{ ledgers(first: 1) { edges { node { transactions(first: 1) { edges { node { hash } } } } } } }"}}
+	status, res = serve(t, h, httptest.NewRequest("GET", "/graphql?"+query.Encode(), nil))
+	assert.Equal(t, http.StatusOK, status)
+	require.NotEmpty(t, res.Errors)
+	assert.Contains(t, res.Errors[0].Message, "exceeds max depth 3")
+
+	// queries whose cost can't be estimated are not executed
+	body = `{"query": "query Ledger { ledger(sequence: 1) { hash } }", "operationName": "Ledgers"}`
+	status, res = serve(t, h, httptest.NewRequest("POST", "/graphql", strings.NewReader(body)))
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, `could not estimate the query cost: no operation "Ledgers"`, res.Errors[0].Message)
+	assert.Nil(t, res.Data)
+
+	status, res = serve(t, h, httptest.NewRequest("POST", "/graphql", strings.NewReader(`{"query": "{ ledger("}`)))
+	assert.Equal(t, http.StatusOK, status)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Message, "syntax error")
+}
@@ -0,0 +1,230 @@
+package gql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// errOffersUnavailable is returned for the offers which can only be loaded
+// from the state ingested by the experimental ingestion system.
+var errOffersUnavailable = errors.New("offers are only available with the experimental ingestion enabled")
+
+// offer represents an offer of the order books of the network
+type offer struct {
+	ID                 string
+	PagingToken        string
+	Seller             string
+	Selling            asset
+	Buying             asset
+	Amount             string
+	Price              string
+	PriceN             int32
+	PriceD             int32
+	LastModifiedLedger int32
+	LastModifiedTime   *graphql.Time
+
+	r *resolver
+}
+
+type offerConnection struct {
+	Edges    []offerEdge
+	PageInfo pageInfo
+}
+
+type offerEdge struct {
+	Cursor string
+	Node   *offer
+}
+
+// offerFilter selects the offers of a seller and assets, or every offer
+// when it is empty.
+type offerFilter struct {
+	seller  string
+	selling *xdr.Asset
+	buying  *xdr.Asset
+}
+
+func (r *resolver) newOffer(res horizon.Offer) *offer {
+	o := &offer{
+		ID:                 strconv.FormatInt(res.ID, 10),
+		PagingToken:        res.PT,
+		Seller:             res.Seller,
+		Selling:            asset(res.Selling),
+		Buying:             asset(res.Buying),
+		Amount:             res.Amount,
+		Price:              res.Price,
+		PriceN:             res.PriceR.N,
+		PriceD:             res.PriceR.D,
+		LastModifiedLedger: res.LastModifiedLedger,
+		r:                  r,
+	}
+	if res.LastModifiedTime != nil {
+		o.LastModifiedTime = &graphql.Time{Time: *res.LastModifiedTime}
+	}
+	return o
+}
+
+// loadOffers returns a page of the offers selected by filter. Offers are
+// loaded from the history with the experimental ingestion, or else from
+// paydex core, which only supports filtering by seller.
+func (r *resolver) loadOffers(ctx context.Context, filter offerFilter, args pageArgs) (*offerConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		offers  []horizon.Offer
+		ledgers = &history.LedgerCache{}
+	)
+	if r.config.EnableExperimentalIngestion {
+		rows, err := hq.GetOffers(history.OffersQuery{
+			PageQuery: pq,
+			SellerID:  filter.seller,
+			Selling:   filter.selling,
+			Buying:    filter.buying,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "loading offers")
+		}
+		for _, row := range rows {
+			ledgers.Queue(int32(row.LastModifiedLedger))
+		}
+		if err = ledgers.Load(hq); err != nil {
+			return nil, errors.Wrap(err, "loading ledgers")
+		}
+		for _, row := range rows {
+			var res horizon.Offer
+			resourceadapter.PopulateHistoryOffer(ctx, &res, row, cachedLedger(ledgers, int32(row.LastModifiedLedger)))
+			offers = append(offers, res)
+		}
+	} else {
+		if filter.seller == "" || filter.selling != nil || filter.buying != nil {
+			return nil, errOffersUnavailable
+		}
+		cq, err := coreQ(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var rows []core.Offer
+		if err = cq.OffersByAddress(&rows, filter.seller, pq); err != nil {
+			return nil, errors.Wrap(err, "loading offers")
+		}
+		for _, row := range rows {
+			ledgers.Queue(row.Lastmodified)
+		}
+		if err = ledgers.Load(hq); err != nil {
+			return nil, errors.Wrap(err, "loading ledgers")
+		}
+		for _, row := range rows {
+			var res horizon.Offer
+			resourceadapter.PopulateOffer(ctx, &res, row, cachedLedger(ledgers, row.Lastmodified))
+			offers = append(offers, res)
+		}
+	}
+
+	n, hasNextPage := args.pageLength(len(offers))
+	connection := &offerConnection{}
+	var cursors []string
+	for _, res := range offers[:n] {
+		node := r.newOffer(res)
+		connection.Edges = append(connection.Edges, offerEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// cachedLedger returns the ledger with the given sequence from the cache, or
+// nil if it is not in the history.
+func cachedLedger(ledgers *history.LedgerCache, sequence int32) *history.Ledger {
+	l, found := ledgers.Records[sequence]
+	if !found {
+		return nil
+	}
+	return &l
+}
+
+// Offer resolves the offer query.
+func (r *resolver) Offer(ctx context.Context, args struct{ ID string }) (*offer, error) {
+	if !r.config.EnableExperimentalIngestion {
+		return nil, errOffersUnavailable
+	}
+	id, err := strconv.ParseInt(args.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid offer id %q", args.ID)
+	}
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := hq.GetOfferByID(id)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading offer")
+	}
+
+	l := new(history.Ledger)
+	err = hq.LedgerBySequence(l, int32(row.LastModifiedLedger))
+	if isNotFound(err) {
+		l = nil
+	} else if err != nil {
+		return nil, errors.Wrap(err, "loading ledger")
+	}
+
+	var res horizon.Offer
+	resourceadapter.PopulateHistoryOffer(ctx, &res, row, l)
+	return r.newOffer(res), nil
+}
+
+// Offers resolves the offers query.
+func (r *resolver) Offers(ctx context.Context, args struct {
+	pageArgs
+	Seller  *string
+	Selling *assetInput
+	Buying  *assetInput
+}) (*offerConnection, error) {
+	var filter offerFilter
+	if args.Seller != nil {
+		filter.seller = *args.Seller
+	}
+	if args.Selling != nil {
+		selling, err := args.Selling.xdrAsset()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid selling asset")
+		}
+		filter.selling = &selling
+	}
+	if args.Buying != nil {
+		buying, err := args.Buying.xdrAsset()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid buying asset")
+		}
+		filter.buying = &buying
+	}
+	return r.loadOffers(ctx, filter, args.pageArgs)
+}
+
+// Trades resolves the trades of the offer.
+func (o *offer) Trades(ctx context.Context, args pageArgs) (*tradeConnection, error) {
+	id, err := strconv.ParseInt(o.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing offer id")
+	}
+	return o.r.loadTrades(ctx, tradeFilter{offer: id}, args)
+}
@@ -0,0 +1,199 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// operation represents an operation of a transaction. Details is the JSON
+// representation of the operation in the REST API, with the fields
+// specific to its type.
+type operation struct {
+	ID                    string
+	PagingToken           string
+	Type                  string
+	TypeI                 int32
+	SourceAccount         string
+	CreatedAt             graphql.Time
+	TransactionHash       string
+	TransactionSuccessful bool
+	Details               string
+
+	r *resolver
+}
+
+type operationConnection struct {
+	Edges    []operationEdge
+	PageInfo pageInfo
+}
+
+type operationEdge struct {
+	Cursor string
+	Node   *operation
+}
+
+// operationFilter selects the operations of an account, a ledger or a
+// transaction, or every operation when it is empty.
+type operationFilter struct {
+	account       string
+	ledger        int32
+	transaction   string
+	includeFailed bool
+	onlyPayments  bool
+}
+
+func (r *resolver) newOperation(ctx context.Context, row history.Operation, l history.Ledger) (*operation, error) {
+	res, err := resourceadapter.NewOperation(ctx, row, nil, l)
+	if err != nil {
+		return nil, errors.Wrap(err, "populating operation")
+	}
+	details, err := json.Marshal(res)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshaling operation")
+	}
+
+	var base operations.Base
+	resourceadapter.PopulateBaseOperation(ctx, &base, row, nil, l)
+	return &operation{
+		ID:                    base.ID,
+		PagingToken:           base.PT,
+		Type:                  base.Type,
+		TypeI:                 base.TypeI,
+		SourceAccount:         base.SourceAccount,
+		CreatedAt:             graphql.Time{Time: base.LedgerCloseTime},
+		TransactionHash:       base.TransactionHash,
+		TransactionSuccessful: base.TransactionSuccessful,
+		Details:               string(details),
+		r:                     r,
+	}, nil
+}
+
+// loadOperations returns a page of the operations selected by filter.
+func (r *resolver) loadOperations(ctx context.Context, filter operationFilter, args pageArgs) (*operationConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	ops := hq.Operations()
+	switch {
+	case filter.account != "":
+		ops.ForAccount(filter.account)
+	case filter.ledger > 0:
+		ops.ForLedger(filter.ledger)
+	case filter.transaction != "":
+		ops.ForTransaction(filter.transaction)
+	}
+	// the operations of a transaction are returned whether it failed or not,
+	// like in the REST API
+	if filter.transaction != "" || filter.includeFailed {
+		ops.IncludeFailed()
+	}
+	if filter.onlyPayments {
+		ops.OnlyPayments()
+	}
+
+	rows, _, err := ops.Page(pq).Fetch()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading operations")
+	}
+
+	n, hasNextPage := args.pageLength(len(rows))
+	rows = rows[:n]
+	ledgers := &history.LedgerCache{}
+	for _, row := range rows {
+		ledgers.Queue(row.LedgerSequence())
+	}
+	if err = ledgers.Load(hq); err != nil {
+		return nil, errors.Wrap(err, "loading ledgers")
+	}
+
+	connection := &operationConnection{}
+	var cursors []string
+	for _, row := range rows {
+		l, found := ledgers.Records[row.LedgerSequence()]
+		if !found {
+			return nil, errors.Errorf("could not find ledger data for sequence %d", row.LedgerSequence())
+		}
+		node, err := r.newOperation(ctx, row, l)
+		if err != nil {
+			return nil, err
+		}
+		connection.Edges = append(connection.Edges, operationEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Operation resolves the operation query.
+func (r *resolver) Operation(ctx context.Context, args struct{ ID string }) (*operation, error) {
+	id, err := strconv.ParseInt(args.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Errorf("invalid operation id %q", args.ID)
+	}
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	row, _, err := hq.OperationByID(false, id)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading operation")
+	}
+
+	var l history.Ledger
+	if err = hq.LedgerBySequence(&l, row.LedgerSequence()); err != nil {
+		return nil, errors.Wrap(err, "loading ledger")
+	}
+	return r.newOperation(ctx, row, l)
+}
+
+// Operations resolves the operations query.
+func (r *resolver) Operations(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	return r.loadOperations(ctx, operationFilter{includeFailed: args.IncludeFailed}, args.pageArgs)
+}
+
+// Payments resolves the payments query.
+func (r *resolver) Payments(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*operationConnection, error) {
+	filter := operationFilter{includeFailed: args.IncludeFailed, onlyPayments: true}
+	return r.loadOperations(ctx, filter, args.pageArgs)
+}
+
+// Transaction resolves the transaction of the operation.
+func (o *operation) Transaction(ctx context.Context) (*transaction, error) {
+	tx, err := o.r.loadTransaction(ctx, o.TransactionHash)
+	if err == nil && tx == nil {
+		err = errors.Errorf("transaction %s of operation %s not found", o.TransactionHash, o.ID)
+	}
+	return tx, err
+}
+
+// Effects resolves the effects of the operation.
+func (o *operation) Effects(ctx context.Context, args pageArgs) (*effectConnection, error) {
+	id, err := strconv.ParseInt(o.ID, 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing operation id")
+	}
+	return o.r.loadEffects(ctx, effectFilter{operation: id}, args)
+}
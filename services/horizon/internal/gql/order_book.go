@@ -0,0 +1,79 @@
+package gql
+
+import (
+	"context"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// orderBook represents the summary of the order book between two assets
+type orderBook struct {
+	Selling asset
+	Buying  asset
+	Bids    []priceLevel
+	Asks    []priceLevel
+}
+
+// priceLevel represents the offers of an order book at a given price
+type priceLevel struct {
+	Price  string
+	PriceN int32
+	PriceD int32
+	Amount string
+}
+
+func newPriceLevels(levels []horizon.PriceLevel) []priceLevel {
+	result := make([]priceLevel, 0, len(levels))
+	for _, level := range levels {
+		result = append(result, priceLevel{
+			Price:  level.Price,
+			PriceN: level.PriceR.N,
+			PriceD: level.PriceR.D,
+			Amount: level.Amount,
+		})
+	}
+	return result
+}
+
+// OrderBook resolves the orderBook query.
+func (r *resolver) OrderBook(ctx context.Context, args struct {
+	Selling assetInput
+	Buying  assetInput
+	Limit   int32
+}) (*orderBook, error) {
+	if args.Limit < 1 || args.Limit > db2.MaxPageSize {
+		return nil, errors.Errorf("limit must be between 1 and %d", db2.MaxPageSize)
+	}
+	selling, err := args.Selling.xdrAsset()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid selling asset")
+	}
+	buying, err := args.Buying.xdrAsset()
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid buying asset")
+	}
+	cq, err := coreQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var row core.OrderBookSummary
+	if err = cq.GetOrderBookSummary(&row, selling, buying, uint64(args.Limit)); err != nil {
+		return nil, errors.Wrap(err, "loading order book")
+	}
+	var res horizon.OrderBookSummary
+	if err = resourceadapter.PopulateOrderBookSummary(ctx, &res, selling, buying, row); err != nil {
+		return nil, errors.Wrap(err, "populating order book")
+	}
+
+	return &orderBook{
+		Selling: asset(res.Selling),
+		Buying:  asset(res.Buying),
+		Bids:    newPriceLevels(res.Bids),
+		Asks:    newPriceLevels(res.Asks),
+	}, nil
+}
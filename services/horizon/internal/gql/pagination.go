@@ -0,0 +1,62 @@
+package gql
+
+import (
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// pageArgs are the arguments of the connection fields. Cursors are the paging
+// tokens of the records, like in the REST API, so that a cursor can be used
+// with both APIs.
+type pageArgs struct {
+	First int32
+	After *string
+	Order string
+}
+
+// pageQuery returns the query of the page described by the arguments. It
+// requests one more record than the size of the page, to know whether there
+// is a next page, see pageLength.
+func (args pageArgs) pageQuery() (db2.PageQuery, error) {
+	if args.First < 1 || args.First > db2.MaxPageSize {
+		return db2.PageQuery{}, errors.Errorf("first must be between 1 and %d", db2.MaxPageSize)
+	}
+
+	pq := db2.PageQuery{
+		Order: db2.OrderAscending,
+		Limit: uint64(args.First) + 1,
+	}
+	if args.Order == "DESC" {
+		pq.Order = db2.OrderDescending
+	}
+	if args.After != nil {
+		pq.Cursor = *args.After
+	}
+	return pq, nil
+}
+
+// pageLength returns the number of records of the page, given the number of
+// records loaded with pageQuery, and whether there is a next page.
+func (args pageArgs) pageLength(loaded int) (int, bool) {
+	if loaded > int(args.First) {
+		return int(args.First), true
+	}
+	return loaded, false
+}
+
+// pageInfo describes a page of a connection.
+type pageInfo struct {
+	StartCursor *string
+	EndCursor   *string
+	HasNextPage bool
+}
+
+// newPageInfo returns the page info of a page with the given cursors.
+func newPageInfo(cursors []string, hasNextPage bool) pageInfo {
+	info := pageInfo{HasNextPage: hasNextPage}
+	if len(cursors) > 0 {
+		info.StartCursor = &cursors[0]
+		info.EndCursor = &cursors[len(cursors)-1]
+	}
+	return info
+}
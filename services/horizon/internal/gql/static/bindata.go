@@ -0,0 +1,274 @@
+// Code generated by go-bindata. DO NOT EDIT.
+// sources:
+// schema.gql (8.058kB)
+
+package static
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes  []byte
+	info   os.FileInfo
+	digest [sha256.Size]byte
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _schemaGql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x18\x5d\x6f\xdb\x36\xf0\x39\xfa\x15\x34\xf2\x92\x02\x86\xd1\x16\xc5\x1e\x82\xb6\x40\x9a\xa4\x98\x87\xb6\xc9\xea\x60\x18\x50\x14\x03\x2d\x9d\x23\x22\x92\xe8\x91\x54\x52\xaf\xe8\x7f\xdf\x1d\x29\x52\xd4\x87\x5d\x27\x68\xd7\x61\xd8\x4b\x62\xdd\x17\xef\xfb\x8e\xd4\x69\x0e\x25\x67\x9f\x93\x83\x3f\x6b\x50\x9b\x63\xf6\x2b\xfd\x4b\xbe\x24\x89\xd9\xac\xc1\x7d\x11\xf6\x90\x29\x30\x4a\xc0\x2d\x30\x5e\x31\x9e\xa6\xb2\xae\x0c\x5b\x6e\x98\x30\x9a\xf1\x2c\x53\xa0\xf5\x2c\x39\x68\x10\x47\x22\x3b\x66\x0b\xa4\xaf\xae\x27\x8f\x8e\xd9\x89\x83\x26\x5d\x31\xac\x80\xec\x1a\x94\x17\xa2\x01\x35\xa8\x52\x60\x55\x5d\x2e\x41\xa1\x30\x87\x3f\xf2\x88\x63\x36\xaf\x0c\x89\x7b\x63\xe1\x5d\x69\x26\x87\x46\x9e\x66\x72\x65\x3f\x2b\x30\x77\x52\xdd\x04\x41\xfa\x68\x25\x94\x36\x56\x0c\x7b\xc1\x9e\x3c\x9e\x32\xbe\x32\xa0\xbc\xa6\x53\x26\x55\x46\x9f\x17\xf4\x0f\x29\x4e\x16\xa7\xe1\xb4\x53\x59\x55\x90\x1a\x21\xab\x49\xdf\x0c\xa3\x78\xa5\xb9\xc5\x79\x5b\x72\xae\x73\x3c\x37\xc2\x1c\x11\x28\xf6\xc9\x55\x8b\x1b\x5a\x12\x31\x0e\xcc\x61\x2b\x2e\xd0\xa0\x0e\x0d\x09\xe0\x0a\x98\xac\x0a\x54\xa0\x4a\x8b\x3a\x43\x8a\xbb\x1c\x2a\xff\xf5\xda\x31\x09\x8d\x7c\x35\x74\x75\xd3\x47\xc9\xc1\x41\xcf\x37\x08\xe9\x38\x07\xbf\x87\xde\x41\x60\x47\xfc\x31\x7b\x25\x65\x01\x98\x20\x2f\x50\xcb\x42\x43\x72\xd0\xb5\x74\xbb\x17\x2b\x26\xd7\xa0\x78\xec\xc4\xf9\x19\xaa\x19\xa0\xbd\x94\xba\xf0\xf0\xa1\xf3\x02\xcb\x48\x26\xb4\xb8\xef\x6b\x73\x50\x6f\xab\xc5\xa4\xd7\x9a\x6f\x4a\xc0\xc3\x77\x69\xdc\xd0\xfc\x2b\xf4\x85\xd5\x0a\x71\x23\x4a\x36\x88\x87\x15\xd8\xb9\x65\xde\x99\x1a\x48\xa1\xba\x69\x41\x90\x7e\x4a\x10\x6c\x24\x1d\x08\xdc\xd7\x19\x55\x59\xd3\x59\xbc\xc0\x82\x59\x89\x02\xb5\xc4\xea\x58\x6e\x88\x5b\x43\x51\xe0\x71\xbc\xca\x18\xd7\x1a\x8c\xf6\xe7\xd9\x18\x38\x6c\xe4\x72\x02\xe0\x2f\xec\x72\x44\x3c\xaf\xd6\xb5\x41\xe8\xb2\xde\x0c\x81\x0f\x8b\x9f\x37\x6d\x67\x68\xb0\x9c\x33\xb8\x8f\x95\xd6\x34\xcc\x40\xa1\x30\x2a\xce\x49\xae\x2b\xa0\x18\xb2\x73\xc9\x35\x58\xe5\x7b\x36\xd8\x4e\x0e\x6a\x0c\x65\x85\xcc\xb3\xc8\x9c\x07\x1b\x7c\x45\x7a\xec\x34\xd8\x32\xb2\xa5\x94\x37\x6c\x89\xd6\x02\xb6\x3a\xb4\xb9\x89\xd8\x94\xdd\x09\x93\xb3\x7a\xcd\x8c\x24\xce\xe7\x85\x28\x85\x79\xc9\xd6\x4a\xa4\x34\x27\x6e\xa1\x40\x5f\x55\x0c\x78\x9a\x33\x2d\x32\x6a\x88\x56\x20\xd6\xc6\xcd\xd1\x48\x44\x27\x53\x36\x8c\x28\x02\xad\x5c\x6f\xe0\xd3\xc7\x8f\x1a\x4b\x48\xcc\x88\xce\xda\xf0\xb6\x7a\x9c\xa6\x3b\x22\x46\xec\x51\xd0\x58\x2a\x33\xb0\x59\x29\xb4\xae\x6d\xb4\x9c\x88\x23\x1b\x95\x0c\x22\xaf\x3a\x8a\x6f\x11\x08\x6b\xed\x02\xf5\x8e\x83\x81\xdb\x81\x4e\x79\xc1\x15\xbb\x12\x25\x24\xc9\x61\x13\x8d\xc6\x16\x05\x29\x7e\x5b\xd3\x38\x6a\xed\xf9\xa6\x64\xc4\x9a\x5f\xe3\x79\x18\x96\x1b\xa8\x66\x09\xe0\xac\x6f\x8e\xc4\x1d\xc3\x9e\x79\x76\x8e\x7f\xbf\x90\x4c\xda\x33\xe8\xf4\x29\xc3\x00\x55\x46\xac\x04\xfa\xa1\x10\x37\x80\x33\xcd\x9e\x63\x37\x16\x94\xa8\x78\x09\x26\xaa\xf1\xf7\xe7\x8b\x2b\xe4\x3f\xb9\x9c\x1f\x33\xbb\xc5\xe0\xd4\xab\xb0\xbb\xdd\xc2\x94\xa5\xe8\x4c\x61\xfe\xe0\xc5\x3a\xe7\x78\xf8\x33\x4a\xfe\x1e\xec\xc9\xd3\x59\x22\x28\xbe\x51\xa8\x49\x3d\x12\x15\xfa\x4d\xd2\x73\x79\xcf\xe3\x7e\x7f\xb2\x12\xbe\xc2\x3c\xe9\x73\x4f\x9c\xfd\xe8\x2a\x98\x57\x2b\xc9\xb0\x22\x53\x25\x96\x58\xde\xdc\x02\xfb\x8e\x9d\x31\xa8\xb2\xd3\x5a\x69\xb4\x85\x06\x7c\x0e\x8e\x3b\x38\xda\x3b\xa6\xe0\xda\x34\xd1\xf1\x20\x92\x37\x45\x2a\x56\x6b\x4a\xc9\x86\xf9\xb9\xcd\x8e\x97\xb8\x53\x5c\xd7\x6e\x42\xf9\x2c\xfd\x64\x2c\xcb\xcc\x99\x77\xe9\x55\x44\x0b\x31\xb9\x95\x71\x5a\xb4\x6e\x09\x8a\xb5\x20\x5c\x84\xde\xa1\x18\x62\x0d\xb3\x68\xd2\x3a\xac\xd9\x2c\x51\x60\xd4\xdd\x51\x7a\x58\x02\x5b\x50\xbd\x44\xd5\xd4\xe6\x94\x18\xdc\x72\x88\x4c\xd5\xaa\xb0\x83\xec\x0c\xb4\x11\x95\xfd\x19\xf1\xe4\xb2\x84\x33\x59\x72\x11\x03\xc9\x2d\x6f\x65\x66\x13\xcc\x6d\x7b\x5e\x9a\xc9\x71\xaf\xcd\x65\x91\x69\x6c\x49\xe1\x37\x22\xf0\x90\x6b\x1d\x16\xdb\xd7\xf4\x85\xd0\x25\xd6\x04\xea\x88\x88\x0f\xaf\xdc\xcf\xc9\x47\x52\x54\x5c\x57\x98\x9e\x08\x5d\xd8\x5f\x16\x98\x71\xc3\x11\x72\x86\xff\xce\xc9\x0a\x0b\xfc\xe1\x4b\xd9\x8f\x5d\x90\x7e\xe4\xb2\xf3\xad\x77\x18\xbf\x32\x3c\x48\xdc\x60\xde\xfb\xc1\xfc\x20\x69\x83\x61\xea\x8b\xad\x4d\x69\xaa\xb7\x42\xde\x05\x80\x2f\x80\x12\xb2\x01\x2c\x17\xd7\x79\x1f\xd8\x2b\x5f\x5b\x10\x24\x93\xd7\x26\x7f\x8f\xa5\x2b\x54\x14\x8c\x89\x87\xdf\xca\x94\x2f\x0b\xe8\x23\xe6\x65\x59\x9b\x1e\xc2\x1f\xd0\xd4\x95\x95\x1d\xad\x20\x6d\xf1\xc5\x75\xed\x86\x73\xf8\x76\x13\xfc\x8d\xe0\x4b\x51\x08\x23\xa8\x4e\xa3\xfe\x62\x67\xfe\x38\x52\xe8\x13\x54\x4b\x2a\xf1\x57\x64\xc5\x8e\xbe\xe1\x95\x75\xe5\x4e\xba\xde\xc0\x26\x92\x77\x07\xe8\xc2\xd0\xb2\xba\xa3\xc1\x8d\x3e\x46\xed\x81\xd9\xf6\x66\xbb\x7d\xb8\x72\xcf\xd8\x2d\x2f\x6a\x3b\xd2\x68\x43\xfb\xe9\x19\x12\xd1\x30\xc9\x9a\x8e\x1c\xfa\xc9\xf0\x54\xcb\xd8\x39\xc8\x72\x38\xcd\x6d\x07\xef\x5c\xb4\x6d\xa7\xce\x23\xfe\xb5\x82\xdb\x9f\x7b\x20\x3b\x64\xae\x68\xc6\x74\x1a\x73\x8a\x4d\x50\xaf\xea\xa2\xd3\x65\xa2\x2e\xed\xae\xb0\xa3\xd8\xa8\x05\x75\x58\xd2\x42\x6a\xc8\x4e\xf0\x9b\x76\x0e\x72\x9b\x34\xbc\x38\x95\xa2\x8a\x23\xb5\x02\xb8\xc4\xf0\xc4\x41\x47\x2f\xbd\x86\x60\x13\x7d\xbe\x07\x0d\xea\x36\x80\x4a\xfe\xe9\xea\xd3\x02\xb7\x1c\x0c\xaf\x87\xad\x95\x34\x32\x95\xc5\x6f\x58\xc2\x76\x82\x34\x1e\x01\xac\x24\xf5\x7b\x16\x8d\xea\xff\x7b\xf6\x7f\xa7\x67\x87\xbe\x18\xbd\xe8\x7c\x1e\xd6\xc1\x57\x92\x3e\x6e\x67\xee\xe5\x69\xd1\x2b\xac\xa2\x69\x16\xae\xf4\x28\xb9\x15\x70\xd3\xc9\x6e\x2d\x6b\x95\x42\xd3\x4e\xe3\x63\x62\xf8\x62\xb8\x14\x61\x01\x0c\x99\xf0\x1e\x01\xb4\x3d\x2a\xbb\x3a\x6b\xa3\xa4\x5c\xe3\x1d\x09\xd7\x3d\x6d\x49\xf0\xbf\xa0\xa6\x8a\x2b\xde\x86\x95\x7c\xc3\x2a\x69\xf0\xea\x61\x88\x1a\xe3\x80\x4a\xcf\xac\xe4\xd3\x1c\x17\x42\x88\xd7\x32\xac\x1d\x5b\x5c\x01\x30\x5e\xbc\x50\xe1\x8d\x0b\x51\xdd\xca\xc1\x11\x52\x17\x66\x0c\xf6\x16\x0c\xef\xc2\xf1\xf4\x21\xb0\x84\x52\x5e\x75\x17\x6b\x02\xb5\xe9\x46\x9b\x17\x37\xb5\xb2\x2b\x59\x43\xf3\xb1\x5b\x31\x0f\x9b\xcf\xff\x50\x36\x1e\x76\x1e\xc6\x66\x78\x13\x30\x58\x23\x9a\xb9\x99\x4d\x1b\xf9\x2f\x8b\x8b\x77\xb8\xd2\x63\x6f\xd6\x58\x84\xee\xfd\xcc\x2d\xeb\x74\x2b\x0b\x4f\x6a\x78\x0d\xad\x55\xe5\xee\x91\xfe\x8a\x44\x17\xa4\xe6\xa2\x4c\xef\x2a\x36\xf3\xf5\x1a\x52\x9c\x67\x69\x42\x57\x4f\xc0\x43\x9a\xb1\x12\x0c\x1e\x2c\xe6\xe3\xd5\xd0\xbb\xee\xd0\xe7\xdc\x27\xc3\xb6\xd4\x1e\x16\x41\xd4\x5a\x7b\x83\x27\xc2\x2c\x46\x0b\xaf\xf1\xd4\x38\x4b\xa7\x95\x7e\xbf\xc0\x39\xb1\xf7\x8b\x5a\xc3\x94\x1c\xde\x3b\x64\xdd\x80\x39\xad\xbe\x41\xb4\xf8\x1e\x71\x1a\x38\xdb\x37\x52\xbb\xc7\xee\xa9\x44\xf7\x61\xad\x5d\xc9\xa2\x0d\x2f\x7e\x81\x21\xd5\xca\x9e\x66\xf6\x79\xa7\xff\xfd\xae\x9d\xe9\xf8\x75\x16\x7a\xf0\xd6\x4b\x5f\x8c\x21\x03\x9d\x99\xdf\x6b\x05\x27\xc4\x9e\x1e\x72\x63\xe3\x94\x16\xa1\x56\x2f\x7f\xd3\x98\x67\xbd\xa5\xe7\x62\x14\x3a\x2c\x3b\x0b\x2d\xc7\x80\xdd\xfd\xba\x79\xf8\x1b\x8a\xf5\x2f\x82\xc3\x44\x69\x10\xe5\x16\x78\x7f\x7f\xd7\x30\xd7\x8b\x26\x0b\xda\x4a\x8e\x82\xd8\x89\x61\x9b\x64\xfe\xc5\xcd\xed\xb0\x5f\x49\x9a\xa5\xa0\xcb\xfc\x87\x4b\x12\xf4\x86\x1e\x02\xed\x40\xe0\xfa\x66\x08\xf5\x07\xb4\x50\x3a\xe1\x3e\x49\xd6\xcf\xd0\xce\xb3\x10\xbd\xaa\x8d\xdc\x63\xc6\x63\x3f\xc8\xf5\xaa\x2e\x1b\x8f\xeb\xb0\x55\x8f\x3e\x4a\x18\x59\x16\xdb\xb6\xfe\x36\x23\x49\x11\x02\x91\x17\x1c\xee\x1c\xff\x58\xdf\xf8\x77\xa8\xe3\xf0\xdc\xd3\x17\x43\xa4\x24\x20\xed\x3c\xf5\x90\x92\xf6\x99\xcb\xaf\x39\x23\xeb\xd5\xb8\x02\x11\xc1\x5e\x5a\xf4\xe8\x77\xa8\xd2\xe9\xfb\x21\x81\x86\xd3\x3c\xd6\x26\xa0\xf7\xd2\xa5\x43\xbd\x43\x93\x40\xd7\xb2\xf6\x27\x49\xac\x84\xc3\xed\xa5\x41\x4b\xba\xe3\x78\x47\xd4\xeb\xd4\x5b\xec\x27\xd4\x7e\xb6\x7b\xca\x5d\x76\x13\x4d\xaf\xff\x6d\xcd\x82\x0c\xf6\x8d\xbf\xa3\xdc\x1d\xf9\x0c\x46\x2a\x70\xfc\xec\x80\xde\xeb\xfc\x0e\xf5\x0e\x1d\x02\x1d\xb1\xfe\x0d\x43\x81\xcb\x7d\x7a\x1f\x00\x00")
+
+func schemaGqlBytes() ([]byte, error) {
+	return bindataRead(
+		_schemaGql,
+		"schema.gql",
+	)
+}
+
+func schemaGql() (*asset, error) {
+	bytes, err := schemaGqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "schema.gql", size: 8058, mode: os.FileMode(0644), modTime: time.Unix(1559692126, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xae, 0x96, 0x73, 0xa4, 0x52, 0xb5, 0x59, 0x19, 0xea, 0x7f, 0x3f, 0xaa, 0xde, 0x76, 0x15, 0x8a, 0x26, 0x86, 0x50, 0xcf, 0x99, 0x43, 0x87, 0x7d, 0xdb, 0x1a, 0x7e, 0xd, 0x90, 0x3d, 0x14, 0xad}}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// AssetString returns the asset contents as a string (instead of a []byte).
+func AssetString(name string) (string, error) {
+	data, err := Asset(name)
+	return string(data), err
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// MustAssetString is like AssetString but panics when Asset would return an
+// error. It simplifies safe initialization of global variables.
+func MustAssetString(name string) string {
+	return string(MustAsset(name))
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetDigest returns the digest of the file with the given name. It returns an
+// error if the asset could not be found or the digest could not be loaded.
+func AssetDigest(name string) ([sha256.Size]byte, error) {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[canonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s can't read by error: %v", name, err)
+		}
+		return a.digest, nil
+	}
+	return [sha256.Size]byte{}, fmt.Errorf("AssetDigest %s not found", name)
+}
+
+// Digests returns a map of all known files and their checksums.
+func Digests() (map[string][sha256.Size]byte, error) {
+	mp := make(map[string][sha256.Size]byte, len(_bindata))
+	for name := range _bindata {
+		a, err := _bindata[name]()
+		if err != nil {
+			return nil, err
+		}
+		mp[name] = a.digest
+	}
+	return mp, nil
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+
+	"schema.gql": schemaGql,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
+// then AssetDir("data") would return []string{"foo.txt", "img"},
+// AssetDir("data/img") would return []string{"a.png", "b.png"},
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error, and
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		canonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(canonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+
+var _bintree = &bintree{nil, map[string]*bintree{
+	"schema.gql": &bintree{schemaGql, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory.
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+}
+
+// RestoreAssets restores an asset under the given directory recursively.
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	canonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(canonicalName, "/")...)...)
+}
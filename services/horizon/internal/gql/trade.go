@@ -0,0 +1,170 @@
+package gql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// trade represents a trade between two offers, or an offer and a path
+// payment
+type trade struct {
+	ID              string
+	PagingToken     string
+	LedgerCloseTime graphql.Time
+	OfferID         string
+	BaseOfferID     string
+	BaseAccount     string
+	BaseAmount      string
+	BaseAsset       asset
+	CounterOfferID  string
+	CounterAccount  string
+	CounterAmount   string
+	CounterAsset    asset
+	BaseIsSeller    bool
+	PriceN          *int32
+	PriceD          *int32
+}
+
+type tradeConnection struct {
+	Edges    []tradeEdge
+	PageInfo pageInfo
+}
+
+type tradeEdge struct {
+	Cursor string
+	Node   *trade
+}
+
+// tradeFilter selects the trades of an account, an offer or an asset pair,
+// or every trade when it is empty.
+type tradeFilter struct {
+	account string
+	offer   int64
+	base    *xdr.Asset
+	counter *xdr.Asset
+}
+
+func newTrade(res horizon.Trade) *trade {
+	t := &trade{
+		ID:              res.ID,
+		PagingToken:     res.PT,
+		LedgerCloseTime: graphql.Time{Time: res.LedgerCloseTime},
+		OfferID:         res.OfferID,
+		BaseOfferID:     res.BaseOfferID,
+		BaseAccount:     res.BaseAccount,
+		BaseAmount:      res.BaseAmount,
+		BaseAsset: asset{
+			Type:   res.BaseAssetType,
+			Code:   res.BaseAssetCode,
+			Issuer: res.BaseAssetIssuer,
+		},
+		CounterOfferID: res.CounterOfferID,
+		CounterAccount: res.CounterAccount,
+		CounterAmount:  res.CounterAmount,
+		CounterAsset: asset{
+			Type:   res.CounterAssetType,
+			Code:   res.CounterAssetCode,
+			Issuer: res.CounterAssetIssuer,
+		},
+		BaseIsSeller: res.BaseIsSeller,
+	}
+	if res.Price != nil {
+		t.PriceN = &res.Price.N
+		t.PriceD = &res.Price.D
+	}
+	return t
+}
+
+// loadTrades returns a page of the trades selected by filter.
+func (r *resolver) loadTrades(ctx context.Context, filter tradeFilter, args pageArgs) (*tradeConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	trades := hq.Trades()
+	if filter.account != "" {
+		trades.ForAccount(filter.account)
+	}
+	if filter.base != nil && filter.counter != nil {
+		baseAssetID, err := hq.GetAssetID(*filter.base)
+		if isNotFound(err) {
+			return &tradeConnection{}, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "loading base asset")
+		}
+		counterAssetID, err := hq.GetAssetID(*filter.counter)
+		if isNotFound(err) {
+			return &tradeConnection{}, nil
+		} else if err != nil {
+			return nil, errors.Wrap(err, "loading counter asset")
+		}
+		trades = hq.TradesForAssetPair(baseAssetID, counterAssetID)
+	} else if filter.base != nil || filter.counter != nil {
+		return nil, errors.New("trades can only be filtered by a pair of assets")
+	}
+	if filter.offer != 0 {
+		trades = trades.ForOffer(filter.offer)
+	}
+
+	var rows []history.Trade
+	if err = trades.Page(pq).Select(&rows); err != nil {
+		return nil, errors.Wrap(err, "loading trades")
+	}
+
+	n, hasNextPage := args.pageLength(len(rows))
+	connection := &tradeConnection{}
+	var cursors []string
+	for _, row := range rows[:n] {
+		var res horizon.Trade
+		resourceadapter.PopulateTrade(ctx, &res, row)
+		node := newTrade(res)
+		connection.Edges = append(connection.Edges, tradeEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Trades resolves the trades query.
+func (r *resolver) Trades(ctx context.Context, args struct {
+	pageArgs
+	BaseAsset    *assetInput
+	CounterAsset *assetInput
+	OfferID      *string
+}) (*tradeConnection, error) {
+	var filter tradeFilter
+	if args.BaseAsset != nil {
+		base, err := args.BaseAsset.xdrAsset()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid base asset")
+		}
+		filter.base = &base
+	}
+	if args.CounterAsset != nil {
+		counter, err := args.CounterAsset.xdrAsset()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid counter asset")
+		}
+		filter.counter = &counter
+	}
+	if args.OfferID != nil {
+		id, err := strconv.ParseInt(*args.OfferID, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid offer id %q", *args.OfferID)
+		}
+		filter.offer = id
+	}
+	return r.loadTrades(ctx, filter, args.pageArgs)
+}
@@ -0,0 +1,165 @@
+package gql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
+	"github.com/paydex-core/paydex-go/support/errors"
+)
+
+// transaction represents a transaction included in a ledger, with its fees
+// as strings since they may not fit in a GraphQL Int
+type transaction struct {
+	Hash                  string
+	PagingToken           string
+	Successful            bool
+	LedgerSequence        int32
+	CreatedAt             graphql.Time
+	SourceAccount         string
+	SourceAccountSequence string
+	FeeAccount            string
+	FeeCharged            string
+	MaxFee                string
+	OperationCount        int32
+	EnvelopeXdr           string
+	ResultXdr             string
+	ResultMetaXdr         string
+	FeeMetaXdr            string
+	MemoType              string
+	Memo                  *string
+	Signatures            []string
+
+	r *resolver
+}
+
+type transactionConnection struct {
+	Edges    []transactionEdge
+	PageInfo pageInfo
+}
+
+type transactionEdge struct {
+	Cursor string
+	Node   *transaction
+}
+
+func (r *resolver) newTransaction(res horizon.Transaction) *transaction {
+	tx := &transaction{
+		Hash:                  res.Hash,
+		PagingToken:           res.PT,
+		Successful:            res.Successful,
+		LedgerSequence:        res.Ledger,
+		CreatedAt:             graphql.Time{Time: res.LedgerCloseTime},
+		SourceAccount:         res.Account,
+		SourceAccountSequence: res.AccountSequence,
+		FeeAccount:            res.FeeAccount,
+		FeeCharged:            strconv.FormatInt(res.FeeCharged, 10),
+		MaxFee:                strconv.FormatInt(res.MaxFee, 10),
+		OperationCount:        res.OperationCount,
+		EnvelopeXdr:           res.EnvelopeXdr,
+		ResultXdr:             res.ResultXdr,
+		ResultMetaXdr:         res.ResultMetaXdr,
+		FeeMetaXdr:            res.FeeMetaXdr,
+		MemoType:              res.MemoType,
+		Signatures:            res.Signatures,
+		r:                     r,
+	}
+	if res.MemoType != "none" {
+		memo := res.Memo
+		tx.Memo = &memo
+	}
+	return tx
+}
+
+// loadTransaction returns the transaction with the given hash, or nil if it
+// is not in the history.
+func (r *resolver) loadTransaction(ctx context.Context, hash string) (*transaction, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := actions.TransactionResource(ctx, hq, hash)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "loading transaction")
+	}
+	return r.newTransaction(res), nil
+}
+
+// loadTransactions returns a page of the transactions of an account or a
+// ledger, or of every transaction when both are empty.
+func (r *resolver) loadTransactions(
+	ctx context.Context,
+	accountID string,
+	ledgerID int32,
+	includeFailed bool,
+	args pageArgs,
+) (*transactionConnection, error) {
+	hq, err := historyQ(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pq, err := args.pageQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := actions.TransactionPage(ctx, hq, accountID, ledgerID, includeFailed, actions.TransactionFilters{}, pq)
+	if err != nil {
+		return nil, err
+	}
+
+	records := page.Embedded.Records
+	n, hasNextPage := args.pageLength(len(records))
+	connection := &transactionConnection{}
+	var cursors []string
+	for _, record := range records[:n] {
+		res, ok := record.(horizon.Transaction)
+		if !ok {
+			return nil, errors.Errorf("unexpected transaction record %T", record)
+		}
+		node := r.newTransaction(res)
+		connection.Edges = append(connection.Edges, transactionEdge{Cursor: node.PagingToken, Node: node})
+		cursors = append(cursors, node.PagingToken)
+	}
+	connection.PageInfo = newPageInfo(cursors, hasNextPage)
+	return connection, nil
+}
+
+// Transaction resolves the transaction query.
+func (r *resolver) Transaction(ctx context.Context, args struct{ Hash string }) (*transaction, error) {
+	return r.loadTransaction(ctx, args.Hash)
+}
+
+// Transactions resolves the transactions query.
+func (r *resolver) Transactions(ctx context.Context, args struct {
+	pageArgs
+	IncludeFailed bool
+}) (*transactionConnection, error) {
+	return r.loadTransactions(ctx, "", 0, args.IncludeFailed, args.pageArgs)
+}
+
+// Ledger resolves the ledger of the transaction.
+func (t *transaction) Ledger(ctx context.Context) (*ledger, error) {
+	l, err := t.r.loadLedger(ctx, t.LedgerSequence)
+	if err == nil && l == nil {
+		err = errors.Errorf("ledger %d of transaction %s not found", t.LedgerSequence, t.Hash)
+	}
+	return l, err
+}
+
+// Operations resolves the operations of the transaction, which include the
+// operations of failed transactions like in the REST API.
+func (t *transaction) Operations(ctx context.Context, args pageArgs) (*operationConnection, error) {
+	return t.r.loadOperations(ctx, operationFilter{transaction: t.Hash}, args)
+}
+
+// Effects resolves the effects of the transaction.
+func (t *transaction) Effects(ctx context.Context, args pageArgs) (*effectConnection, error) {
+	return t.r.loadEffects(ctx, effectFilter{transaction: t.Hash}, args)
+}
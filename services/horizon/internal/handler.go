@@ -13,6 +13,7 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/hchi"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render"
 	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
@@ -28,12 +29,55 @@ import (
 // with stream mode turned on using server-sent events.
 type streamFunc func(context.Context, *sse.Stream, *indexActionQueryParams) error
 
+// liveStreamFunc represents the signature of the function that builds the
+// events of the records of a published ledger coming after lastEventID, for
+// requests with stream mode turned on.
+type liveStreamFunc func(ctx context.Context, l *pubsub.Ledger, lastEventID string, qp *indexActionQueryParams) ([]sse.Event, error)
+
+// liveStream is the stream of an index endpoint fed by the ledgers published
+// to the topics of kind. The records are only loaded with the streamFunc of
+// the endpoint to catch up from the cursor of the request.
+type liveStream struct {
+	kind   pubsub.Kind
+	events liveStreamFunc
+}
+
+// topic returns the topic of the ledgers published to the stream with qp, or
+// false if no published ledger can hold its records.
+func (ls *liveStream) topic(qp *indexActionQueryParams) (pubsub.Topic, bool) {
+	switch {
+	case qp.LedgerID > 0, qp.PagingParams.Order != db2.OrderAscending, !qp.Filters.IsZero():
+		return pubsub.Topic{}, false
+	case qp.AccountID != "":
+		return pubsub.AccountTopic(ls.kind, qp.AccountID), true
+	default:
+		return pubsub.AllTopic(ls.kind), true
+	}
+}
+
+// streamTopic returns the topic of the ledgers published to the stream of a
+// request with params, or false if the stream polls the database on every
+// ledger instead.
+func streamTopic(sfn streamFunc, live *liveStream, params interface{}) (pubsub.Topic, bool) {
+	switch params := params.(type) {
+	case *indexActionQueryParams:
+		if sfn != nil && live != nil {
+			return live.topic(params)
+		}
+	case *showActionQueryParams:
+		if params.AccountID != "" && params.TxHash == "" {
+			return pubsub.AccountTopic(pubsub.Accounts, params.AccountID), true
+		}
+	}
+	return pubsub.Topic{}, false
+}
+
 // streamableEndpointHandler handles endpoints that have the stream mode
 // available. It inspects the Accept header to determine which function to be
 // executed. If it's "application/hal+json" or "application/json", then jfn
 // will be executed with params. If it's "text/event-stream", then either sfn
 // or jfn will be executed with the streamHandler with params.
-func (we *web) streamableEndpointHandler(jfn interface{}, streamSingleObjectEnabled bool, sfn streamFunc, params interface{}) http.HandlerFunc {
+func (we *web) streamableEndpointHandler(jfn interface{}, streamSingleObjectEnabled bool, sfn streamFunc, live *liveStream, params interface{}) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -57,7 +101,7 @@ func (we *web) streamableEndpointHandler(jfn interface{}, streamSingleObjectEnab
 				return
 			}
 
-			we.streamHandler(jfn, sfn, params).ServeHTTP(w, r)
+			we.streamHandler(jfn, sfn, live, params).ServeHTTP(w, r)
 			return
 		}
 
@@ -70,53 +114,70 @@ func (we *web) streamableEndpointHandler(jfn interface{}, streamSingleObjectEnab
 // provided params.
 // Note that we don't return an error if both jfn and sfn are not nil. sfn will
 // simply take precedence.
-func (we *web) streamHandler(jfn interface{}, sfn streamFunc, params interface{}) http.HandlerFunc {
+// When the stream has a topic and the ingested ledgers are published, the
+// records are loaded again only when a ledger is published to the topic, or
+// never when the events of the published ledgers are built by live.
+func (we *web) streamHandler(jfn interface{}, sfn streamFunc, live *liveStream, params interface{}) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
 		stream := sse.NewStream(ctx, w)
+
+		var ledgers <-chan *pubsub.Ledger
+		if topic, ok := streamTopic(sfn, live, params); ok && we.streams != nil {
+			// Subscribe before loading the records so that no ledger
+			// ingested in between is missed.
+			subscription := we.streams.Subscribe(topic)
+			defer subscription.Unsubscribe()
+			ledgers = subscription.Ledgers()
+		}
+
 		var oldHash [32]byte
+		load := true
 		for {
 			lastLedgerState := ledger.CurrentState()
-			rateLimiter := we.rateLimiter
-			if rateLimiter != nil {
-				limited, _, err := rateLimiter.RateLimiter.RateLimit(rateLimiter.VaryBy.Key(r), 1)
-				if err != nil {
-					stream.Err(errors.Wrap(err, "RateLimiter error"))
-					return
-				}
-				if limited {
-					stream.Err(sse.ErrRateLimited)
-					return
-				}
-			}
 
-			if sfn != nil {
-				err := sfn(ctx, stream, params.(*indexActionQueryParams))
-				if err != nil {
-					stream.Err(err)
-					return
-				}
-			} else if jfn != nil {
-				data, ok, err := hal.ExecuteFunc(ctx, jfn, params)
-				if err != nil {
-					if !ok {
-						panic(err)
+			if load {
+				rateLimiter := we.rateLimiter
+				if rateLimiter != nil {
+					limited, _, err := rateLimiter.RateLimiter.RateLimit(rateLimiter.VaryBy.Key(r), 1)
+					if err != nil {
+						stream.Err(errors.Wrap(err, "RateLimiter error"))
+						return
+					}
+					if limited {
+						stream.Err(sse.ErrRateLimited)
+						return
 					}
-					stream.Err(err)
-					return
-				}
-				resource, err := json.Marshal(data)
-				if err != nil {
-					stream.Err(errors.Wrap(err, "unable to marshal next action resource"))
-					return
 				}
 
-				nextHash := sha256.Sum256(resource)
-				if !bytes.Equal(nextHash[:], oldHash[:]) {
-					oldHash = nextHash
-					stream.SetLimit(10)
-					stream.Send(sse.Event{Data: data})
+				if sfn != nil {
+					err := sfn(ctx, stream, params.(*indexActionQueryParams))
+					if err != nil {
+						stream.Err(err)
+						return
+					}
+				} else if jfn != nil {
+					data, ok, err := hal.ExecuteFunc(ctx, jfn, params)
+					if err != nil {
+						if !ok {
+							panic(err)
+						}
+						stream.Err(err)
+						return
+					}
+					resource, err := json.Marshal(data)
+					if err != nil {
+						stream.Err(errors.Wrap(err, "unable to marshal next action resource"))
+						return
+					}
+
+					nextHash := sha256.Sum256(resource)
+					if !bytes.Equal(nextHash[:], oldHash[:]) {
+						oldHash = nextHash
+						stream.SetLimit(10)
+						stream.Send(sse.Event{Data: data})
+					}
 				}
 			}
 
@@ -129,6 +190,39 @@ func (we *web) streamHandler(jfn interface{}, sfn streamFunc, params interface{}
 				return
 			}
 
+			if ledgers != nil {
+				select {
+				case l, ok := <-ledgers:
+					if !ok {
+						// The subscription fell behind, the client catches
+						// up from the database when reconnecting.
+						break
+					}
+					if sfn == nil {
+						continue
+					}
+
+					load = false
+					events, err := live.events(ctx, l, stream.LastEventID(), params.(*indexActionQueryParams))
+					if err != nil {
+						stream.Err(err)
+						return
+					}
+					for _, event := range events {
+						if stream.IsDone() {
+							break
+						}
+						stream.Send(event)
+					}
+					continue
+				case <-ctx.Done():
+				case <-we.appCtx.Done():
+				}
+
+				stream.Done()
+				return
+			}
+
 			// Make sure this is buffered channel of size 1. Otherwise, the go routine below
 			// will never return if `newLedgers` channel is not read. From Effective Go:
 			// > If the channel is unbuffered, the sender blocks until the receiver has received the value.
@@ -168,14 +262,14 @@ func (we *web) streamShowActionHandler(jfn interface{}, requireAccountID bool) h
 			return
 		}
 
-		we.streamableEndpointHandler(jfn, true, nil, param).ServeHTTP(w, r)
+		we.streamableEndpointHandler(jfn, true, nil, nil, param).ServeHTTP(w, r)
 	})
 }
 
 // streamIndexActionHandler gets the required params for indexable endpoints from
 // the URL, validates the cursor is within history, and finally passes the
 // indexAction query params to the more general purpose streamableEndpointHandler.
-func (we *web) streamIndexActionHandler(jfn interface{}, sfn streamFunc) http.HandlerFunc {
+func (we *web) streamIndexActionHandler(jfn interface{}, sfn streamFunc, live *liveStream) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
@@ -191,7 +285,7 @@ func (we *web) streamIndexActionHandler(jfn interface{}, sfn streamFunc) http.Ha
 			return
 		}
 
-		we.streamableEndpointHandler(jfn, false, sfn, params).ServeHTTP(w, r)
+		we.streamableEndpointHandler(jfn, false, sfn, live, params).ServeHTTP(w, r)
 	})
 }
 
@@ -293,11 +387,24 @@ func getIndexActionQueryParams(r *http.Request, ingestFailedTransactions bool) (
 				"transactions. Set `INGEST_FAILED_TRANSACTIONS=true` to start ingesting them."))
 	}
 
+	filters, err := actions.GetTransactionFilters(r)
+	if err != nil {
+		return nil, err
+	}
+	// memos are only indexed by account, see TransactionsQ.ForMemo
+	if filters.Memo != "" && addr == "" {
+		return nil, problem.MakeInvalidFieldProblem(
+			"account_id",
+			errors.New("is required to filter transactions by memo"),
+		)
+	}
+
 	return &indexActionQueryParams{
 		AccountID:        addr,
 		LedgerID:         lid,
 		PagingParams:     pq,
 		IncludeFailedTxs: includeFailedTx,
+		Filters:          filters,
 	}, nil
 }
 
@@ -372,6 +479,34 @@ func (handler objectActionHandler) ServeHTTP(
 
 const singleObjectStreamLimit = 10
 
+// topicAction is implemented by the streamable actions whose resources only
+// change when a ledger is published to a topic.
+type topicAction interface {
+	Topic(r *http.Request) (pubsub.Topic, error)
+}
+
+// serveStream serves the stream of action with streamHandler, subscribed to
+// the topic of action if it has one.
+func serveStream(
+	streamHandler sse.StreamHandler,
+	action interface{},
+	w http.ResponseWriter,
+	r *http.Request,
+	limit int,
+	generateEvents sse.GenerateEventsFunc,
+) {
+	if action, ok := action.(topicAction); ok {
+		topic, err := action.Topic(r)
+		if err != nil {
+			problem.Render(r.Context(), w, err)
+			return
+		}
+		streamHandler.ServeTopicStream(w, r, limit, topic, generateEvents)
+		return
+	}
+	streamHandler.ServeStream(w, r, limit, generateEvents)
+}
+
 type streamableObjectAction interface {
 	GetResource(
 		w actions.HeaderWriter,
@@ -416,7 +551,9 @@ func (handler streamableObjectActionHandler) renderStream(
 ) {
 	var lastResponse actions.StreamableObjectResponse
 
-	handler.streamHandler.ServeStream(
+	serveStream(
+		handler.streamHandler,
+		handler.action,
 		w,
 		r,
 		singleObjectStreamLimit,
@@ -488,7 +625,9 @@ func (handler pageActionHandler) renderStream(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	handler.streamHandler.ServeStream(
+	serveStream(
+		handler.streamHandler,
+		handler.action,
 		w,
 		r,
 		int(pq.Limit),
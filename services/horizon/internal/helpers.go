@@ -3,6 +3,7 @@ package horizon
 import (
 	"encoding/hex"
 
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/support/errors"
 )
 
@@ -39,3 +40,13 @@ func isValidTransactionHash(hash string) bool {
 
 	return len(decoded) == 32
 }
+
+// liveCursor returns the cursor the records of the ledgers published to a
+// stream must come after: the id of the last event sent, or the cursor of the
+// request if no event was sent.
+func liveCursor(lastEventID string, pq db2.PageQuery) string {
+	if lastEventID != "" {
+		return lastEventID
+	}
+	return pq.Cursor
+}
@@ -32,12 +32,13 @@ func (assetStats *AssetStats) handlePaymentOp(paymentOp *xdr.PaymentOp, sourceAc
 	if err != nil {
 		return err
 	}
-	return assetStats.updateIfAssetIssuerInvolved(paymentOp.Asset, paymentOp.Destination)
+	return assetStats.updateIfAssetIssuerInvolved(paymentOp.Asset, paymentOp.Destination.ToAccountId())
 }
 
-func defaultSourceAccount(sourceAccount *xdr.AccountId, defaultAccount *xdr.AccountId) *xdr.AccountId {
+func defaultSourceAccount(sourceAccount *xdr.MuxedAccount, defaultAccount *xdr.AccountId) *xdr.AccountId {
 	if sourceAccount != nil {
-		return sourceAccount
+		aid := sourceAccount.ToAccountId()
+		return &aid
 	}
 	return defaultAccount
 }
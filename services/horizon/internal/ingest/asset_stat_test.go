@@ -194,7 +194,7 @@ func TestAssetModified(t *testing.T) {
 			wantAssets: []string{},
 		}, {
 			opBody: makeOperationBody(xdr.OperationTypePayment, xdr.PaymentOp{
-				Destination: destAccount,
+				Destination: destAccount.ToMuxedAccount(),
 				Asset:       issuerUSD,
 				Amount:      100,
 			}),
@@ -202,7 +202,7 @@ func TestAssetModified(t *testing.T) {
 		}, {
 			// payments is the only operation where we currently perform the optimization of checking against the issuer
 			opBody: makeOperationBody(xdr.OperationTypePayment, xdr.PaymentOp{
-				Destination: issuerAccount,
+				Destination: issuerAccount.ToMuxedAccount(),
 				Asset:       issuerUSD,
 				Amount:      100,
 			}),
@@ -210,7 +210,7 @@ func TestAssetModified(t *testing.T) {
 		}, {
 			// payments is the only operation where we currently perform the optimization of checking against the issuer
 			opBody: makeOperationBody(xdr.OperationTypePayment, xdr.PaymentOp{
-				Destination: issuerAccount,
+				Destination: issuerAccount.ToMuxedAccount(),
 				Asset:       sourceUSD,
 				Amount:      100,
 			}),
@@ -219,7 +219,7 @@ func TestAssetModified(t *testing.T) {
 			opBody: makeOperationBody(xdr.OperationTypePathPaymentStrictReceive, xdr.PathPaymentStrictReceiveOp{
 				SendAsset:   issuerUSD,
 				SendMax:     1000000,
-				Destination: destAccount,
+				Destination: destAccount.ToMuxedAccount(),
 				DestAsset:   anotherUSD,
 				DestAmount:  100,
 				Path:        []xdr.Asset{issuerUSD, destEUR, anotherUSD},
@@ -233,7 +233,7 @@ func TestAssetModified(t *testing.T) {
 			opBody: makeOperationBody(xdr.OperationTypePathPaymentStrictSend, xdr.PathPaymentStrictSendOp{
 				SendAsset:   issuerUSD,
 				SendAmount:  1000000,
-				Destination: destAccount,
+				Destination: destAccount.ToMuxedAccount(),
 				DestAsset:   anotherUSD,
 				DestMin:     100,
 				Path:        []xdr.Asset{issuerUSD, destEUR, anotherUSD},
@@ -369,9 +369,10 @@ func TestAssetModified(t *testing.T) {
 			}
 
 			assetsStats := AssetStats{CoreSession: session}
+			muxedSourceAccount := sourceAccount.ToMuxedAccount()
 			assetsStats.IngestOperation(
 				&xdr.Operation{
-					SourceAccount: &sourceAccount,
+					SourceAccount: &muxedSourceAccount,
 					Body:          kase.opBody,
 				},
 				&sourceAccount)
@@ -154,7 +154,7 @@ func (c *Cursor) NextTx() bool {
 
 // Operation returns the current operation
 func (c *Cursor) Operation() *xdr.Operation {
-	return &c.data.Transactions[c.tx].Envelope.Tx.Operations[c.op]
+	return &c.data.Transactions[c.tx].Envelope.Operations()[c.op]
 }
 
 // OperationChanges returns all of LedgerEntryChanges that occurred in the
@@ -165,7 +165,7 @@ func (c *Cursor) OperationChanges() xdr.LedgerEntryChanges {
 
 // OperationCount returns the count of operations in the current transaction
 func (c *Cursor) OperationCount() int {
-	return len(c.data.Transactions[c.tx].Envelope.Tx.Operations)
+	return len(c.data.Transactions[c.tx].Envelope.Operations())
 }
 
 // OperationID returns the current operations id, as used by the history system.
@@ -182,19 +182,26 @@ func (c *Cursor) OperationOrder() int32 {
 // OperationResult returns the current operation's result record
 func (c *Cursor) OperationResult() *xdr.OperationResultTr {
 	txr := &c.data.Transactions[c.tx].Result.Result
-	tr := txr.Result.MustResults()[c.op].MustTr()
+	results, _ := txr.OperationResults()
+	tr := results[c.op].MustTr()
 	return &tr
 }
 
 // OperationSourceAccount returns the current operation's effective source
 // account (i.e. default's to the transaction's source account).
 func (c *Cursor) OperationSourceAccount() xdr.AccountId {
+	return c.OperationSourceMuxedAccount().ToAccountId()
+}
+
+// OperationSourceMuxedAccount returns the current operation's effective source
+// account as it appears in the transaction, which may be a multiplexed account.
+func (c *Cursor) OperationSourceMuxedAccount() xdr.MuxedAccount {
 	aid := c.Operation().SourceAccount
 	if aid != nil {
 		return *aid
 	}
 
-	return c.TransactionSourceAccount()
+	return c.Transaction().Envelope.SourceAccount()
 }
 
 // OperationType returns the current operation type
@@ -204,7 +211,7 @@ func (c *Cursor) OperationType() xdr.OperationType {
 
 // Operations returns the current transactions operations.
 func (c *Cursor) Operations() []xdr.Operation {
-	return c.data.Transactions[c.tx].Envelope.Tx.Operations
+	return c.data.Transactions[c.tx].Envelope.Operations()
 }
 
 // Transaction returns the current transaction
@@ -233,7 +240,7 @@ func (c *Cursor) SuccessfulLedgerOperationCount() (ret int) {
 		if !c.data.Transactions[i].IsSuccessful() {
 			continue
 		}
-		ret += len(c.data.Transactions[i].Envelope.Tx.Operations)
+		ret += len(c.data.Transactions[i].Envelope.Operations())
 	}
 	return
 }
@@ -268,5 +275,5 @@ func (c *Cursor) TransactionID() int64 {
 
 // TransactionSourceAccount returns the current transaction's source account id
 func (c *Cursor) TransactionSourceAccount() xdr.AccountId {
-	return c.Transaction().Envelope.Tx.SourceAccount
+	return c.Transaction().Envelope.SourceAccount().ToAccountId()
 }
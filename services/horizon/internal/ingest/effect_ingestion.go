@@ -18,6 +18,22 @@ func (ei *EffectIngestion) Add(aid xdr.AccountId, typ history.EffectType, detail
 	return ei.err == nil
 }
 
+// AddMuxed writes an effect for the account backing the provided muxed
+// account. If the account is multiplexed its M... address and id are recorded
+// in the effect details as `account_muxed` and `account_muxed_id`.
+func (ei *EffectIngestion) AddMuxed(muxed xdr.MuxedAccount, typ history.EffectType, details map[string]interface{}) bool {
+	if muxed.Type == xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
+		withMuxed := make(map[string]interface{}, len(details)+2)
+		for k, v := range details {
+			withMuxed[k] = v
+		}
+		addMuxedAccountDetails(withMuxed, muxed, "account")
+		details = withMuxed
+	}
+
+	return ei.Add(muxed.ToAccountId(), typ, details)
+}
+
 // Finish marks this ingestion as complete, returning any error that was recorded.
 func (ei *EffectIngestion) Finish() error {
 	err := ei.err
@@ -327,6 +327,11 @@ func (ingest *Ingestion) Transaction(
 	// Enquote empty signatures
 	signatures := tx.Base64Signatures()
 
+	var innerSignatures interface{}
+	if tx.IsFeeBump() {
+		innerSignatures = sqx.StringArray(tx.InnerBase64Signatures())
+	}
+
 	return ingest.builders[TransactionsTableName].Values(
 		id,
 		tx.TransactionHash,
@@ -336,27 +341,32 @@ func (ingest *Ingestion) Transaction(
 		tx.Sequence(),
 		tx.MaxFee(),
 		tx.FeeCharged(),
-		len(tx.Envelope.Tx.Operations),
+		len(tx.Envelope.Operations()),
 		tx.EnvelopeXDR(),
 		tx.ResultXDR(),
 		tx.ResultMetaXDR(),
 		fee.ChangesXDR(),
 		sqx.StringArray(signatures),
-		ingest.formatTimeBounds(tx.Envelope.Tx.TimeBounds),
+		ingest.formatTimeBounds(tx.Envelope.TimeBounds()),
 		tx.MemoType(),
 		tx.Memo(),
 		time.Now().UTC(),
 		time.Now().UTC(),
 		successful,
+		tx.FeeAccount(),
+		tx.InnerTransactionHash(),
+		tx.NewMaxFee(),
+		innerSignatures,
 	)
 }
 
 // TransactionParticipants ingests the provided account ids as participants of
-// transaction with id `tx`, creating a new row in the
-// `history_transaction_participants` table.
-func (ingest *Ingestion) TransactionParticipants(tx int64, aids []xdr.AccountId) {
+// transaction `tx`, creating a new row in the
+// `history_transaction_participants` table. The memo of the transaction is
+// copied to every row, to look up the transactions of an account by memo.
+func (ingest *Ingestion) TransactionParticipants(tx int64, memoType string, memo null.String, aids []xdr.AccountId) {
 	for _, aid := range aids {
-		ingest.builders[TransactionParticipantsTableName].Values(tx, Address(aid.Address()))
+		ingest.builders[TransactionParticipantsTableName].Values(tx, Address(aid.Address()), memoType, memo)
 	}
 }
 
@@ -411,6 +421,10 @@ func (ingest *Ingestion) createInsertBuilders() {
 			"created_at",
 			"updated_at",
 			"successful",
+			"fee_account",
+			"inner_transaction_hash",
+			"new_max_fee",
+			"inner_signatures",
 		},
 	}
 
@@ -419,6 +433,8 @@ func (ingest *Ingestion) createInsertBuilders() {
 		Columns: []string{
 			"history_transaction_id",
 			"history_account_id",
+			"memo_type",
+			"memo",
 		},
 	}
 
@@ -134,6 +134,9 @@ type System struct {
 	HistoryRetentionCount uint
 	// IngestFailedTransactions toggles whether to ingest failed transactions
 	IngestFailedTransactions bool
+	// LedgersIngested, when set, is called with the sequence of the last
+	// ledger ingested after every successful ingestion session.
+	LedgersIngested func(sequence int32)
 
 	lock    sync.Mutex
 	current *Session
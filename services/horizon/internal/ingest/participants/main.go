@@ -16,20 +16,20 @@ func ForOperation(
 ) (result []xdr.AccountId, err error) {
 
 	if op.SourceAccount != nil {
-		result = append(result, *op.SourceAccount)
+		result = append(result, op.SourceAccount.ToAccountId())
 	} else {
-		result = append(result, tx.SourceAccount)
+		result = append(result, tx.SourceAccount.ToAccountId())
 	}
 
 	switch op.Body.Type {
 	case xdr.OperationTypeCreateAccount:
 		result = append(result, op.Body.MustCreateAccountOp().Destination)
 	case xdr.OperationTypePayment:
-		result = append(result, op.Body.MustPaymentOp().Destination)
+		result = append(result, op.Body.MustPaymentOp().Destination.ToAccountId())
 	case xdr.OperationTypePathPaymentStrictReceive:
-		result = append(result, op.Body.MustPathPaymentStrictReceiveOp().Destination)
+		result = append(result, op.Body.MustPathPaymentStrictReceiveOp().Destination.ToAccountId())
 	case xdr.OperationTypePathPaymentStrictSend:
-		result = append(result, op.Body.MustPathPaymentStrictSendOp().Destination)
+		result = append(result, op.Body.MustPathPaymentStrictSendOp().Destination.ToAccountId())
 	case xdr.OperationTypeManageBuyOffer:
 		// the only direct participant is the source_account
 	case xdr.OperationTypeManageSellOffer:
@@ -43,7 +43,7 @@ func ForOperation(
 	case xdr.OperationTypeAllowTrust:
 		result = append(result, op.Body.MustAllowTrustOp().Trustor)
 	case xdr.OperationTypeAccountMerge:
-		result = append(result, op.Body.MustDestination())
+		result = append(result, op.Body.MustDestination().ToAccountId())
 	case xdr.OperationTypeInflation:
 		// the only direct participant is the source_account
 	case xdr.OperationTypeManageData:
@@ -66,7 +66,7 @@ func ForTransaction(
 	feeMeta *xdr.LedgerEntryChanges,
 ) (result []xdr.AccountId, err error) {
 
-	result = append(result, tx.SourceAccount)
+	result = append(result, tx.SourceAccount.ToAccountId())
 
 	p, err := forMeta(meta)
 	if err != nil {
@@ -18,7 +18,7 @@ func TestForOperation(t *testing.T) {
 
 		err := q.TransactionsByLedger(&txs, lg)
 		tt.Require.NoError(err, "failed to load transaction data")
-		xtx := txs[tx].Envelope.Tx
+		xtx := txs[tx].Envelope.Transaction()
 		xop := xtx.Operations[op]
 		ret, err := ForOperation(&xtx, &xop)
 		tt.Require.NoError(err, "ForOperation() errored")
@@ -96,7 +96,7 @@ func TestPathPaymentStrictSend(t *testing.T) {
 
 		err := q.TransactionsByLedger(&txs, lg)
 		tt.Require.NoError(err, "failed to load transaction data")
-		xtx := txs[tx].Envelope.Tx
+		xtx := txs[tx].Envelope.Transaction()
 		xop := xtx.Operations[op]
 		ret, err := ForOperation(&xtx, &xop)
 		tt.Require.NoError(err, "ForOperation() errored")
@@ -122,7 +122,7 @@ func TestForTransaction(t *testing.T) {
 		err = q.TransactionFeesByLedger(&fees, lg)
 		tt.Require.NoError(err, "failed to load transaction fee data")
 
-		xtx := txs[tx].Envelope.Tx
+		xtx := txs[tx].Envelope.Transaction()
 		meta := txs[tx].ResultMeta
 		fee := fees[tx].Changes
 
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"reflect"
+	"strconv"
 	"time"
 
 	"github.com/paydex-core/paydex-go/clients/paydexcore"
@@ -146,6 +147,7 @@ func (is *Session) ingestEffects() {
 		parent:      is.Ingestion,
 	}
 	source := is.Cursor.OperationSourceAccount()
+	sourceMuxed := is.Cursor.OperationSourceMuxedAccount()
 	opbody := is.Cursor.Operation().Body
 
 	switch is.Cursor.OperationType() {
@@ -158,7 +160,7 @@ func (is *Session) ingestEffects() {
 			},
 		)
 
-		effects.Add(source, history.EffectAccountDebited,
+		effects.AddMuxed(sourceMuxed, history.EffectAccountDebited,
 			map[string]interface{}{
 				"asset_type": "native",
 				"amount":     amount.String(op.StartingBalance),
@@ -177,8 +179,8 @@ func (is *Session) ingestEffects() {
 
 		details := map[string]interface{}{"amount": amount.String(op.Amount)}
 		is.assetDetails(details, op.Asset, "")
-		effects.Add(op.Destination, history.EffectAccountCredited, details)
-		effects.Add(source, history.EffectAccountDebited, details)
+		effects.AddMuxed(op.Destination, history.EffectAccountCredited, details)
+		effects.AddMuxed(sourceMuxed, history.EffectAccountDebited, details)
 
 	case xdr.OperationTypePathPaymentStrictReceive:
 		op := opbody.MustPathPaymentStrictReceiveOp()
@@ -186,12 +188,12 @@ func (is *Session) ingestEffects() {
 
 		details := map[string]interface{}{"amount": amount.String(op.DestAmount)}
 		is.assetDetails(details, op.DestAsset, "")
-		effects.Add(op.Destination, history.EffectAccountCredited, details)
+		effects.AddMuxed(op.Destination, history.EffectAccountCredited, details)
 
 		result := is.Cursor.OperationResult().MustPathPaymentStrictReceiveResult()
 		details = map[string]interface{}{"amount": amount.String(result.SendAmount())}
 		is.assetDetails(details, op.SendAsset, "")
-		effects.Add(source, history.EffectAccountDebited, details)
+		effects.AddMuxed(sourceMuxed, history.EffectAccountDebited, details)
 
 		is.ingestTradeEffects(effects, source, resultSuccess.Offers)
 	case xdr.OperationTypePathPaymentStrictSend:
@@ -201,11 +203,11 @@ func (is *Session) ingestEffects() {
 
 		details := map[string]interface{}{"amount": amount.String(result.DestAmount())}
 		is.assetDetails(details, op.DestAsset, "")
-		effects.Add(op.Destination, history.EffectAccountCredited, details)
+		effects.AddMuxed(op.Destination, history.EffectAccountCredited, details)
 
 		details = map[string]interface{}{"amount": amount.String(op.SendAmount)}
 		is.assetDetails(details, op.SendAsset, "")
-		effects.Add(source, history.EffectAccountDebited, details)
+		effects.AddMuxed(sourceMuxed, history.EffectAccountDebited, details)
 
 		is.ingestTradeEffects(effects, source, resultSuccess.Offers)
 	case xdr.OperationTypeManageBuyOffer:
@@ -331,9 +333,9 @@ func (is *Session) ingestEffects() {
 			"amount":     amount.String(result.MustSourceAccountBalance()),
 			"asset_type": "native",
 		}
-		effects.Add(source, history.EffectAccountDebited, details)
-		effects.Add(dest, history.EffectAccountCredited, details)
-		effects.Add(source, history.EffectAccountRemoved, map[string]interface{}{})
+		effects.AddMuxed(sourceMuxed, history.EffectAccountDebited, details)
+		effects.AddMuxed(dest, history.EffectAccountCredited, details)
+		effects.AddMuxed(sourceMuxed, history.EffectAccountRemoved, map[string]interface{}{})
 	case xdr.OperationTypeInflation:
 		payouts := is.Cursor.OperationResult().MustInflationResult().MustPayouts()
 		for _, payout := range payouts {
@@ -445,9 +447,10 @@ func (is *Session) ingestOperation() {
 		is.ingestTrades()
 
 		if is.Config.EnableAssetStats && is.Err == nil {
+			sourceAccount := is.Cursor.Transaction().Envelope.SourceAccount().ToAccountId()
 			is.Err = is.AssetStats.IngestOperation(
 				is.Cursor.Operation(),
-				&is.Cursor.Transaction().Envelope.Tx.SourceAccount,
+				&sourceAccount,
 			)
 		}
 	}
@@ -465,8 +468,9 @@ func (is *Session) ingestOperationParticipants() {
 
 	// Find the participants
 	var p []xdr.AccountId
+	tx := is.Cursor.Transaction().Envelope.Transaction()
 	p, is.Err = participants.ForOperation(
-		&is.Cursor.Transaction().Envelope.Tx,
+		&tx,
 		is.Cursor.Operation(),
 	)
 	if is.Err != nil {
@@ -694,8 +698,9 @@ func (is *Session) ingestTransactionParticipants() {
 
 	// Find the participants
 	var p []xdr.AccountId
+	tx := is.Cursor.Transaction().Envelope.Transaction()
 	p, is.Err = participants.ForTransaction(
-		&is.Cursor.Transaction().Envelope.Tx,
+		&tx,
 		&is.Cursor.Transaction().ResultMeta,
 		&is.Cursor.TransactionFee().Changes,
 	)
@@ -704,7 +709,33 @@ func (is *Session) ingestTransactionParticipants() {
 		return
 	}
 
-	is.Ingestion.TransactionParticipants(is.Cursor.TransactionID(), p)
+	is.Ingestion.TransactionParticipants(
+		is.Cursor.TransactionID(),
+		is.Cursor.Transaction().MemoType(),
+		is.Cursor.Transaction().Memo(),
+		p,
+	)
+}
+
+// addAccountAndMuxedAccountDetails sets the G... address of the account backing
+// `muxed` on `result` using the key `prefix`, along with the muxed details
+// described in addMuxedAccountDetails.
+func addAccountAndMuxedAccountDetails(result map[string]interface{}, muxed xdr.MuxedAccount, prefix string) {
+	aid := muxed.ToAccountId()
+	result[prefix] = aid.Address()
+	addMuxedAccountDetails(result, muxed, prefix)
+}
+
+// addMuxedAccountDetails sets the M... address and the id of `muxed` on
+// `result` using the keys `prefix_muxed` and `prefix_muxed_id`, if `muxed` is a
+// multiplexed account. Nothing is set for plain ed25519 accounts.
+func addMuxedAccountDetails(result map[string]interface{}, muxed xdr.MuxedAccount, prefix string) {
+	id, ok := muxed.Id()
+	if !ok {
+		return
+	}
+	result[prefix+"_muxed"] = muxed.Address()
+	result[prefix+"_muxed_id"] = strconv.FormatUint(id, 10)
 }
 
 // assetDetails sets the details for `a` on `result` using keys with `prefix`
@@ -736,23 +767,25 @@ func (is *Session) operationDetails() map[string]interface{} {
 	details := map[string]interface{}{}
 	c := is.Cursor
 	source := c.OperationSourceAccount()
+	sourceMuxed := c.OperationSourceMuxedAccount()
+	addMuxedAccountDetails(details, sourceMuxed, "source_account")
 
 	switch c.OperationType() {
 	case xdr.OperationTypeCreateAccount:
 		op := c.Operation().Body.MustCreateAccountOp()
-		details["funder"] = source.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "funder")
 		details["account"] = op.Destination.Address()
 		details["starting_balance"] = amount.String(op.StartingBalance)
 	case xdr.OperationTypePayment:
 		op := c.Operation().Body.MustPaymentOp()
-		details["from"] = source.Address()
-		details["to"] = op.Destination.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "from")
+		addAccountAndMuxedAccountDetails(details, op.Destination, "to")
 		details["amount"] = amount.String(op.Amount)
 		is.assetDetails(details, op.Asset, "")
 	case xdr.OperationTypePathPaymentStrictReceive:
 		op := c.Operation().Body.MustPathPaymentStrictReceiveOp()
-		details["from"] = source.Address()
-		details["to"] = op.Destination.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "from")
+		addAccountAndMuxedAccountDetails(details, op.Destination, "to")
 
 		details["amount"] = amount.String(op.DestAmount)
 		details["source_amount"] = amount.String(0)
@@ -774,8 +807,8 @@ func (is *Session) operationDetails() map[string]interface{} {
 
 	case xdr.OperationTypePathPaymentStrictSend:
 		op := c.Operation().Body.MustPathPaymentStrictSendOp()
-		details["from"] = source.Address()
-		details["to"] = op.Destination.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "from")
+		addAccountAndMuxedAccountDetails(details, op.Destination, "to")
 
 		details["amount"] = amount.String(0)
 		details["source_amount"] = amount.String(op.SendAmount)
@@ -868,19 +901,19 @@ func (is *Session) operationDetails() map[string]interface{} {
 	case xdr.OperationTypeChangeTrust:
 		op := c.Operation().Body.MustChangeTrustOp()
 		is.assetDetails(details, op.Line, "")
-		details["trustor"] = source.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "trustor")
 		details["trustee"] = details["asset_issuer"]
 		details["limit"] = amount.String(op.Limit)
 	case xdr.OperationTypeAllowTrust:
 		op := c.Operation().Body.MustAllowTrustOp()
 		is.assetDetails(details, op.Asset.ToAsset(source), "")
-		details["trustee"] = source.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "trustee")
 		details["trustor"] = op.Trustor.Address()
 		details["authorize"] = op.Authorize
 	case xdr.OperationTypeAccountMerge:
 		aid := c.Operation().Body.MustDestination()
-		details["account"] = source.Address()
-		details["into"] = aid.Address()
+		addAccountAndMuxedAccountDetails(details, sourceMuxed, "account")
+		addAccountAndMuxedAccountDetails(details, aid, "into")
 	case xdr.OperationTypeInflation:
 		// no inflation details, presently
 	case xdr.OperationTypeManageData:
@@ -310,4 +310,8 @@ func (i *System) runOnce() {
 
 	logFields["duration"] = time.Since(ingestStart).Seconds()
 	log.WithFields(logFields).Info("Finished ingesting ledgers")
+
+	if i.LedgersIngested != nil {
+		i.LedgersIngested(is.Cursor.LastLedger)
+	}
 }
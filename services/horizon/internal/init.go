@@ -14,10 +14,12 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/expingest"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ingest"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/simplepath"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub"
 	results "github.com/paydex-core/paydex-go/services/horizon/internal/txsub/results/db"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub/sequence"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/webhooks"
 	"github.com/paydex-core/paydex-go/support/db"
 	"github.com/paydex-core/paydex-go/support/log"
 	metrics "github.com/rcrowley/go-metrics"
@@ -45,6 +47,14 @@ func mustInitCoreDB(app *App) {
 	app.coreQ = &core.Q{session}
 }
 
+func initStreams(app *App) {
+	app.streams = &pubsub.Hub{}
+	app.publisher = pubsub.NewPublisher(
+		app.streams,
+		&history.Q{app.HorizonSession(context.Background())},
+	)
+}
+
 func initIngester(app *App) {
 	if !app.config.Ingest {
 		return
@@ -68,6 +78,7 @@ func initIngester(app *App) {
 
 	app.ingester.SkipCursorUpdate = app.config.SkipCursorUpdate
 	app.ingester.HistoryRetentionCount = app.config.HistoryRetentionCount
+	app.ingester.LedgersIngested = app.publisher.Notify
 }
 
 func initExpIngester(app *App, orderBookGraph *orderbook.OrderBookGraph) {
@@ -106,6 +117,18 @@ func initPathFinder(app *App, orderBookGraph *orderbook.OrderBookGraph) {
 	}
 }
 
+func initWebhooks(app *App) {
+	if !app.config.EnableWebhooks {
+		return
+	}
+
+	app.webhooks = webhooks.New(webhooks.Config{
+		HistorySession: app.HorizonSession(context.Background()),
+		AdminToken:     app.config.WebhooksAdminToken,
+		MaxAttempts:    int32(app.config.WebhooksMaxAttempts),
+	})
+}
+
 // initSentry initialized the default sentry client with the configured DSN
 func initSentry(app *App) {
 	if app.config.SentryDSN == "" {
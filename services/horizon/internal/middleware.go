@@ -254,6 +254,21 @@ func acceptOnlyJSON(h http.Handler) http.Handler {
 	})
 }
 
+// withQueryParam serves the requests including the query parameter `param`
+// with `h` and the other requests with `next`.
+func withQueryParam(param string, h http.Handler) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := r.URL.Query()[param]; ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // ExperimentalIngestionMiddleware is a middleware which enables a handler
 // if the experimental ingestion system is enabled and initialized.
 // It also ensures that state (ledger entries) has been verified and are
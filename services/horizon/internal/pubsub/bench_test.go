@@ -0,0 +1,132 @@
+package pubsub
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
+)
+
+// The benchmarks compare the two ways of feeding the streams of the
+// operations of accounts: every stream querying the database whenever a
+// ledger is closed, and every ledger being loaded once and published to a
+// hub. The database is simulated in memory, the queries/op metric is the
+// number of queries run per ledger closed.
+
+const (
+	benchOperationsPerLedger = 200
+	// publisherQueries is the number of queries of Publisher.load.
+	publisherQueries = 7
+)
+
+var benchStreams = []int{100, 1000, 10000}
+
+// benchDB is the history database of the benchmarks.
+type benchDB struct {
+	accounts []string
+	ledgers  []*Ledger
+	// byAccount is the index of the operations by participant.
+	byAccount map[string][]history.Operation
+	queries   int
+}
+
+func newBenchDB(accounts int) *benchDB {
+	db := &benchDB{byAccount: map[string][]history.Operation{}}
+	for i := 0; i < accounts; i++ {
+		db.accounts = append(db.accounts, keypair.MustRandom().Address())
+	}
+	return db
+}
+
+// close appends a ledger of payments between random accounts.
+func (db *benchDB) close() *Ledger {
+	seq := int32(len(db.ledgers) + 1)
+	l := &Ledger{
+		Ledger:                history.Ledger{Sequence: seq},
+		OperationParticipants: map[int64][]string{},
+	}
+	for i := 0; i < benchOperationsPerLedger; i++ {
+		id := toid.New(seq, int32(i+1), 1).ToInt64()
+		l.Operations = append(l.Operations, history.Operation{
+			TotalOrderID:  history.TotalOrderID{ID: id},
+			TransactionID: toid.New(seq, int32(i+1), 0).ToInt64(),
+		})
+		from := db.accounts[(int(seq)*benchOperationsPerLedger+i)%len(db.accounts)]
+		to := db.accounts[(int(seq)*benchOperationsPerLedger+i*7+1)%len(db.accounts)]
+		l.OperationParticipants[id] = []string{from, to}
+		db.byAccount[from] = append(db.byAccount[from], l.Operations[i])
+		if to != from {
+			db.byAccount[to] = append(db.byAccount[to], l.Operations[i])
+		}
+	}
+	db.ledgers = append(db.ledgers, l)
+	return l
+}
+
+// operationsForAccount is the query of a stream polling the database: the
+// operations of an account after cursor.
+func (db *benchDB) operationsForAccount(account string, cursor int64) []history.Operation {
+	db.queries++
+	index := db.byAccount[account]
+	i := sort.Search(len(index), func(i int) bool {
+		return index[i].ID > cursor
+	})
+	return append([]history.Operation(nil), index[i:]...)
+}
+
+func BenchmarkPolling(b *testing.B) {
+	for _, streams := range benchStreams {
+		b.Run(fmt.Sprintf("streams=%d", streams), func(b *testing.B) {
+			db := newBenchDB(streams)
+			cursors := make([]int64, streams)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				db.close()
+				// every stream wakes up and queries the database
+				for j, account := range db.accounts {
+					operations := db.operationsForAccount(account, cursors[j])
+					if n := len(operations); n > 0 {
+						cursors[j] = operations[n-1].ID
+					}
+				}
+			}
+
+			b.ReportMetric(float64(db.queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func BenchmarkFanOut(b *testing.B) {
+	for _, streams := range benchStreams {
+		b.Run(fmt.Sprintf("streams=%d", streams), func(b *testing.B) {
+			db := newBenchDB(streams)
+			hub := &Hub{}
+			subscriptions := make([]*Subscription, streams)
+			for j, account := range db.accounts {
+				subscriptions[j] = hub.Subscribe(AccountTopic(Operations, account))
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				// the ledger is loaded once and published
+				l := db.close()
+				db.queries += publisherQueries
+				hub.Publish(l)
+
+				// only the streams of the accounts of the ledger wake up
+				for _, subscription := range subscriptions {
+					select {
+					case <-subscription.Ledgers():
+					default:
+					}
+				}
+			}
+
+			b.ReportMetric(float64(db.queries)/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package pubsub
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+)
+
+// AssetPair is an unordered pair of assets, in the format of
+// xdr.Asset.String(). First is lower than Second, so that a pair has a
+// single representation.
+type AssetPair struct {
+	First  string
+	Second string
+}
+
+// Ledger holds the records of an ingested ledger. The ledgers received by a
+// subscription only hold the records of its topic, and the transactions of
+// its operations.
+type Ledger struct {
+	Ledger       history.Ledger
+	Transactions []history.Transaction
+	Operations   []history.Operation
+	Effects      []history.Effect
+	Trades       []history.Trade
+	// Accounts are the addresses of the accounts whose state may have
+	// changed in the ledger.
+	Accounts []string
+	// OrderBooks are the asset pairs whose offers may have changed in the
+	// ledger.
+	OrderBooks []AssetPair
+
+	// TransactionParticipants are the addresses of the participants of the
+	// transactions, by transaction id.
+	TransactionParticipants map[int64][]string
+	// OperationParticipants are the addresses of the participants of the
+	// operations, by operation id.
+	OperationParticipants map[int64][]string
+}
+
+// Transaction returns the transaction with the given id, or nil if the ledger
+// doesn't hold it.
+func (l *Ledger) Transaction(id int64) *history.Transaction {
+	for i := range l.Transactions {
+		if l.Transactions[i].ID == id {
+			return &l.Transactions[i]
+		}
+	}
+	return nil
+}
+
+// partition returns the records of l by topic, for the topics which are
+// wanted.
+func (l *Ledger) partition(wanted func(Topic) bool) map[Topic]*Ledger {
+	partitions := map[Topic]*Ledger{}
+	get := func(topic Topic) *Ledger {
+		if !wanted(topic) {
+			return nil
+		}
+		p, ok := partitions[topic]
+		if !ok {
+			p = &Ledger{Ledger: l.Ledger}
+			partitions[topic] = p
+		}
+		return p
+	}
+
+	get(AllTopic(Ledgers))
+
+	for _, tx := range l.Transactions {
+		topics := accountTopics(Transactions, l.TransactionParticipants[tx.ID])
+		for _, topic := range topics {
+			if p := get(topic); p != nil {
+				p.Transactions = append(p.Transactions, tx)
+			}
+		}
+	}
+
+	transactions := map[int64]*history.Transaction{}
+	for i := range l.Transactions {
+		transactions[l.Transactions[i].ID] = &l.Transactions[i]
+	}
+	for _, op := range l.Operations {
+		topics := accountTopics(Operations, l.OperationParticipants[op.ID])
+		for _, topic := range topics {
+			p := get(topic)
+			if p == nil {
+				continue
+			}
+			p.Operations = append(p.Operations, op)
+			// operations can be joined with their transactions, which are
+			// consecutive
+			tx, ok := transactions[op.TransactionID]
+			n := len(p.Transactions)
+			if ok && (n == 0 || p.Transactions[n-1].ID != tx.ID) {
+				p.Transactions = append(p.Transactions, *tx)
+			}
+		}
+	}
+
+	for _, effect := range l.Effects {
+		for _, topic := range accountTopics(Effects, []string{effect.Account}) {
+			if p := get(topic); p != nil {
+				p.Effects = append(p.Effects, effect)
+			}
+		}
+	}
+
+	for _, trade := range l.Trades {
+		topics := accountTopics(Trades, []string{trade.BaseAccount, trade.CounterAccount})
+		topics = append(topics, assetPairTopic(
+			Trades,
+			assetString(trade.BaseAssetType, trade.BaseAssetCode, trade.BaseAssetIssuer),
+			assetString(trade.CounterAssetType, trade.CounterAssetCode, trade.CounterAssetIssuer),
+		))
+		for _, topic := range topics {
+			if p := get(topic); p != nil {
+				p.Trades = append(p.Trades, trade)
+			}
+		}
+	}
+
+	for _, account := range l.Accounts {
+		if p := get(AccountTopic(Accounts, account)); p != nil {
+			p.Accounts = append(p.Accounts, account)
+		}
+	}
+
+	for _, pair := range l.OrderBooks {
+		if p := get(assetPairTopic(OrderBooks, pair.First, pair.Second)); p != nil {
+			p.OrderBooks = append(p.OrderBooks, pair)
+		}
+	}
+
+	return partitions
+}
+
+// accountTopics returns the topic of every record of kind followed by the
+// topics of the accounts, without duplicates.
+func accountTopics(kind Kind, accounts []string) []Topic {
+	topics := make([]Topic, 0, len(accounts)+1)
+	topics = append(topics, AllTopic(kind))
+	seen := map[string]bool{}
+	for _, account := range accounts {
+		if account == "" || seen[account] {
+			continue
+		}
+		seen[account] = true
+		topics = append(topics, AccountTopic(kind, account))
+	}
+	return topics
+}
+
+// assetString returns an asset in the format of xdr.Asset.String().
+func assetString(assetType, code, issuer string) string {
+	if assetType == "native" {
+		return assetType
+	}
+	return assetType + "/" + code + "/" + issuer
+}
+
+// After returns true if the record with the given paging token comes after
+// cursor in ascending order. Paging tokens are integers, or pairs of integers
+// separated by db2.DefaultPairSep such as the ones of effects and trades. A
+// cursor which is a single integer comes after every pair starting with it,
+// and an empty cursor comes before every record.
+func After(pagingToken, cursor string) bool {
+	if cursor == "" {
+		return true
+	}
+
+	token, ok := parsePagingToken(pagingToken, 0)
+	if !ok {
+		return false
+	}
+	// like db2.PageQuery.CursorInt64Pair
+	c, ok := parsePagingToken(cursor, math.MaxInt64)
+	if !ok {
+		return false
+	}
+
+	if token[0] != c[0] {
+		return token[0] > c[0]
+	}
+	return token[1] > c[1]
+}
+
+func parsePagingToken(s string, missing int64) ([2]int64, bool) {
+	parts := strings.SplitN(s, db2.DefaultPairSep, 2)
+	result := [2]int64{0, missing}
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return result, false
+		}
+		result[i] = n
+	}
+	return result, true
+}
@@ -0,0 +1,189 @@
+// Package pubsub fans the records of the ingested ledgers out to the streams
+// of horizon. Once a ledger is ingested its transactions, operations, effects
+// and trades, and the accounts and order books it changed, are loaded from the
+// history database once and published to a Hub. Every stream subscribes to the
+// topic matching its filter, such as the payments of an account, and receives
+// only the records of that topic, so that the database is only queried by a
+// stream to catch up from its cursor.
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// Kind is the kind of the records of a topic.
+type Kind int
+
+const (
+	// Ledgers is the kind of the topic of the ledgers.
+	Ledgers Kind = iota
+	// Transactions is the kind of the topics of transactions.
+	Transactions
+	// Operations is the kind of the topics of operations.
+	Operations
+	// Effects is the kind of the topics of effects.
+	Effects
+	// Trades is the kind of the topics of trades.
+	Trades
+	// Accounts is the kind of the topics of the changes of the state of an
+	// account: its balances, signers, data and offers.
+	Accounts
+	// OrderBooks is the kind of the topics of the changes of the offers of
+	// an asset pair.
+	OrderBooks
+)
+
+// DefaultBufferSize is the number of published ledgers a subscription holds
+// before it is closed for falling behind.
+const DefaultBufferSize = 16
+
+// Topic identifies the records a stream subscribes to: every record of a
+// kind, or the records of a kind involving an account or an asset pair.
+type Topic struct {
+	Kind Kind
+	Key  string
+}
+
+// AllTopic returns the topic of every record of kind.
+func AllTopic(kind Kind) Topic {
+	return Topic{Kind: kind}
+}
+
+// AccountTopic returns the topic of the records of kind involving the
+// account with the given address.
+func AccountTopic(kind Kind, address string) Topic {
+	return Topic{Kind: kind, Key: "account:" + address}
+}
+
+// AssetPairTopic returns the topic of the records of kind involving both
+// assets, regardless of their order.
+func AssetPairTopic(kind Kind, a, b xdr.Asset) Topic {
+	return assetPairTopic(kind, a.String(), b.String())
+}
+
+func assetPairTopic(kind Kind, a, b string) Topic {
+	if a > b {
+		a, b = b, a
+	}
+	return Topic{Kind: kind, Key: "pair:" + a + "," + b}
+}
+
+// Hub delivers the published ledgers to the subscriptions of their topics.
+// It is safe for concurrent use.
+type Hub struct {
+	// BufferSize is the number of ledgers a subscription holds before it is
+	// closed. DefaultBufferSize is used when 0.
+	BufferSize int
+
+	mutex         sync.Mutex
+	subscriptions map[Topic]map[*Subscription]struct{}
+}
+
+// Subscription receives the records of the published ledgers matching its
+// topic. Its channel is closed when it falls behind, in which case the
+// stream should catch up from the database.
+type Subscription struct {
+	hub    *Hub
+	topic  Topic
+	c      chan *Ledger
+	closed bool
+}
+
+// Ledgers returns the channel of the ledgers published with records of the
+// topic of the subscription. The ledgers only hold those records.
+func (s *Subscription) Ledgers() <-chan *Ledger {
+	return s.c
+}
+
+// Unsubscribe cancels the subscription, and closes its channel.
+func (s *Subscription) Unsubscribe() {
+	s.hub.mutex.Lock()
+	defer s.hub.mutex.Unlock()
+	s.hub.remove(s)
+}
+
+// Subscribe returns a subscription to the records of topic published from
+// now on.
+func (h *Hub) Subscribe(topic Topic) *Subscription {
+	size := h.BufferSize
+	if size == 0 {
+		size = DefaultBufferSize
+	}
+	s := &Subscription{hub: h, topic: topic, c: make(chan *Ledger, size)}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.subscriptions == nil {
+		h.subscriptions = map[Topic]map[*Subscription]struct{}{}
+	}
+	set, ok := h.subscriptions[topic]
+	if !ok {
+		set = map[*Subscription]struct{}{}
+		h.subscriptions[topic] = set
+	}
+	set[s] = struct{}{}
+	return s
+}
+
+// Publish delivers the records of ledger to the subscriptions of their
+// topics. It never blocks: the subscriptions which cannot hold another
+// ledger are closed.
+func (h *Hub) Publish(ledger *Ledger) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	partitions := ledger.partition(func(topic Topic) bool {
+		return len(h.subscriptions[topic]) > 0
+	})
+	for topic, partition := range partitions {
+		for s := range h.subscriptions[topic] {
+			select {
+			case s.c <- partition:
+			default:
+				h.remove(s)
+			}
+		}
+	}
+}
+
+// Reset closes every subscription. It is called when ledgers could not be
+// published, so that every stream catches up from the database.
+func (h *Hub) Reset() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, set := range h.subscriptions {
+		for s := range set {
+			h.remove(s)
+		}
+	}
+}
+
+// Subscriptions returns the number of open subscriptions.
+func (h *Hub) Subscriptions() int {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	n := 0
+	for _, set := range h.subscriptions {
+		n += len(set)
+	}
+	return n
+}
+
+// remove closes s, h.mutex must be held.
+func (h *Hub) remove(s *Subscription) {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.c)
+
+	set := h.subscriptions[s.topic]
+	delete(set, s)
+	if len(set) == 0 {
+		delete(h.subscriptions, s.topic)
+	}
+}
@@ -0,0 +1,211 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+const (
+	alice = "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR"
+	bob   = "GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD"
+	usd   = "credit_alphanum4/USD/GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"
+)
+
+func testLedger() *Ledger {
+	return &Ledger{
+		Ledger: history.Ledger{Sequence: 2},
+		Transactions: []history.Transaction{
+			{TotalOrderID: history.TotalOrderID{ID: 8589938688}, Account: alice},
+			{TotalOrderID: history.TotalOrderID{ID: 8589942784}, Account: bob},
+		},
+		Operations: []history.Operation{
+			{TotalOrderID: history.TotalOrderID{ID: 8589938689}, TransactionID: 8589938688},
+			{TotalOrderID: history.TotalOrderID{ID: 8589942785}, TransactionID: 8589942784},
+		},
+		Effects: []history.Effect{
+			{Account: alice, HistoryOperationID: 8589938689, Order: 1},
+			{Account: bob, HistoryOperationID: 8589938689, Order: 2},
+		},
+		Trades: []history.Trade{
+			{
+				HistoryOperationID: 8589942785,
+				BaseAccount:        alice,
+				BaseAssetType:      "native",
+				CounterAccount:     bob,
+				CounterAssetType:   "credit_alphanum4",
+				CounterAssetCode:   "USD",
+				CounterAssetIssuer: "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+			},
+		},
+		Accounts:   []string{alice, bob},
+		OrderBooks: []AssetPair{{First: usd, Second: "native"}},
+		TransactionParticipants: map[int64][]string{
+			8589938688: {alice, bob},
+			8589942784: {bob},
+		},
+		OperationParticipants: map[int64][]string{
+			8589938689: {alice, bob},
+			8589942785: {bob, bob},
+		},
+	}
+}
+
+func receive(t *testing.T, s *Subscription) *Ledger {
+	select {
+	case l, ok := <-s.Ledgers():
+		require.True(t, ok, "subscription closed")
+		return l
+	default:
+		t.Fatal("no ledger published")
+		return nil
+	}
+}
+
+func assertNothingPublished(t *testing.T, s *Subscription) {
+	select {
+	case l := <-s.Ledgers():
+		t.Fatalf("unexpected ledger %v", l)
+	default:
+	}
+}
+
+func TestPublish(t *testing.T) {
+	hub := &Hub{}
+	ledgers := hub.Subscribe(AllTopic(Ledgers))
+	operations := hub.Subscribe(AllTopic(Operations))
+	alicePayments := hub.Subscribe(AccountTopic(Operations, alice))
+	bobOperations := hub.Subscribe(AccountTopic(Operations, bob))
+	bobTransactions := hub.Subscribe(AccountTopic(Transactions, bob))
+	aliceEffects := hub.Subscribe(AccountTopic(Effects, alice))
+	pairTrades := hub.Subscribe(AssetPairTopic(
+		Trades,
+		xdr.MustNewCreditAsset("USD", "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"),
+		xdr.MustNewNativeAsset(),
+	))
+	orderBook := hub.Subscribe(AssetPairTopic(
+		OrderBooks,
+		xdr.MustNewNativeAsset(),
+		xdr.MustNewCreditAsset("USD", "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"),
+	))
+	aliceAccount := hub.Subscribe(AccountTopic(Accounts, alice))
+	carolAccount := hub.Subscribe(AccountTopic(Accounts, "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"))
+	assert.Equal(t, 10, hub.Subscriptions())
+
+	l := testLedger()
+	hub.Publish(l)
+
+	assert.Equal(t, &Ledger{Ledger: l.Ledger}, receive(t, ledgers))
+
+	all := receive(t, operations)
+	assert.Equal(t, l.Operations, all.Operations)
+	assert.Equal(t, l.Transactions, all.Transactions)
+	assert.Empty(t, all.Effects)
+
+	p := receive(t, alicePayments)
+	assert.Equal(t, l.Operations[:1], p.Operations)
+	// with the transactions of the operations
+	assert.Equal(t, l.Transactions[:1], p.Transactions)
+
+	// the duplicated participant is ignored
+	assert.Equal(t, l.Operations, receive(t, bobOperations).Operations)
+	assert.Equal(t, l.Transactions, receive(t, bobTransactions).Transactions)
+	assert.Equal(t, l.Effects[:1], receive(t, aliceEffects).Effects)
+	assert.Equal(t, l.Trades, receive(t, pairTrades).Trades)
+	assert.Equal(t, l.OrderBooks, receive(t, orderBook).OrderBooks)
+	assert.Equal(t, []string{alice}, receive(t, aliceAccount).Accounts)
+	assertNothingPublished(t, carolAccount)
+
+	carolAccount.Unsubscribe()
+	_, ok := <-carolAccount.Ledgers()
+	assert.False(t, ok)
+	assert.Equal(t, 9, hub.Subscriptions())
+	// unsubscribing twice is allowed
+	carolAccount.Unsubscribe()
+}
+
+func TestSubscriptionFallingBehind(t *testing.T) {
+	hub := &Hub{BufferSize: 2}
+	slow := hub.Subscribe(AllTopic(Ledgers))
+	fast := hub.Subscribe(AllTopic(Ledgers))
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(testLedger())
+		receive(t, fast)
+	}
+
+	receive(t, slow)
+	receive(t, slow)
+	_, ok := <-slow.Ledgers()
+	assert.False(t, ok, "the subscription falling behind is closed")
+	assert.Equal(t, 1, hub.Subscriptions())
+
+	hub.Reset()
+	_, ok = <-fast.Ledgers()
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Subscriptions())
+}
+
+func TestAfter(t *testing.T) {
+	for _, testCase := range []struct {
+		token  string
+		cursor string
+		after  bool
+	}{
+		{"8589938689", "", true},
+		{"8589938689", "8589938688", true},
+		{"8589938689", "8589938689", false},
+		{"8589938689", "8589938690", false},
+		{"8589938689-2", "8589938689-1", true},
+		{"8589938689-1", "8589938689-1", false},
+		{"8589938689-1", "8589938689", false},
+		{"8589938690-1", "8589938689", true},
+		{"invalid", "8589938689", false},
+	} {
+		assert.Equal(
+			t,
+			testCase.after,
+			After(testCase.token, testCase.cursor),
+			"%s after %s",
+			testCase.token,
+			testCase.cursor,
+		)
+	}
+}
+
+func TestChangedOrderBooks(t *testing.T) {
+	l := testLedger()
+	successful := true
+	l.Operations = append(l.Operations, history.Operation{
+		TotalOrderID:          history.TotalOrderID{ID: 8589942786},
+		Type:                  xdr.OperationTypeManageBuyOffer,
+		TransactionSuccessful: &successful,
+		DetailsString: null.StringFrom(`{
+			"selling_asset_type": "credit_alphanum4",
+			"selling_asset_code": "USD",
+			"selling_asset_issuer": "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+			"buying_asset_type": "credit_alphanum4",
+			"buying_asset_code": "EUR",
+			"buying_asset_issuer": "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"
+		}`),
+	})
+
+	pairs, err := changedOrderBooks(l)
+	require.NoError(t, err)
+	assert.Equal(t, []AssetPair{
+		{First: "credit_alphanum4/EUR/GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU", Second: usd},
+		{First: usd, Second: "native"},
+	}, pairs)
+
+	l.Effects = append(l.Effects, history.Effect{Account: "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU"})
+	assert.Equal(
+		t,
+		[]string{alice, "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU", bob},
+		changedAccounts(l),
+	)
+}
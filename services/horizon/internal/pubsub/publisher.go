@@ -0,0 +1,252 @@
+package pubsub
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/log"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// MaxLedgersBehind is the maximum number of ledgers published at once. When
+// more ledgers were ingested since the last ledger published, for example
+// because ingestion was catching up, the hub is reset instead, so that the
+// streams catch up from the database.
+const MaxLedgersBehind = 10
+
+// allRecords is the page query of every record of a ledger.
+var allRecords = db2.PageQuery{Order: db2.OrderAscending, Limit: math.MaxInt32}
+
+// Publisher loads the records of the ingested ledgers from the history
+// database and publishes them to a hub. A ledger is loaded once, whatever the
+// number of streams.
+type Publisher struct {
+	hub      *Hub
+	historyQ *history.Q
+	notify   chan struct{}
+
+	mutex  sync.Mutex
+	target int32
+	// last is the sequence of the last ledger published, only used by Run.
+	last int32
+}
+
+// NewPublisher returns a publisher of the ledgers of historyQ to hub.
+func NewPublisher(hub *Hub, historyQ *history.Q) *Publisher {
+	return &Publisher{
+		hub:      hub,
+		historyQ: historyQ,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Notify tells the publisher that the ledgers up to sequence are ingested. It
+// is called once ingestion committed them, and with the latest ledger of the
+// history database on the instances which don't ingest. It never blocks.
+func (p *Publisher) Notify(sequence int32) {
+	p.mutex.Lock()
+	if sequence > p.target {
+		p.target = sequence
+	}
+	p.mutex.Unlock()
+
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run publishes the ledgers notified until ctx is done.
+func (p *Publisher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.notify:
+		}
+
+		if err := p.publish(); err != nil {
+			// the ledgers are published again at the next notification
+			log.WithStack(err).WithField("err", err.Error()).Error("pubsub: failed to publish ledgers")
+		}
+	}
+}
+
+func (p *Publisher) publish() error {
+	p.mutex.Lock()
+	target := p.target
+	p.mutex.Unlock()
+
+	switch {
+	case target <= p.last:
+		return nil
+	case p.last == 0:
+		// the streams opened before the first ledger is published caught up
+		// from the database
+		p.last = target - 1
+	case target-p.last > MaxLedgersBehind:
+		log.WithField("from", p.last+1).WithField("to", target).
+			Warn("pubsub: too many ledgers to publish, streams will catch up from the database")
+		p.hub.Reset()
+		p.last = target
+		return nil
+	}
+
+	for p.last < target {
+		ledger, err := p.load(p.last + 1)
+		if err != nil {
+			return errors.Wrapf(err, "could not load ledger %d", p.last+1)
+		}
+		p.hub.Publish(ledger)
+		p.last++
+	}
+	return nil
+}
+
+// load returns the records of the ledger with the given sequence.
+func (p *Publisher) load(seq int32) (*Ledger, error) {
+	q := p.historyQ
+	l := &Ledger{}
+	var err error
+
+	if err = q.LedgerBySequence(&l.Ledger, seq); err != nil {
+		return nil, errors.Wrap(err, "could not load ledger")
+	}
+
+	err = q.Transactions().ForLedger(seq).IncludeFailed().Page(allRecords).Select(&l.Transactions)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load transactions")
+	}
+
+	l.Operations, _, err = q.Operations().ForLedger(seq).IncludeFailed().Page(allRecords).Fetch()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load operations")
+	}
+
+	err = q.Effects().ForLedger(seq).Page(allRecords).Select(&l.Effects)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load effects")
+	}
+
+	err = q.Trades().ForLedger(seq).Page(allRecords).Select(&l.Trades)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load trades")
+	}
+
+	l.TransactionParticipants, err = q.TransactionParticipantsForLedger(seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load transaction participants")
+	}
+
+	l.OperationParticipants, err = q.OperationParticipantsForLedger(seq)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load operation participants")
+	}
+
+	l.Accounts = changedAccounts(l)
+	l.OrderBooks, err = changedOrderBooks(l)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// changedAccounts returns the accounts whose state may have changed in l: the
+// accounts paying the fees of the transactions, the participants of the
+// operations and the accounts of the effects.
+func changedAccounts(l *Ledger) []string {
+	set := map[string]bool{}
+	for _, tx := range l.Transactions {
+		set[tx.Account] = true
+		if tx.FeeAccount.Valid {
+			set[tx.FeeAccount.String] = true
+		}
+	}
+	for _, participants := range l.OperationParticipants {
+		for _, address := range participants {
+			set[address] = true
+		}
+	}
+	for _, effect := range l.Effects {
+		set[effect.Account] = true
+	}
+	delete(set, "")
+
+	accounts := make([]string, 0, len(set))
+	for address := range set {
+		accounts = append(accounts, address)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// offerDetails are the details of the operations managing offers.
+type offerDetails struct {
+	SellingAssetType   string `json:"selling_asset_type"`
+	SellingAssetCode   string `json:"selling_asset_code"`
+	SellingAssetIssuer string `json:"selling_asset_issuer"`
+	BuyingAssetType    string `json:"buying_asset_type"`
+	BuyingAssetCode    string `json:"buying_asset_code"`
+	BuyingAssetIssuer  string `json:"buying_asset_issuer"`
+}
+
+// changedOrderBooks returns the asset pairs whose offers may have changed in
+// l: the pairs of the trades and of the successful operations managing
+// offers.
+func changedOrderBooks(l *Ledger) ([]AssetPair, error) {
+	set := map[AssetPair]bool{}
+	add := func(a, b string) {
+		if a > b {
+			a, b = b, a
+		}
+		set[AssetPair{First: a, Second: b}] = true
+	}
+
+	for _, trade := range l.Trades {
+		add(
+			assetString(trade.BaseAssetType, trade.BaseAssetCode, trade.BaseAssetIssuer),
+			assetString(trade.CounterAssetType, trade.CounterAssetCode, trade.CounterAssetIssuer),
+		)
+	}
+
+	for i := range l.Operations {
+		op := &l.Operations[i]
+		switch op.Type {
+		case xdr.OperationTypeManageSellOffer,
+			xdr.OperationTypeManageBuyOffer,
+			xdr.OperationTypeCreatePassiveSellOffer:
+		default:
+			continue
+		}
+		if !op.IsTransactionSuccessful() {
+			continue
+		}
+
+		var details offerDetails
+		if err := op.UnmarshalDetails(&details); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal details of operation %d", op.ID)
+		}
+		add(
+			assetString(details.SellingAssetType, details.SellingAssetCode, details.SellingAssetIssuer),
+			assetString(details.BuyingAssetType, details.BuyingAssetCode, details.BuyingAssetIssuer),
+		)
+	}
+
+	pairs := make([]AssetPair, 0, len(set))
+	for pair := range set {
+		pairs = append(pairs, pair)
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].First != pairs[j].First {
+			return pairs[i].First < pairs[j].First
+		}
+		return pairs[i].Second < pairs[j].Second
+	})
+	return pairs, nil
+}
@@ -3,6 +3,7 @@ package reap
 import (
 	"time"
 
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/errors"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/toid"
@@ -93,6 +94,11 @@ func (r *System) clearBefore(seq int32) error {
 	if err != nil {
 		return err
 	}
+	// the states of the accounts at the new elder are kept
+	err = (&history.Q{r.HorizonDB}).ReapStateHistory(uint32(seq))
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
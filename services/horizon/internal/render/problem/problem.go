@@ -49,6 +49,16 @@ var (
 		Status: http.StatusNotAcceptable,
 	}
 
+	// Unauthorized is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	Unauthorized = problem.P{
+		Type:   "unauthorized",
+		Title:  "Unauthorized",
+		Status: http.StatusUnauthorized,
+		Detail: "The request does not include valid credentials for this " +
+			"resource.",
+	}
+
 	// ServerOverCapacity is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	ServerOverCapacity = problem.P{
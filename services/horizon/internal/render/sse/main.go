@@ -29,10 +29,38 @@ type Eventable interface {
 	SseEvent() Event
 }
 
+// EventWriter receives the events of the streams served over a transport
+// other than Server Sent Events, such as WebSockets.
+type EventWriter interface {
+	// WriteSseEvent is called for every event of a stream, including the
+	// open and close events and the errors.
+	WriteSseEvent(e Event)
+}
+
+type eventWriterKey struct{}
+
+// WithEventWriter returns a context which makes the streams of the requests
+// bound to it pass their events to ew, instead of writing them to the http
+// response in the text/event-stream format.
+func WithEventWriter(ctx context.Context, ew EventWriter) context.Context {
+	return context.WithValue(ctx, eventWriterKey{}, ew)
+}
+
+func eventWriterFromContext(ctx context.Context) EventWriter {
+	ew, _ := ctx.Value(eventWriterKey{}).(EventWriter)
+	return ew
+}
+
 // WritePreamble prepares this http connection for streaming using Server Sent
 // Events. It sends the initial http response with the appropriate headers to
 // do so.
 func WritePreamble(ctx context.Context, w http.ResponseWriter) bool {
+	if ew := eventWriterFromContext(ctx); ew != nil {
+		w.WriteHeader(http.StatusOK)
+		ew.WriteSseEvent(helloEvent)
+		return true
+	}
+
 	_, flushable := w.(http.Flusher)
 	if !flushable {
 		//TODO: render a problem struct instead of simple string
@@ -54,6 +82,11 @@ func WritePreamble(ctx context.Context, w http.ResponseWriter) bool {
 // WriteEvent does the actual work of formatting an SSE compliant message
 // sending it over the provided ResponseWriter and flushing.
 func WriteEvent(ctx context.Context, w http.ResponseWriter, e Event) {
+	if ew := eventWriterFromContext(ctx); ew != nil {
+		ew.WriteSseEvent(e)
+		return
+	}
+
 	if e.Error != nil {
 		fmt.Fprint(w, "event: error\n")
 		fmt.Fprintf(w, "data: %s\n\n", e.Error.Error())
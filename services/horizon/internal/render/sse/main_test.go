@@ -53,3 +53,28 @@ func TestWritePreamble(t *testing.T) {
 	assert.Equal(t, 200, w.Code)
 	assert.Contains(t, w.Body.String(), "retry: 1000\nevent: open\ndata: \"hello\"\n\n")
 }
+
+type recordingEventWriter struct {
+	events []Event
+}
+
+func (w *recordingEventWriter) WriteSseEvent(e Event) {
+	w.events = append(w.events, e)
+}
+
+// Tests that the events are passed to the event writer of the context instead
+// of being written to the response.
+func TestWithEventWriter(t *testing.T) {
+	ctx, _ := test.ContextWithLogBuffer()
+	ew := &recordingEventWriter{}
+	ctx = WithEventWriter(ctx, ew)
+
+	w := httptest.NewRecorder()
+	stream := NewStream(ctx, w)
+	stream.Send(Event{ID: "1", Data: "test"})
+	stream.Done()
+
+	assert.Equal(t, []Event{helloEvent, {ID: "1", Data: "test"}, goodbyeEvent}, ew.events)
+	assert.Equal(t, "", w.Body.String())
+	assert.Equal(t, "", w.Header().Get("Content-Type"))
+}
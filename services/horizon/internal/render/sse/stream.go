@@ -26,6 +26,7 @@ type Stream struct {
 	done     bool
 	sent     int
 	limit    int
+	lastID   string
 }
 
 // NewStream creates a new stream against the provided response writer.
@@ -54,6 +55,9 @@ func (s *Stream) Send(e Event) {
 	s.Init()
 	WriteEvent(s.ctx, s.w, e)
 	s.sent++
+	if e.ID != "" {
+		s.lastID = e.ID
+	}
 }
 
 func (s *Stream) SentCount() int {
@@ -62,6 +66,14 @@ func (s *Stream) SentCount() int {
 	return s.sent
 }
 
+// LastEventID returns the id of the last event sent with an id, or an empty
+// string if there is none.
+func (s *Stream) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastID
+}
+
 func (s *Stream) SetLimit(limit int) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
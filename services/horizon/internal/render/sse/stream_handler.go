@@ -5,6 +5,7 @@ import (
 
 	"github.com/paydex-core/go-throttled"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	"github.com/paydex-core/paydex-go/support/errors"
 )
 
@@ -12,6 +13,9 @@ import (
 type StreamHandler struct {
 	RateLimiter  *throttled.HTTPRateLimiter
 	LedgerSource ledger.Source
+	// Hub publishes the ingested ledgers to the streams served by
+	// ServeTopicStream.
+	Hub *pubsub.Hub
 }
 
 // GenerateEventsFunc generates a slice of sse.Event which are sent via
@@ -25,12 +29,78 @@ func (handler StreamHandler) ServeStream(
 	r *http.Request,
 	limit int,
 	generateEvents GenerateEventsFunc,
+) {
+	currentLedgerSequence := handler.LedgerSource.CurrentLedger()
+	handler.serve(w, r, limit, generateEvents, func() bool {
+		select {
+		case currentLedgerSequence = <-handler.LedgerSource.NextLedger(currentLedgerSequence):
+			return true
+		case <-r.Context().Done():
+			return false
+		}
+	})
+}
+
+// ServeTopicStream handles a SSE request like ServeStream, but only generates
+// the events again when a ledger with records of topic is published to the
+// hub and the ledger source reached it, instead of on every ledger. The stream
+// is closed if its subscription falls behind, the client then catches up from
+// the database when reconnecting. ServeTopicStream falls back to ServeStream
+// without hub.
+func (handler StreamHandler) ServeTopicStream(
+	w http.ResponseWriter,
+	r *http.Request,
+	limit int,
+	topic pubsub.Topic,
+	generateEvents GenerateEventsFunc,
+) {
+	if handler.Hub == nil {
+		handler.ServeStream(w, r, limit, generateEvents)
+		return
+	}
+
+	// Subscribe before generating the first events so that no ledger
+	// ingested in between is missed.
+	subscription := handler.Hub.Subscribe(topic)
+	defer subscription.Unsubscribe()
+
+	handler.serve(w, r, limit, generateEvents, func() bool {
+		select {
+		case published, ok := <-subscription.Ledgers():
+			if !ok {
+				return false
+			}
+			// Wait for the ledger source to reach the published ledger, the
+			// events may be generated from records it ingests separately.
+			sequence := uint32(published.Ledger.Sequence)
+			if handler.LedgerSource.CurrentLedger() >= sequence {
+				return true
+			}
+			select {
+			case <-handler.LedgerSource.NextLedger(sequence - 1):
+				return true
+			case <-r.Context().Done():
+				return false
+			}
+		case <-r.Context().Done():
+			return false
+		}
+	})
+}
+
+// serve sends the events generated every time next returns true, until it
+// returns false or the limit is reached.
+func (handler StreamHandler) serve(
+	w http.ResponseWriter,
+	r *http.Request,
+	limit int,
+	generateEvents GenerateEventsFunc,
+	next func() bool,
 ) {
 	ctx := r.Context()
 	stream := NewStream(ctx, w)
 	stream.SetLimit(limit)
 
-	currentLedgerSequence := handler.LedgerSource.CurrentLedger()
 	for {
 		// Rate limit the request if it's a call to stream since it queries the DB every second.
 		rateLimiter := handler.RateLimiter
@@ -69,10 +139,7 @@ func (handler StreamHandler) ServeStream(
 		// only executed once.
 		stream.Init()
 
-		select {
-		case currentLedgerSequence = <-handler.LedgerSource.NextLedger(currentLedgerSequence):
-			continue
-		case <-ctx.Done():
+		if !next() {
 			stream.Done()
 			return
 		}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 )
 
 func TestSendByeByeOnContextDone(t *testing.T) {
@@ -36,3 +37,46 @@ func TestSendByeByeOnContextDone(t *testing.T) {
 		t.Fatalf("expected '%v' but got '%v'", expected, got)
 	}
 }
+
+func TestServeTopicStream(t *testing.T) {
+	hub := &pubsub.Hub{}
+	handler := StreamHandler{
+		LedgerSource: ledger.NewTestingSource(1),
+		Hub:          hub,
+	}
+	alice := "GAIH3ULLFQ4DGSECF2AR555KZ4KNDGEKN4AFI4SU2M7B43MGK3QJZNSR"
+	bob := "GB2QIYT2IAUFMRXKLSLLPRECC6OCOGJMADSPTRK7TGNT2SFR2YGWDARD"
+
+	r, err := http.NewRequest("GET", "http://localhost", nil)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	w := httptest.NewRecorder()
+
+	calls := 0
+	handler.ServeTopicStream(w, r, 10, pubsub.AccountTopic(pubsub.Accounts, alice), func() ([]Event, error) {
+		calls++
+		switch calls {
+		case 1:
+			// not published to the stream
+			hub.Publish(&pubsub.Ledger{Accounts: []string{bob}})
+			hub.Publish(&pubsub.Ledger{Accounts: []string{alice}})
+		case 2:
+			// the stream catches up from the database
+			hub.Reset()
+		}
+		return []Event{{Data: calls}}, nil
+	})
+
+	expected := "retry: 1000\nevent: open\ndata: \"hello\"\n\n" +
+		"data: 1\n\n" +
+		"data: 2\n\n" +
+		"retry: 10\nevent: close\ndata: \"byebye\"\n\n"
+
+	if got := w.Body.String(); got != expected {
+		t.Fatalf("expected '%v' but got '%v'", expected, got)
+	}
+	if n := hub.Subscriptions(); n != 0 {
+		t.Fatalf("expected no subscription but got %d", n)
+	}
+}
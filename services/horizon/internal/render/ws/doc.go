@@ -0,0 +1,4 @@
+// Package ws contains the WebSocket transport of the horizon streams. A client
+// subscribes to several streams over a single connection, the events of every
+// stream being sent as JSON messages tagged with the id of its subscription.
+package ws
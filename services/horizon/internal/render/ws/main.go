@@ -0,0 +1,118 @@
+package ws
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+)
+
+const (
+	// DefaultSubscriptionID is the id of the subscription to the stream of
+	// the URL of the connection, when it is not `/`.
+	DefaultSubscriptionID = "default"
+	// DefaultHeartbeatInterval is the interval between two heartbeats when
+	// Handler.HeartbeatInterval is not set.
+	DefaultHeartbeatInterval = 15 * time.Second
+
+	// maxMessageSize is the maximum size of a message sent by a client.
+	maxMessageSize = 1 << 14
+	// writeTimeout is the maximum time taken to send a message to a client
+	// before the connection is closed.
+	writeTimeout = 10 * time.Second
+	// defaultRetry is the delay before a stream is requested again when it
+	// did not set one.
+	defaultRetry = time.Second
+)
+
+// Handler serves the streams of horizon over WebSocket connections. Every
+// subscription is served by a request to Router, with the headers and the
+// remote address of the connection, so that the streams are served, and rate
+// limited, like the Server-Sent Events streams. The stream is requested again
+// from its last event when it ends, like Server-Sent Events clients do.
+type Handler struct {
+	// Router serves the requests of the streams.
+	Router http.Handler
+	// HeartbeatInterval is the interval between two heartbeats.
+	HeartbeatInterval time.Duration
+	// MaxSubscriptions is the maximum number of subscriptions of a
+	// connection, there is no limit when 0.
+	MaxSubscriptions int
+}
+
+// IsWebSocketRequest returns true if r asks for its connection to be upgraded
+// to the WebSocket protocol.
+func IsWebSocketRequest(r *http.Request) bool {
+	return r.Method == http.MethodGet &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// Middleware serves the WebSocket requests and passes the other requests to
+// next.
+func (h Handler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsWebSocketRequest(r) {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ServeHTTP upgrades the connection of r and serves its subscriptions until
+// the client closes it. When the path of r is not `/`, its stream is
+// subscribed to with DefaultSubscriptionID.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server := websocket.Server{
+		// the streams can be requested from any origin, like the other
+		// endpoints
+		Handshake: func(*websocket.Config, *http.Request) error {
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			newSession(h, conn, r).serve()
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// session is a WebSocket connection and its subscriptions.
+type session struct {
+	handler Handler
+	conn    *websocket.Conn
+	// request is the upgrade request, from which the requests of the
+	// subscriptions are derived.
+	request *http.Request
+	// ctx is done once the connection is closed. It is not derived from the
+	// upgrade request so that it doesn't carry its routing context.
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    chan protocol.WebSocketMessage
+	wg     sync.WaitGroup
+
+	mutex         sync.Mutex // protects subscriptions
+	subscriptions map[string]*subscription
+}
+
+func newSession(h Handler, conn *websocket.Conn, r *http.Request) *session {
+	if h.HeartbeatInterval <= 0 {
+		h.HeartbeatInterval = DefaultHeartbeatInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &session{
+		handler:       h,
+		conn:          conn,
+		request:       r,
+		ctx:           ctx,
+		cancel:        cancel,
+		out:           make(chan protocol.WebSocketMessage),
+		subscriptions: map[string]*subscription{},
+	}
+}
@@ -0,0 +1,240 @@
+package ws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// newTestRouter returns a router serving `/ticks`, a stream of two events
+// numbered from the cursor at every request, `/missing`, which fails with a
+// problem, and `/document`, which cannot be streamed.
+func newTestRouter() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ticks", func(w http.ResponseWriter, r *http.Request) {
+		stream := sse.NewStream(r.Context(), w)
+		cursor, _ := strconv.Atoi(r.Header.Get("Last-Event-ID"))
+		for i := cursor + 1; i <= cursor+2; i++ {
+			stream.Send(sse.Event{ID: strconv.Itoa(i), Data: map[string]int{"tick": i}})
+		}
+		stream.Done()
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		problem.Render(r.Context(), w, problem.NotFound)
+	})
+	mux.HandleFunc("/document", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tick":1}`))
+	})
+	return mux
+}
+
+func dial(t *testing.T, server *httptest.Server, path string) *websocket.Conn {
+	u := "ws" + strings.TrimPrefix(server.URL, "http") + path
+	conn, err := websocket.Dial(u, "", server.URL)
+	require.NoError(t, err)
+	return conn
+}
+
+func send(t *testing.T, conn *websocket.Conn, msg protocol.WebSocketMessage) {
+	require.NoError(t, websocket.JSON.Send(conn, msg))
+}
+
+// receive returns the next message which is not a heartbeat.
+func receive(t *testing.T, conn *websocket.Conn) protocol.WebSocketMessage {
+	for {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		var msg protocol.WebSocketMessage
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		if msg.Type != protocol.WebSocketHeartbeat {
+			return msg
+		}
+	}
+}
+
+func TestIsWebSocketRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/ledgers", nil)
+	assert.False(t, IsWebSocketRequest(r))
+	r.Header.Set("Upgrade", "WebSocket")
+	assert.True(t, IsWebSocketRequest(r))
+	r.Method = http.MethodPost
+	assert.False(t, IsWebSocketRequest(r))
+}
+
+func TestSubscribe(t *testing.T) {
+	server := httptest.NewServer(Handler{Router: newTestRouter()})
+	defer server.Close()
+	conn := dial(t, server, "/")
+	defer conn.Close()
+
+	send(t, conn, protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, ID: "ticks", Path: "/ticks", Cursor: "10"})
+	assert.Equal(t, protocol.WebSocketMessage{Type: protocol.WebSocketSubscribed, ID: "ticks"}, receive(t, conn))
+
+	// the stream is requested again from the cursor of its last event when
+	// it ends
+	for i := 11; i <= 14; i++ {
+		msg := receive(t, conn)
+		assert.Equal(t, protocol.WebSocketEvent, msg.Type)
+		assert.Equal(t, "ticks", msg.ID)
+		assert.Equal(t, strconv.Itoa(i), msg.Cursor)
+		assert.JSONEq(t, `{"tick":`+strconv.Itoa(i)+`}`, string(msg.Data))
+	}
+
+	send(t, conn, protocol.WebSocketMessage{Type: protocol.WebSocketUnsubscribe, ID: "ticks"})
+	for {
+		msg := receive(t, conn)
+		if msg.Type == protocol.WebSocketEvent {
+			continue
+		}
+		assert.Equal(t, protocol.WebSocketMessage{Type: protocol.WebSocketUnsubscribed, ID: "ticks"}, msg)
+		break
+	}
+
+	send(t, conn, protocol.WebSocketMessage{Type: protocol.WebSocketUnsubscribe, ID: "ticks"})
+	msg := receive(t, conn)
+	assert.Equal(t, protocol.WebSocketError, msg.Type)
+	assert.Equal(t, "ticks", msg.ID)
+	assert.Equal(t, http.StatusNotFound, msg.Error.Status)
+}
+
+func TestSubscribeURLOfConnection(t *testing.T) {
+	server := httptest.NewServer(Handler{Router: newTestRouter()})
+	defer server.Close()
+	conn := dial(t, server, "/ticks")
+	defer conn.Close()
+
+	assert.Equal(
+		t,
+		protocol.WebSocketMessage{Type: protocol.WebSocketSubscribed, ID: DefaultSubscriptionID},
+		receive(t, conn),
+	)
+	msg := receive(t, conn)
+	assert.Equal(t, DefaultSubscriptionID, msg.ID)
+	assert.Equal(t, "1", msg.Cursor)
+}
+
+func TestSubscriptionErrors(t *testing.T) {
+	server := httptest.NewServer(Handler{Router: newTestRouter(), MaxSubscriptions: 2})
+	defer server.Close()
+	conn := dial(t, server, "/")
+	defer conn.Close()
+
+	for _, testCase := range []struct {
+		name   string
+		msg    protocol.WebSocketMessage
+		id     string
+		status int
+	}{
+		{
+			"missing id",
+			protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, Path: "/ticks"},
+			"",
+			http.StatusBadRequest,
+		},
+		{
+			"invalid type",
+			protocol.WebSocketMessage{Type: "publish", ID: "a"},
+			"a",
+			http.StatusBadRequest,
+		},
+		{
+			"absolute path",
+			protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, ID: "a", Path: "http://example.com/ticks"},
+			"a",
+			http.StatusBadRequest,
+		},
+		{
+			"problem",
+			protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, ID: "a", Path: "/missing"},
+			"a",
+			http.StatusNotFound,
+		},
+		{
+			"not a stream",
+			protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, ID: "a", Path: "/document"},
+			"a",
+			http.StatusNotAcceptable,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			send(t, conn, testCase.msg)
+			msg := receive(t, conn)
+			assert.Equal(t, protocol.WebSocketError, msg.Type)
+			assert.Equal(t, testCase.id, msg.ID)
+			if assert.NotNil(t, msg.Error) {
+				assert.Equal(t, testCase.status, msg.Error.Status)
+			}
+		})
+	}
+
+	t.Run("invalid message", func(t *testing.T) {
+		require.NoError(t, websocket.Message.Send(conn, "subscribe"))
+		msg := receive(t, conn)
+		assert.Equal(t, protocol.WebSocketError, msg.Type)
+		assert.Equal(t, http.StatusBadRequest, msg.Error.Status)
+	})
+
+	t.Run("too many subscriptions", func(t *testing.T) {
+		var subscribed []string
+		for _, id := range []string{"a", "b", "c", "a"} {
+			send(t, conn, protocol.WebSocketMessage{Type: protocol.WebSocketSubscribe, ID: id, Path: "/ticks"})
+		}
+		var errors []protocol.WebSocketMessage
+		for len(subscribed) < 2 || len(errors) < 2 {
+			msg := receive(t, conn)
+			switch msg.Type {
+			case protocol.WebSocketSubscribed:
+				subscribed = append(subscribed, msg.ID)
+			case protocol.WebSocketError:
+				errors = append(errors, msg)
+			}
+		}
+		assert.ElementsMatch(t, []string{"a", "b"}, subscribed)
+		for _, msg := range errors {
+			assert.Equal(t, http.StatusBadRequest, msg.Error.Status)
+		}
+		assert.ElementsMatch(t, []string{"c", "a"}, []string{errors[0].ID, errors[1].ID})
+	})
+}
+
+func TestHeartbeat(t *testing.T) {
+	server := httptest.NewServer(Handler{Router: newTestRouter(), HeartbeatInterval: 10 * time.Millisecond})
+	defer server.Close()
+	conn := dial(t, server, "/")
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg protocol.WebSocketMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &msg))
+	assert.Equal(t, protocol.WebSocketMessage{Type: protocol.WebSocketHeartbeat}, msg)
+}
+
+func TestMiddleware(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	server := httptest.NewServer(Handler{Router: newTestRouter()}.Middleware(next))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/ticks")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+
+	conn := dial(t, server, "/ticks")
+	defer conn.Close()
+	var msg protocol.WebSocketMessage
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	require.NoError(t, websocket.JSON.Receive(conn, &msg))
+	assert.Equal(t, protocol.WebSocketSubscribed, msg.Type)
+}
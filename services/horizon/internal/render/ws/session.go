@@ -0,0 +1,188 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// serve reads the messages of the client until the connection is closed.
+func (s *session) serve() {
+	defer func() {
+		s.cancel()
+		s.wg.Wait()
+	}()
+
+	s.conn.MaxPayloadBytes = maxMessageSize
+	go s.writeLoop()
+
+	if s.request.URL.Path != "/" {
+		u := *s.request.URL
+		s.subscribe(DefaultSubscriptionID, &u, "")
+	}
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(s.conn, &data); err != nil {
+			// the connection was closed, or the client sent an invalid frame
+			return
+		}
+
+		var msg protocol.WebSocketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			s.sendError("", problem.MakeInvalidFieldProblem("message", errors.New("must be a JSON object")))
+			continue
+		}
+
+		s.handle(msg)
+	}
+}
+
+// writeLoop sends the messages of the subscriptions and the heartbeats until
+// the session ends.
+func (s *session) writeLoop() {
+	heartbeat := time.NewTicker(s.handler.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var msg protocol.WebSocketMessage
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg = <-s.out:
+		case <-heartbeat.C:
+			msg = protocol.WebSocketMessage{Type: protocol.WebSocketHeartbeat}
+		}
+
+		s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := websocket.JSON.Send(s.conn, msg); err != nil {
+			// the client is gone or doesn't read its messages, closing the
+			// connection ends the read loop of serve
+			s.cancel()
+			s.conn.Close()
+			return
+		}
+	}
+}
+
+// send queues msg, it returns false if ctx is done first.
+func (s *session) send(ctx context.Context, msg protocol.WebSocketMessage) bool {
+	select {
+	case s.out <- msg:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *session) sendError(id string, p *problem.P) {
+	if problem.ServiceHost != "" && !strings.HasPrefix(p.Type, problem.ServiceHost) {
+		p.Type = problem.ServiceHost + p.Type
+	}
+	s.send(s.ctx, protocol.WebSocketMessage{
+		Type:  protocol.WebSocketError,
+		ID:    id,
+		Error: p,
+	})
+}
+
+func (s *session) handle(msg protocol.WebSocketMessage) {
+	if msg.ID == "" && (msg.Type == protocol.WebSocketSubscribe || msg.Type == protocol.WebSocketUnsubscribe) {
+		s.sendError("", problem.MakeInvalidFieldProblem("id", errors.New("is required")))
+		return
+	}
+
+	switch msg.Type {
+	case protocol.WebSocketSubscribe:
+		u, err := url.Parse(msg.Path)
+		if err != nil || u.IsAbs() || u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+			s.sendError(msg.ID, problem.MakeInvalidFieldProblem(
+				"path",
+				errors.New("must be the path of a stream, for example /ledgers?cursor=now"),
+			))
+			return
+		}
+		s.subscribe(msg.ID, u, msg.Cursor)
+	case protocol.WebSocketUnsubscribe:
+		s.unsubscribe(msg.ID)
+	default:
+		s.sendError(msg.ID, problem.MakeInvalidFieldProblem(
+			"type",
+			errors.New("must be subscribe or unsubscribe"),
+		))
+	}
+}
+
+func (s *session) subscribe(id string, path *url.URL, cursor string) {
+	s.mutex.Lock()
+	var err error
+	if _, ok := s.subscriptions[id]; ok {
+		err = errors.New("is already subscribed")
+	} else if s.handler.MaxSubscriptions > 0 && len(s.subscriptions) >= s.handler.MaxSubscriptions {
+		err = errors.Errorf("a connection has at most %d subscriptions", s.handler.MaxSubscriptions)
+	}
+	if err != nil {
+		s.mutex.Unlock()
+		s.sendError(id, problem.MakeInvalidFieldProblem("id", err))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	sub := &subscription{
+		session: s,
+		id:      id,
+		path:    path,
+		cursor:  cursor,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	s.subscriptions[id] = sub
+	s.mutex.Unlock()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		sub.run()
+	}()
+}
+
+func (s *session) unsubscribe(id string) {
+	s.mutex.Lock()
+	sub, ok := s.subscriptions[id]
+	if ok {
+		delete(s.subscriptions, id)
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		p := problem.NotFound
+		p.Detail = "There is no subscription with this id."
+		s.sendError(id, &p)
+		return
+	}
+
+	// the unsubscribed message is sent by the subscription once its stream
+	// ended
+	sub.cancel()
+}
+
+// remove removes sub from the subscriptions of the session, it returns false
+// if it was already removed.
+func (s *session) remove(sub *subscription) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.subscriptions[sub.id] != sub {
+		return false
+	}
+	delete(s.subscriptions, sub.id)
+	return true
+}
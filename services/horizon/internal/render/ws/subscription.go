@@ -0,0 +1,224 @@
+package ws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	protocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/render"
+	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+)
+
+// maxErrorSize is the maximum size of the body of an error response read for
+// a subscription.
+const maxErrorSize = 1 << 16
+
+// hopHeaders are the headers of the upgrade request which are not passed to
+// the requests of the subscriptions.
+var hopHeaders = []string{
+	"Accept-Encoding",
+	"Connection",
+	"Sec-Websocket-Extensions",
+	"Sec-Websocket-Key",
+	"Sec-Websocket-Protocol",
+	"Sec-Websocket-Version",
+	"Upgrade",
+}
+
+// subscription is the subscription of a client to a stream.
+type subscription struct {
+	session *session
+	id      string
+	path    *url.URL
+	// cursor is the paging token of the last event sent, from which the
+	// stream is requested again.
+	cursor     string
+	subscribed bool
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+// run requests the stream until it fails, or until the subscription is
+// cancelled.
+func (sub *subscription) run() {
+	defer sub.cancel()
+
+	for {
+		w := &streamWriter{sub: sub, header: http.Header{}, retry: defaultRetry}
+		sub.session.handler.Router.ServeHTTP(w, sub.newRequest(w))
+
+		if sub.ctx.Err() != nil {
+			break
+		}
+
+		if p := w.problem(); p != nil {
+			if sub.session.remove(sub) {
+				sub.session.sendError(sub.id, p)
+			}
+			return
+		}
+
+		select {
+		case <-time.After(w.retry):
+		case <-sub.ctx.Done():
+		}
+		if sub.ctx.Err() != nil {
+			break
+		}
+	}
+
+	// the subscription is no longer registered once it was unsubscribed,
+	// otherwise the session ended
+	if !sub.session.remove(sub) {
+		sub.session.send(sub.session.ctx, protocol.WebSocketMessage{
+			Type: protocol.WebSocketUnsubscribed,
+			ID:   sub.id,
+		})
+	}
+}
+
+// newRequest returns the request of the stream, which passes its events to w.
+func (sub *subscription) newRequest(w *streamWriter) *http.Request {
+	upgrade := sub.session.request
+	r := upgrade.WithContext(sse.WithEventWriter(sub.ctx, w))
+
+	u := *sub.path
+	r.Method = http.MethodGet
+	r.URL = &u
+	r.RequestURI = u.RequestURI()
+	r.Body = http.NoBody
+	r.ContentLength = 0
+
+	r.Header = upgrade.Header.Clone()
+	for _, header := range hopHeaders {
+		r.Header.Del(header)
+	}
+	r.Header.Set("Accept", render.MimeEventStream)
+	if sub.cursor != "" {
+		r.Header.Set("Last-Event-ID", sub.cursor)
+	}
+
+	return r
+}
+
+// streamWriter is the response writer of a request of a subscription. The
+// events of the stream are passed to WriteSseEvent, the body of the response
+// is only written when the stream fails before it starts.
+type streamWriter struct {
+	sub    *subscription
+	header http.Header
+	status int
+	body   bytes.Buffer
+	// retry is the delay before the stream is requested again.
+	retry time.Duration
+	err   *problem.P
+}
+
+func (w *streamWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *streamWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *streamWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.body.Len() < maxErrorSize {
+		w.body.Write(b)
+	}
+	return len(b), nil
+}
+
+// Flush implements http.Flusher, the events are sent as they are written.
+func (w *streamWriter) Flush() {}
+
+// WriteSseEvent implements sse.EventWriter.
+func (w *streamWriter) WriteSseEvent(e sse.Event) {
+	sub := w.sub
+	if e.Retry != 0 {
+		w.retry = time.Duration(e.Retry) * time.Millisecond
+	}
+	if e.Error != nil {
+		w.err = streamProblem(e.Error)
+		return
+	}
+
+	switch e.Event {
+	case "open":
+		if !sub.subscribed {
+			sub.subscribed = true
+			sub.session.send(sub.ctx, protocol.WebSocketMessage{
+				Type: protocol.WebSocketSubscribed,
+				ID:   sub.id,
+			})
+		}
+		return
+	case "close":
+		return
+	}
+
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		p := problem.ServerError
+		w.err = &p
+		return
+	}
+
+	if sub.session.send(sub.ctx, protocol.WebSocketMessage{
+		Type:   protocol.WebSocketEvent,
+		ID:     sub.id,
+		Cursor: e.ID,
+		Data:   data,
+	}) && e.ID != "" {
+		sub.cursor = e.ID
+	}
+}
+
+// problem returns the problem which ended the stream, or nil if the stream
+// can be requested again.
+func (w *streamWriter) problem() *problem.P {
+	if w.err != nil {
+		return w.err
+	}
+	if w.body.Len() == 0 && w.status < http.StatusBadRequest {
+		return nil
+	}
+
+	// problems can also be rendered after the stream started, when it fails
+	// before its first event
+	var p problem.P
+	if err := json.Unmarshal(w.body.Bytes(), &p); err == nil && p.Status != 0 {
+		return &p
+	}
+	if w.status >= http.StatusBadRequest {
+		p = problem.ServerError
+		p.Status = w.status
+		return &p
+	}
+	// the endpoint responded with a document, it cannot be streamed
+	p = hProblem.NotAcceptable
+	return &p
+}
+
+// streamProblem returns the problem of the error event of a stream.
+func streamProblem(err error) *problem.P {
+	switch p := err.(type) {
+	case problem.P:
+		return &p
+	case *problem.P:
+		return p
+	default:
+		sp := problem.ServerError
+		sp.Detail = err.Error()
+		return &sp
+	}
+}
@@ -34,8 +34,9 @@ func PopulateTransaction(
 	dest.AccountSequence = row.AccountSequence
 	dest.FeePaid = row.FeeCharged
 
-	dest.FeeCharged = row.FeeCharged
-	dest.MaxFee = row.MaxFee
+	dest.FeeCharged = int64(row.FeeCharged)
+	dest.MaxFee = int64(row.MaxFee)
+	dest.FeeAccount = row.Account
 
 	dest.OperationCount = row.OperationCount
 	dest.EnvelopeXdr = row.TxEnvelope
@@ -48,6 +49,20 @@ func PopulateTransaction(
 	dest.ValidBefore = timeString(dest, row.ValidBefore)
 	dest.ValidAfter = timeString(dest, row.ValidAfter)
 
+	if row.InnerTransactionHash.Valid {
+		dest.FeeAccount = row.FeeAccount.String
+		dest.MaxFee = row.NewMaxFee.Int64
+		dest.FeeBumpTransaction = &protocol.FeeBumpTransaction{
+			Hash:       row.TransactionHash,
+			Signatures: dest.Signatures,
+		}
+		dest.InnerTransaction = &protocol.InnerTransaction{
+			Hash:       row.InnerTransactionHash.String,
+			MaxFee:     int64(row.MaxFee),
+			Signatures: strings.Split(row.InnerSignatureString.String, ","),
+		}
+	}
+
 	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
 	dest.Links.Account = lb.Link("/accounts", dest.Account)
 	dest.Links.Ledger = lb.Link("/ledgers", fmt.Sprintf("%d", dest.Ledger))
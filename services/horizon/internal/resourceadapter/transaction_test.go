@@ -3,6 +3,7 @@ package resourceadapter
 import (
 	"testing"
 
+	"github.com/guregu/null"
 	. "github.com/paydex-core/paydex-go/protocols/horizon"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
 	"github.com/paydex-core/paydex-go/support/test"
@@ -55,3 +56,51 @@ func TestPopulateTransaction_Fee(t *testing.T) {
 	PopulateTransaction(ctx, &dest, row)
 	assert.Equal(t, int32(100), dest.FeePaid)
 }
+
+// TestPopulateTransaction_FeeBump tests fee bump transaction object population.
+func TestPopulateTransaction_FeeBump(t *testing.T) {
+	ctx, _ := test.ContextWithLogBuffer()
+
+	var (
+		dest Transaction
+		row  history.Transaction
+	)
+
+	dest = Transaction{}
+	row = history.Transaction{
+		TransactionHash: "cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a",
+		Account:         "GAFPSJGPCJGWFU6C2JKFOPUJ2J7U3QG4GHQ4K2QZXVZHTQGWJEBQGRBQ",
+		MaxFee:          100,
+		FeeCharged:      200,
+		SignatureString: "a,b",
+	}
+
+	PopulateTransaction(ctx, &dest, row)
+	assert.Equal(t, row.Account, dest.FeeAccount)
+	assert.Equal(t, int64(100), dest.MaxFee)
+	assert.Nil(t, dest.FeeBumpTransaction)
+	assert.Nil(t, dest.InnerTransaction)
+
+	dest = Transaction{}
+	row.FeeAccount = null.StringFrom("GDRJ7DJFQNF5FBBNUAGUHDE7OGHDSJTPLYZYOSVIYU6IZDGVBUOFKFCD")
+	row.InnerTransactionHash = null.StringFrom("e31b9e6fe6e5a5cd8e44ab41aeff6e68f2ad9b1d72d2bee17e21f4a9d1b3a0b9")
+	row.NewMaxFee = null.IntFrom(10000)
+	row.InnerSignatureString = null.StringFrom("c")
+
+	PopulateTransaction(ctx, &dest, row)
+	assert.Equal(t, row.TransactionHash, dest.Hash)
+	assert.Equal(t, row.Account, dest.Account)
+	assert.Equal(t, row.FeeAccount.String, dest.FeeAccount)
+	assert.Equal(t, int64(10000), dest.MaxFee)
+	assert.Equal(t, int64(200), dest.FeeCharged)
+	assert.Equal(t, []string{"a", "b"}, dest.Signatures)
+	assert.Equal(t, &FeeBumpTransaction{
+		Hash:       row.TransactionHash,
+		Signatures: []string{"a", "b"},
+	}, dest.FeeBumpTransaction)
+	assert.Equal(t, &InnerTransaction{
+		Hash:       row.InnerTransactionHash.String,
+		MaxFee:     100,
+		Signatures: []string{"c"},
+	}, dest.InnerTransaction)
+}
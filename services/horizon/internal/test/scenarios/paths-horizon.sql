@@ -448,7 +448,11 @@ CREATE TABLE history_transactions (
     memo character varying,
     time_bounds int8range,
     successful boolean,
-    fee_charged integer
+    fee_charged integer,
+    fee_account character varying(64),
+    inner_transaction_hash character varying(64),
+    new_max_fee bigint,
+    inner_signatures character varying(96)[]
 );
 
 
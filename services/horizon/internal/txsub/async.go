@@ -0,0 +1,103 @@
+package txsub
+
+import (
+	"context"
+
+	proto "github.com/paydex-core/paydex-go/protocols/paydexcore"
+	"github.com/paydex-core/paydex-go/support/log"
+)
+
+// AsyncResult represents the response of paydex-core to a transaction
+// submitted with SubmitAsync.
+type AsyncResult struct {
+	// The transaction hash of the submitted transaction
+	Hash string
+
+	// Status is the status returned by paydex-core: PENDING, DUPLICATE,
+	// TRY_AGAIN_LATER or ERROR. A transaction which already has a result is
+	// not submitted again and is DUPLICATE.
+	Status string
+
+	// The base64-encoded TransactionResult of a transaction rejected by
+	// paydex-core, set when Status is ERROR
+	ErrorResultXDR string
+}
+
+// SubmitAsync submits the provided base64 encoded transaction envelope to
+// paydex-core and returns its response, without waiting for the transaction
+// to be included in a ledger. Unlike Submit, the submission isn't queued
+// behind the other submissions of its source account, so the transactions of
+// an account must be submitted in sequence order.
+//
+// PENDING and DUPLICATE transactions are added to the open submission list,
+// where Status finds them until their result is available or the submission
+// times out.
+func (sys *System) SubmitAsync(ctx context.Context, env string) (AsyncResult, error) {
+	sys.Init()
+
+	info, err := extractEnvelopeInfo(ctx, env, sys.NetworkPassphrase)
+	if err != nil {
+		return AsyncResult{}, err
+	}
+	result := AsyncResult{Hash: info.Hash}
+
+	sys.Log.Ctx(ctx).WithFields(log.F{
+		"hash": info.Hash,
+		"tx":   env,
+	}).Info("Processing asynchronous transaction")
+
+	// check the configured result provider for an existing result
+	r := sys.Results.ResultByHash(ctx, info.Hash)
+	if _, failed := r.Err.(*FailedTransactionError); r.Err == nil || failed {
+		result.Status = proto.TXStatusDuplicate
+		return result, nil
+	}
+	if r.Err != ErrNoResults {
+		return result, r.Err
+	}
+
+	sr := sys.submitOnce(ctx, env)
+	if sr.Err != nil {
+		fte, ok := sr.Err.(*FailedTransactionError)
+		if !ok {
+			return result, sr.Err
+		}
+
+		result.Status = proto.TXStatusError
+		result.ErrorResultXDR = fte.ResultXDR
+		return result, nil
+	}
+
+	result.Status = sr.Status
+	if sr.Status == proto.TXStatusPending || sr.Status == proto.TXStatusDuplicate {
+		// nobody waits for the result, the listener only needs to hold it
+		// until the submission is finished or cleaned
+		err = sys.Pending.Add(ctx, info.Hash, make(chan Result, 1))
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Status returns the result of the transaction with the provided hash from
+// the configured result provider. When no result is available yet, Result.Err
+// is ErrNoResults and pending is true if the transaction is in the open
+// submission list of this system, submitted less than SubmissionTimeout ago.
+func (sys *System) Status(ctx context.Context, hash string) (r Result, pending bool) {
+	sys.Init()
+
+	r = sys.Results.ResultByHash(ctx, hash)
+	if r.Err != ErrNoResults {
+		return r, false
+	}
+
+	for _, pendingHash := range sys.Pending.Pending(ctx) {
+		if pendingHash == hash {
+			return r, true
+		}
+	}
+
+	return r, false
+}
@@ -53,7 +53,7 @@ func (fte *FailedTransactionError) OperationResultCodes() (result []string, err
 		return
 	}
 
-	oprs, ok := r.Result.GetResults()
+	oprs, ok := r.OperationResults()
 
 	if !ok {
 		return
@@ -2,14 +2,16 @@ package txsub
 
 import (
 	"context"
+	"encoding/hex"
 
-	"github.com/paydex-core/paydex-go/build"
+	"github.com/paydex-core/paydex-go/network"
 	"github.com/paydex-core/paydex-go/strkey"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
 type envelopeInfo struct {
 	Hash          string
+	InnerHash     string
 	Sequence      uint64
 	SourceAddress string
 }
@@ -24,17 +26,30 @@ func extractEnvelopeInfo(ctx context.Context, env string, passphrase string) (re
 		return
 	}
 
-	txb := build.TransactionBuilder{TX: &tx.Tx}
-	txb.Mutate(build.Network{passphrase})
-
-	result.Hash, err = txb.HashHex()
+	hash, err := network.HashTransactionInEnvelope(tx, passphrase)
 	if err != nil {
 		return
 	}
+	result.Hash = hex.EncodeToString(hash[:])
+
+	if tx.IsFeeBump() {
+		var innerHash [32]byte
+		innerTx := tx.InnerTransactionEnvelope().Tx
+		innerHash, err = network.HashTransaction(&innerTx, passphrase)
+		if err != nil {
+			return
+		}
+		result.InnerHash = hex.EncodeToString(innerHash[:])
+	}
 
-	result.Sequence = uint64(tx.Tx.SeqNum)
+	// For fee bump transactions the sequence number and source account are
+	// the ones of the inner transaction.
+	result.Sequence = uint64(tx.SeqNum())
 
-	aid := tx.Tx.SourceAccount.MustEd25519()
+	// The sequence number belongs to the account behind a muxed source
+	// account.
+	sourceAccount := tx.SourceAccount().ToAccountId()
+	aid := sourceAccount.MustEd25519()
 	result.SourceAddress, err = strkey.Encode(strkey.VersionByteAccountID, aid[:])
 
 	return
@@ -0,0 +1,98 @@
+package txsub
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envelopeWithOnePayment = "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"
+
+func TestExtractEnvelopeInfo(t *testing.T) {
+	ctx := context.Background()
+
+	info, err := extractEnvelopeInfo(ctx, envelopeWithOnePayment, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d", info.Hash)
+	assert.Equal(t, "", info.InnerHash)
+	assert.Equal(t, uint64(1), info.Sequence)
+	assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", info.SourceAddress)
+
+	_, err = extractEnvelopeInfo(ctx, "not an envelope", network.TestNetworkPassphrase)
+	assert.IsType(t, &MalformedTransactionError{}, err)
+}
+
+func TestExtractEnvelopeInfoFeeBump(t *testing.T) {
+	ctx := context.Background()
+
+	var inner xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(envelopeWithOnePayment, &inner))
+
+	var feeSource xdr.MuxedAccount
+	require.NoError(t, feeSource.SetAddress("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"))
+
+	feeBump := xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: xdr.FeeBumpTransaction{
+				FeeSource: feeSource,
+				Fee:       200,
+				InnerTx: xdr.FeeBumpTransactionInnerTx{
+					Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+					V1: &xdr.TransactionV1Envelope{
+						Tx:         inner.Transaction(),
+						Signatures: inner.Signatures(),
+					},
+				},
+			},
+		},
+	}
+	env, err := xdr.MarshalBase64(feeBump)
+	require.NoError(t, err)
+
+	outerHash, err := network.HashTransactionInEnvelope(feeBump, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	info, err := extractEnvelopeInfo(ctx, env, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, info.Hash, info.InnerHash)
+	assert.Equal(t, hex.EncodeToString(outerHash[:]), info.Hash)
+	assert.Equal(t, "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d", info.InnerHash)
+	assert.Equal(t, uint64(1), info.Sequence)
+	assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", info.SourceAddress)
+}
+
+// muxedSourceEnvelope returns envelopeWithOnePayment with its source account
+// replaced by a muxed account of the same key.
+func muxedSourceEnvelope(t *testing.T) string {
+	var inner xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(envelopeWithOnePayment, &inner))
+
+	tx := inner.Transaction()
+	sourceAccount := tx.SourceAccount.ToAccountId()
+	tx.SourceAccount = xdr.MuxedAccount{
+		Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+		Med25519: &xdr.MuxedAccountMed25519{
+			Id:      123,
+			Ed25519: sourceAccount.MustEd25519(),
+		},
+	}
+	env, err := xdr.MarshalBase64(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: tx, Signatures: inner.Signatures()},
+	})
+	require.NoError(t, err)
+	return env
+}
+
+func TestExtractEnvelopeInfoMuxedSource(t *testing.T) {
+	info, err := extractEnvelopeInfo(context.Background(), muxedSourceEnvelope(t), network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), info.Sequence)
+	assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", info.SourceAddress)
+}
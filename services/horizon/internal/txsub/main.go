@@ -96,6 +96,11 @@ type SubmissionResult struct {
 	// inclusion in the ledger (i.e. A successful submission).
 	Err error
 
+	// Status is the status returned by paydex-core: PENDING, DUPLICATE,
+	// TRY_AGAIN_LATER or ERROR. It is empty if paydex-core could not be
+	// reached.
+	Status string
+
 	// Duration records the time it took to submit a transaction
 	// to paydex-core
 	Duration time.Duration
@@ -66,10 +66,14 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 		return
 	}
 
-	sys.Log.Ctx(ctx).WithFields(log.F{
+	logFields := log.F{
 		"hash": info.Hash,
 		"tx":   env,
-	}).Info("Processing transaction")
+	}
+	if info.InnerHash != "" {
+		logFields["inner_hash"] = info.InnerHash
+	}
+	sys.Log.Ctx(ctx).WithFields(logFields).Info("Processing transaction")
 
 	// check the configured result provider for an existing result
 	r := sys.Results.ResultByHash(ctx, info.Hash)
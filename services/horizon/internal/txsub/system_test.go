@@ -113,6 +113,105 @@ func (suite *SystemTestSuite) TestSubmit_OpenTransactionList() {
 	assert.Equal(suite.T(), int64(1), suite.system.Metrics.SubmissionTimer.Count())
 }
 
+// Transactions with a muxed source account use the sequence number of the
+// account behind it.
+func (suite *SystemTestSuite) TestSubmit_MuxedSource() {
+	env := muxedSourceEnvelope(suite.T())
+	_ = suite.system.Submit(suite.ctx, env)
+
+	assert.True(suite.T(), suite.submitter.WasSubmittedTo)
+	pending := suite.system.Pending.Pending(suite.ctx)
+	assert.Equal(suite.T(), 1, len(pending))
+	suite.sequences.AssertExpectations(suite.T())
+}
+
+// Returns DUPLICATE without submitting if a result is found.
+func (suite *SystemTestSuite) TestSubmitAsync_Duplicate() {
+	suite.results.Results = []Result{suite.successTx}
+	r, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.successTx.Hash, r.Hash)
+	assert.Equal(suite.T(), "DUPLICATE", r.Status)
+	assert.False(suite.T(), suite.submitter.WasSubmittedTo)
+}
+
+// Returns the status of paydex-core and adds pending transactions to the open
+// transaction list.
+func (suite *SystemTestSuite) TestSubmitAsync_Pending() {
+	suite.submitter.R.Status = "PENDING"
+	r, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), suite.successTx.Hash, r.Hash)
+	assert.Equal(suite.T(), "PENDING", r.Status)
+	assert.Empty(suite.T(), r.ErrorResultXDR)
+	assert.True(suite.T(), suite.submitter.WasSubmittedTo)
+	assert.Equal(suite.T(), []string{suite.successTx.Hash}, suite.system.Pending.Pending(suite.ctx))
+	assert.Equal(suite.T(), int64(1), suite.system.Metrics.SuccessfulSubmissionsMeter.Count())
+}
+
+// Transactions paydex-core asks to try again later aren't open.
+func (suite *SystemTestSuite) TestSubmitAsync_TryAgainLater() {
+	suite.submitter.R.Status = "TRY_AGAIN_LATER"
+	r, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "TRY_AGAIN_LATER", r.Status)
+	assert.Empty(suite.T(), suite.system.Pending.Pending(suite.ctx))
+}
+
+// Returns ERROR with the transaction result when paydex-core rejects the
+// transaction.
+func (suite *SystemTestSuite) TestSubmitAsync_Error() {
+	suite.submitter.R = suite.badSeq
+	suite.submitter.R.Status = "ERROR"
+	r, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "ERROR", r.Status)
+	assert.Equal(suite.T(), ErrBadSequence.ResultXDR, r.ErrorResultXDR)
+	assert.Empty(suite.T(), suite.system.Pending.Pending(suite.ctx))
+	assert.Equal(suite.T(), int64(1), suite.system.Metrics.FailedSubmissionsMeter.Count())
+}
+
+// Returns the error from submission if paydex-core can't be reached.
+func (suite *SystemTestSuite) TestSubmitAsync_SubmissionError() {
+	suite.submitter.R.Err = errors.New("busted for some reason")
+	_, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.EqualError(suite.T(), err, "busted for some reason")
+}
+
+// Rejects envelopes which can't be decoded.
+func (suite *SystemTestSuite) TestSubmitAsync_Malformed() {
+	_, err := suite.system.SubmitAsync(suite.ctx, "not an envelope")
+
+	assert.IsType(suite.T(), &MalformedTransactionError{}, err)
+	assert.False(suite.T(), suite.submitter.WasSubmittedTo)
+}
+
+// Status reports open submissions as pending until their result is found.
+func (suite *SystemTestSuite) TestStatus() {
+	r, pending := suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.Equal(suite.T(), ErrNoResults, r.Err)
+	assert.False(suite.T(), pending)
+
+	suite.submitter.R.Status = "PENDING"
+	_, err := suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+	assert.NoError(suite.T(), err)
+
+	r, pending = suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.Equal(suite.T(), ErrNoResults, r.Err)
+	assert.True(suite.T(), pending)
+
+	suite.results.Results = []Result{suite.successTx}
+	r, pending = suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.NoError(suite.T(), r.Err)
+	assert.Equal(suite.T(), suite.successTx.LedgerSequence, r.LedgerSequence)
+	assert.False(suite.T(), pending)
+}
+
 // Tick should be a no-op if there are no open submissions.
 func (suite *SystemTestSuite) TestTick_Noop() {
 	suite.system.Tick(suite.ctx)
@@ -20,11 +20,15 @@ import (
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/core"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/gql"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/ledger"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/paths"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/pubsub"
 	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/render/sse"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/render/ws"
 	"github.com/paydex-core/paydex-go/services/horizon/internal/txsub/sequence"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/webhooks"
 	"github.com/paydex-core/paydex-go/support/db"
 	"github.com/paydex-core/paydex-go/support/log"
 	"github.com/paydex-core/paydex-go/support/render/problem"
@@ -41,6 +45,7 @@ type web struct {
 	appCtx             context.Context
 	router             *chi.Mux
 	rateLimiter        *throttled.HTTPRateLimiter
+	streams            *pubsub.Hub
 	sseUpdateFrequency time.Duration
 	staleThreshold     uint
 	ingestFailedTx     bool
@@ -108,6 +113,13 @@ func (w *web) mustInstallMiddlewares(app *App, connTimeout time.Duration) {
 	r.Use(contextMiddleware)
 	r.Use(xff.Handler)
 	r.Use(loggerMiddleware)
+	// WebSocket connections are long lived, the requests of their streams go
+	// through the whole stack, including the timeout and the rate limiter
+	r.Use(ws.Handler{
+		Router:            r,
+		HeartbeatInterval: app.config.WebSocketHeartbeatInterval,
+		MaxSubscriptions:  app.config.WebSocketMaxSubscriptions,
+	}.Middleware)
 	r.Use(timeoutMiddleware(connTimeout))
 	r.Use(requestMetricsMiddleware)
 	r.Use(recoverMiddleware)
@@ -166,6 +178,7 @@ func (w *web) mustInstallActions(
 	pathFinder paths.Finder,
 	orderBookGraph *orderbook.OrderBookGraph,
 	requiresExperimentalIngestion *ExperimentalIngestionMiddleware,
+	webhookSystem *webhooks.System,
 ) {
 	if w == nil {
 		log.Fatal("missing web instance for installing web actions")
@@ -173,6 +186,8 @@ func (w *web) mustInstallActions(
 
 	r := w.router
 	r.Get("/", RootAction{}.Handle)
+
+	liveTransactions := &liveStream{kind: pubsub.Transactions, events: w.liveTransactions}
 	r.Get("/metrics", MetricsAction{}.Handle)
 
 	// ledger actions
@@ -180,7 +195,7 @@ func (w *web) mustInstallActions(
 		r.Get("/", LedgerIndexAction{}.Handle)
 		r.Route("/{ledger_id}", func(r chi.Router) {
 			r.Get("/", LedgerShowAction{}.Handle)
-			r.Get("/transactions", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions))
+			r.Get("/transactions", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions, liveTransactions))
 			r.Get("/operations", OperationIndexAction{}.Handle)
 			r.Get("/payments", OperationIndexAction{OnlyPayments: true}.Handle)
 			r.Get("/effects", EffectIndexAction{}.Handle)
@@ -196,8 +211,12 @@ func (w *web) mustInstallActions(
 				restPageHandler(actions.GetAccountsHandler{}),
 			)
 		r.Route("/{account_id}", func(r chi.Router) {
-			r.Get("/", w.streamShowActionHandler(w.getAccountInfo, true))
-			r.Get("/transactions", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions))
+			accountState := chi.Chain(acceptOnlyJSON, requiresExperimentalIngestion.Wrap).
+				Handler(objectActionHandler{actions.GetAccountStateHandler{}})
+			r.With(withQueryParam("ledger", accountState)).
+				Get("/", w.streamShowActionHandler(w.getAccountInfo, true))
+			r.Method(http.MethodGet, "/balances", accountState)
+			r.Get("/transactions", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions, liveTransactions))
 			r.Get("/operations", OperationIndexAction{}.Handle)
 			r.Get("/payments", OperationIndexAction{OnlyPayments: true}.Handle)
 			r.Get("/effects", EffectIndexAction{}.Handle)
@@ -209,6 +228,7 @@ func (w *web) mustInstallActions(
 	streamHandler := sse.StreamHandler{
 		RateLimiter:  w.rateLimiter,
 		LedgerSource: ledger.NewHistoryDBSource(w.sseUpdateFrequency),
+		Hub:          w.streams,
 	}
 
 	installAccountOfferRoute(
@@ -221,7 +241,7 @@ func (w *web) mustInstallActions(
 
 	// transaction history actions
 	r.Route("/transactions", func(r chi.Router) {
-		r.Get("/", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions))
+		r.Get("/", w.streamIndexActionHandler(w.getTransactionPage, w.streamTransactions, liveTransactions))
 		r.Route("/{tx_id}", func(r chi.Router) {
 			r.Get("/", showActionHandler(w.getTransactionResource))
 			r.Get("/operations", OperationIndexAction{}.Handle)
@@ -280,6 +300,8 @@ func (w *web) mustInstallActions(
 
 	// Transaction submission API
 	r.Post("/transactions", TransactionCreateAction{}.Handle)
+	r.Post("/transactions_async", TransactionAsyncCreateAction{}.Handle)
+	r.Get("/transactions_async/{tx_id}", TransactionAsyncStatusAction{}.Handle)
 
 	findPaths := FindPathsHandler{
 		staleThreshold:       config.StaleThreshold,
@@ -315,6 +337,24 @@ func (w *web) mustInstallActions(
 		r.Get("/assets", AssetsAction{}.Handle)
 	}
 
+	if config.EnableGraphQL {
+		graphQL := gql.New(gql.Config{
+			HistorySession:              w.horizonSession,
+			CoreSession:                 w.coreSession,
+			MaxDepth:                    config.GraphQLMaxDepth,
+			MaxCost:                     config.GraphQLMaxCost,
+			QueryTimeout:                config.GraphQLQueryTimeout,
+			EnableExperimentalIngestion: config.EnableExperimentalIngestion,
+			EnableAssetStats:            config.EnableAssetStats,
+		})
+		r.Method(http.MethodGet, "/graphql", graphQL)
+		r.Method(http.MethodPost, "/graphql", graphQL)
+	}
+
+	if webhookSystem != nil {
+		r.Mount("/admin/webhooks", webhookSystem.AdminHandler())
+	}
+
 	// Network state related endpoints
 	r.Get("/fee_stats", FeeStatsAction{}.Handle)
 
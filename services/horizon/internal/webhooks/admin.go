@@ -0,0 +1,346 @@
+package webhooks
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-chi/chi"
+	"github.com/guregu/null"
+	"github.com/lib/pq"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/actions"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	hProblem "github.com/paydex-core/paydex-go/services/horizon/internal/render/problem"
+	"github.com/paydex-core/paydex-go/strkey"
+	"github.com/paydex-core/paydex-go/support/db"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/support/render/httpjson"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+const (
+	// maxRequestSize is the maximum size of the body of an admin request.
+	maxRequestSize = 1 << 16
+	// maxURLLength is the maximum length of the URL of a subscription.
+	maxURLLength = 2048
+)
+
+// createRequest is the body of a request creating a subscription.
+type createRequest struct {
+	URL            string   `json:"url"`
+	Accounts       []string `json:"accounts"`
+	Asset          string   `json:"asset"`
+	OperationTypes []string `json:"operation_types"`
+	// StartLedger is the first ledger checked for matching operations. The
+	// ledgers ingested after the subscription is created are checked when 0.
+	StartLedger int32 `json:"start_ledger"`
+}
+
+// AdminHandler returns the handler of the admin API, which must be mounted
+// at `/admin/webhooks`. Every request must include the admin token in an
+// `Authorization: Bearer` header.
+func (s *System) AdminHandler() http.Handler {
+	r := chi.NewRouter()
+	r.Use(s.requireAdminToken)
+	r.Get("/", s.listSubscriptions)
+	r.Post("/", s.createSubscription)
+	r.Route("/{id}", func(r chi.Router) {
+		r.Get("/", s.showSubscription)
+		r.Delete("/", s.deleteSubscription)
+		r.Get("/deliveries", s.listDeliveries)
+		r.Post("/replay", s.replaySubscription)
+	})
+	return r
+}
+
+func (s *System) requireAdminToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "Bearer "
+		header := r.Header.Get("Authorization")
+		token := strings.TrimPrefix(header, prefix)
+		if s.config.AdminToken == "" ||
+			!strings.HasPrefix(header, prefix) ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(s.config.AdminToken)) != 1 {
+			problem.Render(r.Context(), w, hProblem.Unauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestQ returns the history queries bound to the context of r.
+func (s *System) requestQ(r *http.Request) *history.Q {
+	return &history.Q{Session: &db.Session{DB: s.historyQ.DB, Ctx: r.Context()}}
+}
+
+func (s *System) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	rows, err := s.requestQ(r).WebhookSubscriptions()
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	var page hal.BasePage
+	page.Init()
+	for _, row := range rows {
+		page.Add(newSubscription(r.Context(), row))
+	}
+	httpjson.Render(w, page, httpjson.HALJSON)
+}
+
+func (s *System) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err == nil {
+		err = json.Unmarshal(body, &req)
+	}
+	if err != nil {
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem("body", err))
+		return
+	}
+
+	q := s.requestQ(r)
+	row, err := s.newSubscriptionRow(q, req)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	row.ID, err = q.InsertWebhookSubscription(row)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	resource := newSubscription(r.Context(), row)
+	resource.Secret = row.Secret
+	httpjson.Render(w, resource, httpjson.HALJSON)
+}
+
+// newSubscriptionRow validates a request creating a subscription and returns
+// the subscription to insert, with a new secret.
+func (s *System) newSubscriptionRow(q *history.Q, req createRequest) (history.WebhookSubscription, error) {
+	row := history.WebhookSubscription{
+		URL:            req.URL,
+		Accounts:       pq.StringArray{},
+		OperationTypes: pq.Int64Array{},
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return row, problem.MakeInvalidFieldProblem("url", errors.New("must be an absolute http or https URL"))
+	}
+	if len(req.URL) > maxURLLength {
+		return row, problem.MakeInvalidFieldProblem("url", errors.Errorf("must be at most %d characters", maxURLLength))
+	}
+
+	for _, account := range req.Accounts {
+		if _, err = strkey.Decode(strkey.VersionByteAccountID, account); err != nil {
+			return row, problem.MakeInvalidFieldProblem("accounts", errors.Errorf("%s is not a valid account id", account))
+		}
+		row.Accounts = append(row.Accounts, account)
+	}
+
+	if req.Asset != "" {
+		assets, err := xdr.BuildAssets(req.Asset)
+		if err != nil || len(assets) != 1 {
+			return row, problem.MakeInvalidFieldProblem("asset", errors.New("must be `native` or `code:issuer`"))
+		}
+		row.Asset = null.StringFrom(req.Asset)
+	}
+
+	types := map[string]xdr.OperationType{}
+	for typ, name := range operations.TypeNames {
+		types[name] = typ
+	}
+	for _, name := range req.OperationTypes {
+		typ, ok := types[name]
+		if !ok {
+			return row, problem.MakeInvalidFieldProblem("operation_types", errors.Errorf("%s is not a valid operation type", name))
+		}
+		row.OperationTypes = append(row.OperationTypes, int64(typ))
+	}
+
+	switch {
+	case req.StartLedger < 0:
+		return row, problem.MakeInvalidFieldProblem("start_ledger", errors.New("must be positive"))
+	case req.StartLedger > 0:
+		row.LastLedger = req.StartLedger - 1
+	default:
+		if err = q.LatestLedger(&row.LastLedger); err != nil {
+			return row, err
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err = rand.Read(secret); err != nil {
+		return row, errors.Wrap(err, "could not generate secret")
+	}
+	row.Secret = hex.EncodeToString(secret)
+	row.CreatedAt = s.now().UTC()
+
+	return row, nil
+}
+
+func (s *System) showSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := actions.GetInt64(r, "id")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	var row history.WebhookSubscription
+	if err = s.requestQ(r).WebhookSubscriptionByID(&row, id); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	httpjson.Render(w, newSubscription(r.Context(), row), httpjson.HALJSON)
+}
+
+func (s *System) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := actions.GetInt64(r, "id")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	rows, err := s.requestQ(r).DeleteWebhookSubscription(id)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	if rows == 0 {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *System) listDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := actions.GetInt64(r, "id")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	state, err := actions.GetString(r, "state")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	switch state {
+	case "", history.WebhookDeliveryPending, history.WebhookDeliveryDelivered, history.WebhookDeliveryDead:
+	default:
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"state",
+			errors.New("must be one of pending, delivered or dead"),
+		))
+		return
+	}
+
+	pageQuery, err := actions.GetPageQuery(r)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	q := s.requestQ(r)
+	var subscription history.WebhookSubscription
+	if err = q.WebhookSubscriptionByID(&subscription, id); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	rows, err := q.WebhookDeliveries(id, state, pageQuery)
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	page := hal.Page{
+		Cursor: pageQuery.Cursor,
+		Order:  pageQuery.Order,
+		Limit:  pageQuery.Limit,
+	}
+	for _, row := range rows {
+		page.Add(newDelivery(r.Context(), row))
+	}
+	page.FullURL = actions.FullURL(r.Context())
+	page.PopulateLinks()
+
+	httpjson.Render(w, page, httpjson.HALJSON)
+}
+
+// replaySubscription checks again the ledgers from `from_ledger` for
+// operations matching a subscription, queueing new deliveries for them.
+func (s *System) replaySubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := actions.GetInt64(r, "id")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	from, err := actions.GetInt64(r, "from_ledger")
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	q := s.requestQ(r)
+	var latest, elder int32
+	if err = q.LatestLedger(&latest); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	if err = q.ElderLedger(&elder); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	switch {
+	case from <= 0:
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"from_ledger",
+			errors.New("must be positive"),
+		))
+		return
+	case from < int64(elder):
+		problem.Render(r.Context(), w, hProblem.BeforeHistory)
+		return
+	case from > int64(latest)+1:
+		problem.Render(r.Context(), w, problem.MakeInvalidFieldProblem(
+			"from_ledger",
+			errors.New("must not be after the latest ingested ledger"),
+		))
+		return
+	}
+
+	rows, err := q.UpdateWebhookSubscriptionLastLedger(id, int32(from-1))
+	if err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+	if rows == 0 {
+		problem.Render(r.Context(), w, problem.NotFound)
+		return
+	}
+
+	var row history.WebhookSubscription
+	if err = q.WebhookSubscriptionByID(&row, id); err != nil {
+		problem.Render(r.Context(), w, err)
+		return
+	}
+
+	httpjson.Render(w, newSubscription(r.Context(), row), httpjson.HALJSON)
+}
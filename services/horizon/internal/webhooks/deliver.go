@@ -0,0 +1,171 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/guregu/null"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/log"
+)
+
+const (
+	// DeliveryHeader is the header containing the id of a delivery. It is
+	// the same for every attempt of the delivery.
+	DeliveryHeader = "X-Paydex-Webhook-Delivery"
+	// TimestampHeader is the header containing the unix timestamp of a
+	// delivery attempt.
+	TimestampHeader = "X-Paydex-Webhook-Timestamp"
+	// SignatureHeader is the header containing the signature of a delivery
+	// attempt, `sha256=` followed by the result of Signature.
+	SignatureHeader = "X-Paydex-Webhook-Signature"
+)
+
+// Signature returns the hex encoded HMAC-SHA256, keyed with the secret of a
+// subscription, of the timestamp of a delivery attempt, a dot and the body of
+// the request.
+func Signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryDelay returns the delay before the next attempt of a delivery which
+// failed `attempts` times.
+func retryDelay(attempts int32) time.Duration {
+	delay := retryBaseDelay
+	for i := int32(1); i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+// deliver attempts the deliveries which are due.
+func (s *System) deliver() error {
+	deliveries, err := s.historyQ.PendingWebhookDeliveries(s.now().UTC(), maxDeliveriesPerTick)
+	if err != nil {
+		return errors.Wrap(err, "could not load pending deliveries")
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	rows, err := s.historyQ.WebhookSubscriptions()
+	if err != nil {
+		return errors.Wrap(err, "could not load subscriptions")
+	}
+	subscriptions := map[int64]history.WebhookSubscription{}
+	for _, row := range rows {
+		subscriptions[row.ID] = row
+	}
+
+	var wg sync.WaitGroup
+	queue := make(chan history.WebhookDelivery)
+	for i := 0; i < deliveryWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for delivery := range queue {
+				subscription, ok := subscriptions[delivery.SubscriptionID]
+				// the subscription was removed after the deliveries were
+				// loaded, its deliveries are removed with it
+				if !ok {
+					continue
+				}
+				s.attempt(subscription, delivery)
+			}
+		}()
+	}
+
+	for _, delivery := range deliveries {
+		queue <- delivery
+	}
+	close(queue)
+	wg.Wait()
+
+	return nil
+}
+
+// attempt POSTs a delivery to its subscription and records the outcome.
+func (s *System) attempt(
+	subscription history.WebhookSubscription,
+	delivery history.WebhookDelivery,
+) {
+	err := s.post(subscription, delivery)
+
+	now := s.now().UTC()
+	delivery.Attempts++
+	if err == nil {
+		delivery.State = history.WebhookDeliveryDelivered
+		delivery.DeliveredAt = null.TimeFrom(now)
+		delivery.LastError = null.String{}
+	} else {
+		delivery.LastError = null.StringFrom(err.Error())
+		if delivery.Attempts >= s.config.MaxAttempts {
+			delivery.State = history.WebhookDeliveryDead
+		} else {
+			delivery.NextAttemptAt = now.Add(retryDelay(delivery.Attempts))
+		}
+	}
+
+	if _, updateErr := s.historyQ.UpdateWebhookDelivery(delivery); updateErr != nil {
+		log.WithStack(updateErr).
+			WithField("delivery", delivery.ID).
+			WithField("err", updateErr.Error()).
+			Error("webhooks: failed to update delivery")
+	}
+
+	if err != nil {
+		log.WithField("delivery", delivery.ID).
+			WithField("subscription", subscription.ID).
+			WithField("attempts", delivery.Attempts).
+			WithField("state", delivery.State).
+			WithField("err", err.Error()).
+			Warn("webhooks: delivery attempt failed")
+	}
+}
+
+func (s *System) post(
+	subscription history.WebhookSubscription,
+	delivery history.WebhookDelivery,
+) error {
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+
+	req, err := http.NewRequest(http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/hal+json; charset=utf-8")
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, "sha256="+Signature(subscription.Secret, timestamp, body))
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request failed")
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
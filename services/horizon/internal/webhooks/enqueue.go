@@ -0,0 +1,195 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/resourceadapter"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/support/log"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+)
+
+// enqueue queues a delivery for every operation matching a subscription in
+// the ledgers ingested since the last tick.
+func (s *System) enqueue() error {
+	var latest, elder int32
+	if err := s.historyQ.LatestLedger(&latest); err != nil {
+		return errors.Wrap(err, "could not load the latest ledger")
+	}
+	if err := s.historyQ.ElderLedger(&elder); err != nil {
+		return errors.Wrap(err, "could not load the elder ledger")
+	}
+
+	subscriptions, err := s.historyQ.WebhookSubscriptions()
+	if err != nil {
+		return errors.Wrap(err, "could not load subscriptions")
+	}
+
+	for _, subscription := range subscriptions {
+		err := s.enqueueSubscription(subscription, elder, latest)
+		if err != nil {
+			log.WithStack(err).
+				WithField("subscription", subscription.ID).
+				WithField("err", err.Error()).
+				Error("webhooks: failed to queue deliveries for subscription")
+		}
+	}
+
+	return nil
+}
+
+func (s *System) enqueueSubscription(
+	subscription history.WebhookSubscription,
+	elder, latest int32,
+) error {
+	from := subscription.LastLedger + 1
+	// the ledgers removed by the reaper cannot be delivered anymore
+	if from < elder {
+		from = elder
+	}
+	if from > latest {
+		return nil
+	}
+	to := latest
+	if to-from >= maxLedgersPerTick {
+		to = from + maxLedgersPerTick - 1
+	}
+
+	operations, err := s.historyQ.WebhookOperations(subscription, from, to)
+	if err != nil {
+		return errors.Wrap(err, "could not load operations")
+	}
+
+	deliveries, err := s.buildDeliveries(subscription, operations)
+	if err != nil {
+		return err
+	}
+
+	q := &history.Q{Session: s.historyQ.Clone()}
+	if err = q.Begin(); err != nil {
+		return errors.Wrap(err, "could not begin transaction")
+	}
+	defer q.Rollback()
+
+	for _, delivery := range deliveries {
+		if err = q.InsertWebhookDelivery(delivery); err != nil {
+			return errors.Wrap(err, "could not insert delivery")
+		}
+	}
+
+	rows, err := q.AdvanceWebhookSubscription(subscription.ID, subscription.LastLedger, to)
+	if err != nil {
+		return errors.Wrap(err, "could not update subscription")
+	}
+	// the subscription was replayed or removed in the meantime
+	if rows == 0 {
+		return nil
+	}
+
+	if err = q.Commit(); err != nil {
+		return errors.Wrap(err, "could not commit transaction")
+	}
+
+	return nil
+}
+
+func (s *System) buildDeliveries(
+	subscription history.WebhookSubscription,
+	operations []history.Operation,
+) ([]history.WebhookDelivery, error) {
+	if len(operations) == 0 {
+		return nil, nil
+	}
+
+	var sequences []int32
+	seen := map[int32]bool{}
+	for _, operation := range operations {
+		sequence := operation.LedgerSequence()
+		if !seen[sequence] {
+			seen[sequence] = true
+			sequences = append(sequences, sequence)
+		}
+	}
+
+	var ledgerRows []history.Ledger
+	if err := s.historyQ.LedgersBySequence(&ledgerRows, sequences...); err != nil {
+		return nil, errors.Wrap(err, "could not load ledgers")
+	}
+	ledgers := map[int32]history.Ledger{}
+	for _, ledger := range ledgerRows {
+		ledgers[ledger.Sequence] = ledger
+	}
+
+	now := s.now().UTC()
+	deliveries := make([]history.WebhookDelivery, 0, len(operations))
+	for _, operation := range operations {
+		ledger, ok := ledgers[operation.LedgerSequence()]
+		if !ok {
+			return nil, errors.Errorf("ledger %d not found", operation.LedgerSequence())
+		}
+
+		payload, err := s.buildEvent(subscription, operation, ledger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not build event for operation %d", operation.ID)
+		}
+
+		deliveries = append(deliveries, history.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			LedgerSequence: ledger.Sequence,
+			OperationID:    operation.ID,
+			Payload:        string(payload),
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		})
+	}
+
+	return deliveries, nil
+}
+
+func (s *System) buildEvent(
+	subscription history.WebhookSubscription,
+	operation history.Operation,
+	ledger history.Ledger,
+) ([]byte, error) {
+	// events are rendered outside of a request, with relative links
+	ctx := context.Background()
+
+	var event Event
+	event.SubscriptionID = fmt.Sprintf("%d", subscription.ID)
+	event.LedgerSequence = ledger.Sequence
+	event.PT = operation.PagingToken()
+
+	lb := hal.LinkBuilder{}
+	event.Links.Subscription = lb.Link(subscriptionPath(subscription.ID))
+	event.Links.Operation = lb.Linkf("/operations/%d", operation.ID)
+
+	resource, err := resourceadapter.NewOperation(ctx, operation, nil, ledger)
+	if err != nil {
+		return nil, err
+	}
+	event.Embedded.Operation = resource
+
+	var effects []history.Effect
+	err = s.historyQ.Effects().ForOperation(operation.ID).Select(&effects)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not load effects")
+	}
+	sort.Slice(effects, func(i, j int) bool {
+		return effects[i].Order < effects[j].Order
+	})
+
+	event.Embedded.Effects = make([]hal.Pageable, 0, len(effects))
+	for _, effect := range effects {
+		resource, err := resourceadapter.NewEffect(ctx, effect, ledger)
+		if err != nil {
+			return nil, err
+		}
+		event.Embedded.Effects = append(event.Embedded.Effects, resource)
+	}
+
+	return json.Marshal(event)
+}
@@ -0,0 +1,110 @@
+// Package webhooks contains the webhook subsystem of horizon. Clients register
+// subscriptions, a URL and filters on the accounts, asset and types of the
+// operations, through an authenticated admin API. As ledgers are ingested the
+// operations matching a subscription are queued in the history database, and
+// then POSTed with their effects to the URL of the subscription, signed with
+// the secret of the subscription.
+//
+// Deliveries are at-least-once: a delivery is retried with an exponential
+// backoff until the subscriber responds with a 2xx status code, or until it
+// failed `Config.MaxAttempts` times, after which it is moved to the dead state.
+package webhooks
+
+import (
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/errors"
+	"github.com/paydex-core/paydex-go/support/db"
+	"github.com/paydex-core/paydex-go/support/log"
+)
+
+const (
+	// maxLedgersPerTick is the maximum number of ledgers checked for matching
+	// operations for a subscription during a tick.
+	maxLedgersPerTick = 100
+	// maxDeliveriesPerTick is the maximum number of deliveries attempted
+	// during a tick.
+	maxDeliveriesPerTick = 200
+	// deliveryWorkers is the number of deliveries attempted concurrently.
+	deliveryWorkers = 10
+	// deliveryTimeout is the default timeout of a delivery attempt.
+	deliveryTimeout = 10 * time.Second
+	// retryBaseDelay is the delay before the first retry of a failed
+	// delivery, it doubles after every failed attempt.
+	retryBaseDelay = 10 * time.Second
+	// retryMaxDelay is the maximum delay between two attempts of a delivery.
+	retryMaxDelay = time.Hour
+	// DefaultMaxAttempts is the default number of attempts of a delivery
+	// before it is moved to the dead state.
+	DefaultMaxAttempts = 12
+)
+
+// Config configures the webhook subsystem.
+type Config struct {
+	HistorySession *db.Session
+	// AdminToken is the bearer token required by the admin API.
+	AdminToken string
+	// MaxAttempts is the number of attempts of a delivery before it is moved
+	// to the dead state. DefaultMaxAttempts is used when 0.
+	MaxAttempts int32
+	// Client is the HTTP client used to deliver events. A client with a 10
+	// seconds timeout is used when nil.
+	Client *http.Client
+}
+
+// System represents the webhook subsystem of horizon.
+type System struct {
+	config   Config
+	historyQ *history.Q
+	running  int32
+	now      func() time.Time
+}
+
+// New initializes the webhook subsystem.
+func New(config Config) *System {
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: deliveryTimeout}
+	}
+
+	return &System{
+		config:   config,
+		historyQ: &history.Q{Session: config.HistorySession},
+		now:      time.Now,
+	}
+}
+
+// Tick queues the operations of the newly ingested ledgers matching the
+// subscriptions and attempts the deliveries which are due. It returns
+// immediately if the previous tick is still running.
+func (s *System) Tick() {
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&s.running, 0)
+
+	s.runOnce()
+}
+
+func (s *System) runOnce() {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err := errors.FromPanic(rec)
+			log.Errorf("webhooks panicked: %s", err)
+			errors.ReportToSentry(err, nil)
+		}
+	}()
+
+	if err := s.enqueue(); err != nil {
+		log.WithStack(err).WithField("err", err.Error()).Error("webhooks: failed to queue deliveries")
+	}
+
+	if err := s.deliver(); err != nil {
+		log.WithStack(err).WithField("err", err.Error()).Error("webhooks: failed to attempt deliveries")
+	}
+}
@@ -0,0 +1,157 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"id":"12884905985"}`)
+	mac := hmac.New(sha256.New, []byte("s3cr3t"))
+	mac.Write([]byte(`1583056800.{"id":"12884905985"}`))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, Signature("s3cr3t", "1583056800", body))
+	assert.NotEqual(t, expected, Signature("s3cr3t", "1583056801", body))
+	assert.NotEqual(t, expected, Signature("other", "1583056800", body))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 10*time.Second, retryDelay(1))
+	assert.Equal(t, 20*time.Second, retryDelay(2))
+	assert.Equal(t, 40*time.Second, retryDelay(3))
+	assert.Equal(t, 2560*time.Second, retryDelay(9))
+	assert.Equal(t, time.Hour, retryDelay(10))
+	assert.Equal(t, time.Hour, retryDelay(100))
+}
+
+func TestPost(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	now := time.Unix(1583056800, 0)
+	s := New(Config{})
+	s.now = func() time.Time { return now }
+
+	subscription := history.WebhookSubscription{ID: 1, URL: server.URL, Secret: "s3cr3t"}
+	delivery := history.WebhookDelivery{ID: 7, SubscriptionID: 1, Payload: `{"id":"12884905985"}`}
+
+	require.NoError(t, s.post(subscription, delivery))
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, `{"id":"12884905985"}`, string(body))
+	assert.Equal(t, "application/hal+json; charset=utf-8", received.Header.Get("Content-Type"))
+	assert.Equal(t, "7", received.Header.Get(DeliveryHeader))
+	assert.Equal(t, "1583056800", received.Header.Get(TimestampHeader))
+	assert.Equal(
+		t,
+		"sha256="+Signature("s3cr3t", "1583056800", body),
+		received.Header.Get(SignatureHeader),
+	)
+
+	status = http.StatusInternalServerError
+	assert.EqualError(t, s.post(subscription, delivery), "unexpected status code 500")
+
+	subscription.URL = "http://127.0.0.1:0"
+	assert.Error(t, s.post(subscription, delivery))
+}
+
+func TestRequireAdminToken(t *testing.T) {
+	handler := func(token string) http.Handler {
+		s := New(Config{AdminToken: token})
+		return s.requireAdminToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	}
+
+	for _, testCase := range []struct {
+		name          string
+		token         string
+		authorization string
+		status        int
+	}{
+		{"valid token", "t0k3n", "Bearer t0k3n", http.StatusTeapot},
+		{"missing header", "t0k3n", "", http.StatusUnauthorized},
+		{"invalid token", "t0k3n", "Bearer other", http.StatusUnauthorized},
+		{"missing scheme", "t0k3n", "t0k3n", http.StatusUnauthorized},
+		{"token not configured", "", "Bearer ", http.StatusUnauthorized},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/admin/webhooks", nil)
+			if testCase.authorization != "" {
+				r.Header.Set("Authorization", testCase.authorization)
+			}
+			w := httptest.NewRecorder()
+			handler(testCase.token).ServeHTTP(w, r)
+			assert.Equal(t, testCase.status, w.Code)
+		})
+	}
+}
+
+func TestNewSubscriptionRow(t *testing.T) {
+	now := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	s := New(Config{})
+	s.now = func() time.Time { return now }
+
+	account := "GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON"
+	row, err := s.newSubscriptionRow(nil, createRequest{
+		URL:            "https://example.com/hook",
+		Accounts:       []string{account},
+		Asset:          "native",
+		OperationTypes: []string{"payment", "path_payment_strict_send"},
+		StartLedger:    10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", row.URL)
+	assert.Equal(t, pq.StringArray{account}, row.Accounts)
+	assert.Equal(t, "native", row.Asset.String)
+	assert.Equal(t, pq.Int64Array{1, 13}, row.OperationTypes)
+	assert.Equal(t, int32(9), row.LastLedger)
+	assert.Len(t, row.Secret, 64)
+	assert.Equal(t, now, row.CreatedAt)
+
+	other, err := s.newSubscriptionRow(nil, createRequest{URL: "http://example.com", StartLedger: 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, row.Secret, other.Secret)
+	assert.Len(t, other.Accounts, 0)
+	assert.False(t, other.Asset.Valid)
+
+	for _, testCase := range []struct {
+		name  string
+		req   createRequest
+		field string
+	}{
+		{"relative url", createRequest{URL: "/hook", StartLedger: 1}, "url"},
+		{"invalid scheme", createRequest{URL: "ftp://example.com", StartLedger: 1}, "url"},
+		{"invalid account", createRequest{URL: "https://example.com", Accounts: []string{"GABC"}, StartLedger: 1}, "accounts"},
+		{"invalid asset", createRequest{URL: "https://example.com", Asset: "USD", StartLedger: 1}, "asset"},
+		{"invalid operation type", createRequest{URL: "https://example.com", OperationTypes: []string{"pay"}, StartLedger: 1}, "operation_types"},
+		{"negative start ledger", createRequest{URL: "https://example.com", StartLedger: -1}, "start_ledger"},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := s.newSubscriptionRow(nil, testCase.req)
+			p, ok := err.(*problem.P)
+			if assert.True(t, ok, "expected a problem, got %v", err) {
+				assert.Equal(t, testCase.field, p.Extras["invalid_field"])
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/db2/history"
+	"github.com/paydex-core/paydex-go/services/horizon/internal/httpx"
+	"github.com/paydex-core/paydex-go/support/render/hal"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// Event is the resource POSTed to the URL of a subscription for every
+// operation matching its filters.
+type Event struct {
+	Links struct {
+		Subscription hal.Link `json:"subscription"`
+		Operation    hal.Link `json:"operation"`
+	} `json:"_links"`
+	Embedded struct {
+		Operation hal.Pageable   `json:"operation"`
+		Effects   []hal.Pageable `json:"effects"`
+	} `json:"_embedded"`
+
+	SubscriptionID string `json:"subscription_id"`
+	LedgerSequence int32  `json:"ledger"`
+	// PT is the paging token of the operation, which can be used to
+	// deduplicate the events delivered more than once.
+	PT string `json:"paging_token"`
+}
+
+// Subscription is the admin API resource of a webhook subscription.
+type Subscription struct {
+	Links struct {
+		Self       hal.Link `json:"self"`
+		Deliveries hal.Link `json:"deliveries"`
+	} `json:"_links"`
+
+	ID  string `json:"id"`
+	PT  string `json:"paging_token"`
+	URL string `json:"url"`
+	// Secret is only included when the subscription is created.
+	Secret         string    `json:"secret,omitempty"`
+	Accounts       []string  `json:"accounts"`
+	Asset          string    `json:"asset,omitempty"`
+	OperationTypes []string  `json:"operation_types"`
+	LastLedger     int32     `json:"last_ledger"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (s Subscription) PagingToken() string {
+	return s.PT
+}
+
+// Delivery is the admin API resource of a delivery of an event to a
+// subscription.
+type Delivery struct {
+	Links struct {
+		Subscription hal.Link `json:"subscription"`
+	} `json:"_links"`
+
+	ID             string          `json:"id"`
+	PT             string          `json:"paging_token"`
+	SubscriptionID string          `json:"subscription_id"`
+	LedgerSequence int32           `json:"ledger"`
+	OperationID    string          `json:"operation_id"`
+	State          string          `json:"state"`
+	Attempts       int32           `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (d Delivery) PagingToken() string {
+	return d.PT
+}
+
+func subscriptionPath(id int64) string {
+	return fmt.Sprintf("/admin/webhooks/%d", id)
+}
+
+func newSubscription(ctx context.Context, row history.WebhookSubscription) Subscription {
+	var dest Subscription
+	dest.ID = fmt.Sprintf("%d", row.ID)
+	dest.PT = dest.ID
+	dest.URL = row.URL
+	dest.Accounts = append([]string{}, row.Accounts...)
+	dest.Asset = row.Asset.String
+	dest.OperationTypes = make([]string, 0, len(row.OperationTypes))
+	for _, typ := range row.OperationTypes {
+		name, ok := operations.TypeNames[xdr.OperationType(typ)]
+		if !ok {
+			name = "unknown"
+		}
+		dest.OperationTypes = append(dest.OperationTypes, name)
+	}
+	dest.LastLedger = row.LastLedger
+	dest.CreatedAt = row.CreatedAt
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	self := subscriptionPath(row.ID)
+	dest.Links.Self = lb.Link(self)
+	dest.Links.Deliveries = lb.PagedLink(self, "deliveries")
+	return dest
+}
+
+func newDelivery(ctx context.Context, row history.WebhookDelivery) Delivery {
+	var dest Delivery
+	dest.ID = fmt.Sprintf("%d", row.ID)
+	dest.PT = dest.ID
+	dest.SubscriptionID = fmt.Sprintf("%d", row.SubscriptionID)
+	dest.LedgerSequence = row.LedgerSequence
+	dest.OperationID = fmt.Sprintf("%d", row.OperationID)
+	dest.State = row.State
+	dest.Attempts = row.Attempts
+	if row.State == history.WebhookDeliveryPending {
+		nextAttemptAt := row.NextAttemptAt
+		dest.NextAttemptAt = &nextAttemptAt
+	}
+	dest.LastError = row.LastError.String
+	dest.CreatedAt = row.CreatedAt
+	if row.DeliveredAt.Valid {
+		deliveredAt := row.DeliveredAt.Time
+		dest.DeliveredAt = &deliveredAt
+	}
+	dest.Payload = json.RawMessage(row.Payload)
+
+	lb := hal.LinkBuilder{Base: httpx.BaseURL(ctx)}
+	dest.Links.Subscription = lb.Link(subscriptionPath(row.SubscriptionID))
+	return dest
+}
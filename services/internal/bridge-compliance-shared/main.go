@@ -42,7 +42,7 @@ func BuildTransaction(accountID, networkPassphrase string, operation []txnbuild.
 	if err != nil {
 		return "", errors.Wrap(err, "unable to decode transaction envelope")
 	}
-	txB64, err := xdr.MarshalBase64(txXDR.Tx)
+	txB64, err := xdr.MarshalBase64(txXDR.Transaction())
 	if err != nil {
 		return "", errors.Wrap(err, "unable to encode transaction")
 	}
@@ -13,6 +13,11 @@ import (
 // strkey-encoded string is not one of the valid values.
 var ErrInvalidVersionByte = errors.New("invalid version byte")
 
+// encoding is the base32 encoding used by strkeys. Strkeys are never padded,
+// which matters for payloads whose length is not a multiple of 5 bytes, like
+// multiplexed addresses.
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
 // VersionByte represents one of the possible prefix values for a StrKey base
 // string--the string the when encoded using base32 yields a final StrKey.
 type VersionByte byte
@@ -31,6 +36,10 @@ const (
 	//VersionByteHashX is the version byte used for encoded paydex hashX
 	//signer keys.
 	VersionByteHashX = 23 << 3 // Base32-encodes to 'X...'
+
+	//VersionByteMuxedAccount is the version byte used for encoded paydex
+	//multiplexed addresses.
+	VersionByteMuxedAccount = 12 << 3 // Base32-encodes to 'M...'
 )
 
 // DecodeAny decodes the provided StrKey into a raw value, checking the checksum
@@ -128,7 +137,7 @@ func Encode(version VersionByte, src []byte) (string, error) {
 		return "", err
 	}
 
-	result := base32.StdEncoding.EncodeToString(raw.Bytes())
+	result := encoding.EncodeToString(raw.Bytes())
 	return result, nil
 }
 
@@ -171,6 +180,10 @@ func checkValidVersionByte(version VersionByte) error {
 		return nil
 	}
 
+	if version == VersionByteMuxedAccount {
+		return nil
+	}
+
 	return ErrInvalidVersionByte
 }
 
@@ -178,11 +191,17 @@ func checkValidVersionByte(version VersionByte) error {
 // potentially be strkey encoded (i.e. it has both a version byte and a
 // checksum, neither of which are explicitly checked by this func)
 func decodeString(src string) ([]byte, error) {
-	raw, err := base32.StdEncoding.DecodeString(src)
+	raw, err := encoding.DecodeString(src)
 	if err != nil {
 		return nil, errors.Wrap(err, "base32 decode failed")
 	}
 
+	// unused trailing bits must be zero, otherwise several strings would
+	// decode to the same value
+	if encoding.EncodeToString(raw) != src {
+		return nil, errors.New("base32 decode failed: non-canonical encoding")
+	}
+
 	if len(raw) < 3 {
 		return nil, errors.Errorf("encoded value is %d bytes; minimum valid length is 3", len(raw))
 	}
@@ -203,6 +222,19 @@ func IsValidEd25519PublicKey(i interface{}) bool {
 	return err == nil
 }
 
+// IsValidMuxedAccountEd25519PublicKey validates a paydex multiplexed address
+func IsValidMuxedAccountEd25519PublicKey(i interface{}) bool {
+	enc, ok := i.(string)
+
+	if !ok {
+		return false
+	}
+
+	raw, err := Decode(VersionByteMuxedAccount, enc)
+
+	return err == nil && len(raw) == 40
+}
+
 // IsValidEd25519SecretSeed validates a paydex secret key
 func IsValidEd25519SecretSeed(i interface{}) bool {
 	enc, ok := i.(string)
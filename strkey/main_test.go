@@ -33,9 +33,14 @@ func TestVersion(t *testing.T) {
 			ExpectedVersionByte: VersionByteHashX,
 		},
 		{
-			Name:                "Other (0x60)",
-			Address:             "MBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
-			ExpectedVersionByte: VersionByte(0x60),
+			Name:                "MuxedAccount",
+			Address:             "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK",
+			ExpectedVersionByte: VersionByteMuxedAccount,
+		},
+		{
+			Name:                "Other (0x68)",
+			Address:             "NBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG",
+			ExpectedVersionByte: VersionByte(0x68),
 		},
 	}
 
@@ -82,3 +87,21 @@ func TestIsValidEd25519SecretSeed(t *testing.T) {
 	isValid = IsValidEd25519SecretSeed(invalidKey)
 	assert.Equal(t, false, isValid)
 }
+
+func TestIsValidMuxedAccountEd25519PublicKey(t *testing.T) {
+	validKey := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK"
+	isValid := IsValidMuxedAccountEd25519PublicKey(validKey)
+	assert.Equal(t, true, isValid)
+
+	invalidKey := "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLL"
+	isValid = IsValidMuxedAccountEd25519PublicKey(invalidKey)
+	assert.Equal(t, false, isValid)
+
+	invalidKey = ""
+	isValid = IsValidMuxedAccountEd25519PublicKey(invalidKey)
+	assert.Equal(t, false, isValid)
+
+	invalidKey = "GA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVSGZ"
+	isValid = IsValidMuxedAccountEd25519PublicKey(invalidKey)
+	assert.Equal(t, false, isValid)
+}
@@ -10,3 +10,9 @@ import (
 func Render(w http.ResponseWriter, data interface{}) {
 	httpjson.Render(w, data, httpjson.HALJSON)
 }
+
+// RenderStatus write data to w with the provided status code, after
+// marshalling to json
+func RenderStatus(w http.ResponseWriter, statusCode int, data interface{}) {
+	httpjson.RenderStatus(w, statusCode, data, httpjson.HALJSON)
+}
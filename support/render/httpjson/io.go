@@ -27,6 +27,12 @@ func renderToString(data interface{}, pretty bool) ([]byte, error) {
 // Render write data to w, after marshalling to json. The response header is
 // set based on cType.
 func Render(w http.ResponseWriter, data interface{}, cType contentType) {
+	RenderStatus(w, http.StatusOK, data, cType)
+}
+
+// RenderStatus write data to w with the provided status code, after
+// marshalling to json. The response header is set based on cType.
+func RenderStatus(w http.ResponseWriter, statusCode int, data interface{}, cType contentType) {
 	js, err := renderToString(data, true)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -39,6 +45,7 @@ func Render(w http.ResponseWriter, data interface{}, cType contentType) {
 	} else {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 	}
+	w.WriteHeader(statusCode)
 	w.Write(js)
 }
 
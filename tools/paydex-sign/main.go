@@ -6,6 +6,7 @@ package main
 
 import (
 	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -15,12 +16,14 @@ import (
 
 	"github.com/howeyc/gopass"
 	"github.com/paydex-core/paydex-go/build"
+	"github.com/paydex-core/paydex-go/txnbuild"
 	"github.com/paydex-core/paydex-go/xdr"
 )
 
 var in *bufio.Reader
 
 var infile = flag.String("infile", "", "transaction envelope")
+var jsonOutput = flag.Bool("json", false, "print the transaction summary as JSON")
 
 func main() {
 	flag.Parse()
@@ -61,14 +64,33 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if txe.IsFeeBump() {
+		log.Fatal("signing fee bump transactions is not supported")
+	}
+
+	// describe the transaction, so that it is reviewed before being signed
+	description, err := txnbuild.Describe(txe)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	fmt.Println("")
 	fmt.Println("Transaction Summary:")
-	fmt.Printf("  source: %s\n", txe.Tx.SourceAccount.Address())
-	fmt.Printf("  ops: %d\n", len(txe.Tx.Operations))
-	fmt.Printf("  sigs: %d\n", len(txe.Signatures))
+	if *jsonOutput {
+		var out []byte
+		out, err = json.MarshalIndent(description, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(out))
+	} else {
+		fmt.Print(description)
+	}
 	fmt.Println("")
-
-	// TODO: add operation details
+	if description.Risky() {
+		fmt.Println("WARNING: this transaction contains risky operations, review them carefully before signing.")
+		fmt.Println("")
+	}
 
 	// read seed
 	seed, err := readLine("Enter seed: ", true)
@@ -88,7 +110,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	newEnv, err := xdr.MarshalBase64(b.E)
+	newEnv, err := b.Base64()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,235 @@
+// paydex-tx builds, signs and submits transactions described by JSON or YAML
+// specs, and decodes transaction envelopes back to specs so that they can be
+// reviewed. See the txnbuild/txnspec package for the format of specs.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/paydex-core/paydex-go/clients/horizonclient"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild/txnspec"
+)
+
+const usage = `Usage:
+  paydex-tx build [flags] SPEC
+  paydex-tx decode [flags] [ENVELOPE]
+
+build builds the transaction described by the JSON or YAML spec in the file
+SPEC, signs it and prints its base64 encoded envelope, or submits it.
+
+decode prints the spec of a base64 encoded transaction envelope, read from
+stdin when ENVELOPE is not given.
+
+Run paydex-tx build -h or paydex-tx decode -h for the flags of each command.
+`
+
+// stringsFlag is a flag which may be given more than once.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "build":
+		build(os.Args[2:])
+	case "decode":
+		decode(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func build(args []string) {
+	var secretEnvs, secretFiles stringsFlag
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	flags.Var(&secretEnvs, "secret-env", "name of an environment variable holding a secret seed to sign with, may be repeated")
+	flags.Var(&secretFiles, "secret-file", "path of a file holding a secret seed to sign with, may be repeated")
+	networkFlag := flags.String("network", "", `network to build the transaction for, "test", "public" or a network passphrase; overrides the network_passphrase of the spec`)
+	horizonURL := flags.String("horizon", "", "horizon server used to load the sequence number and submit the transaction, defaults to the SDF server of the network")
+	submit := flags.Bool("submit", false, "submit the transaction instead of printing it")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	spec, err := txnspec.Load(flags.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *networkFlag != "" {
+		spec.NetworkPassphrase = networkPassphrase(*networkFlag)
+	}
+	if spec.NetworkPassphrase == "" {
+		log.Fatal("the network must be set with -network or network_passphrase")
+	}
+
+	signers, err := loadSigners(secretEnvs, secretFiles)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var client *horizonclient.Client
+	if spec.Sequence == 0 || *submit {
+		client = horizonClient(*horizonURL, spec.NetworkPassphrase)
+	}
+
+	if spec.Sequence == 0 {
+		account, err := client.AccountDetail(horizonclient.AccountRequest{AccountID: spec.SourceAccount})
+		if err != nil {
+			log.Fatal(err)
+		}
+		seq, err := account.GetSequenceNumber()
+		if err != nil {
+			log.Fatal(err)
+		}
+		spec.Sequence = int64(seq) + 1
+	}
+
+	tx, err := spec.Transaction()
+	if err != nil {
+		log.Fatal(err)
+	}
+	txe, err := tx.BuildSignEncode(signers...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !*submit {
+		fmt.Println(txe)
+		return
+	}
+
+	result, err := client.SubmitTransactionXDR(txe)
+	if err != nil {
+		if herr, ok := err.(*horizonclient.Error); ok {
+			if resultCodes, rerr := herr.ResultCodes(); rerr == nil {
+				log.Fatalf("%s: %s %v", err, resultCodes.TransactionCode, resultCodes.OperationCodes)
+			}
+		}
+		log.Fatal(err)
+	}
+	fmt.Printf("transaction %s applied in ledger %d\n", result.Hash, result.Ledger)
+}
+
+func decode(args []string) {
+	flags := flag.NewFlagSet("decode", flag.ExitOnError)
+	jsonOutput := flags.Bool("json", false, "print the spec as JSON instead of YAML")
+	flags.Parse(args)
+
+	var txe string
+	switch flags.NArg() {
+	case 0:
+		raw, err := ioutil.ReadAll(bufio.NewReader(os.Stdin))
+		if err != nil {
+			log.Fatal(err)
+		}
+		txe = string(raw)
+	case 1:
+		txe = flags.Arg(0)
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	spec, err := txnspec.FromXDR(strings.TrimSpace(txe))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	format := "spec.yaml"
+	if *jsonOutput {
+		format = "spec.json"
+	}
+	out, err := spec.Marshal(format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Stdout.Write(out)
+}
+
+// networkPassphrase returns the passphrase of a -network flag.
+func networkPassphrase(name string) string {
+	switch name {
+	case "test":
+		return network.TestNetworkPassphrase
+	case "public":
+		return network.PublicNetworkPassphrase
+	default:
+		return name
+	}
+}
+
+func horizonClient(url, passphrase string) *horizonclient.Client {
+	if url != "" {
+		return &horizonclient.Client{HorizonURL: url}
+	}
+	switch passphrase {
+	case network.TestNetworkPassphrase:
+		return horizonclient.DefaultTestNetClient
+	case network.PublicNetworkPassphrase:
+		return horizonclient.DefaultPublicNetClient
+	default:
+		log.Fatal("-horizon is required for networks other than the test and public networks")
+		return nil
+	}
+}
+
+// loadSigners reads the secret seeds held by environment variables and
+// files.
+func loadSigners(envs, files []string) ([]*keypair.Full, error) {
+	var seeds []string
+	for _, name := range envs {
+		seed, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, errors.Errorf("environment variable %s is not set", name)
+		}
+		seeds = append(seeds, seed)
+	}
+	for _, path := range files {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading secret file")
+		}
+		seeds = append(seeds, string(raw))
+	}
+
+	var signers []*keypair.Full
+	for _, seed := range seeds {
+		// errors are not wrapped, so that seeds are never logged
+		kp, err := keypair.Parse(strings.TrimSpace(seed))
+		if err != nil {
+			return nil, errors.New("invalid secret seed")
+		}
+		full, ok := kp.(*keypair.Full)
+		if !ok {
+			return nil, errors.New("an address was given instead of a secret seed")
+		}
+		signers = append(signers, full)
+	}
+	return signers, nil
+}
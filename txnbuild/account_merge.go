@@ -14,7 +14,7 @@ type AccountMerge struct {
 
 // BuildXDR for AccountMerge returns a fully configured XDR Operation.
 func (am *AccountMerge) BuildXDR() (xdr.Operation, error) {
-	var xdrOp xdr.AccountId
+	var xdrOp xdr.MuxedAccount
 
 	err := xdrOp.SetAddress(am.Destination)
 	if err != nil {
@@ -48,7 +48,7 @@ func (am *AccountMerge) FromXDR(xdrOp xdr.Operation) error {
 // Validate for AccountMerge validates the required struct fields. It returns an error if any of the fields are
 // invalid. Otherwise, it returns nil.
 func (am *AccountMerge) Validate() error {
-	err := validatePaydexPublicKey(am.Destination)
+	err := validatePaydexAddress(am.Destination)
 	if err != nil {
 		return NewValidationError("Destination", err.Error())
 	}
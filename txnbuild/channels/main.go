@@ -0,0 +1,368 @@
+// Package channels manages a pool of channel accounts, which lets a single
+// funding account submit many transactions concurrently.
+//
+// Every transaction of an account must use the next sequence number of the
+// account, so transactions sharing a source account can't be submitted in
+// parallel. A Pool works around this by leasing a channel account to each
+// transaction, which is used as the source account of the transaction and
+// pays its fee, while the operations keep the funding account as their source
+// account.
+package channels
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/paydex-core/paydex-go/clients/horizonclient"
+	"github.com/paydex-core/paydex-go/keypair"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// DefaultSubmitTimeout is the time a transaction submitted by a Pool is valid
+// for when neither the transaction nor the context of the submission have a
+// deadline.
+const DefaultSubmitTimeout = 30 * time.Second
+
+// Horizon represents the horizon client used by a Pool to load the sequence
+// numbers of channel accounts and submit transactions.
+type Horizon interface {
+	AccountDetail(request horizonclient.AccountRequest) (hProtocol.Account, error)
+	SubmitTransactionXDR(transactionXdr string) (hProtocol.TransactionSuccess, error)
+}
+
+// confirm interface conformity
+var _ Horizon = &horizonclient.Client{}
+var _ txnbuild.Account = &Channel{}
+
+// Channel is a channel account of a Pool. It implements txnbuild.Account, and
+// is used as the source account of the transactions it is leased for.
+type Channel struct {
+	Keypair *keypair.Full
+
+	account txnbuild.SimpleAccount
+	// stale is set when the in-memory sequence number of the channel may
+	// not match the sequence number of the account on the network.
+	stale bool
+}
+
+// GetAccountID returns the address of the channel account.
+func (c *Channel) GetAccountID() string {
+	return c.account.GetAccountID()
+}
+
+// IncrementSequenceNumber increments the in-memory sequence number of the
+// channel account by 1.
+func (c *Channel) IncrementSequenceNumber() (xdr.SequenceNumber, error) {
+	return c.account.IncrementSequenceNumber()
+}
+
+// ForceRefreshSequence makes the pool reload the sequence number of the
+// channel account from Horizon the next time it is leased. It should be called
+// when a transaction of a leased channel is not known to have been applied or
+// rejected, for example after a timeout.
+func (c *Channel) ForceRefreshSequence() {
+	c.stale = true
+}
+
+// Pool is a pool of channel accounts funded by a single account. It is safe
+// for concurrent use.
+type Pool struct {
+	horizon Horizon
+	funder  *keypair.Full
+	network string
+	baseFee uint32
+
+	mutex    sync.Mutex
+	channels []*Channel
+	free     []*Channel
+	// released is closed, and replaced, whenever a channel is released.
+	released chan struct{}
+}
+
+// NewPool returns an empty pool of channel accounts funded by funder, whose
+// transactions are submitted to the network with the given passphrase. Channels
+// are added with CreateChannels or AddChannel.
+func NewPool(horizon Horizon, funder *keypair.Full, network string, baseFee uint32) *Pool {
+	return &Pool{
+		horizon:  horizon,
+		funder:   funder,
+		network:  network,
+		baseFee:  baseFee,
+		released: make(chan struct{}),
+	}
+}
+
+// Funder returns the funding account of the pool, which should be the source
+// account of the operations submitted through the pool.
+func (p *Pool) Funder() txnbuild.Account {
+	return &txnbuild.SimpleAccount{AccountID: p.funder.Address()}
+}
+
+// Size returns the number of channels of the pool.
+func (p *Pool) Size() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.channels)
+}
+
+// Available returns the number of channels which are not leased.
+func (p *Pool) Available() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.free)
+}
+
+// AddChannel adds an existing channel account to the pool. If sequence is 0 the
+// sequence number of the account is loaded from Horizon when the channel is
+// first leased.
+func (p *Pool) AddChannel(kp *keypair.Full, sequence int64) *Channel {
+	c := &Channel{
+		Keypair: kp,
+		account: txnbuild.NewSimpleAccount(kp.Address(), sequence),
+		stale:   sequence == 0,
+	}
+
+	p.mutex.Lock()
+	p.channels = append(p.channels, c)
+	p.mutex.Unlock()
+
+	p.Release(c)
+	return c
+}
+
+// createChannelsBatchSize is the number of channel accounts created by each
+// transaction of CreateChannels.
+const createChannelsBatchSize = 100
+
+// CreateChannels creates n channel accounts, each funded with startingBalance
+// by the funding account, and adds them to the pool. Accounts are created in
+// batches of 100; if a batch fails the channels created by earlier batches
+// stay in the pool and the number of channels created is returned with the
+// error.
+func (p *Pool) CreateChannels(n int, startingBalance string) (int, error) {
+	funder := txnbuild.SimpleAccount{AccountID: p.funder.Address()}
+	created := 0
+	for created < n {
+		// Refresh the sequence number of the funder before every batch,
+		// since it may have submitted other transactions.
+		accountDetail, err := p.horizon.AccountDetail(horizonclient.AccountRequest{AccountID: funder.AccountID})
+		if err != nil {
+			return created, errors.Wrap(err, "getting funder account detail")
+		}
+		seq, err := accountDetail.GetSequenceNumber()
+		if err != nil {
+			return created, errors.Wrap(err, "parsing funder seqnum")
+		}
+		funder.Sequence = int64(seq)
+
+		batchSize := createChannelsBatchSize
+		if n-created < batchSize {
+			batchSize = n - created
+		}
+		keypairs := make([]*keypair.Full, 0, batchSize)
+		ops := make([]txnbuild.Operation, 0, batchSize)
+		for i := 0; i < batchSize; i++ {
+			kp, err := keypair.Random()
+			if err != nil {
+				return created, errors.Wrap(err, "making keypair")
+			}
+			keypairs = append(keypairs, kp)
+			ops = append(ops, &txnbuild.CreateAccount{
+				Destination: kp.Address(),
+				Amount:      startingBalance,
+			})
+		}
+
+		tx := txnbuild.Transaction{
+			SourceAccount: &funder,
+			Operations:    ops,
+			Timebounds:    txnbuild.NewTimeout(int64(DefaultSubmitTimeout / time.Second)),
+			Network:       p.network,
+			BaseFee:       p.baseFee,
+		}
+		txe, err := tx.BuildSignEncode(p.funder)
+		if err != nil {
+			return created, errors.Wrap(err, "making create accounts tx")
+		}
+		if _, err := p.horizon.SubmitTransactionXDR(txe); err != nil {
+			return created, errors.Wrap(err, "submitting create accounts tx")
+		}
+
+		for _, kp := range keypairs {
+			// The sequence number of new accounts is derived from the
+			// ledger they are created in, so it is loaded on first use.
+			p.AddChannel(kp, 0)
+		}
+		created += batchSize
+	}
+	return created, nil
+}
+
+// Lease takes a channel out of the pool, waiting for one to be released if all
+// of them are leased, until ctx is done. The sequence number of the channel is
+// refreshed if needed. The channel must be returned with Release.
+func (p *Pool) Lease(ctx context.Context) (*Channel, error) {
+	var c *Channel
+	for {
+		p.mutex.Lock()
+		if len(p.channels) == 0 {
+			p.mutex.Unlock()
+			return nil, errors.New("pool has no channels")
+		}
+		if len(p.free) > 0 {
+			c = p.free[0]
+			p.free = p.free[1:]
+		}
+		released := p.released
+		p.mutex.Unlock()
+
+		if c != nil {
+			break
+		}
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "waiting for a channel")
+		}
+	}
+
+	if c.stale {
+		if err := p.refreshSequence(c); err != nil {
+			p.Release(c)
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Release returns a leased channel to the pool.
+func (p *Pool) Release(c *Channel) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.free = append(p.free, c)
+	close(p.released)
+	p.released = make(chan struct{})
+}
+
+// refreshSequence loads the sequence number of a channel account from Horizon.
+func (p *Pool) refreshSequence(c *Channel) error {
+	accountDetail, err := p.horizon.AccountDetail(horizonclient.AccountRequest{AccountID: c.GetAccountID()})
+	if err != nil {
+		return errors.Wrap(err, "getting channel account detail")
+	}
+	seq, err := accountDetail.GetSequenceNumber()
+	if err != nil {
+		return errors.Wrap(err, "parsing channel seqnum")
+	}
+	c.account.Sequence = int64(seq)
+	c.stale = false
+	return nil
+}
+
+// Submit leases a channel, builds tx with the channel as its source account,
+// signs it with the keys of the channel, the funding account if it is the
+// source account of an operation, and any other signers, and submits it. The network, base fee and time bounds of the pool
+// are used unless tx sets them; the default time bounds expire at the deadline
+// of ctx, or after DefaultSubmitTimeout. Operations without a source account
+// are paid for by the channel account, so they should usually have the
+// funding account as their source account.
+//
+// The channel is released when the submission completes, even if ctx is done
+// before it does, so that it is not leased again while the transaction may
+// still be applied. Its sequence number is refreshed before it is leased again
+// unless the transaction was applied or failed after consuming its sequence
+// number, which covers tx_bad_seq errors.
+//
+// Errors returned by Horizon are returned as is, so that they can be inspected
+// as *horizonclient.Error.
+func (p *Pool) Submit(ctx context.Context, tx txnbuild.Transaction, signers ...*keypair.Full) (hProtocol.TransactionSuccess, error) {
+	c, err := p.Lease(ctx)
+	if err != nil {
+		return hProtocol.TransactionSuccess{}, err
+	}
+
+	tx.SourceAccount = c
+	if tx.Network == "" {
+		tx.Network = p.network
+	}
+	if tx.BaseFee == 0 {
+		tx.BaseFee = p.baseFee
+	}
+	if tx.Timebounds == (txnbuild.Timebounds{}) {
+		deadline, ok := ctx.Deadline()
+		if !ok {
+			deadline = time.Now().Add(DefaultSubmitTimeout)
+		}
+		tx.Timebounds = txnbuild.NewTimebounds(0, deadline.Unix())
+	}
+
+	keys := []*keypair.Full{c.Keypair}
+	if p.isOperationSource(tx) {
+		keys = append(keys, p.funder)
+	}
+	txe, err := tx.BuildSignEncode(append(keys, signers...)...)
+	if err != nil {
+		// The sequence number may have been incremented before the build
+		// failed.
+		c.ForceRefreshSequence()
+		p.Release(c)
+		return hProtocol.TransactionSuccess{}, errors.Wrap(err, "building transaction")
+	}
+
+	type submitResult struct {
+		success hProtocol.TransactionSuccess
+		err     error
+	}
+	results := make(chan submitResult, 1)
+	go func() {
+		success, err := p.horizon.SubmitTransactionXDR(txe)
+		results <- submitResult{success, err}
+	}()
+
+	release := func(result submitResult) {
+		if result.err != nil && !consumedSequence(result.err) {
+			c.ForceRefreshSequence()
+		}
+		p.Release(c)
+	}
+
+	select {
+	case result := <-results:
+		release(result)
+		return result.success, result.err
+	case <-ctx.Done():
+		// The transaction may still be applied, so the channel is kept until
+		// the outcome of the submission is known.
+		go func() {
+			release(<-results)
+		}()
+		return hProtocol.TransactionSuccess{}, errors.Wrap(ctx.Err(), "submitting transaction")
+	}
+}
+
+// isOperationSource returns true if the funding account is the source account
+// of one of the operations of tx, so its signature is required.
+func (p *Pool) isOperationSource(tx txnbuild.Transaction) bool {
+	for _, op := range tx.Operations {
+		source := op.GetSourceAccount()
+		if source != nil && source.GetAccountID() == p.funder.Address() {
+			return true
+		}
+	}
+	return false
+}
+
+// consumedSequence returns true if a submission error shows that the
+// transaction was applied, and failed, so its sequence number was consumed.
+func consumedSequence(err error) bool {
+	herr, ok := err.(*horizonclient.Error)
+	if !ok {
+		return false
+	}
+	resultCodes, rerr := herr.ResultCodes()
+	return rerr == nil && resultCodes.TransactionCode == "tx_failed"
+}
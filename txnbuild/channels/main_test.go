@@ -0,0 +1,212 @@
+package channels
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paydex-core/paydex-go/clients/horizonclient"
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/render/problem"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newPayment(pool *Pool) txnbuild.Transaction {
+	return txnbuild.Transaction{
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{
+				Destination:   "GCCOBXW2XQNUSL467IEILE6MMCNRR66SSVL4YQADUNYYNUVREF3FIV2Z",
+				Amount:        "10",
+				Asset:         txnbuild.NativeAsset{},
+				SourceAccount: pool.Funder(),
+			},
+		},
+	}
+}
+
+// submittedTx decodes a transaction envelope passed to the mock client.
+func submittedTx(t *testing.T, txeB64 string) xdr.TransactionEnvelope {
+	var txe xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(txeB64, &txe))
+	return txe
+}
+
+func TestPoolSubmit(t *testing.T) {
+	hmock := &horizonclient.MockClient{}
+	funder := keypair.MustRandom()
+	pool := NewPool(hmock, funder, network.TestNetworkPassphrase, txnbuild.MinBaseFee)
+	channel := pool.AddChannel(keypair.MustRandom(), 0)
+	assert.Equal(t, 1, pool.Size())
+
+	hmock.On("AccountDetail", horizonclient.AccountRequest{AccountID: channel.GetAccountID()}).
+		Return(hProtocol.Account{Sequence: "100"}, nil).Once()
+
+	var txe xdr.TransactionEnvelope
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			txe = submittedTx(t, args.String(0))
+		}).
+		Return(hProtocol.TransactionSuccess{Hash: "a"}, nil).Twice()
+
+	success, err := pool.Submit(context.Background(), newPayment(pool))
+	require.NoError(t, err)
+	assert.Equal(t, "a", success.Hash)
+	sourceAccount := txe.SourceAccount()
+	assert.Equal(t, channel.GetAccountID(), sourceAccount.Address())
+	assert.Equal(t, int64(101), txe.SeqNum())
+	assert.Len(t, txe.Signatures(), 2)
+	opSource := txe.Operations()[0].SourceAccount
+	assert.Equal(t, funder.Address(), opSource.Address())
+	assert.NotZero(t, txe.TimeBounds().MaxTime)
+	assert.Equal(t, 1, pool.Available())
+
+	// the sequence number is not refreshed after a successful submission
+	_, err = pool.Submit(context.Background(), newPayment(pool))
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), txe.SeqNum())
+
+	hmock.AssertExpectations(t)
+}
+
+func TestPoolSubmitBadSeq(t *testing.T) {
+	hmock := &horizonclient.MockClient{}
+	pool := NewPool(hmock, keypair.MustRandom(), network.TestNetworkPassphrase, txnbuild.MinBaseFee)
+	channel := pool.AddChannel(keypair.MustRandom(), 10)
+
+	badSeq := &horizonclient.Error{Problem: problem.P{
+		Title:  "Transaction Failed",
+		Extras: map[string]interface{}{"result_codes": map[string]interface{}{"transaction": "tx_bad_seq"}},
+	}}
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Return(hProtocol.TransactionSuccess{}, badSeq).Once()
+	_, err := pool.Submit(context.Background(), newPayment(pool))
+	assert.Equal(t, badSeq, err)
+
+	// the sequence number is refreshed before the channel is leased again
+	hmock.On("AccountDetail", horizonclient.AccountRequest{AccountID: channel.GetAccountID()}).
+		Return(hProtocol.Account{Sequence: "50"}, nil).Once()
+	var txe xdr.TransactionEnvelope
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			txe = submittedTx(t, args.String(0))
+		}).
+		Return(hProtocol.TransactionSuccess{}, nil).Once()
+	_, err = pool.Submit(context.Background(), newPayment(pool))
+	require.NoError(t, err)
+	assert.Equal(t, int64(51), txe.SeqNum())
+
+	hmock.AssertExpectations(t)
+}
+
+func TestPoolLeaseTimeout(t *testing.T) {
+	hmock := &horizonclient.MockClient{}
+	pool := NewPool(hmock, keypair.MustRandom(), network.TestNetworkPassphrase, txnbuild.MinBaseFee)
+
+	_, err := pool.Lease(context.Background())
+	assert.EqualError(t, err, "pool has no channels")
+
+	pool.AddChannel(keypair.MustRandom(), 10)
+	channel, err := pool.Lease(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, pool.Available())
+
+	// all channels are leased
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Lease(ctx)
+	assert.EqualError(t, err, "waiting for a channel: context deadline exceeded")
+
+	// a released channel is handed to a waiting lease
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		pool.Release(channel)
+	}()
+	leased, err := pool.Lease(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, channel, leased)
+	pool.Release(leased)
+
+	// a submission which times out keeps the channel until its outcome is
+	// known
+	submitted := make(chan time.Time)
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		WaitUntil(submitted).
+		Return(hProtocol.TransactionSuccess{}, errors.New("connection reset")).Once()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Submit(ctx, newPayment(pool))
+	assert.EqualError(t, err, "submitting transaction: context deadline exceeded")
+	assert.Equal(t, 0, pool.Available())
+	close(submitted)
+
+	// the channel is released once the submission fails, and its sequence
+	// number is refreshed before it is leased again
+	hmock.On("AccountDetail", horizonclient.AccountRequest{AccountID: channel.GetAccountID()}).
+		Return(hProtocol.Account{Sequence: "20"}, nil).Once()
+	leased, err = pool.Lease(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, channel, leased)
+	assert.Equal(t, int64(20), channel.account.Sequence)
+
+	hmock.AssertExpectations(t)
+}
+
+func TestPoolSubmitFunderSignature(t *testing.T) {
+	hmock := &horizonclient.MockClient{}
+	pool := NewPool(hmock, keypair.MustRandom(), network.TestNetworkPassphrase, txnbuild.MinBaseFee)
+	pool.AddChannel(keypair.MustRandom(), 10)
+
+	var txe xdr.TransactionEnvelope
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			txe = submittedTx(t, args.String(0))
+		}).
+		Return(hProtocol.TransactionSuccess{}, nil).Once()
+
+	// the funding account does not sign operations paid by other accounts
+	payment := newPayment(pool)
+	other := keypair.MustRandom()
+	payment.Operations[0].(*txnbuild.Payment).SourceAccount = &txnbuild.SimpleAccount{AccountID: other.Address()}
+	_, err := pool.Submit(context.Background(), payment, other)
+	require.NoError(t, err)
+	assert.Len(t, txe.Signatures(), 2)
+
+	hmock.AssertExpectations(t)
+}
+
+func TestPoolCreateChannels(t *testing.T) {
+	hmock := &horizonclient.MockClient{}
+	funder := keypair.MustRandom()
+	pool := NewPool(hmock, funder, network.TestNetworkPassphrase, txnbuild.MinBaseFee)
+
+	hmock.On("AccountDetail", horizonclient.AccountRequest{AccountID: funder.Address()}).
+		Return(hProtocol.Account{Sequence: "1"}, nil).Twice()
+	var opCounts []int
+	hmock.On("SubmitTransactionXDR", mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) {
+			txe := submittedTx(t, args.String(0))
+			opCounts = append(opCounts, len(txe.Operations()))
+		}).
+		Return(hProtocol.TransactionSuccess{}, nil).Twice()
+
+	created, err := pool.CreateChannels(150, "2")
+	require.NoError(t, err)
+	assert.Equal(t, 150, created)
+	assert.Equal(t, []int{100, 50}, opCounts)
+	assert.Equal(t, 150, pool.Size())
+	assert.Equal(t, 150, pool.Available())
+
+	hmock.On("AccountDetail", horizonclient.AccountRequest{AccountID: funder.Address()}).
+		Return(hProtocol.Account{}, horizonclient.Error{Problem: problem.NotFound}).Once()
+	created, err = pool.CreateChannels(1, "2")
+	assert.Error(t, err)
+	assert.Equal(t, 0, created)
+	assert.Equal(t, 150, pool.Size())
+}
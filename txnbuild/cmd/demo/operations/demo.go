@@ -400,7 +400,7 @@ func printHorizonError(hError *horizonclient.Error) error {
 		return errors.Wrap(err, "Couldn't read Envelope")
 	}
 
-	txe := envelope.Tx
+	txe := envelope.Transaction()
 	aid := txe.SourceAccount.MustEd25519()
 	decodedAID, err := strkey.Encode(strkey.VersionByteAccountID, aid[:])
 	if err != nil {
@@ -0,0 +1,481 @@
+package txnbuild
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/paydex-core/paydex-go/amount"
+	"github.com/paydex-core/paydex-go/protocols/horizon/operations"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// Description is a human readable description of a transaction envelope, which
+// lets signers review a transaction before signing it. It can be rendered as
+// text with String or as JSON with json.Marshal.
+type Description struct {
+	// FeeBump is set when the envelope is a fee bump transaction, in which
+	// case the other fields describe its inner transaction.
+	FeeBump       *FeeBumpDescription    `json:"fee_bump,omitempty"`
+	SourceAccount string                 `json:"source_account"`
+	Sequence      int64                  `json:"sequence,string"`
+	Fee           uint32                 `json:"fee"`
+	TimeBounds    *TimeBoundsDescription `json:"time_bounds,omitempty"`
+	Memo          *MemoDescription       `json:"memo,omitempty"`
+	Operations    []OperationDescription `json:"operations"`
+	Signatures    int                    `json:"signatures"`
+}
+
+// FeeBumpDescription describes the fee bump part of a fee bump transaction.
+type FeeBumpDescription struct {
+	FeeSource  string `json:"fee_source"`
+	Fee        int64  `json:"fee"`
+	Signatures int    `json:"signatures"`
+}
+
+// TimeBoundsDescription describes the time bounds of a transaction, as UNIX
+// timestamps. A MaxTime of 0 means the transaction never expires.
+type TimeBoundsDescription struct {
+	MinTime int64 `json:"min_time"`
+	MaxTime int64 `json:"max_time"`
+}
+
+// MemoDescription describes the memo of a transaction. Hash and return memos
+// are hex encoded.
+type MemoDescription struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// OperationDescription describes an operation of a transaction. Risks lists
+// the effects of the operation a signer should pay particular attention to,
+// such as changes to the signers of an account.
+type OperationDescription struct {
+	Type          string   `json:"type"`
+	SourceAccount string   `json:"source_account,omitempty"`
+	Details       Details  `json:"details"`
+	Risks         []string `json:"risks,omitempty"`
+}
+
+// Detail is a named field of an operation. Quoted is set for free-form values
+// chosen by the author of the transaction, such as data entry values, which
+// are quoted when rendered as text.
+type Detail struct {
+	Name   string
+	Value  string
+	Quoted bool
+}
+
+// Details are the fields of an operation, in the order they are displayed. They
+// are encoded as a JSON object.
+type Details []Detail
+
+// MarshalJSON encodes the details as a JSON object, preserving their order.
+func (d Details) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, detail := range d {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(detail.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(detail.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Risky returns true if any operation of the transaction has risks.
+func (d Description) Risky() bool {
+	for _, op := range d.Operations {
+		if len(op.Risks) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Describe returns a description of a transaction envelope.
+func Describe(txe xdr.TransactionEnvelope) (Description, error) {
+	d := Description{}
+	if txe.IsFeeBump() {
+		feeSource := txe.FeeBumpAccount()
+		d.FeeBump = &FeeBumpDescription{
+			FeeSource:  feeSource.Address(),
+			Fee:        txe.FeeBumpFee(),
+			Signatures: len(txe.FeeBumpSignatures()),
+		}
+		inner := txe.InnerTransactionEnvelope()
+		txe = xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: &inner}
+	}
+
+	sourceAccount := txe.SourceAccount()
+	d.SourceAccount = sourceAccount.Address()
+	d.Sequence = txe.SeqNum()
+	d.Fee = txe.Fee()
+	d.Signatures = len(txe.Signatures())
+
+	if tb := txe.TimeBounds(); tb != nil {
+		d.TimeBounds = &TimeBoundsDescription{MinTime: int64(tb.MinTime), MaxTime: int64(tb.MaxTime)}
+	}
+
+	memo, err := describeMemo(txe.Memo())
+	if err != nil {
+		return d, err
+	}
+	d.Memo = memo
+
+	d.Operations = []OperationDescription{}
+	for i, op := range txe.Operations() {
+		opDescription, err := describeOperation(op, sourceAccount)
+		if err != nil {
+			return d, errors.Wrapf(err, "failed to describe operation %d", i)
+		}
+		d.Operations = append(d.Operations, opDescription)
+	}
+
+	return d, nil
+}
+
+// Describe returns a description of the transaction, which must have been
+// built.
+func (tx *Transaction) Describe() (Description, error) {
+	if tx.xdrEnvelope == nil {
+		return Description{}, errors.New("transaction must be built before it is described")
+	}
+	return Describe(*tx.xdrEnvelope)
+}
+
+// String renders the description as text.
+func (d Description) String() string {
+	var b strings.Builder
+
+	if d.FeeBump != nil {
+		b.WriteString("Fee bump transaction\n")
+		fmt.Fprintf(&b, "  fee source: %s\n", d.FeeBump.FeeSource)
+		fmt.Fprintf(&b, "  fee:        %s\n", describeFee(d.FeeBump.Fee))
+		fmt.Fprintf(&b, "  signatures: %d\n", d.FeeBump.Signatures)
+		b.WriteString("Inner transaction\n")
+	} else {
+		b.WriteString("Transaction\n")
+	}
+
+	fmt.Fprintf(&b, "  source:      %s\n", d.SourceAccount)
+	fmt.Fprintf(&b, "  sequence:    %d\n", d.Sequence)
+	fmt.Fprintf(&b, "  fee:         %s\n", describeFee(int64(d.Fee)))
+	fmt.Fprintf(&b, "  time bounds: %s\n", d.TimeBounds)
+	if d.Memo == nil {
+		b.WriteString("  memo:        none\n")
+	} else {
+		fmt.Fprintf(&b, "  memo:        %s %q\n", d.Memo.Type, d.Memo.Value)
+	}
+	fmt.Fprintf(&b, "  signatures:  %d\n", d.Signatures)
+
+	fmt.Fprintf(&b, "Operations (%d)\n", len(d.Operations))
+	for i, op := range d.Operations {
+		fmt.Fprintf(&b, "  %d: %s\n", i, op.Type)
+		details := op.Details
+		if op.SourceAccount != "" {
+			details = append(Details{{Name: "source", Value: op.SourceAccount}}, details...)
+		}
+		width := 0
+		for _, detail := range details {
+			if len(detail.Name) > width {
+				width = len(detail.Name)
+			}
+		}
+		for _, detail := range details {
+			value := detail.Value
+			if detail.Quoted {
+				value = strconv.Quote(value)
+			}
+			fmt.Fprintf(&b, "       %-*s %s\n", width+1, detail.Name+":", value)
+		}
+		for _, risk := range op.Risks {
+			fmt.Fprintf(&b, "       WARNING: %s\n", risk)
+		}
+	}
+
+	return b.String()
+}
+
+// String renders the time bounds as text.
+func (tb *TimeBoundsDescription) String() string {
+	if tb == nil {
+		return "none"
+	}
+	parts := []string{}
+	if tb.MinTime != 0 {
+		parts = append(parts, "not before "+time.Unix(tb.MinTime, 0).UTC().Format(time.RFC3339))
+	}
+	if tb.MaxTime != 0 {
+		parts = append(parts, "not after "+time.Unix(tb.MaxTime, 0).UTC().Format(time.RFC3339))
+	} else {
+		parts = append(parts, "never expires")
+	}
+	return strings.Join(parts, ", ")
+}
+
+func describeFee(fee int64) string {
+	return fmt.Sprintf("%d stroops (%s)", fee, amount.StringFromInt64(fee))
+}
+
+func describeMemo(memo xdr.Memo) (*MemoDescription, error) {
+	switch memo.Type {
+	case xdr.MemoTypeMemoNone:
+		return nil, nil
+	case xdr.MemoTypeMemoText:
+		return &MemoDescription{Type: "text", Value: memo.MustText()}, nil
+	case xdr.MemoTypeMemoId:
+		return &MemoDescription{Type: "id", Value: strconv.FormatUint(uint64(memo.MustId()), 10)}, nil
+	case xdr.MemoTypeMemoHash:
+		hash := memo.MustHash()
+		return &MemoDescription{Type: "hash", Value: hex.EncodeToString(hash[:])}, nil
+	case xdr.MemoTypeMemoReturn:
+		hash := memo.MustRetHash()
+		return &MemoDescription{Type: "return", Value: hex.EncodeToString(hash[:])}, nil
+	default:
+		return nil, errors.Errorf("unknown memo type %d", memo.Type)
+	}
+}
+
+// describeAsset renders an asset as "native" or in code:issuer form.
+func describeAsset(asset xdr.Asset) (string, error) {
+	var typ, code, issuer string
+	if err := asset.Extract(&typ, &code, &issuer); err != nil {
+		return "", errors.Wrap(err, "failed to extract asset")
+	}
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		return typ, nil
+	}
+	return code + ":" + issuer, nil
+}
+
+func describePath(path []xdr.Asset) (string, error) {
+	assets := make([]string, 0, len(path))
+	for _, asset := range path {
+		a, err := describeAsset(asset)
+		if err != nil {
+			return "", err
+		}
+		assets = append(assets, a)
+	}
+	return strings.Join(assets, ", "), nil
+}
+
+// detailBuilder collects the details of an operation, keeping the first error
+// encountered when describing assets.
+type detailBuilder struct {
+	details Details
+	err     error
+}
+
+func (b *detailBuilder) add(name, value string) {
+	b.details = append(b.details, Detail{Name: name, Value: value})
+}
+
+func (b *detailBuilder) addText(name, value string) {
+	b.details = append(b.details, Detail{Name: name, Value: value, Quoted: true})
+}
+
+func (b *detailBuilder) addAsset(name string, asset xdr.Asset) {
+	value, err := describeAsset(asset)
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	b.add(name, value)
+}
+
+func (b *detailBuilder) addPath(path []xdr.Asset) {
+	if len(path) == 0 {
+		return
+	}
+	value, err := describePath(path)
+	if err != nil && b.err == nil {
+		b.err = err
+	}
+	b.add("path", value)
+}
+
+func describeOperation(op xdr.Operation, txSourceAccount xdr.MuxedAccount) (OperationDescription, error) {
+	typeName, ok := operations.TypeNames[op.Body.Type]
+	if !ok {
+		return OperationDescription{}, errors.Errorf("unknown operation type %d", op.Body.Type)
+	}
+
+	d := OperationDescription{Type: typeName}
+	source := txSourceAccount
+	if op.SourceAccount != nil {
+		source = *op.SourceAccount
+		d.SourceAccount = source.Address()
+	}
+	sourceAccount := source.Address()
+
+	b := &detailBuilder{}
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		body := op.Body.MustCreateAccountOp()
+		b.add("destination", body.Destination.Address())
+		b.add("starting_balance", amount.String(body.StartingBalance))
+	case xdr.OperationTypePayment:
+		body := op.Body.MustPaymentOp()
+		b.add("destination", body.Destination.Address())
+		b.addAsset("asset", body.Asset)
+		b.add("amount", amount.String(body.Amount))
+	case xdr.OperationTypePathPaymentStrictReceive:
+		body := op.Body.MustPathPaymentStrictReceiveOp()
+		b.add("destination", body.Destination.Address())
+		b.addAsset("send_asset", body.SendAsset)
+		b.add("send_max", amount.String(body.SendMax))
+		b.addAsset("dest_asset", body.DestAsset)
+		b.add("dest_amount", amount.String(body.DestAmount))
+		b.addPath(body.Path)
+	case xdr.OperationTypePathPaymentStrictSend:
+		body := op.Body.MustPathPaymentStrictSendOp()
+		b.add("destination", body.Destination.Address())
+		b.addAsset("send_asset", body.SendAsset)
+		b.add("send_amount", amount.String(body.SendAmount))
+		b.addAsset("dest_asset", body.DestAsset)
+		b.add("dest_min", amount.String(body.DestMin))
+		b.addPath(body.Path)
+	case xdr.OperationTypeManageSellOffer:
+		body := op.Body.MustManageSellOfferOp()
+		b.addAsset("selling", body.Selling)
+		b.addAsset("buying", body.Buying)
+		b.add("amount", amount.String(body.Amount))
+		b.add("price", body.Price.String())
+		b.add("offer_id", strconv.FormatInt(int64(body.OfferId), 10))
+	case xdr.OperationTypeManageBuyOffer:
+		body := op.Body.MustManageBuyOfferOp()
+		b.addAsset("selling", body.Selling)
+		b.addAsset("buying", body.Buying)
+		b.add("buy_amount", amount.String(body.BuyAmount))
+		b.add("price", body.Price.String())
+		b.add("offer_id", strconv.FormatInt(int64(body.OfferId), 10))
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		body := op.Body.MustCreatePassiveSellOfferOp()
+		b.addAsset("selling", body.Selling)
+		b.addAsset("buying", body.Buying)
+		b.add("amount", amount.String(body.Amount))
+		b.add("price", body.Price.String())
+	case xdr.OperationTypeSetOptions:
+		d.Risks = describeSetOptions(b, op.Body.MustSetOptionsOp(), sourceAccount)
+	case xdr.OperationTypeChangeTrust:
+		body := op.Body.MustChangeTrustOp()
+		b.addAsset("asset", body.Line)
+		b.add("limit", amount.String(body.Limit))
+	case xdr.OperationTypeAllowTrust:
+		body := op.Body.MustAllowTrustOp()
+		b.add("trustor", body.Trustor.Address())
+		b.addAsset("asset", body.Asset.ToAsset(source.ToAccountId()))
+		b.add("authorize", strconv.FormatBool(body.Authorize))
+	case xdr.OperationTypeAccountMerge:
+		destination := op.Body.MustDestination()
+		b.add("destination", destination.Address())
+		d.Risks = append(d.Risks, fmt.Sprintf(
+			"merges account %s into %s, deleting it and transferring its whole native balance",
+			sourceAccount, destination.Address(),
+		))
+	case xdr.OperationTypeInflation:
+	case xdr.OperationTypeManageData:
+		body := op.Body.MustManageDataOp()
+		b.addText("name", string(body.DataName))
+		if body.DataValue == nil {
+			b.add("value", "(deleted)")
+		} else if utf8.Valid(*body.DataValue) {
+			b.addText("value", string(*body.DataValue))
+		} else {
+			b.add("value", base64.StdEncoding.EncodeToString(*body.DataValue))
+		}
+	case xdr.OperationTypeBumpSequence:
+		body := op.Body.MustBumpSequenceOp()
+		b.add("bump_to", strconv.FormatInt(int64(body.BumpTo), 10))
+	}
+
+	if b.err != nil {
+		return d, b.err
+	}
+	d.Details = b.details
+	if d.Details == nil {
+		d.Details = Details{}
+	}
+	return d, nil
+}
+
+// describeSetOptions adds the details of a set options operation and returns
+// its risks.
+func describeSetOptions(b *detailBuilder, body xdr.SetOptionsOp, sourceAccount string) []string {
+	var risks []string
+
+	if body.InflationDest != nil {
+		b.add("inflation_dest", body.InflationDest.Address())
+	}
+	if body.ClearFlags != nil {
+		b.add("clear_flags", strconv.FormatUint(uint64(*body.ClearFlags), 10))
+	}
+	if body.SetFlags != nil {
+		b.add("set_flags", strconv.FormatUint(uint64(*body.SetFlags), 10))
+	}
+	if body.MasterWeight != nil {
+		b.add("master_weight", strconv.FormatUint(uint64(*body.MasterWeight), 10))
+		if *body.MasterWeight == 0 {
+			risks = append(risks, fmt.Sprintf(
+				"sets the master key weight of %s to 0, so its master key can no longer sign",
+				sourceAccount,
+			))
+		}
+	}
+
+	thresholds := false
+	for _, threshold := range []struct {
+		name  string
+		value *xdr.Uint32
+	}{
+		{"low_threshold", body.LowThreshold},
+		{"med_threshold", body.MedThreshold},
+		{"high_threshold", body.HighThreshold},
+	} {
+		if threshold.value != nil {
+			b.add(threshold.name, strconv.FormatUint(uint64(*threshold.value), 10))
+			thresholds = true
+		}
+	}
+	if thresholds {
+		risks = append(risks, fmt.Sprintf("changes the signature thresholds of %s", sourceAccount))
+	}
+
+	if body.HomeDomain != nil {
+		b.addText("home_domain", string(*body.HomeDomain))
+	}
+	if body.Signer != nil {
+		signer := body.Signer.Key.Address()
+		b.add("signer_key", signer)
+		b.add("signer_weight", strconv.FormatUint(uint64(body.Signer.Weight), 10))
+		if body.Signer.Weight == 0 {
+			risks = append(risks, fmt.Sprintf("removes signer %s from %s", signer, sourceAccount))
+		} else {
+			risks = append(risks, fmt.Sprintf(
+				"adds or updates signer %s of %s with weight %d",
+				signer, sourceAccount, body.Signer.Weight,
+			))
+		}
+	}
+
+	return risks
+}
@@ -0,0 +1,169 @@
+package txnbuild
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribe(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	kp2 := newKeypair2()
+	sourceAccount := NewSimpleAccount(kp0.Address(), 9605939170639897)
+	mergedAccount := NewSimpleAccount(kp2.Address(), 0)
+
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []Operation{
+			&Payment{
+				Destination: kp1.Address(),
+				Amount:      "10.5",
+				Asset:       CreditAsset{Code: "USD", Issuer: kp2.Address()},
+			},
+			&SetOptions{
+				MasterWeight: NewThreshold(0),
+				Signer:       &Signer{Address: kp1.Address(), Weight: 1},
+			},
+			&AccountMerge{Destination: kp0.Address(), SourceAccount: &mergedAccount},
+		},
+		Memo:       MemoText("invoice 42"),
+		Timebounds: NewTimebounds(0, 1588000000),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+	require.NoError(t, tx.Sign(kp0))
+
+	description, err := tx.Describe()
+	require.NoError(t, err)
+	assert.True(t, description.Risky())
+	assert.Equal(t, kp0.Address(), description.SourceAccount)
+	assert.Equal(t, int64(9605939170639898), description.Sequence)
+	assert.Equal(t, uint32(300), description.Fee)
+	assert.Equal(t, &MemoDescription{Type: "text", Value: "invoice 42"}, description.Memo)
+	assert.Equal(t, 1, description.Signatures)
+	require.Len(t, description.Operations, 3)
+
+	assert.Equal(t, OperationDescription{
+		Type: "payment",
+		Details: Details{
+			{Name: "destination", Value: kp1.Address()},
+			{Name: "asset", Value: "USD:" + kp2.Address()},
+			{Name: "amount", Value: "10.5000000"},
+		},
+	}, description.Operations[0])
+	assert.Equal(t, []string{
+		"sets the master key weight of " + kp0.Address() + " to 0, so its master key can no longer sign",
+		"adds or updates signer " + kp1.Address() + " of " + kp0.Address() + " with weight 1",
+	}, description.Operations[1].Risks)
+	assert.Equal(t, kp2.Address(), description.Operations[2].SourceAccount)
+	assert.Equal(t, []string{
+		"merges account " + kp2.Address() + " into " + kp0.Address() + ", deleting it and transferring its whole native balance",
+	}, description.Operations[2].Risks)
+
+	assert.Equal(t, `Transaction
+  source:      GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3
+  sequence:    9605939170639898
+  fee:         300 stroops (0.0000300)
+  time bounds: not after 2020-04-27T15:06:40Z
+  memo:        text "invoice 42"
+  signatures:  1
+Operations (3)
+  0: payment
+       destination: GAS4V4O2B7DW5T7IQRPEEVCRXMDZESKISR7DVIGKZQYYV3OSQ5SH5LVP
+       asset:       USD:GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+       amount:      10.5000000
+  1: set_options
+       master_weight: 0
+       signer_key:    GAS4V4O2B7DW5T7IQRPEEVCRXMDZESKISR7DVIGKZQYYV3OSQ5SH5LVP
+       signer_weight: 1
+       WARNING: sets the master key weight of GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3 to 0, so its master key can no longer sign
+       WARNING: adds or updates signer GAS4V4O2B7DW5T7IQRPEEVCRXMDZESKISR7DVIGKZQYYV3OSQ5SH5LVP of GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3 with weight 1
+  2: account_merge
+       source:      GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+       destination: GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3
+       WARNING: merges account GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H into GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3, deleting it and transferring its whole native balance
+`, description.String())
+
+	encoded, err := json.Marshal(description.Operations[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "payment",
+		"details": {
+			"destination": "GAS4V4O2B7DW5T7IQRPEEVCRXMDZESKISR7DVIGKZQYYV3OSQ5SH5LVP",
+			"asset": "USD:GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H",
+			"amount": "10.5000000"
+		}
+	}`, string(encoded))
+
+	_, err = (&Transaction{}).Describe()
+	assert.EqualError(t, err, "transaction must be built before it is described")
+}
+
+func TestDescribeFeeBump(t *testing.T) {
+	kp0 := newKeypair0()
+	kp1 := newKeypair1()
+	sourceAccount := NewSimpleAccount(kp0.Address(), 1)
+
+	inner := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations:    []Operation{&BumpSequence{BumpTo: 100}},
+		Timebounds:    NewInfiniteTimeout(),
+		Network:       network.TestNetworkPassphrase,
+		BaseFee:       MinBaseFee,
+	}
+	require.NoError(t, inner.Build())
+	feeBump := FeeBumpTransaction{FeeAccount: kp1.Address(), BaseFee: 500, Inner: &inner}
+	require.NoError(t, feeBump.Build())
+
+	description, err := Describe(*feeBump.TxEnvelope())
+	require.NoError(t, err)
+	assert.Equal(t, &FeeBumpDescription{FeeSource: kp1.Address(), Fee: 1000}, description.FeeBump)
+	assert.Equal(t, kp0.Address(), description.SourceAccount)
+	assert.Equal(t, &TimeBoundsDescription{}, description.TimeBounds)
+	assert.False(t, description.Risky())
+	assert.Equal(t, OperationDescription{
+		Type:    "bump_sequence",
+		Details: Details{{Name: "bump_to", Value: "100"}},
+	}, description.Operations[0])
+	assert.Contains(t, description.String(), "Fee bump transaction\n  fee source: "+kp1.Address()+"\n")
+	assert.Contains(t, description.String(), "  time bounds: never expires\n")
+}
+
+func TestDescribeText(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := NewSimpleAccount(kp0.Address(), 1)
+	homeDomain := "example.com\n       WARNING: none"
+
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []Operation{
+			&ManageData{Name: "note", Value: []byte("ok\n  1: payment")},
+			&ManageData{Name: "stale"},
+			&SetOptions{HomeDomain: &homeDomain},
+		},
+		Timebounds: NewInfiniteTimeout(),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+
+	description, err := tx.Describe()
+	require.NoError(t, err)
+	assert.Equal(t, Details{
+		{Name: "name", Value: "note", Quoted: true},
+		{Name: "value", Value: "ok\n  1: payment", Quoted: true},
+	}, description.Operations[0].Details)
+
+	// free-form values are quoted so that they can't be mistaken for other
+	// lines of the description
+	text := description.String()
+	assert.Contains(t, text, "  0: manage_data\n       name:  \"note\"\n       value: \"ok\\n  1: payment\"\n")
+	assert.Contains(t, text, "  1: manage_data\n       name:  \"stale\"\n       value: (deleted)\n")
+	assert.Contains(t, text, "  2: set_options\n       home_domain: \"example.com\\n       WARNING: none\"\n")
+	assert.NotContains(t, text, "\n       WARNING:")
+}
@@ -0,0 +1,254 @@
+package txnbuild
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// MinBaseFee is the minimum fee, in stroops, that the network accepts per
+// operation.
+const MinBaseFee = 100
+
+// FeeBumpTransaction represents a CAP 15 fee bump transaction. A fee bump
+// transaction wraps a previously signed transaction and allows FeeAccount to
+// pay, and bid a higher fee, for it. See
+// https://github.com/paydex-core/paydex-protocol/blob/master/core/cap-0015.md
+type FeeBumpTransaction struct {
+	// FeeAccount is the account paying the fee for the fee bump transaction.
+	FeeAccount string
+	// BaseFee is the fee per operation. The total fee of the fee bump
+	// transaction is BaseFee multiplied by the number of operations of the
+	// inner transaction plus one. It cannot be lower than the base fee of the
+	// inner transaction.
+	BaseFee int64
+	// Inner is the transaction wrapped by the fee bump transaction. It must
+	// have been built, and it is usually signed, before the fee bump
+	// transaction is built.
+	Inner *Transaction
+	// Network is the passphrase of the network the transaction will be
+	// submitted to. If it is empty the network of the inner transaction is used.
+	Network     string
+	xdrFeeBump  xdr.FeeBumpTransaction
+	xdrEnvelope *xdr.TransactionEnvelope
+}
+
+// Build for FeeBumpTransaction completely configures the fee bump transaction.
+// After calling Build, the FeeBumpTransaction is ready to be serialised or signed.
+func (tx *FeeBumpTransaction) Build() error {
+	// If transaction envelope has been signed, don't build transaction
+	if tx.xdrEnvelope != nil && tx.xdrEnvelope.FeeBumpSignatures() != nil {
+		return errors.New("fee bump transaction has already been signed, so cannot be rebuilt.")
+	}
+	tx.xdrEnvelope = nil
+	tx.xdrFeeBump = xdr.FeeBumpTransaction{}
+
+	if tx.Inner == nil || tx.Inner.xdrEnvelope == nil {
+		return errors.New("inner transaction must be built before the fee bump transaction")
+	}
+
+	if tx.Network == "" {
+		tx.Network = tx.Inner.Network
+	} else if tx.Inner.Network != "" && tx.Network != tx.Inner.Network {
+		return errors.New("network of the fee bump transaction does not match the network of the inner transaction")
+	}
+
+	// Public keys start with 'G'
+	if len(tx.FeeAccount) == 0 || tx.FeeAccount[0] != 'G' {
+		return errors.New("invalid public key for fee account")
+	}
+	err := tx.xdrFeeBump.FeeSource.SetAddress(tx.FeeAccount)
+	if err != nil {
+		return errors.Wrap(err, "invalid fee account")
+	}
+
+	innerTx := tx.Inner.xdrTransaction
+	innerOps := int64(len(innerTx.Operations))
+	if innerOps == 0 {
+		return errors.New("inner transaction must have at least one operation")
+	}
+	if tx.BaseFee < MinBaseFee {
+		return errors.Errorf("base fee cannot be lower than network minimum of %d", MinBaseFee)
+	}
+	if tx.BaseFee < int64(innerTx.Fee)/innerOps {
+		return errors.New("base fee cannot be lower than provided inner transaction base fee")
+	}
+
+	// the fee bump counts as an additional operation
+	tx.xdrFeeBump.Fee = xdr.Int64(tx.BaseFee * (innerOps + 1))
+	tx.xdrFeeBump.InnerTx = xdr.FeeBumpTransactionInnerTx{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1: &xdr.TransactionV1Envelope{
+			Tx:         innerTx,
+			Signatures: tx.Inner.xdrEnvelope.Signatures(),
+		},
+	}
+
+	tx.xdrEnvelope = &xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTxFeeBump,
+		FeeBump: &xdr.FeeBumpTransactionEnvelope{
+			Tx: tx.xdrFeeBump,
+		},
+	}
+
+	return nil
+}
+
+// Hash provides a signable object representing the fee bump transaction on the
+// specified network.
+func (tx *FeeBumpTransaction) Hash() ([32]byte, error) {
+	return network.HashFeeBumpTransaction(&tx.xdrFeeBump, tx.Network)
+}
+
+// HashHex returns the hex-encoded hash of the fee bump transaction.
+func (tx *FeeBumpTransaction) HashHex() (string, error) {
+	hashByte, err := tx.Hash()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hashByte[:]), nil
+}
+
+// InnerTransactionHash returns the hash of the transaction wrapped by the fee
+// bump transaction.
+func (tx *FeeBumpTransaction) InnerTransactionHash() ([32]byte, error) {
+	return network.HashTransaction(&tx.xdrFeeBump.InnerTx.V1.Tx, tx.Network)
+}
+
+// Sign for FeeBumpTransaction signs a previously built fee bump transaction.
+// The signature of the fee account is required to submit the fee bump
+// transaction to the network.
+func (tx *FeeBumpTransaction) Sign(kps ...*keypair.Full) error {
+	if tx.xdrEnvelope == nil {
+		return errors.New("fee bump transaction must be built before it is signed")
+	}
+
+	// Hash the transaction
+	hash, err := tx.Hash()
+	if err != nil {
+		return errors.Wrap(err, "failed to hash fee bump transaction")
+	}
+
+	// Sign the hash
+	for _, kp := range kps {
+		sig, err := kp.SignDecorated(hash[:])
+		if err != nil {
+			return errors.Wrap(err, "failed to sign fee bump transaction")
+		}
+		// Append the signature to the envelope
+		tx.xdrEnvelope.FeeBump.Signatures = append(tx.xdrEnvelope.FeeBump.Signatures, sig)
+	}
+
+	return nil
+}
+
+// SignWithKeyString for FeeBumpTransaction signs a previously built fee bump
+// transaction with the secret key as a string.
+func (tx *FeeBumpTransaction) SignWithKeyString(keys ...string) error {
+	signers := []*keypair.Full{}
+	for _, k := range keys {
+		kp, err := keypair.Parse(k)
+		if err != nil {
+			return errors.Wrap(err, "provided string is not a valid Paydex key")
+		}
+		kpf, ok := kp.(*keypair.Full)
+		if !ok {
+			return errors.New("provided string is not a valid Paydex secret key")
+		}
+		signers = append(signers, kpf)
+	}
+
+	return tx.Sign(signers...)
+}
+
+// BuildSignEncode performs all the steps to produce a final fee bump
+// transaction suitable for submitting to the network.
+func (tx *FeeBumpTransaction) BuildSignEncode(keypairs ...*keypair.Full) (string, error) {
+	err := tx.Build()
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't build fee bump transaction")
+	}
+
+	err = tx.Sign(keypairs...)
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't sign fee bump transaction")
+	}
+
+	txeBase64, err := tx.Base64()
+	if err != nil {
+		return "", errors.Wrap(err, "couldn't encode fee bump transaction")
+	}
+
+	return txeBase64, err
+}
+
+// MarshalBinary returns the binary XDR representation of the fee bump
+// transaction envelope.
+func (tx *FeeBumpTransaction) MarshalBinary() ([]byte, error) {
+	if tx.xdrEnvelope == nil {
+		return nil, errors.New("fee bump transaction must be built before it is encoded")
+	}
+
+	var txBytes bytes.Buffer
+	_, err := xdr.Marshal(&txBytes, tx.xdrEnvelope)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal XDR")
+	}
+
+	return txBytes.Bytes(), nil
+}
+
+// Base64 returns the base 64 XDR representation of the fee bump transaction
+// envelope.
+func (tx *FeeBumpTransaction) Base64() (string, error) {
+	bs, err := tx.MarshalBinary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get XDR bytestring")
+	}
+
+	return base64.StdEncoding.EncodeToString(bs), nil
+}
+
+// TxEnvelope returns the TransactionEnvelope XDR struct.
+func (tx *FeeBumpTransaction) TxEnvelope() *xdr.TransactionEnvelope {
+	return tx.xdrEnvelope
+}
+
+// FeeBumpTransactionFromXDR parses the supplied fee bump transaction envelope
+// in base64 XDR and returns a FeeBumpTransaction object.
+func FeeBumpTransactionFromXDR(txeB64 string) (FeeBumpTransaction, error) {
+	var xdrEnv xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txeB64, &xdrEnv)
+	if err != nil {
+		return FeeBumpTransaction{}, errors.Wrap(err, "unable to unmarshal transaction envelope")
+	}
+
+	if !xdrEnv.IsFeeBump() {
+		return FeeBumpTransaction{}, errors.New("transaction envelope is not a fee bump transaction, use TransactionFromXDR")
+	}
+
+	innerEnv := xdrEnv.InnerTransactionEnvelope()
+	inner, err := transactionFromEnvelope(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &innerEnv,
+	})
+	if err != nil {
+		return FeeBumpTransaction{}, errors.Wrap(err, "unable to parse inner transaction")
+	}
+
+	feeAccount := xdrEnv.FeeBumpAccount()
+	newTx := FeeBumpTransaction{
+		FeeAccount:  feeAccount.Address(),
+		BaseFee:     xdrEnv.FeeBumpFee() / int64(len(innerEnv.Tx.Operations)+1),
+		Inner:       &inner,
+		xdrFeeBump:  xdrEnv.FeeBump.Tx,
+		xdrEnvelope: &xdrEnv,
+	}
+
+	return newTx, nil
+}
@@ -0,0 +1,177 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedInnerTransaction(t *testing.T) *Transaction {
+	kp0 := newKeypair0()
+	sourceAccount := NewSimpleAccount(kp0.Address(), int64(9605939170639897))
+
+	inner := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []Operation{
+			&Inflation{},
+			&BumpSequence{BumpTo: 1},
+		},
+		Timebounds: NewInfiniteTimeout(),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    200,
+	}
+	require.NoError(t, inner.Build())
+	require.NoError(t, inner.Sign(kp0))
+	return &inner
+}
+
+func TestFeeBumpTransactionBuild(t *testing.T) {
+	inner := newSignedInnerTransaction(t)
+	kp1 := newKeypair1()
+
+	feeBump := FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+		Inner:      inner,
+	}
+	require.NoError(t, feeBump.Build())
+	assert.Equal(t, network.TestNetworkPassphrase, feeBump.Network)
+
+	env := feeBump.TxEnvelope()
+	assert.Equal(t, xdr.EnvelopeTypeEnvelopeTypeTxFeeBump, env.Type)
+	assert.Equal(t, int64(1500), env.FeeBumpFee())
+	feeAccount := env.FeeBumpAccount()
+	assert.Equal(t, kp1.Address(), feeAccount.Address())
+	assert.Equal(t, inner.xdrEnvelope.Signatures(), env.Signatures())
+	assert.Equal(t, inner.xdrTransaction, env.InnerTransactionEnvelope().Tx)
+
+	innerHash, err := inner.Hash()
+	require.NoError(t, err)
+	feeBumpInnerHash, err := feeBump.InnerTransactionHash()
+	require.NoError(t, err)
+	assert.Equal(t, innerHash, feeBumpInnerHash)
+
+	hash, err := feeBump.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, innerHash, hash)
+	expectedHash, err := network.HashTransactionInEnvelope(*env, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	require.NoError(t, feeBump.Sign(kp1))
+	assert.Len(t, env.FeeBumpSignatures(), 1)
+	assert.NoError(t, kp1.Verify(hash[:], env.FeeBumpSignatures()[0].Signature))
+
+	err = feeBump.Build()
+	assert.EqualError(t, err, "fee bump transaction has already been signed, so cannot be rebuilt.")
+}
+
+func TestFeeBumpTransactionSignWithKeyString(t *testing.T) {
+	kp1 := newKeypair1()
+	feeBump := FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+		Inner:      newSignedInnerTransaction(t),
+	}
+	require.NoError(t, feeBump.Build())
+
+	// the provided strings are not echoed in errors
+	err := feeBump.SignWithKeyString(kp1.Address())
+	assert.EqualError(t, err, "provided string is not a valid Paydex secret key")
+	err = feeBump.SignWithKeyString("SBAD")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "SBAD")
+
+	require.NoError(t, feeBump.SignWithKeyString(kp1.Seed()))
+	assert.Len(t, feeBump.TxEnvelope().FeeBumpSignatures(), 1)
+}
+
+func TestFeeBumpTransactionBuildErrors(t *testing.T) {
+	inner := newSignedInnerTransaction(t)
+	kp1 := newKeypair1()
+
+	feeBump := FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+	}
+	assert.EqualError(t, feeBump.Build(), "inner transaction must be built before the fee bump transaction")
+
+	feeBump = FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+		Inner:      inner,
+		Network:    network.PublicNetworkPassphrase,
+	}
+	assert.EqualError(t, feeBump.Build(), "network of the fee bump transaction does not match the network of the inner transaction")
+
+	feeBump = FeeBumpTransaction{
+		FeeAccount: "SBPQUZ6G4FZNWFHKUWC5BEYWF6R52E3SEP7R3GWYSM2XTKGF5LNTWW4R",
+		BaseFee:    500,
+		Inner:      inner,
+	}
+	assert.EqualError(t, feeBump.Build(), "invalid public key for fee account")
+
+	feeBump = FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    99,
+		Inner:      inner,
+	}
+	assert.EqualError(t, feeBump.Build(), "base fee cannot be lower than network minimum of 100")
+
+	feeBump = FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    150,
+		Inner:      inner,
+	}
+	assert.EqualError(t, feeBump.Build(), "base fee cannot be lower than provided inner transaction base fee")
+
+	feeBump = FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+		Inner:      inner,
+	}
+	assert.EqualError(t, feeBump.Sign(kp1), "fee bump transaction must be built before it is signed")
+}
+
+func TestFeeBumpTransactionRoundTrip(t *testing.T) {
+	inner := newSignedInnerTransaction(t)
+	kp1 := newKeypair1()
+
+	feeBump := FeeBumpTransaction{
+		FeeAccount: kp1.Address(),
+		BaseFee:    500,
+		Inner:      inner,
+	}
+	txeB64, err := feeBump.BuildSignEncode(kp1)
+	require.NoError(t, err)
+
+	parsed, err := FeeBumpTransactionFromXDR(txeB64)
+	require.NoError(t, err)
+	assert.Equal(t, kp1.Address(), parsed.FeeAccount)
+	assert.Equal(t, int64(500), parsed.BaseFee)
+	assert.Equal(t, inner.SourceAccount.GetAccountID(), parsed.Inner.SourceAccount.GetAccountID())
+	assert.Equal(t, uint32(200), parsed.Inner.BaseFee)
+	assert.Len(t, parsed.Inner.Operations, 2)
+
+	parsed.Network = network.TestNetworkPassphrase
+	expectedHash, err := feeBump.Hash()
+	require.NoError(t, err)
+	hash, err := parsed.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	parsedB64, err := parsed.Base64()
+	require.NoError(t, err)
+	assert.Equal(t, txeB64, parsedB64)
+
+	_, err = TransactionFromXDR(txeB64)
+	assert.EqualError(t, err, "transaction envelope is a fee bump transaction, use FeeBumpTransactionFromXDR")
+
+	innerB64, err := inner.Base64()
+	require.NoError(t, err)
+	_, err = FeeBumpTransactionFromXDR(innerB64)
+	assert.EqualError(t, err, "transaction envelope is not a fee bump transaction, use TransactionFromXDR")
+}
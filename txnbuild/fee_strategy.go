@@ -0,0 +1,165 @@
+package txnbuild
+
+import (
+	"math"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// FeeStatsSource is a source of the fee stats of recent ledgers, such as
+// horizonclient.Client.
+type FeeStatsSource interface {
+	FeeStats() (hProtocol.FeeStats, error)
+}
+
+// FeeStrategy chooses the base fee of transactions, that is the fee per
+// operation in stroops, from the fee stats of recent ledgers.
+type FeeStrategy interface {
+	BaseFee(stats hProtocol.FeeStats) (uint32, error)
+}
+
+// FixedFee is a FeeStrategy which always returns the same base fee.
+type FixedFee uint32
+
+// BaseFee returns the fixed base fee.
+func (f FixedFee) BaseFee(stats hProtocol.FeeStats) (uint32, error) {
+	return uint32(f), nil
+}
+
+// PercentileFee is a FeeStrategy which returns a percentile of the fees
+// accepted in recent ledgers. Percentile must be one of 10, 20, 30, 40, 50,
+// 60, 70, 80, 90, 95 or 99.
+type PercentileFee struct {
+	Percentile int
+}
+
+// BaseFee returns the percentile of the fees accepted in recent ledgers.
+func (f PercentileFee) BaseFee(stats hProtocol.FeeStats) (uint32, error) {
+	return acceptedFeePercentile(stats, f.Percentile)
+}
+
+// CapacityFee is a FeeStrategy which bids according to how full recent
+// ledgers were. While ledgers are less than half full every transaction is
+// included, so the base fee of the last ledger is enough. As ledgers fill up
+// transactions compete for inclusion, and the 50th, 90th and then 99th
+// percentile of the accepted fees is used, once the capacity usage reaches
+// 0.5, 0.8 and 0.95 respectively.
+type CapacityFee struct{}
+
+// BaseFee returns a base fee matching the capacity usage of recent ledgers.
+func (f CapacityFee) BaseFee(stats hProtocol.FeeStats) (uint32, error) {
+	switch usage := stats.LedgerCapacityUsage; {
+	case usage < 0.5:
+		if stats.LastLedgerBaseFee < 0 {
+			return 0, errors.Errorf("invalid last ledger base fee %d", stats.LastLedgerBaseFee)
+		}
+		return uint32(stats.LastLedgerBaseFee), nil
+	case usage < 0.8:
+		return acceptedFeePercentile(stats, 50)
+	case usage < 0.95:
+		return acceptedFeePercentile(stats, 90)
+	default:
+		return acceptedFeePercentile(stats, 99)
+	}
+}
+
+// acceptedFeePercentile returns a percentile of the accepted fees of the fee
+// stats.
+func acceptedFeePercentile(stats hProtocol.FeeStats, percentile int) (uint32, error) {
+	var fee int
+	switch percentile {
+	case 10:
+		fee = stats.P10AcceptedFee
+	case 20:
+		fee = stats.P20AcceptedFee
+	case 30:
+		fee = stats.P30AcceptedFee
+	case 40:
+		fee = stats.P40AcceptedFee
+	case 50:
+		fee = stats.P50AcceptedFee
+	case 60:
+		fee = stats.P60AcceptedFee
+	case 70:
+		fee = stats.P70AcceptedFee
+	case 80:
+		fee = stats.P80AcceptedFee
+	case 90:
+		fee = stats.P90AcceptedFee
+	case 95:
+		fee = stats.P95AcceptedFee
+	case 99:
+		fee = stats.P99AcceptedFee
+	default:
+		return 0, errors.Errorf("unsupported fee percentile %d", percentile)
+	}
+	if fee < 0 {
+		return 0, errors.Errorf("invalid p%d accepted fee %d", percentile, fee)
+	}
+	return uint32(fee), nil
+}
+
+// SuggestedBaseFee loads the fee stats of recent ledgers from source and
+// returns the base fee chosen by strategy. The base fee is never lower than
+// MinBaseFee, and never higher than maxBaseFee unless maxBaseFee is 0, so
+// that callers stay in control of how much they pay during surges.
+func SuggestedBaseFee(source FeeStatsSource, strategy FeeStrategy, maxBaseFee uint32) (uint32, error) {
+	stats, err := source.FeeStats()
+	if err != nil {
+		return 0, errors.Wrap(err, "loading fee stats")
+	}
+	baseFee, err := strategy.BaseFee(stats)
+	if err != nil {
+		return 0, err
+	}
+
+	if baseFee < MinBaseFee {
+		baseFee = MinBaseFee
+	}
+	if maxBaseFee != 0 && baseFee > maxBaseFee {
+		baseFee = maxBaseFee
+	}
+	return baseFee, nil
+}
+
+// BumpFee returns a copy of a built transaction bidding a higher base fee,
+// signed by signers. The copy keeps the source account, sequence number,
+// time bounds, memo and operations of the transaction, so it replaces a
+// transaction which is stuck in the queue of the network: only one of the two
+// can be applied. The signatures of the transaction are not copied, since they
+// do not match the new fee.
+func BumpFee(tx *Transaction, baseFee uint32, signers ...*keypair.Full) (*Transaction, error) {
+	if tx.xdrEnvelope == nil {
+		return nil, errors.New("transaction must be built before its fee is bumped")
+	}
+	if baseFee <= tx.BaseFee {
+		return nil, errors.Errorf("base fee %d must be higher than the current base fee %d", baseFee, tx.BaseFee)
+	}
+
+	xdrTx := tx.xdrEnvelope.Transaction()
+	fee := uint64(baseFee) * uint64(len(xdrTx.Operations))
+	if fee > math.MaxUint32 {
+		return nil, errors.Errorf("fee %d overflows", fee)
+	}
+	xdrTx.Fee = xdr.Uint32(fee)
+
+	bumped := &Transaction{
+		SourceAccount: tx.SourceAccount,
+		Operations:    tx.Operations,
+		BaseFee:       baseFee,
+		Memo:          tx.Memo,
+		Timebounds:    tx.Timebounds,
+		Network:       tx.Network,
+	}
+	bumped.xdrTransaction = xdrTx
+	if err := bumped.initEnvelope(); err != nil {
+		return nil, err
+	}
+	if err := bumped.Sign(signers...); err != nil {
+		return nil, err
+	}
+	return bumped, nil
+}
@@ -0,0 +1,142 @@
+package txnbuild
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feeStatsSource struct {
+	stats hProtocol.FeeStats
+	err   error
+}
+
+func (s feeStatsSource) FeeStats() (hProtocol.FeeStats, error) {
+	return s.stats, s.err
+}
+
+func newFeeStats(capacityUsage float64) hProtocol.FeeStats {
+	return hProtocol.FeeStats{
+		LastLedgerBaseFee:   100,
+		LedgerCapacityUsage: capacityUsage,
+		MinAcceptedFee:      100,
+		P10AcceptedFee:      100,
+		P20AcceptedFee:      100,
+		P30AcceptedFee:      150,
+		P40AcceptedFee:      200,
+		P50AcceptedFee:      250,
+		P60AcceptedFee:      300,
+		P70AcceptedFee:      400,
+		P80AcceptedFee:      500,
+		P90AcceptedFee:      1000,
+		P95AcceptedFee:      2000,
+		P99AcceptedFee:      5000,
+	}
+}
+
+func TestFeeStrategies(t *testing.T) {
+	stats := newFeeStats(0.97)
+
+	fee, err := FixedFee(300).BaseFee(stats)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(300), fee)
+
+	fee, err = PercentileFee{Percentile: 95}.BaseFee(stats)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2000), fee)
+
+	_, err = PercentileFee{Percentile: 75}.BaseFee(stats)
+	assert.EqualError(t, err, "unsupported fee percentile 75")
+
+	for _, testCase := range []struct {
+		usage    float64
+		expected uint32
+	}{
+		{0, 100},
+		{0.49, 100},
+		{0.5, 250},
+		{0.8, 1000},
+		{0.95, 5000},
+		{1, 5000},
+	} {
+		fee, err = CapacityFee{}.BaseFee(newFeeStats(testCase.usage))
+		require.NoError(t, err)
+		assert.Equal(t, testCase.expected, fee, "capacity usage %v", testCase.usage)
+	}
+}
+
+func TestSuggestedBaseFee(t *testing.T) {
+	source := feeStatsSource{stats: newFeeStats(0.97)}
+
+	fee, err := SuggestedBaseFee(source, CapacityFee{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5000), fee)
+
+	fee, err = SuggestedBaseFee(source, CapacityFee{}, 1500)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1500), fee)
+
+	fee, err = SuggestedBaseFee(source, FixedFee(10), 1500)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(MinBaseFee), fee)
+
+	_, err = SuggestedBaseFee(feeStatsSource{err: errors.New("horizon is down")}, CapacityFee{}, 0)
+	assert.EqualError(t, err, "loading fee stats: horizon is down")
+}
+
+func TestBumpFee(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := NewSimpleAccount(kp0.Address(), 10)
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []Operation{
+			&Payment{Destination: newKeypair1().Address(), Amount: "10", Asset: NativeAsset{}},
+			&BumpSequence{BumpTo: 100},
+		},
+		Memo:       MemoText("stuck"),
+		Timebounds: NewTimebounds(0, 1600000000),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+	require.NoError(t, tx.Sign(kp0))
+
+	_, err := BumpFee(&tx, MinBaseFee, kp0)
+	assert.EqualError(t, err, "base fee 100 must be higher than the current base fee 100")
+
+	bumped, err := BumpFee(&tx, 1000, kp0)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1000), bumped.BaseFee)
+
+	original := tx.TxEnvelope()
+	envelope := bumped.TxEnvelope()
+	assert.Equal(t, uint32(2000), envelope.Fee())
+	assert.Equal(t, original.SeqNum(), envelope.SeqNum())
+	assert.Equal(t, original.TimeBounds(), envelope.TimeBounds())
+	assert.Equal(t, original.Memo(), envelope.Memo())
+	assert.Equal(t, original.Operations(), envelope.Operations())
+	assert.Equal(t, int64(11), sourceAccount.Sequence)
+
+	// the bumped transaction is signed by the given signers only
+	require.Len(t, envelope.Signatures(), 1)
+	hash, err := bumped.Hash()
+	require.NoError(t, err)
+	assert.NoError(t, kp0.Verify(hash[:], envelope.Signatures()[0].Signature))
+
+	// the bumped transaction can be decoded and bumped again
+	txeB64, err := bumped.Base64()
+	require.NoError(t, err)
+	decoded, err := TransactionFromXDR(txeB64)
+	require.NoError(t, err)
+	decoded.Network = network.TestNetworkPassphrase
+	assert.Equal(t, uint32(1000), decoded.BaseFee)
+	_, err = BumpFee(&decoded, 2000, kp0)
+	assert.NoError(t, err)
+
+	_, err = BumpFee(&Transaction{}, 1000)
+	assert.EqualError(t, err, "transaction must be built before its fee is bumped")
+}
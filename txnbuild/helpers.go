@@ -21,6 +21,19 @@ func validatePaydexPublicKey(publicKey string) error {
 	return nil
 }
 
+// validatePaydexAddress checks if the string provided is a valid paydex
+// public key or multiplexed (M...) address.
+func validatePaydexAddress(address string) error {
+	if address == "" {
+		return errors.New("public key is undefined")
+	}
+
+	if !strkey.IsValidEd25519PublicKey(address) && !strkey.IsValidMuxedAccountEd25519PublicKey(address) {
+		return errors.Errorf("%s is not a valid paydex public key", address)
+	}
+	return nil
+}
+
 // validatePaydexAsset checks if the asset supplied is a valid paydex Asset. It returns an error if the asset is
 // nil, has an invalid asset code or issuer.
 func validatePaydexAsset(asset Asset) error {
@@ -0,0 +1,378 @@
+package txnbuild
+
+import (
+	"encoding"
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/paydex-core/paydex-go/amount"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/base"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// DefaultBaseReserve is the base reserve, in stroops, used by a LedgerState
+// when none is provided.
+const DefaultBaseReserve = 5000000
+
+// LedgerState is an in-memory snapshot of the ledger entries a transaction
+// depends on. Transactions can be applied to a LedgerState to find out, before
+// submitting them, whether they would succeed and what balances they would
+// leave behind. Entries are loaded either from Horizon resources or from
+// xdr.LedgerEntry values; entries missing from the snapshot are treated as not
+// existing on the network.
+type LedgerState struct {
+	// BaseReserve is the base reserve, in stroops, of the network. If it is
+	// zero DefaultBaseReserve is used.
+	BaseReserve int64
+	// BaseFee is the fee, in stroops, charged per operation by the network.
+	// If it is zero MinBaseFee is used.
+	BaseFee int64
+	// CloseTime is the close time of the ledger transactions are applied in,
+	// used to check their time bounds. If it is zero the current time is used.
+	CloseTime time.Time
+	// SkipSignatureCheck disables signature and threshold checks, so that
+	// transactions can be simulated before they are signed.
+	SkipSignatureCheck bool
+
+	accounts   map[string]*xdr.AccountEntry
+	trustlines map[string]*xdr.TrustLineEntry
+	offers     map[xdr.Int64]*xdr.OfferEntry
+	data       map[string]*xdr.DataEntry
+}
+
+// NewLedgerState returns an empty LedgerState.
+func NewLedgerState() *LedgerState {
+	return &LedgerState{
+		accounts:   map[string]*xdr.AccountEntry{},
+		trustlines: map[string]*xdr.TrustLineEntry{},
+		offers:     map[xdr.Int64]*xdr.OfferEntry{},
+		data:       map[string]*xdr.DataEntry{},
+	}
+}
+
+// AddLedgerEntry adds an account, trustline, offer or data entry to the
+// snapshot, replacing any existing entry with the same key. Offers are kept
+// so that they can be inspected, but they are not matched against when
+// transactions are applied.
+func (ls *LedgerState) AddLedgerEntry(entry xdr.LedgerEntry) error {
+	switch entry.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		var account xdr.AccountEntry
+		if err := copyXDR(entry.Data.MustAccount(), &account); err != nil {
+			return errors.Wrap(err, "invalid account entry")
+		}
+		ls.accounts[account.AccountId.Address()] = &account
+	case xdr.LedgerEntryTypeTrustline:
+		var trustline xdr.TrustLineEntry
+		if err := copyXDR(entry.Data.MustTrustLine(), &trustline); err != nil {
+			return errors.Wrap(err, "invalid trustline entry")
+		}
+		ls.trustlines[trustlineKey(trustline.AccountId, trustline.Asset)] = &trustline
+	case xdr.LedgerEntryTypeOffer:
+		var offer xdr.OfferEntry
+		if err := copyXDR(entry.Data.MustOffer(), &offer); err != nil {
+			return errors.Wrap(err, "invalid offer entry")
+		}
+		ls.offers[offer.OfferId] = &offer
+	case xdr.LedgerEntryTypeData:
+		var data xdr.DataEntry
+		if err := copyXDR(entry.Data.MustData(), &data); err != nil {
+			return errors.Wrap(err, "invalid data entry")
+		}
+		ls.data[dataKey(data.AccountId, string(data.DataName))] = &data
+	default:
+		return errors.Errorf("unknown ledger entry type: %v", entry.Data.Type)
+	}
+
+	return nil
+}
+
+// AddAccount adds an account loaded from Horizon, for example with
+// horizonclient.Client.AccountDetail, to the snapshot. The account entry, its
+// trustlines and its data entries are all added, replacing any existing
+// entries with the same keys.
+func (ls *LedgerState) AddAccount(account hProtocol.Account) error {
+	var entry xdr.AccountEntry
+	err := entry.AccountId.SetAddress(account.AccountID)
+	if err != nil {
+		return errors.Wrap(err, "invalid account id")
+	}
+
+	seqNum, err := strconv.ParseInt(account.Sequence, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "invalid sequence number")
+	}
+	entry.SeqNum = xdr.SequenceNumber(seqNum)
+	entry.NumSubEntries = xdr.Uint32(account.SubentryCount)
+	entry.HomeDomain = xdr.String32(account.HomeDomain)
+
+	if account.InflationDestination != "" {
+		inflationDest, err := xdr.AddressToAccountId(account.InflationDestination)
+		if err != nil {
+			return errors.Wrap(err, "invalid inflation destination")
+		}
+		entry.InflationDest = &inflationDest
+	}
+
+	if account.Flags.AuthRequired {
+		entry.Flags |= xdr.Uint32(xdr.AccountFlagsAuthRequiredFlag)
+	}
+	if account.Flags.AuthRevocable {
+		entry.Flags |= xdr.Uint32(xdr.AccountFlagsAuthRevocableFlag)
+	}
+	if account.Flags.AuthImmutable {
+		entry.Flags |= xdr.Uint32(xdr.AccountFlagsAuthImmutableFlag)
+	}
+
+	signers, err := AccountSignersFromHorizon(account)
+	if err != nil {
+		return err
+	}
+	entry.Thresholds = signers.Thresholds
+	entry.Signers = signers.Signers
+
+	var trustlines []*xdr.TrustLineEntry
+	for _, balance := range account.Balances {
+		value, err := amount.Parse(balance.Balance)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s balance", balance.Asset.Type)
+		}
+		liabilities, err := parseLiabilities(balance)
+		if err != nil {
+			return err
+		}
+
+		if balance.Asset.Type == "native" {
+			entry.Balance = value
+			entry.Ext = xdr.AccountEntryExt{V: 1, V1: &xdr.AccountEntryV1{Liabilities: liabilities}}
+			continue
+		}
+
+		asset, err := xdr.BuildAsset(balance.Asset.Type, balance.Asset.Issuer, balance.Asset.Code)
+		if err != nil {
+			return errors.Wrap(err, "invalid balance asset")
+		}
+		limit, err := amount.Parse(balance.Limit)
+		if err != nil {
+			return errors.Wrapf(err, "invalid %s limit", asset.String())
+		}
+
+		trustline := &xdr.TrustLineEntry{
+			AccountId: entry.AccountId,
+			Asset:     asset,
+			Balance:   value,
+			Limit:     limit,
+			Ext:       xdr.TrustLineEntryExt{V: 1, V1: &xdr.TrustLineEntryV1{Liabilities: liabilities}},
+		}
+		if balance.IsAuthorized == nil || *balance.IsAuthorized {
+			trustline.Flags = xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag)
+		}
+		trustlines = append(trustlines, trustline)
+	}
+
+	var data []*xdr.DataEntry
+	for name, value := range account.Data {
+		raw, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for data entry %s", name)
+		}
+		data = append(data, &xdr.DataEntry{
+			AccountId: entry.AccountId,
+			DataName:  xdr.String64(name),
+			DataValue: xdr.DataValue(raw),
+		})
+	}
+
+	ls.accounts[account.AccountID] = &entry
+	for _, trustline := range trustlines {
+		ls.trustlines[trustlineKey(trustline.AccountId, trustline.Asset)] = trustline
+	}
+	for _, entry := range data {
+		ls.data[dataKey(entry.AccountId, string(entry.DataName))] = entry
+	}
+
+	return nil
+}
+
+// Account returns the account entry of the account with the given address,
+// and whether it exists in the snapshot.
+func (ls *LedgerState) Account(address string) (xdr.AccountEntry, bool) {
+	account, ok := ls.accounts[address]
+	if !ok {
+		return xdr.AccountEntry{}, false
+	}
+	return *account, true
+}
+
+// Balances returns the balances of the account with the given address in the
+// format used by Horizon, or nil if the account does not exist in the
+// snapshot. The native balance is always the last one.
+func (ls *LedgerState) Balances(address string) []hProtocol.Balance {
+	account, ok := ls.accounts[address]
+	if !ok {
+		return nil
+	}
+
+	var balances []hProtocol.Balance
+	for _, trustline := range ls.trustlines {
+		if trustline.AccountId.Address() != address {
+			continue
+		}
+
+		var assetType, code, issuer string
+		trustline.Asset.MustExtract(&assetType, &code, &issuer)
+		liabilities := trustlineLiabilities(trustline)
+		authorized := xdr.TrustLineFlags(trustline.Flags).IsAuthorized()
+		balances = append(balances, hProtocol.Balance{
+			Balance:            amount.String(trustline.Balance),
+			Limit:              amount.String(trustline.Limit),
+			BuyingLiabilities:  amount.String(liabilities.Buying),
+			SellingLiabilities: amount.String(liabilities.Selling),
+			IsAuthorized:       &authorized,
+			Asset:              base.Asset{Type: assetType, Code: code, Issuer: issuer},
+		})
+	}
+	sort.Slice(balances, func(i, j int) bool {
+		if balances[i].Code != balances[j].Code {
+			return balances[i].Code < balances[j].Code
+		}
+		return balances[i].Issuer < balances[j].Issuer
+	})
+
+	liabilities := accountLiabilities(account)
+	balances = append(balances, hProtocol.Balance{
+		Balance:            amount.String(account.Balance),
+		BuyingLiabilities:  amount.String(liabilities.Buying),
+		SellingLiabilities: amount.String(liabilities.Selling),
+		Asset:              base.Asset{Type: "native"},
+	})
+
+	return balances
+}
+
+// Clone returns a deep copy of the snapshot.
+func (ls *LedgerState) Clone() (*LedgerState, error) {
+	clone := NewLedgerState()
+	clone.BaseReserve = ls.BaseReserve
+	clone.BaseFee = ls.BaseFee
+	clone.CloseTime = ls.CloseTime
+	clone.SkipSignatureCheck = ls.SkipSignatureCheck
+
+	for key, account := range ls.accounts {
+		var entry xdr.AccountEntry
+		if err := copyXDR(account, &entry); err != nil {
+			return nil, errors.Wrap(err, "failed to copy account entry")
+		}
+		clone.accounts[key] = &entry
+	}
+	for key, trustline := range ls.trustlines {
+		var entry xdr.TrustLineEntry
+		if err := copyXDR(trustline, &entry); err != nil {
+			return nil, errors.Wrap(err, "failed to copy trustline entry")
+		}
+		clone.trustlines[key] = &entry
+	}
+	for key, offer := range ls.offers {
+		var entry xdr.OfferEntry
+		if err := copyXDR(offer, &entry); err != nil {
+			return nil, errors.Wrap(err, "failed to copy offer entry")
+		}
+		clone.offers[key] = &entry
+	}
+	for key, data := range ls.data {
+		var entry xdr.DataEntry
+		if err := copyXDR(data, &entry); err != nil {
+			return nil, errors.Wrap(err, "failed to copy data entry")
+		}
+		clone.data[key] = &entry
+	}
+
+	return clone, nil
+}
+
+func (ls *LedgerState) baseReserve() int64 {
+	if ls.BaseReserve == 0 {
+		return DefaultBaseReserve
+	}
+	return ls.BaseReserve
+}
+
+func (ls *LedgerState) baseFee() int64 {
+	if ls.BaseFee == 0 {
+		return MinBaseFee
+	}
+	return ls.BaseFee
+}
+
+func (ls *LedgerState) closeTime() time.Time {
+	if ls.CloseTime.IsZero() {
+		return time.Now()
+	}
+	return ls.CloseTime
+}
+
+// minBalance returns the minimum balance of an account with the given number
+// of subentries.
+func (ls *LedgerState) minBalance(numSubEntries xdr.Uint32) int64 {
+	return (2 + int64(numSubEntries)) * ls.baseReserve()
+}
+
+// availableNativeBalance returns the amount of lumens the account can spend
+// without going below its minimum balance.
+func (ls *LedgerState) availableNativeBalance(account *xdr.AccountEntry) int64 {
+	return int64(account.Balance) - ls.minBalance(account.NumSubEntries) - int64(accountLiabilities(account).Selling)
+}
+
+func trustlineKey(account xdr.AccountId, asset xdr.Asset) string {
+	return account.Address() + "/" + asset.String()
+}
+
+func dataKey(account xdr.AccountId, name string) string {
+	return account.Address() + "/" + name
+}
+
+func accountLiabilities(account *xdr.AccountEntry) xdr.Liabilities {
+	if account.Ext.V1 == nil {
+		return xdr.Liabilities{}
+	}
+	return account.Ext.V1.Liabilities
+}
+
+func trustlineLiabilities(trustline *xdr.TrustLineEntry) xdr.Liabilities {
+	if trustline.Ext.V1 == nil {
+		return xdr.Liabilities{}
+	}
+	return trustline.Ext.V1.Liabilities
+}
+
+func parseLiabilities(balance hProtocol.Balance) (xdr.Liabilities, error) {
+	var liabilities xdr.Liabilities
+	var err error
+	if balance.BuyingLiabilities != "" {
+		liabilities.Buying, err = amount.Parse(balance.BuyingLiabilities)
+		if err != nil {
+			return liabilities, errors.Wrap(err, "invalid buying liabilities")
+		}
+	}
+	if balance.SellingLiabilities != "" {
+		liabilities.Selling, err = amount.Parse(balance.SellingLiabilities)
+		if err != nil {
+			return liabilities, errors.Wrap(err, "invalid selling liabilities")
+		}
+	}
+	return liabilities, nil
+}
+
+// copyXDR deep copies src into dst by round-tripping it through its XDR
+// encoding.
+func copyXDR(src encoding.BinaryMarshaler, dst encoding.BinaryUnmarshaler) error {
+	raw, err := src.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return dst.UnmarshalBinary(raw)
+}
@@ -52,3 +52,14 @@ func TestManageDataValidateValue(t *testing.T) {
 		assert.Contains(t, err.Error(), expected)
 	}
 }
+
+func TestManageDataRoundTripDelete(t *testing.T) {
+	manageData := ManageData{Name: "cars"}
+	xdrOp, err := manageData.BuildXDR()
+	assert.NoError(t, err)
+
+	var parsed ManageData
+	assert.NoError(t, parsed.FromXDR(xdrOp))
+	assert.Equal(t, "cars", parsed.Name)
+	assert.Nil(t, parsed.Value)
+}
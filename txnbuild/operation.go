@@ -12,12 +12,13 @@ type Operation interface {
 	GetSourceAccount() Account
 }
 
-// SetOpSourceAccount sets the source account ID on an Operation.
+// SetOpSourceAccount sets the source account ID on an Operation. The source
+// account may be a multiplexed (M...) address.
 func SetOpSourceAccount(op *xdr.Operation, sourceAccount Account) {
 	if sourceAccount == nil {
 		return
 	}
-	var opSourceAccountID xdr.AccountId
+	var opSourceAccountID xdr.MuxedAccount
 	opSourceAccountID.SetAddress(sourceAccount.GetAccountID())
 	op.SourceAccount = &opSourceAccountID
 }
@@ -61,7 +62,7 @@ func operationFromXDR(xdrOp xdr.Operation) (Operation, error) {
 }
 
 // accountFromXDR returns a txnbuild Account from a XDR Account.
-func accountFromXDR(account *xdr.AccountId) Account {
+func accountFromXDR(account *xdr.MuxedAccount) Account {
 	if account != nil {
 		return &SimpleAccount{AccountID: account.Address()}
 	}
@@ -17,7 +17,7 @@ type Payment struct {
 
 // BuildXDR for Payment returns a fully configured XDR Operation.
 func (p *Payment) BuildXDR() (xdr.Operation, error) {
-	var destAccountID xdr.AccountId
+	var destAccountID xdr.MuxedAccount
 
 	err := destAccountID.SetAddress(p.Destination)
 	if err != nil {
@@ -75,7 +75,7 @@ func (p *Payment) FromXDR(xdrOp xdr.Operation) error {
 // Validate for Payment validates the required struct fields. It returns an error if any
 // of the fields are invalid. Otherwise, it returns nil.
 func (p *Payment) Validate() error {
-	err := validatePaydexPublicKey(p.Destination)
+	err := validatePaydexAddress(p.Destination)
 	if err != nil {
 		return NewValidationError("Destination", err.Error())
 	}
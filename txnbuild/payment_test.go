@@ -78,3 +78,36 @@ func TestPaymentValidateAsset(t *testing.T) {
 		assert.Contains(t, err.Error(), expected)
 	}
 }
+
+func TestPaymentMuxedAccounts(t *testing.T) {
+	kp0 := newKeypair0()
+	sourceAccount := NewSimpleAccount(kp0.Address(), int64(9605939170639898))
+	opSourceAccount := NewSimpleAccount("MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJVAAAAAAAAAAAAAJLK", 0)
+
+	payment := Payment{
+		Destination:   "MA7QYNF7SOWQ3GLR2BGMZEHXAVIRZA4KVWLTJJFC7MGXUA74P7UJUAAAAAAAAAAAACJUQ",
+		Amount:        "10",
+		Asset:         NativeAsset{},
+		SourceAccount: &opSourceAccount,
+	}
+
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations:    []Operation{&payment},
+		Timebounds:    NewInfiniteTimeout(),
+		Network:       network.TestNetworkPassphrase,
+	}
+
+	txeB64, err := tx.BuildSignEncode(kp0)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	parsed, err := TransactionFromXDR(txeB64)
+	if assert.NoError(t, err) && assert.Len(t, parsed.Operations, 1) {
+		parsedPayment, ok := parsed.Operations[0].(*Payment)
+		assert.True(t, ok)
+		assert.Equal(t, payment.Destination, parsedPayment.Destination)
+		assert.Equal(t, opSourceAccount.AccountID, parsedPayment.SourceAccount.GetAccountID())
+	}
+}
@@ -0,0 +1,470 @@
+package txnbuild
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// AccountSigners contains the signers and thresholds of an account, which
+// determine the signatures required by transactions and operations with the
+// account as their source account. See
+// https://www.paydex.org/developers/guides/concepts/multi-sig.html
+type AccountSigners struct {
+	AccountID string
+	// Thresholds contains the weight of the master key followed by the low,
+	// medium and high thresholds of the account.
+	Thresholds xdr.Thresholds
+	// Signers contains the signers of the account, other than its master key.
+	Signers []xdr.Signer
+}
+
+// AccountSignersFromXDR returns the signers and thresholds of an account
+// entry.
+func AccountSignersFromXDR(entry xdr.AccountEntry) AccountSigners {
+	return AccountSigners{
+		AccountID:  entry.AccountId.Address(),
+		Thresholds: entry.Thresholds,
+		Signers:    entry.Signers,
+	}
+}
+
+// AccountSignersFromHorizon returns the signers and thresholds of an account
+// loaded from Horizon, for example with horizonclient.Client.AccountDetail.
+func AccountSignersFromHorizon(account hProtocol.Account) (AccountSigners, error) {
+	signers := AccountSigners{AccountID: account.AccountID}
+	signers.Thresholds[1] = account.Thresholds.LowThreshold
+	signers.Thresholds[2] = account.Thresholds.MedThreshold
+	signers.Thresholds[3] = account.Thresholds.HighThreshold
+
+	for _, signer := range account.Signers {
+		if signer.Key == account.AccountID {
+			signers.Thresholds[0] = byte(signer.Weight)
+			continue
+		}
+
+		var key xdr.SignerKey
+		if err := key.SetAddress(signer.Key); err != nil {
+			return AccountSigners{}, errors.Wrapf(err, "invalid signer %s", signer.Key)
+		}
+		signers.Signers = append(signers.Signers, xdr.Signer{Key: key, Weight: xdr.Uint32(signer.Weight)})
+	}
+
+	return signers, nil
+}
+
+// allSigners returns the signers of the account, starting with its master key
+// if the master key has a weight.
+func (as AccountSigners) allSigners() ([]xdr.Signer, error) {
+	var signers []xdr.Signer
+	if weight := as.Thresholds.MasterKeyWeight(); weight > 0 {
+		var key xdr.SignerKey
+		if err := key.SetAddress(as.AccountID); err != nil {
+			return nil, errors.Wrapf(err, "invalid account id %s", as.AccountID)
+		}
+		signers = append(signers, xdr.Signer{Key: key, Weight: xdr.Uint32(weight)})
+	}
+	return append(signers, as.Signers...), nil
+}
+
+// SignatureRequirement describes the signatures a transaction needs from one of
+// its source accounts, and the signatures it already has.
+type SignatureRequirement struct {
+	// Operation is the index of the operation with the source account, or -1
+	// for the transaction itself.
+	Operation int
+	// SourceAccount is the address of the account whose signers have to sign.
+	SourceAccount string
+	// Threshold is the threshold of the source account required by the
+	// transaction or operation.
+	Threshold byte
+	// Weight is the combined weight of the valid signatures from signers of
+	// the source account.
+	Weight uint32
+	// Signers contains the signers of the source account which have signed.
+	Signers []string
+}
+
+// Met returns true if the signatures meet the threshold. As on the network, at
+// least one signature is required even if the threshold is zero.
+func (r SignatureRequirement) Met() bool {
+	return r.Weight > 0 && r.Weight >= uint32(r.Threshold)
+}
+
+// MissingWeight returns the weight of the signatures which are still needed
+// to meet the threshold.
+func (r SignatureRequirement) MissingWeight() uint32 {
+	if r.Met() {
+		return 0
+	}
+	if r.Threshold == 0 {
+		return 1
+	}
+	return uint32(r.Threshold) - r.Weight
+}
+
+// SignatureStatus describes whether the signatures of a transaction meet the
+// thresholds of its source accounts.
+type SignatureStatus struct {
+	// Requirements contains the requirement of the transaction followed by the
+	// requirement of each operation.
+	Requirements []SignatureRequirement
+	// InvalidSignatures is the number of signatures which do not match any
+	// signer of the source accounts, including duplicate signatures.
+	InvalidSignatures int
+}
+
+// Complete returns true if every threshold is met and there are no invalid
+// signatures, in which case the transaction is ready to be submitted.
+func (s SignatureStatus) Complete() bool {
+	for _, requirement := range s.Requirements {
+		if !requirement.Met() {
+			return false
+		}
+	}
+	return s.InvalidSignatures == 0
+}
+
+// Pending returns the requirements whose thresholds are not met yet.
+func (s SignatureStatus) Pending() []SignatureRequirement {
+	var pending []SignatureRequirement
+	for _, requirement := range s.Requirements {
+		if !requirement.Met() {
+			pending = append(pending, requirement)
+		}
+	}
+	return pending
+}
+
+// SignatureStatus checks the signatures of a built transaction against the
+// signers and thresholds of its source accounts. The signers of the source
+// account of the transaction and of every operation source account must be
+// provided.
+func (tx *Transaction) SignatureStatus(accounts ...AccountSigners) (SignatureStatus, error) {
+	hash, requirements, signers, err := tx.signatureRequirements(accounts)
+	if err != nil {
+		return SignatureStatus{}, err
+	}
+
+	signatures := tx.xdrEnvelope.Signatures()
+	used := make([]bool, len(signatures))
+	for i := range requirements {
+		requirement := &requirements[i]
+		requirement.Weight = matchSignatures(hash, signatures, signers[requirement.SourceAccount], math.MaxUint32,
+			func(index int, signer xdr.Signer) {
+				if index >= 0 {
+					used[index] = true
+				}
+				requirement.Signers = append(requirement.Signers, signer.Key.Address())
+			},
+		)
+	}
+
+	status := SignatureStatus{Requirements: requirements}
+	for _, u := range used {
+		if !u {
+			status.InvalidSignatures++
+		}
+	}
+	return status, nil
+}
+
+// PruneSignatures removes invalid, duplicate and redundant signatures from a
+// built transaction and returns the number of signatures removed. Signatures
+// are redundant if the thresholds they are needed for are already met by
+// signatures which precede them. The network rejects transactions with
+// signatures it does not need, so a transaction signed by more signers than
+// required has to be pruned before it is submitted.
+func (tx *Transaction) PruneSignatures(accounts ...AccountSigners) (int, error) {
+	hash, requirements, signers, err := tx.signatureRequirements(accounts)
+	if err != nil {
+		return 0, err
+	}
+
+	// the signatures needed by an account are the ones needed to meet its
+	// highest required threshold
+	needed := map[string]uint32{}
+	for _, requirement := range requirements {
+		threshold := uint32(requirement.Threshold)
+		if threshold == 0 {
+			threshold = 1
+		}
+		if threshold > needed[requirement.SourceAccount] {
+			needed[requirement.SourceAccount] = threshold
+		}
+	}
+
+	keep := map[int]bool{}
+	for account, threshold := range needed {
+		matchSignatures(hash, tx.xdrEnvelope.Signatures(), signers[account], threshold,
+			func(index int, _ xdr.Signer) {
+				if index >= 0 {
+					keep[index] = true
+				}
+			},
+		)
+	}
+
+	signatures := tx.xdrEnvelope.Signatures()
+	kept := make([]xdr.DecoratedSignature, 0, len(keep))
+	for i, signature := range signatures {
+		if keep[i] {
+			kept = append(kept, signature)
+		}
+	}
+	tx.setSignatures(kept)
+
+	return len(signatures) - len(kept), nil
+}
+
+// MergeSignatures adds the signatures of other copies of a built transaction,
+// given as base64 encoded transaction envelopes, to the transaction. It can be
+// used to combine the signatures collected separately by each signer of a
+// multisig account. Signatures already present are not added again.
+func (tx *Transaction) MergeSignatures(txeB64s ...string) error {
+	if tx.xdrEnvelope == nil {
+		return errors.New("transaction must be built before signatures are merged")
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return errors.Wrap(err, "failed to hash transaction")
+	}
+
+	for i, txeB64 := range txeB64s {
+		other, err := TransactionFromXDR(txeB64)
+		if err != nil {
+			return errors.Wrapf(err, "unable to parse transaction %d", i)
+		}
+		other.Network = tx.Network
+		otherHash, err := other.Hash()
+		if err != nil {
+			return errors.Wrapf(err, "failed to hash transaction %d", i)
+		}
+		if otherHash != hash {
+			return errors.Errorf("transaction %d is not a copy of the transaction", i)
+		}
+
+		for _, signature := range other.xdrEnvelope.Signatures() {
+			if !hasSignature(tx.xdrEnvelope.Signatures(), signature) {
+				tx.appendSignatures(signature)
+			}
+		}
+	}
+
+	return nil
+}
+
+// signatureRequirements returns the hash of the transaction, the requirement
+// of the transaction and of each of its operations, and the signers of each
+// source account.
+func (tx *Transaction) signatureRequirements(accounts []AccountSigners) ([32]byte, []SignatureRequirement, map[string][]xdr.Signer, error) {
+	var hash [32]byte
+	if tx.xdrEnvelope == nil {
+		return hash, nil, nil, errors.New("transaction must be built before its signatures are checked")
+	}
+	hash, err := tx.Hash()
+	if err != nil {
+		return hash, nil, nil, errors.Wrap(err, "failed to hash transaction")
+	}
+
+	byAddress := map[string]AccountSigners{}
+	for _, account := range accounts {
+		byAddress[account.AccountID] = account
+	}
+
+	signers := map[string][]xdr.Signer{}
+	requirement := func(operation int, source xdr.AccountId, threshold func(xdr.Thresholds) byte) (SignatureRequirement, error) {
+		address := source.Address()
+		account, ok := byAddress[address]
+		if !ok {
+			return SignatureRequirement{}, errors.Errorf("signers of source account %s are missing", address)
+		}
+		if _, ok := signers[address]; !ok {
+			if signers[address], err = account.allSigners(); err != nil {
+				return SignatureRequirement{}, err
+			}
+		}
+		return SignatureRequirement{
+			Operation:     operation,
+			SourceAccount: address,
+			Threshold:     threshold(account.Thresholds),
+		}, nil
+	}
+
+	xdrTx := tx.xdrTransaction
+	txRequirement, err := requirement(-1, xdrTx.SourceAccount.ToAccountId(), xdr.Thresholds.ThresholdLow)
+	if err != nil {
+		return hash, nil, nil, err
+	}
+	requirements := []SignatureRequirement{txRequirement}
+	for i, op := range xdrTx.Operations {
+		opRequirement, err := requirement(i, operationSourceAccount(xdrTx, op), operationThreshold(op))
+		if err != nil {
+			return hash, nil, nil, err
+		}
+		requirements = append(requirements, opRequirement)
+	}
+
+	return hash, requirements, signers, nil
+}
+
+// setSignatures replaces the signatures of the transaction envelope.
+func (tx *Transaction) setSignatures(signatures []xdr.DecoratedSignature) {
+	switch tx.xdrEnvelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		tx.xdrEnvelope.V0.Signatures = signatures
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		tx.xdrEnvelope.V1.Signatures = signatures
+	}
+}
+
+// operationThreshold returns the function selecting the threshold of the
+// source account which the signatures of the operation have to meet.
+func operationThreshold(op xdr.Operation) func(xdr.Thresholds) byte {
+	switch op.Body.Type {
+	case xdr.OperationTypeAllowTrust, xdr.OperationTypeBumpSequence:
+		return xdr.Thresholds.ThresholdLow
+	case xdr.OperationTypeAccountMerge:
+		return xdr.Thresholds.ThresholdHigh
+	case xdr.OperationTypeSetOptions:
+		setOptions := op.Body.MustSetOptionsOp()
+		if setOptions.MasterWeight != nil || setOptions.LowThreshold != nil ||
+			setOptions.MedThreshold != nil || setOptions.HighThreshold != nil || setOptions.Signer != nil {
+			return xdr.Thresholds.ThresholdHigh
+		}
+	}
+	return xdr.Thresholds.ThresholdMedium
+}
+
+// matchSignatures matches signatures against signers the way the network does:
+// pre-authorized transaction signers are matched first, followed by each
+// signature, in order, against the hash(x) and then the ed25519 signers. Each
+// signer is matched at most once and `visit` is called with the index of the
+// signature, or -1 for pre-authorized transactions, and the signer of each
+// match. Matching stops once the combined weight of the matched signers reaches
+// `limit`, and the combined weight is returned.
+func matchSignatures(hash [32]byte, signatures []xdr.DecoratedSignature, signers []xdr.Signer, limit uint32, visit func(index int, signer xdr.Signer)) uint32 {
+	var total uint32
+	add := func(index int, signer xdr.Signer) bool {
+		weight := uint32(signer.Weight)
+		if weight > math.MaxUint8 {
+			weight = math.MaxUint8
+		}
+		total += weight
+		visit(index, signer)
+		return total >= limit
+	}
+
+	for _, signer := range signers {
+		if signer.Key.Type == xdr.SignerKeyTypeSignerKeyTypePreAuthTx && bytes.Equal(signer.Key.PreAuthTx[:], hash[:]) {
+			if add(-1, signer) {
+				return total
+			}
+		}
+	}
+
+	for _, keyType := range []xdr.SignerKeyType{xdr.SignerKeyTypeSignerKeyTypeHashX, xdr.SignerKeyTypeSignerKeyTypeEd25519} {
+		var remaining []xdr.Signer
+		for _, signer := range signers {
+			if signer.Key.Type == keyType {
+				remaining = append(remaining, signer)
+			}
+		}
+
+		for i, signature := range signatures {
+			for j, signer := range remaining {
+				if !signatureMatches(hash, signature, signer.Key) {
+					continue
+				}
+				if add(i, signer) {
+					return total
+				}
+				remaining = append(remaining[:j:j], remaining[j+1:]...)
+				break
+			}
+		}
+	}
+
+	return total
+}
+
+// signatureMatches returns true if the signature was made by the signer key.
+func signatureMatches(hash [32]byte, signature xdr.DecoratedSignature, key xdr.SignerKey) bool {
+	switch key.Type {
+	case xdr.SignerKeyTypeSignerKeyTypeHashX:
+		if !bytes.Equal(signature.Hint[:], key.HashX[28:]) {
+			return false
+		}
+		preimageHash := sha256.Sum256(signature.Signature)
+		return bytes.Equal(preimageHash[:], key.HashX[:])
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519:
+		kp, err := keypair.Parse(key.Address())
+		if err != nil {
+			return false
+		}
+		hint := kp.Hint()
+		if !bytes.Equal(signature.Hint[:], hint[:]) {
+			return false
+		}
+		return kp.Verify(hash[:], signature.Signature) == nil
+	default:
+		return false
+	}
+}
+
+func hasSignature(signatures []xdr.DecoratedSignature, signature xdr.DecoratedSignature) bool {
+	for _, existing := range signatures {
+		if existing.Hint == signature.Hint && bytes.Equal(existing.Signature, signature.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureChecker checks whether the signatures of a transaction meet the
+// thresholds of accounts, keeping track of the signatures which have been
+// used.
+type signatureChecker struct {
+	hash       [32]byte
+	signatures []xdr.DecoratedSignature
+	used       []bool
+	skip       bool
+}
+
+// check returns true if the signatures match signers of the account whose
+// combined weight is at least `threshold`.
+func (c *signatureChecker) check(account *xdr.AccountEntry, threshold byte) bool {
+	if c.skip {
+		return true
+	}
+
+	signers, err := AccountSignersFromXDR(*account).allSigners()
+	if err != nil {
+		return false
+	}
+	total := matchSignatures(c.hash, c.signatures, signers, uint32(threshold), func(index int, _ xdr.Signer) {
+		if index >= 0 {
+			c.used[index] = true
+		}
+	})
+	return total > 0 && total >= uint32(threshold)
+}
+
+// allUsed returns true if every signature of the transaction matched a
+// signer.
+func (c *signatureChecker) allUsed() bool {
+	if c.skip {
+		return true
+	}
+	for _, used := range c.used {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
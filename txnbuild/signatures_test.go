@@ -0,0 +1,196 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTreasury returns a 3-of-5 multisig account whose master key cannot sign,
+// and its five signers.
+func newTreasury(t *testing.T) (AccountSigners, []*keypair.Full) {
+	treasury := newKeypair0()
+	signers := []*keypair.Full{}
+	account := hProtocol.Account{
+		AccountID:  treasury.Address(),
+		Thresholds: hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 3, HighThreshold: 5},
+		Signers:    []hProtocol.Signer{{Key: treasury.Address(), Weight: 0, Type: "ed25519_public_key"}},
+	}
+	for i := 0; i < 5; i++ {
+		kp := keypair.MustRandom()
+		signers = append(signers, kp)
+		account.Signers = append(account.Signers, hProtocol.Signer{Key: kp.Address(), Weight: 1, Type: "ed25519_public_key"})
+	}
+
+	accountSigners, err := AccountSignersFromHorizon(account)
+	require.NoError(t, err)
+	return accountSigners, signers
+}
+
+func newTreasuryPayment(t *testing.T) *Transaction {
+	sourceAccount := NewSimpleAccount(newKeypair0().Address(), 10)
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations: []Operation{
+			&Payment{Destination: newKeypair1().Address(), Amount: "10", Asset: NativeAsset{}},
+		},
+		Timebounds: NewInfiniteTimeout(),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+	return &tx
+}
+
+func TestAccountSignersFromHorizon(t *testing.T) {
+	treasury, signers := newTreasury(t)
+	assert.Equal(t, newKeypair0().Address(), treasury.AccountID)
+	assert.Equal(t, xdr.Thresholds{0, 1, 3, 5}, treasury.Thresholds)
+	require.Len(t, treasury.Signers, 5)
+	assert.Equal(t, signers[0].Address(), treasury.Signers[0].Key.Address())
+	assert.Equal(t, xdr.Uint32(1), treasury.Signers[0].Weight)
+
+	entry := xdr.AccountEntry{
+		AccountId:  xdr.MustAddress(treasury.AccountID),
+		Thresholds: treasury.Thresholds,
+		Signers:    treasury.Signers,
+	}
+	assert.Equal(t, treasury, AccountSignersFromXDR(entry))
+
+	_, err := AccountSignersFromHorizon(hProtocol.Account{
+		AccountID: treasury.AccountID,
+		Signers:   []hProtocol.Signer{{Key: "GBAD", Weight: 1}},
+	})
+	assert.EqualError(t, err, "invalid signer GBAD: failed to extract address version: base32 decode failed: non-canonical encoding")
+}
+
+func TestSignatureStatus(t *testing.T) {
+	treasury, signers := newTreasury(t)
+	tx := newTreasuryPayment(t)
+
+	status, err := tx.SignatureStatus(treasury)
+	require.NoError(t, err)
+	assert.False(t, status.Complete())
+	require.Len(t, status.Requirements, 2)
+	assert.Equal(t, SignatureRequirement{Operation: -1, SourceAccount: treasury.AccountID, Threshold: 1}, status.Requirements[0])
+	assert.Equal(t, SignatureRequirement{Operation: 0, SourceAccount: treasury.AccountID, Threshold: 3}, status.Requirements[1])
+	assert.Equal(t, uint32(1), status.Requirements[0].MissingWeight())
+	assert.Equal(t, uint32(3), status.Requirements[1].MissingWeight())
+
+	require.NoError(t, tx.Sign(signers[0], signers[1]))
+	status, err = tx.SignatureStatus(treasury)
+	require.NoError(t, err)
+	assert.False(t, status.Complete())
+	assert.True(t, status.Requirements[0].Met())
+	assert.Equal(t, uint32(2), status.Requirements[1].Weight)
+	assert.Equal(t, uint32(1), status.Requirements[1].MissingWeight())
+	assert.Equal(t, []string{signers[0].Address(), signers[1].Address()}, status.Requirements[1].Signers)
+	assert.Equal(t, []SignatureRequirement{status.Requirements[1]}, status.Pending())
+
+	require.NoError(t, tx.Sign(signers[2], newKeypair1()))
+	status, err = tx.SignatureStatus(treasury)
+	require.NoError(t, err)
+	assert.True(t, status.Requirements[1].Met())
+	assert.Equal(t, 1, status.InvalidSignatures)
+	assert.False(t, status.Complete())
+
+	_, err = tx.SignatureStatus()
+	assert.EqualError(t, err, "signers of source account "+treasury.AccountID+" are missing")
+}
+
+func TestPruneSignatures(t *testing.T) {
+	treasury, signers := newTreasury(t)
+	tx := newTreasuryPayment(t)
+
+	// a duplicate signature, a signature from a key which is not a signer and
+	// one more signature than the medium threshold requires
+	require.NoError(t, tx.Sign(signers[0], signers[0], newKeypair1(), signers[1], signers[2], signers[3]))
+	removed, err := tx.PruneSignatures(treasury)
+	require.NoError(t, err)
+	assert.Equal(t, 3, removed)
+
+	signatures := tx.TxEnvelope().Signatures()
+	require.Len(t, signatures, 3)
+	for i, signature := range signatures {
+		hash, err := tx.Hash()
+		require.NoError(t, err)
+		assert.NoError(t, signers[i].Verify(hash[:], signature.Signature))
+	}
+
+	status, err := tx.SignatureStatus(treasury)
+	require.NoError(t, err)
+	assert.True(t, status.Complete())
+
+	// the network accepts the pruned transaction
+	state := NewLedgerState()
+	require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(treasury.AccountID),
+				Balance:    100 * 10000000,
+				SeqNum:     10,
+				Thresholds: treasury.Thresholds,
+				Signers:    treasury.Signers,
+			},
+		},
+	}))
+	require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(newKeypair1().Address()), Balance: 10000000},
+		},
+	}))
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+
+	require.NoError(t, tx.Sign(signers[4]))
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_bad_auth_extra", result.ResultCodes.TransactionCode)
+}
+
+func TestMergeSignatures(t *testing.T) {
+	treasury, signers := newTreasury(t)
+	tx := newTreasuryPayment(t)
+	txeB64, err := tx.Base64()
+	require.NoError(t, err)
+
+	// each signer signs their own copy of the transaction
+	var copies []string
+	for _, signer := range signers[:3] {
+		txCopy, err := TransactionFromXDR(txeB64)
+		require.NoError(t, err)
+		txCopy.Network = network.TestNetworkPassphrase
+		require.NoError(t, txCopy.Sign(signer))
+		signed, err := txCopy.Base64()
+		require.NoError(t, err)
+		copies = append(copies, signed)
+	}
+
+	require.NoError(t, tx.Sign(signers[0]))
+	require.NoError(t, tx.MergeSignatures(copies...))
+	assert.Len(t, tx.TxEnvelope().Signatures(), 3)
+
+	status, err := tx.SignatureStatus(treasury)
+	require.NoError(t, err)
+	assert.True(t, status.Complete())
+
+	other := newTreasuryPayment(t)
+	other.Memo = MemoText("other")
+	require.NoError(t, other.Build())
+	otherB64, err := other.Base64()
+	require.NoError(t, err)
+	err = tx.MergeSignatures(otherB64)
+	assert.EqualError(t, err, "transaction 0 is not a copy of the transaction")
+
+	unbuilt := Transaction{}
+	err = unbuilt.MergeSignatures(copies...)
+	assert.EqualError(t, err, "transaction must be built before signatures are merged")
+}
@@ -0,0 +1,680 @@
+package txnbuild
+
+import (
+	"math"
+
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/xdr"
+)
+
+// Result codes reported by the simulator. They are the same strings Horizon
+// uses in the `extras.result_codes` of failed transaction submissions.
+const (
+	txSuccess             = "tx_success"
+	txFailed              = "tx_failed"
+	txTooEarly            = "tx_too_early"
+	txTooLate             = "tx_too_late"
+	txMissingOperation    = "tx_missing_operation"
+	txBadSeq              = "tx_bad_seq"
+	txBadAuth             = "tx_bad_auth"
+	txInsufficientBalance = "tx_insufficient_balance"
+	txNoSourceAccount     = "tx_no_source_account"
+	txInsufficientFee     = "tx_insufficient_fee"
+	txBadAuthExtra        = "tx_bad_auth_extra"
+
+	opSuccess             = "op_success"
+	opMalformed           = "op_malformed"
+	opUnderfunded         = "op_underfunded"
+	opLowReserve          = "op_low_reserve"
+	opLineFull            = "op_line_full"
+	opBadAuth             = "op_bad_auth"
+	opNoSourceAccount     = "op_no_source_account"
+	opTooManySubentries   = "op_too_many_subentries"
+	opAlreadyExists       = "op_already_exists"
+	opSrcNoTrust          = "op_src_no_trust"
+	opSrcNotAuthorized    = "op_src_not_authorized"
+	opNoDestination       = "op_no_destination"
+	opNoTrust             = "op_no_trust"
+	opNotAuthorized       = "op_not_authorized"
+	opOverSourceMax       = "op_over_source_max"
+	opUnderDestMin        = "op_under_dest_min"
+	opTooManySigners      = "op_too_many_signers"
+	opBadFlags            = "op_bad_flags"
+	opInvalidInflation    = "op_invalid_inflation"
+	opCantChange          = "op_cant_change"
+	opUnknownFlag         = "op_unknown_flag"
+	opThresholdOutOfRange = "op_threshold_out_of_range"
+	opBadSigner           = "op_bad_signer"
+	opInvalidLimit        = "op_invalid_limit"
+	opSelfNotAllowed      = "op_self_not_allowed"
+	opNoTrustline         = "op_no_trustline"
+	opNotRequired         = "op_not_required"
+	opCantRevoke          = "op_cant_revoke"
+	opNoAccount           = "op_no_account"
+	opImmutableSet        = "op_immutable_set"
+	opHasSubEntries       = "op_has_sub_entries"
+	opDestFull            = "op_dest_full"
+	opDataNameNotFound    = "op_data_name_not_found"
+	opDataInvalidName     = "op_data_invalid_name"
+	opBadSeq              = "op_bad_seq"
+)
+
+const (
+	maxSubentries = 1000
+	maxSigners    = 20
+)
+
+// SimulationResult is the outcome of applying a transaction to a LedgerState.
+type SimulationResult struct {
+	// ResultCodes contains the result code of the transaction and, if the
+	// transaction was valid, the result code of each of its operations.
+	ResultCodes hProtocol.TransactionResultCodes
+	// FeeCharged is the fee, in stroops, charged to the source account.
+	FeeCharged int64
+	// Balances contains the balances, keyed by account address, of every
+	// account in the snapshot after the transaction has been applied.
+	Balances map[string][]hProtocol.Balance
+}
+
+// Successful returns true if the transaction would succeed.
+func (r SimulationResult) Successful() bool {
+	return r.ResultCodes.TransactionCode == txSuccess
+}
+
+// Simulate applies a built transaction to a copy of the snapshot and returns
+// the result, leaving the snapshot unchanged. Signatures are checked against
+// the signers in the snapshot unless SkipSignatureCheck is set.
+//
+// Offers are not matched against, so manage offer operations and path
+// payments which would cross offers cannot be simulated and result in an
+// error. Inflation operations cannot be simulated either.
+func (ls *LedgerState) Simulate(tx *Transaction) (SimulationResult, error) {
+	clone, err := ls.Clone()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	return clone.Apply(tx)
+}
+
+// Apply is like Simulate but modifies the snapshot, so that a sequence of
+// transactions can be simulated. As on the network, the fee and the sequence
+// number of a valid transaction are consumed even if one of its operations
+// fails, in which case the effects of all its operations are discarded.
+func (ls *LedgerState) Apply(tx *Transaction) (SimulationResult, error) {
+	if tx.xdrEnvelope == nil {
+		return SimulationResult{}, errors.New("transaction must be built before it is simulated")
+	}
+	for _, op := range tx.xdrTransaction.Operations {
+		if err := checkSimulationSupported(op); err != nil {
+			return SimulationResult{}, err
+		}
+	}
+
+	hash, err := tx.Hash()
+	if err != nil {
+		return SimulationResult{}, errors.Wrap(err, "failed to hash transaction")
+	}
+	checker := &signatureChecker{
+		hash:       hash,
+		signatures: tx.xdrEnvelope.Signatures(),
+		used:       make([]bool, len(tx.xdrEnvelope.Signatures())),
+		skip:       ls.SkipSignatureCheck,
+	}
+
+	xdrTx := tx.xdrTransaction
+	result := SimulationResult{}
+	result.ResultCodes = ls.validateTransaction(xdrTx, checker)
+	if result.ResultCodes.TransactionCode != "" {
+		result.Balances = ls.allBalances()
+		return result, nil
+	}
+
+	// consume the fee and the sequence number before applying the operations
+	result.FeeCharged = ls.baseFee() * int64(len(xdrTx.Operations))
+	sourceAccount := xdrTx.SourceAccount.ToAccountId()
+	source := ls.accounts[sourceAccount.Address()]
+	source.Balance -= xdr.Int64(result.FeeCharged)
+	source.SeqNum = xdrTx.SeqNum
+
+	// operations are applied to a copy of the snapshot, which replaces the
+	// snapshot only if all of them succeed
+	applied, err := ls.Clone()
+	if err != nil {
+		return SimulationResult{}, err
+	}
+
+	success := true
+	for _, op := range xdrTx.Operations {
+		code := applied.applyOperation(op, operationSourceAccount(xdrTx, op))
+		result.ResultCodes.OperationCodes = append(result.ResultCodes.OperationCodes, code)
+		if code != opSuccess {
+			success = false
+		}
+	}
+
+	if success {
+		result.ResultCodes.TransactionCode = txSuccess
+		ls.accounts, ls.trustlines, ls.offers, ls.data = applied.accounts, applied.trustlines, applied.offers, applied.data
+	} else {
+		result.ResultCodes.TransactionCode = txFailed
+	}
+	ls.removePreAuthTxSigners(xdrTx, hash)
+	result.Balances = ls.allBalances()
+
+	return result, nil
+}
+
+// validateTransaction returns the result codes of a transaction which would
+// be rejected by the network, or empty result codes if the transaction is
+// valid. The snapshot is not modified.
+func (ls *LedgerState) validateTransaction(tx xdr.Transaction, checker *signatureChecker) hProtocol.TransactionResultCodes {
+	rejected := func(code string) hProtocol.TransactionResultCodes {
+		return hProtocol.TransactionResultCodes{TransactionCode: code}
+	}
+
+	closeTime := ls.closeTime().Unix()
+	if tx.TimeBounds != nil {
+		if closeTime < int64(tx.TimeBounds.MinTime) {
+			return rejected(txTooEarly)
+		}
+		if tx.TimeBounds.MaxTime != 0 && closeTime > int64(tx.TimeBounds.MaxTime) {
+			return rejected(txTooLate)
+		}
+	}
+
+	if len(tx.Operations) == 0 {
+		return rejected(txMissingOperation)
+	}
+
+	fee := ls.baseFee() * int64(len(tx.Operations))
+	if int64(tx.Fee) < fee {
+		return rejected(txInsufficientFee)
+	}
+
+	sourceAccount := tx.SourceAccount.ToAccountId()
+	source, ok := ls.accounts[sourceAccount.Address()]
+	if !ok {
+		return rejected(txNoSourceAccount)
+	}
+
+	if tx.SeqNum != source.SeqNum+1 {
+		return rejected(txBadSeq)
+	}
+
+	if !checker.check(source, source.ThresholdLow()) {
+		return rejected(txBadAuth)
+	}
+
+	if ls.availableNativeBalance(source) < fee {
+		return rejected(txInsufficientBalance)
+	}
+
+	// the signatures of each operation are checked against its source account
+	// before any operation is applied
+	codes := make([]string, 0, len(tx.Operations))
+	valid := true
+	for _, op := range tx.Operations {
+		code := opSuccess
+		sourceAccount := operationSourceAccount(tx, op)
+		opSource, ok := ls.accounts[sourceAccount.Address()]
+		if !ok {
+			code = opNoSourceAccount
+		} else if !checker.check(opSource, operationThreshold(op)(opSource.Thresholds)) {
+			code = opBadAuth
+		}
+		if code != opSuccess {
+			valid = false
+		}
+		codes = append(codes, code)
+	}
+	if !valid {
+		return hProtocol.TransactionResultCodes{TransactionCode: txFailed, OperationCodes: codes}
+	}
+
+	if !checker.allUsed() {
+		return rejected(txBadAuthExtra)
+	}
+
+	return hProtocol.TransactionResultCodes{}
+}
+
+// applyOperation applies an operation to the snapshot and returns its result
+// code. The snapshot may be left partially modified if the operation fails.
+func (ls *LedgerState) applyOperation(op xdr.Operation, sourceAccount xdr.AccountId) string {
+	source, ok := ls.accounts[sourceAccount.Address()]
+	if !ok {
+		return opNoSourceAccount
+	}
+
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		return ls.applyCreateAccount(source, op.Body.MustCreateAccountOp())
+	case xdr.OperationTypePayment:
+		payment := op.Body.MustPaymentOp()
+		return ls.applyPayment(source, payment.Destination.ToAccountId(), payment.Asset, payment.Amount)
+	case xdr.OperationTypePathPaymentStrictReceive:
+		payment := op.Body.MustPathPaymentStrictReceiveOp()
+		if payment.SendMax < payment.DestAmount {
+			return opOverSourceMax
+		}
+		return ls.applyPayment(source, payment.Destination.ToAccountId(), payment.DestAsset, payment.DestAmount)
+	case xdr.OperationTypePathPaymentStrictSend:
+		payment := op.Body.MustPathPaymentStrictSendOp()
+		if payment.SendAmount < payment.DestMin {
+			return opUnderDestMin
+		}
+		return ls.applyPayment(source, payment.Destination.ToAccountId(), payment.SendAsset, payment.SendAmount)
+	case xdr.OperationTypeSetOptions:
+		return ls.applySetOptions(source, op.Body.MustSetOptionsOp())
+	case xdr.OperationTypeChangeTrust:
+		return ls.applyChangeTrust(source, op.Body.MustChangeTrustOp())
+	case xdr.OperationTypeAllowTrust:
+		return ls.applyAllowTrust(source, op.Body.MustAllowTrustOp())
+	case xdr.OperationTypeAccountMerge:
+		return ls.applyAccountMerge(source, op.Body.MustDestination().ToAccountId())
+	case xdr.OperationTypeManageData:
+		return ls.applyManageData(source, op.Body.MustManageDataOp())
+	case xdr.OperationTypeBumpSequence:
+		bumpTo := op.Body.MustBumpSequenceOp().BumpTo
+		if bumpTo < 0 {
+			return opBadSeq
+		}
+		if bumpTo > source.SeqNum {
+			source.SeqNum = bumpTo
+		}
+		return opSuccess
+	default:
+		panic(errors.Errorf("unexpected operation type: %v", op.Body.Type))
+	}
+}
+
+func (ls *LedgerState) applyCreateAccount(source *xdr.AccountEntry, op xdr.CreateAccountOp) string {
+	if op.StartingBalance <= 0 || op.Destination.Equals(source.AccountId) {
+		return opMalformed
+	}
+	if _, ok := ls.accounts[op.Destination.Address()]; ok {
+		return opAlreadyExists
+	}
+	if int64(op.StartingBalance) < ls.minBalance(0) {
+		return opLowReserve
+	}
+	if ls.availableNativeBalance(source) < int64(op.StartingBalance) {
+		return opUnderfunded
+	}
+
+	source.Balance -= op.StartingBalance
+	ls.accounts[op.Destination.Address()] = &xdr.AccountEntry{
+		AccountId:  op.Destination,
+		Balance:    op.StartingBalance,
+		SeqNum:     0,
+		Thresholds: xdr.Thresholds{1, 0, 0, 0},
+	}
+	return opSuccess
+}
+
+// applyPayment moves `amount` of `asset` from the source account to the
+// destination account. It is used for payments and for path payments which
+// send and receive the same asset.
+func (ls *LedgerState) applyPayment(source *xdr.AccountEntry, destination xdr.AccountId, asset xdr.Asset, amount xdr.Int64) string {
+	if amount <= 0 {
+		return opMalformed
+	}
+
+	dest, ok := ls.accounts[destination.Address()]
+	if !ok {
+		return opNoDestination
+	}
+	if source.AccountId.Equals(destination) {
+		return opSuccess
+	}
+
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		if int64(dest.Balance) > math.MaxInt64-int64(amount)-int64(accountLiabilities(dest).Buying) {
+			return opLineFull
+		}
+		if ls.availableNativeBalance(source) < int64(amount) {
+			return opUnderfunded
+		}
+		dest.Balance += amount
+		source.Balance -= amount
+		return opSuccess
+	}
+
+	issuer := assetIssuer(asset)
+
+	var destLine *xdr.TrustLineEntry
+	if !issuer.Equals(destination) {
+		destLine, ok = ls.trustlines[trustlineKey(destination, asset)]
+		if !ok {
+			return opNoTrust
+		}
+		if !xdr.TrustLineFlags(destLine.Flags).IsAuthorized() {
+			return opNotAuthorized
+		}
+		if int64(destLine.Balance) > int64(destLine.Limit)-int64(amount)-int64(trustlineLiabilities(destLine).Buying) {
+			return opLineFull
+		}
+	}
+
+	var sourceLine *xdr.TrustLineEntry
+	if !issuer.Equals(source.AccountId) {
+		sourceLine, ok = ls.trustlines[trustlineKey(source.AccountId, asset)]
+		if !ok {
+			return opSrcNoTrust
+		}
+		if !xdr.TrustLineFlags(sourceLine.Flags).IsAuthorized() {
+			return opSrcNotAuthorized
+		}
+		if int64(sourceLine.Balance)-int64(trustlineLiabilities(sourceLine).Selling) < int64(amount) {
+			return opUnderfunded
+		}
+	}
+
+	if destLine != nil {
+		destLine.Balance += amount
+	}
+	if sourceLine != nil {
+		sourceLine.Balance -= amount
+	}
+	return opSuccess
+}
+
+func (ls *LedgerState) applySetOptions(source *xdr.AccountEntry, op xdr.SetOptionsOp) string {
+	for _, weight := range []*xdr.Uint32{op.MasterWeight, op.LowThreshold, op.MedThreshold, op.HighThreshold} {
+		if weight != nil && *weight > math.MaxUint8 {
+			return opThresholdOutOfRange
+		}
+	}
+	if op.SetFlags != nil && op.ClearFlags != nil && *op.SetFlags&*op.ClearFlags != 0 {
+		return opBadFlags
+	}
+	if op.Signer != nil && (op.Signer.Weight > math.MaxUint8 || op.Signer.Key.Equals(masterSignerKey(source.AccountId))) {
+		return opBadSigner
+	}
+
+	if op.InflationDest != nil {
+		if _, ok := ls.accounts[op.InflationDest.Address()]; !ok {
+			return opInvalidInflation
+		}
+		inflationDest := *op.InflationDest
+		source.InflationDest = &inflationDest
+	}
+
+	if op.ClearFlags != nil || op.SetFlags != nil {
+		if xdr.AccountFlags(source.Flags).IsAuthImmutable() {
+			return opCantChange
+		}
+		if op.ClearFlags != nil {
+			if *op.ClearFlags&^xdr.MaskAccountFlags != 0 {
+				return opUnknownFlag
+			}
+			source.Flags &^= *op.ClearFlags
+		}
+		if op.SetFlags != nil {
+			if *op.SetFlags&^xdr.MaskAccountFlags != 0 {
+				return opUnknownFlag
+			}
+			source.Flags |= *op.SetFlags
+		}
+	}
+
+	if op.HomeDomain != nil {
+		source.HomeDomain = *op.HomeDomain
+	}
+	if op.MasterWeight != nil {
+		source.Thresholds[0] = byte(*op.MasterWeight)
+	}
+	if op.LowThreshold != nil {
+		source.Thresholds[1] = byte(*op.LowThreshold)
+	}
+	if op.MedThreshold != nil {
+		source.Thresholds[2] = byte(*op.MedThreshold)
+	}
+	if op.HighThreshold != nil {
+		source.Thresholds[3] = byte(*op.HighThreshold)
+	}
+
+	if op.Signer != nil {
+		return ls.updateSigner(source, *op.Signer)
+	}
+	return opSuccess
+}
+
+// updateSigner adds, updates or, if its weight is zero, removes a signer of
+// the account.
+func (ls *LedgerState) updateSigner(account *xdr.AccountEntry, signer xdr.Signer) string {
+	for i, existing := range account.Signers {
+		if !existing.Key.Equals(signer.Key) {
+			continue
+		}
+		if signer.Weight == 0 {
+			account.Signers = append(account.Signers[:i:i], account.Signers[i+1:]...)
+			account.NumSubEntries--
+		} else {
+			account.Signers[i].Weight = signer.Weight
+		}
+		return opSuccess
+	}
+
+	if signer.Weight == 0 {
+		return opSuccess
+	}
+	if len(account.Signers) >= maxSigners {
+		return opTooManySigners
+	}
+	if code := ls.addSubentry(account); code != opSuccess {
+		return code
+	}
+	account.Signers = append(account.Signers, signer)
+	return opSuccess
+}
+
+func (ls *LedgerState) applyChangeTrust(source *xdr.AccountEntry, op xdr.ChangeTrustOp) string {
+	if op.Line.Type == xdr.AssetTypeAssetTypeNative || op.Limit < 0 {
+		return opMalformed
+	}
+	issuer := assetIssuer(op.Line)
+	if issuer.Equals(source.AccountId) {
+		return opSelfNotAllowed
+	}
+
+	key := trustlineKey(source.AccountId, op.Line)
+	if trustline, ok := ls.trustlines[key]; ok {
+		liabilities := trustlineLiabilities(trustline)
+		if int64(op.Limit) < int64(trustline.Balance)+int64(liabilities.Buying) {
+			return opInvalidLimit
+		}
+		if op.Limit == 0 {
+			delete(ls.trustlines, key)
+			source.NumSubEntries--
+		} else {
+			trustline.Limit = op.Limit
+		}
+		return opSuccess
+	}
+
+	if op.Limit == 0 {
+		return opInvalidLimit
+	}
+	if code := ls.addSubentry(source); code != opSuccess {
+		return code
+	}
+
+	// trustlines are authorized unless the issuer requires authorization;
+	// issuers missing from the snapshot are assumed not to require it
+	var flags xdr.Uint32
+	if issuerAccount, ok := ls.accounts[issuer.Address()]; !ok || !xdr.AccountFlags(issuerAccount.Flags).IsAuthRequired() {
+		flags = xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+	ls.trustlines[key] = &xdr.TrustLineEntry{
+		AccountId: source.AccountId,
+		Asset:     op.Line,
+		Limit:     op.Limit,
+		Flags:     flags,
+	}
+	return opSuccess
+}
+
+func (ls *LedgerState) applyAllowTrust(source *xdr.AccountEntry, op xdr.AllowTrustOp) string {
+	if op.Trustor.Equals(source.AccountId) {
+		return opSelfNotAllowed
+	}
+
+	flags := xdr.AccountFlags(source.Flags)
+	if !flags.IsAuthRequired() {
+		return opNotRequired
+	}
+	if !op.Authorize && !flags.IsAuthRevocable() {
+		return opCantRevoke
+	}
+
+	trustline, ok := ls.trustlines[trustlineKey(op.Trustor, op.Asset.ToAsset(source.AccountId))]
+	if !ok {
+		return opNoTrustline
+	}
+
+	if op.Authorize {
+		trustline.Flags |= xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag)
+	} else {
+		trustline.Flags &^= xdr.Uint32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+	return opSuccess
+}
+
+func (ls *LedgerState) applyAccountMerge(source *xdr.AccountEntry, destination xdr.AccountId) string {
+	if source.AccountId.Equals(destination) {
+		return opMalformed
+	}
+	dest, ok := ls.accounts[destination.Address()]
+	if !ok {
+		return opNoAccount
+	}
+	if xdr.AccountFlags(source.Flags).IsAuthImmutable() {
+		return opImmutableSet
+	}
+	if int(source.NumSubEntries) != len(source.Signers) {
+		return opHasSubEntries
+	}
+	if int64(dest.Balance) > math.MaxInt64-int64(source.Balance)-int64(accountLiabilities(dest).Buying) {
+		return opDestFull
+	}
+
+	dest.Balance += source.Balance
+	delete(ls.accounts, source.AccountId.Address())
+	return opSuccess
+}
+
+func (ls *LedgerState) applyManageData(source *xdr.AccountEntry, op xdr.ManageDataOp) string {
+	if len(op.DataName) == 0 || len(op.DataName) > 64 {
+		return opDataInvalidName
+	}
+
+	key := dataKey(source.AccountId, string(op.DataName))
+	data, ok := ls.data[key]
+	if op.DataValue == nil {
+		if !ok {
+			return opDataNameNotFound
+		}
+		delete(ls.data, key)
+		source.NumSubEntries--
+		return opSuccess
+	}
+
+	if ok {
+		data.DataValue = append(xdr.DataValue(nil), *op.DataValue...)
+		return opSuccess
+	}
+	if code := ls.addSubentry(source); code != opSuccess {
+		return code
+	}
+	ls.data[key] = &xdr.DataEntry{
+		AccountId: source.AccountId,
+		DataName:  op.DataName,
+		DataValue: append(xdr.DataValue(nil), *op.DataValue...),
+	}
+	return opSuccess
+}
+
+// addSubentry increments the number of subentries of the account, if the
+// account can afford the reserve of the new subentry.
+func (ls *LedgerState) addSubentry(account *xdr.AccountEntry) string {
+	if account.NumSubEntries >= maxSubentries {
+		return opTooManySubentries
+	}
+	if int64(account.Balance)-int64(accountLiabilities(account).Selling) < ls.minBalance(account.NumSubEntries+1) {
+		return opLowReserve
+	}
+	account.NumSubEntries++
+	return opSuccess
+}
+
+// removePreAuthTxSigners removes the pre-authorized transaction signers for
+// the transaction from its source accounts, as the network does once the
+// transaction has been applied.
+func (ls *LedgerState) removePreAuthTxSigners(tx xdr.Transaction, hash [32]byte) {
+	sources := []xdr.AccountId{tx.SourceAccount.ToAccountId()}
+	for _, op := range tx.Operations {
+		if op.SourceAccount != nil {
+			sources = append(sources, op.SourceAccount.ToAccountId())
+		}
+	}
+
+	key := xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypePreAuthTx, PreAuthTx: (*xdr.Uint256)(&hash)}
+	for _, source := range sources {
+		account, ok := ls.accounts[source.Address()]
+		if !ok {
+			continue
+		}
+		ls.updateSigner(account, xdr.Signer{Key: key, Weight: 0})
+	}
+}
+
+func (ls *LedgerState) allBalances() map[string][]hProtocol.Balance {
+	balances := make(map[string][]hProtocol.Balance, len(ls.accounts))
+	for address := range ls.accounts {
+		balances[address] = ls.Balances(address)
+	}
+	return balances
+}
+
+// checkSimulationSupported returns an error for operations which cannot be
+// simulated because they depend on the order book.
+func checkSimulationSupported(op xdr.Operation) error {
+	switch op.Body.Type {
+	case xdr.OperationTypeManageSellOffer, xdr.OperationTypeManageBuyOffer, xdr.OperationTypeCreatePassiveSellOffer:
+		return errors.Errorf("simulating %s operations is not supported", op.Body.Type)
+	case xdr.OperationTypeInflation:
+		return errors.New("simulating inflation operations is not supported")
+	case xdr.OperationTypePathPaymentStrictReceive:
+		payment := op.Body.MustPathPaymentStrictReceiveOp()
+		if !payment.SendAsset.Equals(payment.DestAsset) || len(payment.Path) > 0 {
+			return errors.New("simulating path payments which cross offers is not supported")
+		}
+	case xdr.OperationTypePathPaymentStrictSend:
+		payment := op.Body.MustPathPaymentStrictSendOp()
+		if !payment.SendAsset.Equals(payment.DestAsset) || len(payment.Path) > 0 {
+			return errors.New("simulating path payments which cross offers is not supported")
+		}
+	}
+	return nil
+}
+
+// operationSourceAccount returns the source account of the operation, which
+// defaults to the source account of the transaction.
+func operationSourceAccount(tx xdr.Transaction, op xdr.Operation) xdr.AccountId {
+	if op.SourceAccount != nil {
+		return op.SourceAccount.ToAccountId()
+	}
+	return tx.SourceAccount.ToAccountId()
+}
+
+func assetIssuer(asset xdr.Asset) xdr.AccountId {
+	var assetType, code, issuer string
+	asset.MustExtract(&assetType, &code, &issuer)
+	return xdr.MustAddress(issuer)
+}
+
+func masterSignerKey(account xdr.AccountId) xdr.SignerKey {
+	return xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypeEd25519, Ed25519: account.Ed25519}
+}
@@ -0,0 +1,405 @@
+package txnbuild
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	hProtocol "github.com/paydex-core/paydex-go/protocols/horizon"
+	"github.com/paydex-core/paydex-go/protocols/horizon/base"
+	"github.com/paydex-core/paydex-go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSimulationState(t *testing.T) *LedgerState {
+	state := NewLedgerState()
+	for _, kp := range []*keypair.Full{newKeypair0(), newKeypair1()} {
+		require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+			Data: xdr.LedgerEntryData{
+				Type: xdr.LedgerEntryTypeAccount,
+				Account: &xdr.AccountEntry{
+					AccountId:  xdr.MustAddress(kp.Address()),
+					Balance:    100 * 10000000,
+					SeqNum:     10,
+					Thresholds: xdr.Thresholds{1, 0, 0, 0},
+				},
+			},
+		}))
+	}
+	return state
+}
+
+func newSimulatedTransaction(t *testing.T, source *keypair.Full, sequence int64, ops ...Operation) *Transaction {
+	sourceAccount := NewSimpleAccount(source.Address(), sequence)
+	tx := Transaction{
+		SourceAccount: &sourceAccount,
+		Operations:    ops,
+		Timebounds:    NewInfiniteTimeout(),
+		Network:       network.TestNetworkPassphrase,
+		BaseFee:       MinBaseFee,
+	}
+	require.NoError(t, tx.Build())
+	return &tx
+}
+
+func TestSimulatePayment(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1 := newKeypair0(), newKeypair1()
+
+	tx := newSimulatedTransaction(t, kp0, 10, &Payment{
+		Destination: kp1.Address(),
+		Amount:      "10",
+		Asset:       NativeAsset{},
+	})
+	require.NoError(t, tx.Sign(kp0))
+
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+	assert.Equal(t, []string{"op_success"}, result.ResultCodes.OperationCodes)
+	assert.Equal(t, int64(100), result.FeeCharged)
+	assert.Equal(t, "89.9999900", result.Balances[kp0.Address()][0].Balance)
+	assert.Equal(t, "110.0000000", result.Balances[kp1.Address()][0].Balance)
+
+	// the snapshot is left unchanged
+	account, ok := state.Account(kp0.Address())
+	require.True(t, ok)
+	assert.Equal(t, xdr.Int64(100*10000000), account.Balance)
+	assert.Equal(t, xdr.SequenceNumber(10), account.SeqNum)
+
+	result, err = state.Apply(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+	account, _ = state.Account(kp0.Address())
+	assert.Equal(t, xdr.SequenceNumber(11), account.SeqNum)
+	assert.Equal(t, "89.9999900", state.Balances(kp0.Address())[0].Balance)
+}
+
+func TestSimulateFailedOperations(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	usd := CreditAsset{Code: "USD", Issuer: newKeypair2().Address()}
+
+	tx := newSimulatedTransaction(t, kp0, 10,
+		&Payment{Destination: kp1.Address(), Amount: "1", Asset: NativeAsset{}},
+		&Payment{Destination: kp1.Address(), Amount: "99", Asset: NativeAsset{}},
+		&Payment{Destination: kp1.Address(), Amount: "1", Asset: usd},
+	)
+	require.NoError(t, tx.Sign(kp0))
+
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.False(t, result.Successful())
+	assert.Equal(t, hProtocol.TransactionResultCodes{
+		TransactionCode: "tx_failed",
+		OperationCodes:  []string{"op_success", "op_underfunded", "op_no_trust"},
+	}, result.ResultCodes)
+
+	// only the fee is charged when the transaction fails
+	assert.Equal(t, int64(300), result.FeeCharged)
+	assert.Equal(t, "99.9999700", result.Balances[kp0.Address()][0].Balance)
+	assert.Equal(t, "100.0000000", result.Balances[kp1.Address()][0].Balance)
+}
+
+func TestSimulateRejectedTransactions(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	payment := &Payment{Destination: kp1.Address(), Amount: "1", Asset: NativeAsset{}}
+
+	tx := newSimulatedTransaction(t, kp0, 11, payment)
+	require.NoError(t, tx.Sign(kp0))
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{TransactionCode: "tx_bad_seq"}, result.ResultCodes)
+	assert.Equal(t, int64(0), result.FeeCharged)
+
+	tx = newSimulatedTransaction(t, kp0, 10, payment)
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{TransactionCode: "tx_bad_auth"}, result.ResultCodes)
+
+	require.NoError(t, tx.Sign(kp0, kp1))
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{TransactionCode: "tx_bad_auth_extra"}, result.ResultCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 10, &Payment{
+		Destination:   kp0.Address(),
+		Amount:        "1",
+		Asset:         NativeAsset{},
+		SourceAccount: &SimpleAccount{AccountID: kp1.Address()},
+	})
+	require.NoError(t, tx.Sign(kp0))
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{
+		TransactionCode: "tx_failed",
+		OperationCodes:  []string{"op_bad_auth"},
+	}, result.ResultCodes)
+	assert.Equal(t, int64(0), result.FeeCharged)
+
+	state.SkipSignatureCheck = true
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+}
+
+func TestSimulateTrustlines(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	state.SkipSignatureCheck = true
+	require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(kp2.Address()),
+				Balance:    100 * 10000000,
+				SeqNum:     10,
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			},
+		},
+	}))
+	usd := CreditAsset{Code: "USD", Issuer: kp2.Address()}
+
+	tx := newSimulatedTransaction(t, kp0, 10,
+		&ChangeTrust{Line: usd, Limit: "100"},
+		&Payment{Destination: kp0.Address(), Amount: "50", Asset: usd, SourceAccount: &SimpleAccount{AccountID: kp2.Address()}},
+		&Payment{Destination: kp2.Address(), Amount: "20", Asset: usd},
+	)
+	result, err := state.Apply(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+	assert.Equal(t, []hProtocol.Balance{
+		{
+			Balance:            "30.0000000",
+			Limit:              "100.0000000",
+			BuyingLiabilities:  "0.0000000",
+			SellingLiabilities: "0.0000000",
+			IsAuthorized:       &[]bool{true}[0],
+			Asset:              base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: kp2.Address()},
+		},
+		{
+			Balance:            "99.9999700",
+			BuyingLiabilities:  "0.0000000",
+			SellingLiabilities: "0.0000000",
+			Asset:              base.Asset{Type: "native"},
+		},
+	}, result.Balances[kp0.Address()])
+	account, _ := state.Account(kp0.Address())
+	assert.Equal(t, xdr.Uint32(1), account.NumSubEntries)
+
+	tx = newSimulatedTransaction(t, kp0, 11,
+		&ChangeTrust{Line: usd, Limit: "10"},
+		&ChangeTrust{Line: CreditAsset{Code: "EUR", Issuer: kp2.Address()}, Limit: "10", SourceAccount: &SimpleAccount{AccountID: kp1.Address()}},
+		&Payment{Destination: kp0.Address(), Amount: "1", Asset: NativeAsset{}, SourceAccount: &SimpleAccount{AccountID: kp1.Address()}},
+	)
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_invalid_limit", "op_success", "op_success"}, result.ResultCodes.OperationCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 11,
+		&Payment{Destination: kp0.Address(), Amount: "98.5", Asset: NativeAsset{}, SourceAccount: &SimpleAccount{AccountID: kp1.Address()}},
+	)
+	result, err = state.Apply(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+
+	tx = newSimulatedTransaction(t, kp1, 10,
+		&ChangeTrust{Line: CreditAsset{Code: "EUR", Issuer: kp2.Address()}, Limit: "10"},
+	)
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{
+		TransactionCode: "tx_failed",
+		OperationCodes:  []string{"op_low_reserve"},
+	}, result.ResultCodes)
+}
+
+func TestSimulateAccountsAndData(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1 := newKeypair0(), newKeypair1()
+	state.SkipSignatureCheck = true
+	newAccount := keypair.MustRandom()
+
+	tx := newSimulatedTransaction(t, kp0, 10,
+		&CreateAccount{Destination: newAccount.Address(), Amount: "10"},
+		&ManageData{Name: "name", Value: []byte("value"), SourceAccount: &SimpleAccount{AccountID: newAccount.Address()}},
+		&ManageData{Name: "name", Value: nil, SourceAccount: &SimpleAccount{AccountID: newAccount.Address()}},
+		&AccountMerge{Destination: kp1.Address(), SourceAccount: &SimpleAccount{AccountID: newAccount.Address()}},
+	)
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, hProtocol.TransactionResultCodes{
+		TransactionCode: "tx_failed",
+		OperationCodes:  []string{"op_success", "op_no_source_account", "op_no_source_account", "op_no_source_account"},
+	}, result.ResultCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 10,
+		&CreateAccount{Destination: newAccount.Address(), Amount: "0.5"},
+		&CreateAccount{Destination: kp1.Address(), Amount: "10"},
+		&ManageData{Name: "name", Value: nil},
+		&BumpSequence{BumpTo: 100},
+	)
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_low_reserve", "op_already_exists", "op_data_name_not_found", "op_success"}, result.ResultCodes.OperationCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 10,
+		&CreateAccount{Destination: newAccount.Address(), Amount: "10"},
+		&ManageData{Name: "name", Value: []byte("value")},
+		&AccountMerge{Destination: kp1.Address()},
+	)
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_success", "op_success", "op_has_sub_entries"}, result.ResultCodes.OperationCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 10,
+		&CreateAccount{Destination: newAccount.Address(), Amount: "10"},
+		&AccountMerge{Destination: kp1.Address()},
+	)
+	result, err = state.Apply(tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+	assert.Nil(t, state.Balances(kp0.Address()))
+	assert.Equal(t, "189.9999800", state.Balances(kp1.Address())[0].Balance)
+	assert.Equal(t, "10.0000000", state.Balances(newAccount.Address())[0].Balance)
+}
+
+func TestSimulateAddAccount(t *testing.T) {
+	kp0, kp1, kp2 := newKeypair0(), newKeypair1(), newKeypair2()
+	state := NewLedgerState()
+	notAuthorized := false
+	err := state.AddAccount(hProtocol.Account{
+		AccountID:     kp0.Address(),
+		Sequence:      "10",
+		SubentryCount: 3,
+		Thresholds:    hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+		Balances: []hProtocol.Balance{
+			{
+				Balance:            "5.0000000",
+				Limit:              "10.0000000",
+				BuyingLiabilities:  "0.0000000",
+				SellingLiabilities: "0.0000000",
+				IsAuthorized:       &notAuthorized,
+				Asset:              base.Asset{Type: "credit_alphanum4", Code: "USD", Issuer: kp2.Address()},
+			},
+			{
+				Balance:            "20.0000000",
+				BuyingLiabilities:  "0.0000000",
+				SellingLiabilities: "1.0000000",
+				Asset:              base.Asset{Type: "native"},
+			},
+		},
+		Signers: []hProtocol.Signer{
+			{Key: kp0.Address(), Weight: 1, Type: "ed25519_public_key"},
+			{Key: kp1.Address(), Weight: 2, Type: "ed25519_public_key"},
+		},
+		Data: map[string]string{"name": base64.StdEncoding.EncodeToString([]byte("value"))},
+	})
+	require.NoError(t, err)
+
+	account, ok := state.Account(kp0.Address())
+	require.True(t, ok)
+	assert.Equal(t, xdr.Thresholds{1, 1, 2, 3}, account.Thresholds)
+	assert.Equal(t, map[string]int32{kp0.Address(): 1, kp1.Address(): 2}, account.SignerSummary())
+	assert.Equal(t, xdr.Int64(20*10000000), account.Balance)
+
+	balances := state.Balances(kp0.Address())
+	require.Len(t, balances, 2)
+	assert.Equal(t, "5.0000000", balances[0].Balance)
+	assert.False(t, *balances[0].IsAuthorized)
+	assert.Equal(t, "1.0000000", balances[1].SellingLiabilities)
+
+	// the medium threshold requires the signature of the second signer
+	tx := newSimulatedTransaction(t, kp0, 10, &Payment{Destination: kp0.Address(), Amount: "1", Asset: NativeAsset{}})
+	require.NoError(t, tx.Sign(kp0))
+	result, err := state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_bad_auth"}, result.ResultCodes.OperationCodes)
+
+	state.SkipSignatureCheck = true
+	require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(kp1.Address()), Balance: 10000000},
+		},
+	}))
+	require.NoError(t, state.AddLedgerEntry(xdr.LedgerEntry{
+		Data: xdr.LedgerEntryData{
+			Type:    xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{AccountId: xdr.MustAddress(kp2.Address()), Balance: 10000000},
+		},
+	}))
+	tx = newSimulatedTransaction(t, kp0, 10, &Payment{Destination: kp1.Address(), Amount: "16.5", Asset: NativeAsset{}})
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	// 20 - 0.5 * (2 + 3) - 1 lumens are available, minus the fee
+	assert.Equal(t, []string{"op_underfunded"}, result.ResultCodes.OperationCodes)
+
+	tx = newSimulatedTransaction(t, kp0, 10, &Payment{Destination: kp2.Address(), Amount: "1", Asset: CreditAsset{Code: "USD", Issuer: kp2.Address()}})
+	result, err = state.Simulate(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"op_src_not_authorized"}, result.ResultCodes.OperationCodes)
+}
+
+func TestSimulateUnsupportedOperations(t *testing.T) {
+	state := newSimulationState(t)
+	kp0 := newKeypair0()
+
+	tx := newSimulatedTransaction(t, kp0, 10, &ManageSellOffer{
+		Selling: NativeAsset{},
+		Buying:  CreditAsset{Code: "USD", Issuer: newKeypair2().Address()},
+		Amount:  "10",
+		Price:   "1",
+	})
+	_, err := state.Simulate(tx)
+	assert.EqualError(t, err, "simulating OperationTypeManageSellOffer operations is not supported")
+
+	sourceAccount := NewSimpleAccount(kp0.Address(), 10)
+	_, err = state.Simulate(&Transaction{SourceAccount: &sourceAccount})
+	assert.EqualError(t, err, "transaction must be built before it is simulated")
+}
+
+func TestSimulateMuxedSource(t *testing.T) {
+	state := newSimulationState(t)
+	kp0, kp1 := newKeypair0(), newKeypair1()
+
+	// the muxed account shares the sequence number and balance of kp0
+	account := xdr.MustAddress(kp0.Address())
+	muxed := xdr.MuxedAccount{
+		Type: xdr.CryptoKeyTypeKeyTypeMuxedEd25519,
+		Med25519: &xdr.MuxedAccountMed25519{
+			Id:      42,
+			Ed25519: account.MustEd25519(),
+		},
+	}
+	// transactions can't be built with a muxed source account, but can be
+	// parsed from XDR
+	built := newSimulatedTransaction(t, kp0, 10, &Payment{
+		Destination: kp1.Address(),
+		Amount:      "10",
+		Asset:       NativeAsset{},
+	})
+	xdrTx := built.xdrTransaction
+	xdrTx.SourceAccount = muxed
+	txeB64, err := xdr.MarshalBase64(xdr.TransactionEnvelope{
+		Type: xdr.EnvelopeTypeEnvelopeTypeTx,
+		V1:   &xdr.TransactionV1Envelope{Tx: xdrTx},
+	})
+	require.NoError(t, err)
+	tx, err := TransactionFromXDR(txeB64)
+	require.NoError(t, err)
+	tx.Network = network.TestNetworkPassphrase
+	require.NoError(t, tx.Sign(kp0))
+
+	result, err := state.Apply(&tx)
+	require.NoError(t, err)
+	assert.True(t, result.Successful())
+	assert.Equal(t, []string{"op_success"}, result.ResultCodes.OperationCodes)
+	assert.Equal(t, "89.9999900", state.Balances(kp0.Address())[0].Balance)
+	account0, ok := state.Account(kp0.Address())
+	require.True(t, ok)
+	assert.Equal(t, xdr.SequenceNumber(11), account0.SeqNum)
+}
@@ -16,10 +16,14 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/paydex-core/paydex-go/keypair"
 	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/strkey"
 	"github.com/paydex-core/paydex-go/support/errors"
 	"github.com/paydex-core/paydex-go/xdr"
 )
@@ -95,12 +99,11 @@ func (tx *Transaction) SetDefaultFee() {
 func (tx *Transaction) Build() error {
 	// If transaction envelope has been signed, don't build transaction
 	if tx.xdrEnvelope != nil {
-		if tx.xdrEnvelope.Signatures != nil {
+		if tx.xdrEnvelope.Signatures() != nil {
 			return errors.New("transaction has already been signed, so cannot be rebuilt.")
 		}
 		// clear the existing XDR so we don't append to any existing fields
-		tx.xdrEnvelope = &xdr.TransactionEnvelope{}
-		tx.xdrEnvelope.Tx = xdr.Transaction{}
+		tx.xdrEnvelope = nil
 	}
 
 	// reset tx.xdrTransaction
@@ -162,12 +165,31 @@ func (tx *Transaction) Build() error {
 	tx.SetDefaultFee()
 
 	// Initialise transaction envelope
-	tx.xdrEnvelope = &xdr.TransactionEnvelope{}
-	tx.xdrEnvelope.Tx = tx.xdrTransaction
+	return tx.initEnvelope()
+}
 
+// initEnvelope wraps the transaction in a new, unsigned envelope. Transactions
+// are wrapped in V0 envelopes, which are accepted by every protocol version
+// and have the same hash as the equivalent V1 envelope.
+func (tx *Transaction) initEnvelope() error {
+	envelope, err := xdr.NewTransactionV0Envelope(tx.xdrTransaction, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to build transaction envelope")
+	}
+	tx.xdrEnvelope = &envelope
 	return nil
 }
 
+// appendSignatures adds signatures to the envelope of the transaction.
+func (tx *Transaction) appendSignatures(signatures ...xdr.DecoratedSignature) {
+	switch tx.xdrEnvelope.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
+		tx.xdrEnvelope.V0.Signatures = append(tx.xdrEnvelope.V0.Signatures, signatures...)
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		tx.xdrEnvelope.V1.Signatures = append(tx.xdrEnvelope.V1.Signatures, signatures...)
+	}
+}
+
 // Sign for Transaction signs a previously built transaction. A signed transaction may be
 // submitted to the network.
 func (tx *Transaction) Sign(kps ...*keypair.Full) error {
@@ -187,7 +209,7 @@ func (tx *Transaction) Sign(kps ...*keypair.Full) error {
 			return errors.Wrap(err, "failed to sign transaction")
 		}
 		// Append the signature to the envelope
-		tx.xdrEnvelope.Signatures = append(tx.xdrEnvelope.Signatures, sig)
+		tx.appendSignatures(sig)
 	}
 
 	return nil
@@ -218,6 +240,26 @@ func (tx *Transaction) BuildSignEncode(keypairs ...*keypair.Full) (string, error
 // "timebound" is the time duration the transaction should be valid for, and must be greater than 1s (300s is recommended).
 // More details on SEP 10: https://github.com/paydex-core/paydex-protocol/blob/master/ecosystem/sep-0010.md
 func BuildChallengeTx(serverSignerSecret, clientAccountID, anchorName, network string, timebound time.Duration) (string, error) {
+	return BuildChallengeTxWithOptions(serverSignerSecret, clientAccountID, network, timebound, ChallengeTxOptions{
+		HomeDomain: anchorName,
+	})
+}
+
+// ChallengeTxOptions contains the optional fields of a SEP 10 challenge.
+type ChallengeTxOptions struct {
+	// HomeDomain is the home domain of the server, which is used as the name
+	// of the first manage_data operation ("<home domain> auth").
+	HomeDomain string
+	// WebAuthDomain is the domain of the server's web authentication service.
+	// If it is not empty a second manage_data operation named
+	// "web_auth_domain", with the server account as its source account, is
+	// added to the challenge.
+	WebAuthDomain string
+}
+
+// BuildChallengeTxWithOptions is like BuildChallengeTx but accepts the home
+// domain and the web auth domain of the challenge as options.
+func BuildChallengeTxWithOptions(serverSignerSecret, clientAccountID, network string, timebound time.Duration, options ChallengeTxOptions) (string, error) {
 
 	if timebound < time.Second {
 		return "", errors.New("provided timebound must be at least 1s (300s is recommended)")
@@ -257,20 +299,29 @@ func BuildChallengeTx(serverSignerSecret, clientAccountID, anchorName, network s
 	maxTime := currentTime.Add(timebound)
 	txTimebound := NewTimebounds(currentTime.Unix(), maxTime.Unix())
 
+	operations := []Operation{
+		&ManageData{
+			SourceAccount: &ca,
+			Name:          options.HomeDomain + " auth",
+			Value:         []byte(randomNonceToString),
+		},
+	}
+	if options.WebAuthDomain != "" {
+		operations = append(operations, &ManageData{
+			SourceAccount: &SimpleAccount{AccountID: serverKP.Address()},
+			Name:          "web_auth_domain",
+			Value:         []byte(options.WebAuthDomain),
+		})
+	}
+
 	// Create a SEP 10 compatible response. See
 	// https://github.com/paydex-core/paydex-protocol/blob/master/ecosystem/sep-0010.md#response
 	tx := Transaction{
 		SourceAccount: &sa,
-		Operations: []Operation{
-			&ManageData{
-				SourceAccount: &ca,
-				Name:          anchorName + " auth",
-				Value:         []byte(randomNonceToString),
-			},
-		},
-		Timebounds: txTimebound,
-		Network:    network,
-		BaseFee:    uint32(100),
+		Operations:    operations,
+		Timebounds:    txTimebound,
+		Network:       network,
+		BaseFee:       uint32(100),
 	}
 
 	txeB64, err := tx.BuildSignEncode(serverKP.(*keypair.Full))
@@ -329,8 +380,10 @@ func (tx *Transaction) TransactionFee() int {
 // See description here: https://www.paydex.org/developers/guides/concepts/multi-sig.html#hashx.
 func (tx *Transaction) SignHashX(preimage []byte) error {
 	if tx.xdrEnvelope == nil {
-		tx.xdrEnvelope = &xdr.TransactionEnvelope{}
-		tx.xdrEnvelope.Tx = tx.xdrTransaction
+		err := tx.initEnvelope()
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(preimage) > xdr.Signature(preimage).XDRMaxSize() {
@@ -347,12 +400,13 @@ func (tx *Transaction) SignHashX(preimage []byte) error {
 		Signature: xdr.Signature(preimage),
 	}
 
-	tx.xdrEnvelope.Signatures = append(tx.xdrEnvelope.Signatures, sig)
+	tx.appendSignatures(sig)
 
 	return nil
 }
 
 // TransactionFromXDR parses the supplied transaction envelope in base64 XDR and returns a Transaction object.
+// Fee bump transaction envelopes must be parsed with FeeBumpTransactionFromXDR.
 func TransactionFromXDR(txeB64 string) (Transaction, error) {
 	var xdrEnv xdr.TransactionEnvelope
 	err := xdr.SafeUnmarshalBase64(txeB64, &xdrEnv)
@@ -360,29 +414,41 @@ func TransactionFromXDR(txeB64 string) (Transaction, error) {
 		return Transaction{}, errors.Wrap(err, "unable to unmarshal transaction envelope")
 	}
 
+	if xdrEnv.IsFeeBump() {
+		return Transaction{}, errors.New("transaction envelope is a fee bump transaction, use FeeBumpTransactionFromXDR")
+	}
+
+	return transactionFromEnvelope(xdrEnv)
+}
+
+// transactionFromEnvelope returns a Transaction object for the transaction
+// wrapped by a V0 or V1 envelope.
+func transactionFromEnvelope(xdrEnv xdr.TransactionEnvelope) (Transaction, error) {
+	var err error
 	var newTx Transaction
-	newTx.xdrTransaction = xdrEnv.Tx
+	newTx.xdrTransaction = xdrEnv.Transaction()
 	newTx.xdrEnvelope = &xdrEnv
 
-	if len(xdrEnv.Tx.Operations) > 0 {
-		newTx.BaseFee = uint32(xdrEnv.Tx.Fee) / uint32(len(xdrEnv.Tx.Operations))
+	xdrTx := newTx.xdrTransaction
+	if len(xdrTx.Operations) > 0 {
+		newTx.BaseFee = uint32(xdrTx.Fee) / uint32(len(xdrTx.Operations))
 	}
 
 	newTx.SourceAccount = &SimpleAccount{
-		AccountID: xdrEnv.Tx.SourceAccount.Address(),
-		Sequence:  int64(xdrEnv.Tx.SeqNum),
+		AccountID: xdrTx.SourceAccount.Address(),
+		Sequence:  int64(xdrTx.SeqNum),
 	}
 
-	if xdrEnv.Tx.TimeBounds != nil {
-		newTx.Timebounds = NewTimebounds(int64(xdrEnv.Tx.TimeBounds.MinTime), int64(xdrEnv.Tx.TimeBounds.MaxTime))
+	if xdrTx.TimeBounds != nil {
+		newTx.Timebounds = NewTimebounds(int64(xdrTx.TimeBounds.MinTime), int64(xdrTx.TimeBounds.MaxTime))
 	}
 
-	newTx.Memo, err = memoFromXDR(xdrEnv.Tx.Memo)
+	newTx.Memo, err = memoFromXDR(xdrTx.Memo)
 	if err != nil {
 		return Transaction{}, errors.Wrap(err, "unable to parse memo")
 	}
 
-	for _, op := range xdrEnv.Tx.Operations {
+	for _, op := range xdrTx.Operations {
 		newOp, err := operationFromXDR(op)
 		if err != nil {
 			return Transaction{}, err
@@ -401,11 +467,11 @@ func (tx *Transaction) SignWithKeyString(keys ...string) error {
 	for _, k := range keys {
 		kp, err := keypair.Parse(k)
 		if err != nil {
-			return errors.Wrapf(err, "provided string %s is not a valid Paydex key", k)
+			return errors.Wrap(err, "provided string is not a valid Paydex key")
 		}
 		kpf, ok := kp.(*keypair.Full)
 		if !ok {
-			return errors.New("provided string %s is not a valid Paydex secret key")
+			return errors.New("provided string is not a valid Paydex secret key")
 		}
 		signers = append(signers, kpf)
 	}
@@ -413,77 +479,280 @@ func (tx *Transaction) SignWithKeyString(keys ...string) error {
 	return tx.Sign(signers...)
 }
 
-// VerifyChallengeTx is a factory method that verifies a SEP 10 challenge transaction,
-// for use in web authentication. It can be used by a server to verify that the challenge
-// has been signed by the client.
+// ReadChallengeTx reads a SEP 10 challenge transaction and returns the decoded
+// transaction and the client account ID contained within.
+//
+// It also verifies that the transaction has been signed by the server.
+//
+// It does not verify that the transaction has been signed by the client or
+// that any signatures other than the server's on the transaction are valid. Use
+// one of the following functions to completely verify the transaction:
+// - VerifyChallengeTxThreshold
+// - VerifyChallengeTxSigners
+//
 // More details on SEP 10: https://github.com/paydex-core/paydex-protocol/blob/master/ecosystem/sep-0010.md
-func VerifyChallengeTx(challengeTx, serverAccountID, network string) (bool, error) {
-	tx, err := TransactionFromXDR(challengeTx)
+func ReadChallengeTx(challengeTx, serverAccountID, network string) (tx Transaction, clientAccountID string, err error) {
+	tx, err = TransactionFromXDR(challengeTx)
 	if err != nil {
-		return false, err
+		return tx, clientAccountID, err
 	}
 	tx.Network = network
 
 	// verify transaction source
 	if tx.SourceAccount == nil {
-		return false, errors.New("transaction requires a source account")
+		return tx, clientAccountID, errors.New("transaction requires a source account")
 	}
 	if tx.SourceAccount.GetAccountID() != serverAccountID {
-		return false, errors.New("transaction source account is not equal to server's account")
+		return tx, clientAccountID, errors.New("transaction source account is not equal to server's account")
 	}
 
 	//verify sequence number
 	txSourceAccount, ok := tx.SourceAccount.(*SimpleAccount)
 	if !ok {
-		return false, errors.New("source account is not of type SimpleAccount unable to verify sequence number")
+		return tx, clientAccountID, errors.New("source account is not of type SimpleAccount unable to verify sequence number")
 	}
 	if txSourceAccount.Sequence != 0 {
-		return false, errors.New("transaction sequence number must be 0")
+		return tx, clientAccountID, errors.New("transaction sequence number must be 0")
 	}
 
 	// verify timebounds
 	if tx.Timebounds.MaxTime == TimeoutInfinite {
-		return false, errors.New("transaction requires non-infinite timebounds")
+		return tx, clientAccountID, errors.New("transaction requires non-infinite timebounds")
 	}
 	currentTime := time.Now().UTC().Unix()
 	if currentTime < tx.Timebounds.MinTime || currentTime > tx.Timebounds.MaxTime {
-		return false, errors.Errorf("transaction is not within range of the specified timebounds (currentTime=%d, MinTime=%d, MaxTime=%d)",
+		return tx, clientAccountID, errors.Errorf("transaction is not within range of the specified timebounds (currentTime=%d, MinTime=%d, MaxTime=%d)",
 			currentTime, tx.Timebounds.MinTime, tx.Timebounds.MaxTime)
 	}
 
 	// verify operation
-	if len(tx.Operations) != 1 {
-		return false, errors.New("transaction requires a single manage_data operation")
+	if len(tx.Operations) == 0 {
+		return tx, clientAccountID, errors.New("transaction requires at least one manage_data operation")
 	}
 	op, ok := tx.Operations[0].(*ManageData)
 	if !ok {
-		return false, errors.New("operation type should be manage_data")
+		return tx, clientAccountID, errors.New("operation type should be manage_data")
 	}
 	if op.SourceAccount == nil {
-		return false, errors.New("operation should have a source account")
+		return tx, clientAccountID, errors.New("operation should have a source account")
 	}
+	clientAccountID = op.SourceAccount.GetAccountID()
 
 	// verify manage data value
 	nonceB64 := string(op.Value)
 	if len(nonceB64) != 64 {
-		return false, errors.New("random nonce encoded as base64 should be 64 bytes long")
+		return tx, clientAccountID, errors.New("random nonce encoded as base64 should be 64 bytes long")
 	}
 	nonceBytes, err := base64.StdEncoding.DecodeString(nonceB64)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to decode random nonce provided in manage_data operation")
+		return tx, clientAccountID, errors.Wrap(err, "failed to decode random nonce provided in manage_data operation")
 	}
 	if len(nonceBytes) != 48 {
-		return false, errors.New("random nonce before encoding as base64 should be 48 bytes long")
+		return tx, clientAccountID, errors.New("random nonce before encoding as base64 should be 48 bytes long")
+	}
+
+	// verify subsequent operations are manage data ops from the server, such
+	// as the web_auth_domain operation
+	for _, op := range tx.Operations[1:] {
+		op, ok := op.(*ManageData)
+		if !ok {
+			return tx, clientAccountID, errors.New("operation type should be manage_data")
+		}
+		if op.SourceAccount == nil || op.SourceAccount.GetAccountID() != serverAccountID {
+			return tx, clientAccountID, errors.New("subsequent operations are unrecognized")
+		}
+	}
+
+	// verify signature from server signing key
+	_, err = verifyTxSignature(tx, serverAccountID)
+	if err != nil {
+		return tx, clientAccountID, err
+	}
+
+	return tx, clientAccountID, nil
+}
+
+// SignerSummary is a map of signers to their weights.
+type SignerSummary map[string]int32
+
+// VerifyChallengeTxThreshold verifies that for a SEP 10 challenge transaction
+// all signatures on the transaction are accounted for and that the signatures
+// meet a threshold on an account. A transaction is verified if it is signed by
+// the server account, and all other signatures match a signer that has been
+// provided as an argument, and those signatures meet a threshold on the
+// account.
+//
+// Signers that are not prefixed as an address/account ID strkey (G...) will be
+// ignored.
+//
+// Errors will be raised if:
+//   - The transaction is invalid according to ReadChallengeTx.
+//   - No client signatures are found on the transaction.
+//   - One or more signatures in the transaction are not identifiable as the
+//     server account or one of the signers provided in the arguments.
+//   - The signatures are all valid but do not meet the threshold.
+func VerifyChallengeTxThreshold(challengeTx, serverAccountID, network string, threshold Threshold, signerSummary SignerSummary) (signersFound []string, err error) {
+	signers := make([]string, 0, len(signerSummary))
+	for s := range signerSummary {
+		signers = append(signers, s)
+	}
+	sort.Strings(signers)
+
+	signersFound, err = VerifyChallengeTxSigners(challengeTx, serverAccountID, network, signers...)
+	if err != nil {
+		return nil, err
+	}
+
+	weight := int32(0)
+	for _, s := range signersFound {
+		weight += signerSummary[s]
+	}
+
+	if weight < int32(threshold) {
+		return nil, errors.Errorf("signers with weight %d do not meet threshold %d", weight, threshold)
+	}
+
+	return signersFound, nil
+}
+
+// VerifyChallengeTxSigners verifies that for a SEP 10 challenge transaction
+// all signatures on the transaction are accounted for. A transaction is
+// verified if it is signed by the server account, and all other signatures
+// match a signer that has been provided as an argument. Additional signers can
+// be provided that do not have a signature, but all signatures must be matched
+// to a signer for verification to succeed. If verification succeeds a list of
+// signers that were found is returned, excluding the server account ID.
+//
+// Signers that are not prefixed as an address/account ID strkey (G...) will be
+// ignored.
+//
+// Errors will be raised if:
+//   - The transaction is invalid according to ReadChallengeTx.
+//   - No client signatures are found on the transaction.
+//   - One or more signatures in the transaction are not identifiable as the
+//     server account or one of the signers provided in the arguments, including
+//     duplicate signatures.
+func VerifyChallengeTxSigners(challengeTx, serverAccountID, network string, signers ...string) ([]string, error) {
+	// Read the transaction which validates its structure.
+	tx, _, err := ReadChallengeTx(challengeTx, serverAccountID, network)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deduplicate the client signers and ensure the server is not included
+	// anywhere we check or output the list of signers.
+	clientSigners := []string{}
+	clientSignersSeen := map[string]struct{}{}
+	for _, signer := range signers {
+		// Ignore the server signer if it is in the signers list. It's
+		// important when verifying signers of a challenge transaction that we
+		// only verify and return client signers. If an account has the server
+		// as a signer the server should not play a part in the authentication
+		// of the client.
+		if signer == serverAccountID {
+			continue
+		}
+		// Ignore duplicate signers.
+		if _, seen := clientSignersSeen[signer]; seen {
+			continue
+		}
+		// Ignore non-G... account/address signers.
+		if !strkey.IsValidEd25519PublicKey(signer) {
+			continue
+		}
+		clientSigners = append(clientSigners, signer)
+		clientSignersSeen[signer] = struct{}{}
+	}
+
+	// Don't continue if none of the signers provided are in the final list.
+	if len(clientSigners) == 0 {
+		return nil, errors.New("no verifiable signers provided, at least one G... address must be provided")
+	}
+
+	// Verify all the transaction's signers (server and client) in one
+	// hit. We do this in one hit here even though the server signature was
+	// checked in the ReadChallengeTx to ensure that every signature and signer
+	// are consumed only once on the transaction.
+	allSigners := append([]string{serverAccountID}, clientSigners...)
+	allSignersFound, err := verifyTxSignatures(tx, allSigners...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Confirm the server is in the list of signers found and remove it.
+	serverSignerFound := false
+	signersFound := make([]string, 0, len(allSignersFound)-1)
+	for _, signer := range allSignersFound {
+		if signer == serverAccountID {
+			serverSignerFound = true
+			continue
+		}
+		signersFound = append(signersFound, signer)
+	}
+
+	// Confirm we matched a signature to the server signer.
+	if !serverSignerFound {
+		return nil, errors.Errorf("transaction not signed by %s", serverAccountID)
+	}
+
+	// Confirm we matched signatures to the client signers.
+	if len(signersFound) == 0 {
+		return nil, errors.Errorf("transaction not signed by %s", strings.Join(clientSigners, ", "))
+	}
+
+	// Confirm all signatures were consumed by a signer.
+	if len(allSignersFound) != len(tx.xdrEnvelope.Signatures()) {
+		return nil, errors.New("transaction has unrecognized signatures")
+	}
+
+	return signersFound, nil
+}
+
+// VerifyChallengeTx is a factory method that verifies a SEP 10 challenge transaction,
+// for use in web authentication. It can be used by a server to verify that the challenge
+// has been signed by the client.
+// More details on SEP 10: https://github.com/paydex-core/paydex-protocol/blob/master/ecosystem/sep-0010.md
+//
+// Deprecated: VerifyChallengeTx only verifies the signature of the client
+// account's master key and ignores any other signatures. Use
+// VerifyChallengeTxThreshold or VerifyChallengeTxSigners, which support
+// accounts with multiple signers.
+func VerifyChallengeTx(challengeTx, serverAccountID, network string) (bool, error) {
+	tx, clientAccountID, err := ReadChallengeTx(challengeTx, serverAccountID, network)
+	if err != nil {
+		return false, err
 	}
 
 	// verify signature from operation source
-	ok, err = verifyTxSignature(tx, op.SourceAccount.GetAccountID())
+	return verifyTxSignature(tx, clientAccountID)
+}
+
+// verifyTxSignatures checks if a transaction has been signed by one or more of
+// the provided Paydex accounts, and returns the accounts whose signatures were
+// found. Each signature is matched to at most one account.
+func verifyTxSignatures(tx Transaction, signers ...string) ([]string, error) {
+	if tx.xdrEnvelope == nil {
+		return nil, errors.New("transaction has no signatures")
+	}
+	txHash, err := tx.Hash()
 	if err != nil {
-		return ok, err
+		return nil, err
 	}
 
-	// verify signature from server signing key
-	return verifyTxSignature(tx, serverAccountID)
+	accountSigners := make([]xdr.Signer, 0, len(signers))
+	for _, signer := range signers {
+		var key xdr.SignerKey
+		if err := key.SetAddress(signer); err != nil {
+			return nil, errors.Wrapf(err, "invalid signer %s", signer)
+		}
+		accountSigners = append(accountSigners, xdr.Signer{Key: key, Weight: 1})
+	}
+
+	signersFound := []string{}
+	matchSignatures(txHash, tx.xdrEnvelope.Signatures(), accountSigners, math.MaxUint32, func(_ int, signer xdr.Signer) {
+		signersFound = append(signersFound, signer.Key.Address())
+	})
+	return signersFound, nil
 }
 
 // verifyTxSignature checks if a transaction has been signed by the provided Paydex account.
@@ -503,7 +772,7 @@ func verifyTxSignature(tx Transaction, accountID string) (bool, error) {
 	if tx.xdrEnvelope == nil {
 		return signerFound, errors.New("transaction has no signatures")
 	}
-	for _, s := range tx.xdrEnvelope.Signatures {
+	for _, s := range tx.xdrEnvelope.Signatures() {
 		e := kp.Verify(txHash[:], s.Signature)
 		if e == nil {
 			signerFound = true
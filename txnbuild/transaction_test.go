@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/paydex-core/paydex-go/keypair"
 	"github.com/paydex-core/paydex-go/network"
 	"github.com/paydex-core/paydex-go/strkey"
 	"github.com/paydex-core/paydex-go/xdr"
@@ -747,12 +748,12 @@ func TestBuildChallengeTx(t *testing.T) {
 	var txXDR xdr.TransactionEnvelope
 	err = xdr.SafeUnmarshalBase64(txeBase64, &txXDR)
 	assert.NoError(t, err)
-	assert.Equal(t, xdr.SequenceNumber(0), txXDR.Tx.SeqNum, "sequence number should be 0")
-	assert.Equal(t, xdr.Uint32(100), txXDR.Tx.Fee, "Fee should be 100")
-	assert.Equal(t, 1, len(txXDR.Tx.Operations), "number operations should be 1")
-	timeDiff := txXDR.Tx.TimeBounds.MaxTime - txXDR.Tx.TimeBounds.MinTime
+	assert.Equal(t, xdr.SequenceNumber(0), txXDR.V0.Tx.SeqNum, "sequence number should be 0")
+	assert.Equal(t, xdr.Uint32(100), txXDR.V0.Tx.Fee, "Fee should be 100")
+	assert.Equal(t, 1, len(txXDR.V0.Tx.Operations), "number operations should be 1")
+	timeDiff := txXDR.V0.Tx.TimeBounds.MaxTime - txXDR.V0.Tx.TimeBounds.MinTime
 	assert.Equal(t, int64(60), int64(timeDiff), "time difference should be 300 seconds")
-	op := txXDR.Tx.Operations[0]
+	op := txXDR.V0.Tx.Operations[0]
 	assert.Equal(t, xdr.OperationTypeManageData, op.Body.Type, "operation type should be manage data")
 	assert.Equal(t, xdr.String64("SDF auth"), op.Body.ManageDataOp.DataName, "DataName should be 'SDF auth'")
 	assert.Equal(t, 64, len(*op.Body.ManageDataOp.DataValue), "DataValue should be 64 bytes")
@@ -763,13 +764,13 @@ func TestBuildChallengeTx(t *testing.T) {
 	var txXDR1 xdr.TransactionEnvelope
 	err = xdr.SafeUnmarshalBase64(txeBase64, &txXDR1)
 	assert.NoError(t, err)
-	assert.Equal(t, xdr.SequenceNumber(0), txXDR1.Tx.SeqNum, "sequence number should be 0")
-	assert.Equal(t, xdr.Uint32(100), txXDR1.Tx.Fee, "Fee should be 100")
-	assert.Equal(t, 1, len(txXDR1.Tx.Operations), "number operations should be 1")
+	assert.Equal(t, xdr.SequenceNumber(0), txXDR1.V0.Tx.SeqNum, "sequence number should be 0")
+	assert.Equal(t, xdr.Uint32(100), txXDR1.V0.Tx.Fee, "Fee should be 100")
+	assert.Equal(t, 1, len(txXDR1.V0.Tx.Operations), "number operations should be 1")
 
-	timeDiff = txXDR1.Tx.TimeBounds.MaxTime - txXDR1.Tx.TimeBounds.MinTime
+	timeDiff = txXDR1.V0.Tx.TimeBounds.MaxTime - txXDR1.V0.Tx.TimeBounds.MinTime
 	assert.Equal(t, int64(300), int64(timeDiff), "time difference should be 300 seconds")
-	op1 := txXDR1.Tx.Operations[0]
+	op1 := txXDR1.V0.Tx.Operations[0]
 	assert.Equal(t, xdr.OperationTypeManageData, op1.Body.Type, "operation type should be manage data")
 	assert.Equal(t, xdr.String64("SDF1 auth"), op1.Body.ManageDataOp.DataName, "DataName should be 'SDF1 auth'")
 	assert.Equal(t, 64, len(*op1.Body.ManageDataOp.DataValue), "DataValue should be 64 bytes")
@@ -1453,3 +1454,145 @@ func TestVerifyChallengeTxRandomNonce(t *testing.T) {
 	}
 	assert.Equal(t, false, isValid, "challenge should be invalid")
 }
+
+// signChallenge signs a challenge built by BuildChallengeTx with the given
+// keypairs and returns it base64 encoded.
+func signChallenge(t *testing.T, challenge string, kps ...*keypair.Full) string {
+	tx, err := TransactionFromXDR(challenge)
+	require.NoError(t, err)
+	tx.Network = network.TestNetworkPassphrase
+	require.NoError(t, tx.Sign(kps...))
+	challenge, err = tx.Base64()
+	require.NoError(t, err)
+	return challenge
+}
+
+func TestBuildChallengeTxWithOptions(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP := newKeypair1()
+
+	challenge, err := BuildChallengeTxWithOptions(serverKP.Seed(), clientKP.Address(), network.TestNetworkPassphrase, time.Minute, ChallengeTxOptions{
+		HomeDomain:    "testanchor.paydex.org",
+		WebAuthDomain: "auth.testanchor.paydex.org",
+	})
+	require.NoError(t, err)
+
+	tx, clientAccountID, err := ReadChallengeTx(challenge, serverKP.Address(), network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, clientKP.Address(), clientAccountID)
+	require.Len(t, tx.Operations, 2)
+
+	op := tx.Operations[0].(*ManageData)
+	assert.Equal(t, "testanchor.paydex.org auth", op.Name)
+	assert.Equal(t, clientKP.Address(), op.SourceAccount.GetAccountID())
+
+	op = tx.Operations[1].(*ManageData)
+	assert.Equal(t, "web_auth_domain", op.Name)
+	assert.Equal(t, []byte("auth.testanchor.paydex.org"), op.Value)
+	assert.Equal(t, serverKP.Address(), op.SourceAccount.GetAccountID())
+}
+
+func TestReadChallengeTx(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP := newKeypair1()
+
+	challenge, err := BuildChallengeTx(serverKP.Seed(), clientKP.Address(), "sdf", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+
+	// the client signature is not required to read the challenge
+	tx, clientAccountID, err := ReadChallengeTx(challenge, serverKP.Address(), network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, clientKP.Address(), clientAccountID)
+	assert.Len(t, tx.Operations, 1)
+
+	// the server signature is required
+	unsigned, err := BuildChallengeTx(clientKP.Seed(), clientKP.Address(), "sdf", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+	_, _, err = ReadChallengeTx(unsigned, serverKP.Address(), network.TestNetworkPassphrase)
+	assert.EqualError(t, err, "transaction source account is not equal to server's account")
+
+	// subsequent operations must come from the server
+	txSource := NewSimpleAccount(serverKP.Address(), -1)
+	opSource := NewSimpleAccount(clientKP.Address(), 0)
+	randomNonce, err := generateRandomNonce(48)
+	require.NoError(t, err)
+	newTx := Transaction{
+		SourceAccount: &txSource,
+		Operations: []Operation{
+			&ManageData{
+				SourceAccount: &opSource,
+				Name:          "sdf auth",
+				Value:         []byte(base64.StdEncoding.EncodeToString(randomNonce)),
+			},
+			&ManageData{
+				SourceAccount: &opSource,
+				Name:          "key",
+				Value:         []byte("value"),
+			},
+		},
+		Timebounds: NewTimeout(300),
+		Network:    network.TestNetworkPassphrase,
+		BaseFee:    uint32(100),
+	}
+	challenge, err = newTx.BuildSignEncode(serverKP)
+	require.NoError(t, err)
+	_, _, err = ReadChallengeTx(challenge, serverKP.Address(), network.TestNetworkPassphrase)
+	assert.EqualError(t, err, "subsequent operations are unrecognized")
+}
+
+func TestVerifyChallengeTxSigners(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP := newKeypair1()
+	signerKP1 := keypair.MustRandom()
+	signerKP2 := keypair.MustRandom()
+
+	challenge, err := BuildChallengeTx(serverKP.Seed(), clientKP.Address(), "sdf", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+
+	signed := signChallenge(t, challenge, signerKP1, signerKP2)
+	signersFound, err := VerifyChallengeTxSigners(signed, serverKP.Address(), network.TestNetworkPassphrase,
+		clientKP.Address(), signerKP1.Address(), signerKP2.Address(), signerKP1.Address(), serverKP.Address(), "TAQCSRX2RIDJNHFIFHWD63X7D7D6TRT5Y2S6E3TEMXTG5W3OECHZ2OG4")
+	require.NoError(t, err)
+	assert.Equal(t, []string{signerKP1.Address(), signerKP2.Address()}, signersFound)
+
+	// no client signatures
+	_, err = VerifyChallengeTxSigners(challenge, serverKP.Address(), network.TestNetworkPassphrase, clientKP.Address())
+	assert.EqualError(t, err, "transaction not signed by "+clientKP.Address())
+
+	// a signature from a key which is not a signer
+	_, err = VerifyChallengeTxSigners(signed, serverKP.Address(), network.TestNetworkPassphrase, signerKP1.Address())
+	assert.EqualError(t, err, "transaction has unrecognized signatures")
+
+	// duplicate signatures
+	duplicated := signChallenge(t, challenge, signerKP1, signerKP1)
+	_, err = VerifyChallengeTxSigners(duplicated, serverKP.Address(), network.TestNetworkPassphrase, signerKP1.Address())
+	assert.EqualError(t, err, "transaction has unrecognized signatures")
+
+	// no verifiable signers
+	_, err = VerifyChallengeTxSigners(signed, serverKP.Address(), network.TestNetworkPassphrase, serverKP.Address(), "TAQCSRX2RIDJNHFIFHWD63X7D7D6TRT5Y2S6E3TEMXTG5W3OECHZ2OG4")
+	assert.EqualError(t, err, "no verifiable signers provided, at least one G... address must be provided")
+}
+
+func TestVerifyChallengeTxThreshold(t *testing.T) {
+	serverKP := newKeypair0()
+	clientKP := newKeypair1()
+	signerKP1 := keypair.MustRandom()
+	signerKP2 := keypair.MustRandom()
+	signerSummary := SignerSummary{
+		clientKP.Address():  0,
+		signerKP1.Address(): 1,
+		signerKP2.Address(): 2,
+	}
+
+	challenge, err := BuildChallengeTx(serverKP.Seed(), clientKP.Address(), "sdf", network.TestNetworkPassphrase, time.Minute)
+	require.NoError(t, err)
+
+	signed := signChallenge(t, challenge, signerKP1, signerKP2)
+	signersFound, err := VerifyChallengeTxThreshold(signed, serverKP.Address(), network.TestNetworkPassphrase, Threshold(3), signerSummary)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{signerKP1.Address(), signerKP2.Address()}, signersFound)
+
+	signed = signChallenge(t, challenge, clientKP, signerKP2)
+	_, err = VerifyChallengeTxThreshold(signed, serverKP.Address(), network.TestNetworkPassphrase, Threshold(3), signerSummary)
+	assert.EqualError(t, err, "signers with weight 2 do not meet threshold 3")
+}
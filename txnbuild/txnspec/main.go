@@ -0,0 +1,226 @@
+// Package txnspec describes Paydex transactions as declarative JSON or YAML
+// documents, so that they can be written by hand, built without writing a Go
+// program, and reviewed in pull requests.
+//
+// A spec looks like this, in YAML:
+//
+//	source_account: GAY5...
+//	sequence: 3556091187167235
+//	base_fee: 100
+//	timeout: 300
+//	memo:
+//	  type: text
+//	  value: issue USD
+//	operations:
+//	- type: change_trust
+//	  source_account: GBPU...
+//	  line: USD:GAY5...
+//	- type: payment
+//	  destination: GBPU...
+//	  asset: USD:GAY5...
+//	  amount: "1000"
+//
+// Assets are written as "native" or "CODE:ISSUER", as defined by SEP-11. The
+// sequence is the sequence number of the transaction itself, that is the
+// sequence number of the source account plus one.
+package txnspec
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"gopkg.in/yaml.v2"
+)
+
+// Transaction is the spec of a transaction.
+type Transaction struct {
+	SourceAccount string `json:"source_account" yaml:"source_account"`
+	// Sequence is the sequence number of the transaction. It may be left
+	// out of hand written specs, and set with the next sequence number of
+	// the source account before the transaction is built.
+	Sequence int64 `json:"sequence,omitempty" yaml:"sequence,omitempty"`
+	// BaseFee is the fee per operation, in stroops. txnbuild.MinBaseFee is
+	// used when it is 0.
+	BaseFee uint32 `json:"base_fee,omitempty" yaml:"base_fee,omitempty"`
+	Memo    *Memo  `json:"memo,omitempty" yaml:"memo,omitempty"`
+	// TimeBounds and Timeout are mutually exclusive. Timeout is the number
+	// of seconds, from the time the transaction is built, after which the
+	// transaction expires.
+	TimeBounds        *TimeBounds `json:"time_bounds,omitempty" yaml:"time_bounds,omitempty"`
+	Timeout           int64       `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	NetworkPassphrase string      `json:"network_passphrase,omitempty" yaml:"network_passphrase,omitempty"`
+	Operations        []Operation `json:"operations" yaml:"operations"`
+}
+
+// Memo is the spec of a transaction memo. Type is one of "text", "id",
+// "hash" or "return"; hashes are hex encoded.
+type Memo struct {
+	Type  string `json:"type" yaml:"type"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// TimeBounds is the spec of the time bounds of a transaction, in unix
+// seconds. A MaxTime of 0 means that the transaction never expires.
+type TimeBounds struct {
+	MinTime int64 `json:"min_time" yaml:"min_time"`
+	MaxTime int64 `json:"max_time" yaml:"max_time"`
+}
+
+// Parse parses a JSON or YAML transaction spec. Documents starting with "{"
+// are parsed as JSON. Unknown fields are rejected, so that typos in
+// hand written specs are not silently ignored.
+func Parse(data []byte) (Transaction, error) {
+	var spec Transaction
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&spec); err != nil {
+			return Transaction{}, errors.Wrap(err, "parsing JSON spec")
+		}
+		return spec, nil
+	}
+
+	if err := yaml.UnmarshalStrict(data, &spec); err != nil {
+		return Transaction{}, errors.Wrap(err, "parsing YAML spec")
+	}
+	return spec, nil
+}
+
+// Load reads and parses the transaction spec in the file at path.
+func Load(path string) (Transaction, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "reading spec")
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, path)
+	}
+	return spec, nil
+}
+
+// Marshal encodes the spec as YAML, unless path has a ".json" extension, in
+// which case it is encoded as indented JSON.
+func (t Transaction) Marshal(path string) ([]byte, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		data, err := json.MarshalIndent(t, "", "  ")
+		if err != nil {
+			return nil, errors.Wrap(err, "encoding JSON spec")
+		}
+		return append(data, '\n'), nil
+	}
+
+	data, err := yaml.Marshal(t)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding YAML spec")
+	}
+	return data, nil
+}
+
+// Transaction returns the unbuilt transaction described by the spec. The
+// sequence number of the source account is set so that the built
+// transaction has the sequence number of the spec.
+func (t Transaction) Transaction() (txnbuild.Transaction, error) {
+	if t.SourceAccount == "" {
+		return txnbuild.Transaction{}, errors.New("source_account is required")
+	}
+	if t.Sequence <= 0 {
+		return txnbuild.Transaction{}, errors.New("sequence is required")
+	}
+
+	tx := txnbuild.Transaction{
+		SourceAccount: &txnbuild.SimpleAccount{
+			AccountID: t.SourceAccount,
+			Sequence:  t.Sequence - 1,
+		},
+		BaseFee: t.BaseFee,
+		Network: t.NetworkPassphrase,
+	}
+	if tx.BaseFee == 0 {
+		tx.BaseFee = txnbuild.MinBaseFee
+	}
+
+	switch {
+	case t.TimeBounds != nil && t.Timeout != 0:
+		return txnbuild.Transaction{}, errors.New("time_bounds and timeout are mutually exclusive")
+	case t.TimeBounds != nil:
+		tx.Timebounds = txnbuild.NewTimebounds(t.TimeBounds.MinTime, t.TimeBounds.MaxTime)
+	case t.Timeout != 0:
+		tx.Timebounds = txnbuild.NewTimeout(t.Timeout)
+	default:
+		return txnbuild.Transaction{}, errors.New("time_bounds or timeout is required")
+	}
+
+	if t.Memo != nil {
+		memo, err := t.Memo.memo()
+		if err != nil {
+			return txnbuild.Transaction{}, errors.Wrap(err, "invalid memo")
+		}
+		tx.Memo = memo
+	}
+
+	if len(t.Operations) == 0 {
+		return txnbuild.Transaction{}, errors.New("at least one operation is required")
+	}
+	for i, opSpec := range t.Operations {
+		op, err := opSpec.operation()
+		if err != nil {
+			return txnbuild.Transaction{}, errors.Wrapf(err, "invalid operation %d (%s)", i, opSpec.Type)
+		}
+		tx.Operations = append(tx.Operations, op)
+	}
+
+	return tx, nil
+}
+
+// FromTransaction returns the spec of a built transaction, such as one
+// returned by txnbuild.TransactionFromXDR. Amounts and prices are written the
+// way txnbuild decodes them from XDR, so specs made by FromTransaction round
+// trip exactly through Transaction and FromTransaction.
+func FromTransaction(tx *txnbuild.Transaction) (Transaction, error) {
+	txe := tx.TxEnvelope()
+	if txe == nil {
+		return Transaction{}, errors.New("transaction must be built before it is converted to a spec")
+	}
+
+	spec := Transaction{
+		SourceAccount:     tx.SourceAccount.GetAccountID(),
+		Sequence:          txe.SeqNum(),
+		BaseFee:           tx.BaseFee,
+		NetworkPassphrase: tx.Network,
+	}
+
+	if tb := txe.TimeBounds(); tb != nil {
+		spec.TimeBounds = &TimeBounds{MinTime: int64(tb.MinTime), MaxTime: int64(tb.MaxTime)}
+	}
+
+	memo, err := memoSpec(tx.Memo)
+	if err != nil {
+		return Transaction{}, err
+	}
+	spec.Memo = memo
+
+	for i, op := range tx.Operations {
+		opSpec, err := operationSpec(op)
+		if err != nil {
+			return Transaction{}, errors.Wrapf(err, "operation %d", i)
+		}
+		spec.Operations = append(spec.Operations, opSpec)
+	}
+
+	return spec, nil
+}
+
+// FromXDR returns the spec of a base64 encoded transaction envelope.
+func FromXDR(txeB64 string) (Transaction, error) {
+	tx, err := txnbuild.TransactionFromXDR(txeB64)
+	if err != nil {
+		return Transaction{}, err
+	}
+	return FromTransaction(&tx)
+}
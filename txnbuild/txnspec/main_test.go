@@ -0,0 +1,293 @@
+package txnspec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/paydex-core/paydex-go/keypair"
+	"github.com/paydex-core/paydex-go/network"
+	"github.com/paydex-core/paydex-go/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	issuer = keypair.MustParse("SBPQUZ6G4FZNWFHKUWC5BEYWF6R52E3SEP7R3GWYSM2XTKGF5LNTWW4R").(*keypair.Full)
+	holder = keypair.MustParse("SBZVMB74Z76QZ3ZOY7UTDFYKMEGKW5XFJEB6PFKBF4UYSSWHG4EDH7PY").(*keypair.Full)
+)
+
+const yamlSpec = `
+source_account: GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3
+sequence: 3556091187167236
+base_fee: 200
+time_bounds:
+  min_time: 0
+  max_time: 1600000000
+memo:
+  type: text
+  value: issue USD
+operations:
+- type: create_account
+  destination: GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+  starting_balance: "10"
+- type: change_trust
+  source_account: GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+  line: USD:GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3
+  limit: "1000"
+- type: allow_trust
+  trustor: GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+  asset_code: USD
+  authorize: true
+- type: payment
+  destination: GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+  asset: USD:GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3
+  amount: "100"
+- type: set_options
+  set_flags: [auth_required, auth_revocable]
+  master_weight: 1
+  med_threshold: 2
+  home_domain: example.com
+  signer:
+    key: GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H
+    weight: 1
+- type: manage_data
+  name: key
+  value: value
+- type: manage_data
+  name: removed
+`
+
+const jsonSpec = `{
+  "source_account": "GDQNY3PBOJOKYZSRMK2S7LHHGWZIUISD4QORETLMXEWXBI7KFZZMKTL3",
+  "sequence": 3556091187167236,
+  "timeout": 300,
+  "operations": [
+    {"type": "payment", "destination": "GB7BDSZU2Y27LYNLALKKALB52WS2IZWYBDGY6EQBLEED3TJOCVMZRH7H", "asset": "native", "amount": "1"}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	spec, err := Parse([]byte(yamlSpec))
+	require.NoError(t, err)
+	assert.Equal(t, issuer.Address(), spec.SourceAccount)
+	assert.Equal(t, int64(3556091187167236), spec.Sequence)
+	assert.Equal(t, &Memo{Type: MemoTypeText, Value: "issue USD"}, spec.Memo)
+	require.Len(t, spec.Operations, 7)
+	assert.Equal(t, []string{"auth_required", "auth_revocable"}, spec.Operations[4].SetFlags)
+	assert.Nil(t, spec.Operations[6].Value)
+
+	spec, err = Parse([]byte(jsonSpec))
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), spec.Timeout)
+	assert.Equal(t, "native", spec.Operations[0].Asset)
+
+	_, err = Parse([]byte("source_account: GA\nsource_acount: GB\n"))
+	assert.Error(t, err)
+	_, err = Parse([]byte(`{"source_account": "GA", "fee": 100}`))
+	assert.EqualError(t, err, `parsing JSON spec: json: unknown field "fee"`)
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txnspec")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "tx.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(jsonSpec), 0600))
+	spec, err := Load(path)
+	require.NoError(t, err)
+
+	data, err := spec.Marshal(path)
+	require.NoError(t, err)
+	reparsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, spec, reparsed)
+
+	data, err = spec.Marshal("tx.yaml")
+	require.NoError(t, err)
+	reparsed, err = Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, spec, reparsed)
+
+	_, err = Load(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestTransaction(t *testing.T) {
+	spec, err := Parse([]byte(yamlSpec))
+	require.NoError(t, err)
+	spec.NetworkPassphrase = network.TestNetworkPassphrase
+
+	tx, err := spec.Transaction()
+	require.NoError(t, err)
+	require.NoError(t, tx.Build())
+	assert.Equal(t, int64(3556091187167236), tx.TxEnvelope().SeqNum())
+	assert.Equal(t, uint32(200*7), tx.TxEnvelope().Fee())
+	assert.Equal(t, txnbuild.MemoText("issue USD"), tx.Memo)
+
+	setOptions := tx.Operations[4].(*txnbuild.SetOptions)
+	assert.Equal(t, []txnbuild.AccountFlag{txnbuild.AuthRequired, txnbuild.AuthRevocable}, setOptions.SetFlags)
+	assert.Equal(t, txnbuild.Threshold(2), *setOptions.MediumThreshold)
+	assert.Nil(t, setOptions.LowThreshold)
+	assert.Nil(t, tx.Operations[6].(*txnbuild.ManageData).Value)
+
+	spec, err = Parse([]byte(jsonSpec))
+	require.NoError(t, err)
+	tx, err = spec.Transaction()
+	require.NoError(t, err)
+	assert.Equal(t, txnbuild.MinBaseFee, int(tx.BaseFee))
+	assert.NotZero(t, tx.Timebounds.MaxTime)
+}
+
+func TestTransactionErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		name     string
+		modify   func(spec *Transaction)
+		expected string
+	}{
+		{
+			"missing sequence",
+			func(spec *Transaction) { spec.Sequence = 0 },
+			"sequence is required",
+		},
+		{
+			"timeout and time bounds",
+			func(spec *Transaction) { spec.TimeBounds = &TimeBounds{} },
+			"time_bounds and timeout are mutually exclusive",
+		},
+		{
+			"missing time bounds",
+			func(spec *Transaction) { spec.Timeout = 0 },
+			"time_bounds or timeout is required",
+		},
+		{
+			"invalid memo",
+			func(spec *Transaction) { spec.Memo = &Memo{Type: MemoTypeHash, Value: "abcd"} },
+			"invalid memo: hash must be 32 bytes long",
+		},
+		{
+			"invalid asset",
+			func(spec *Transaction) { spec.Operations[0].Asset = "USD" },
+			`invalid operation 0 (payment): asset: "USD" is not a valid asset, it must be "native" or "CODE:ISSUER"`,
+		},
+		{
+			"unknown operation",
+			func(spec *Transaction) { spec.Operations[0].Type = "pay" },
+			`invalid operation 0 (pay): unknown operation type "pay"`,
+		},
+		{
+			"unknown flag",
+			func(spec *Transaction) {
+				spec.Operations[0] = Operation{Type: TypeSetOptions, SetFlags: []string{"auth_everything"}}
+			},
+			`invalid operation 0 (set_options): invalid set_flags: unknown flag "auth_everything"`,
+		},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			spec, err := Parse([]byte(jsonSpec))
+			require.NoError(t, err)
+			testCase.modify(&spec)
+			_, err = spec.Transaction()
+			assert.EqualError(t, err, testCase.expected)
+		})
+	}
+}
+
+// allOperations returns one operation of each type.
+func allOperations() []txnbuild.Operation {
+	usd := txnbuild.CreditAsset{Code: "USD", Issuer: issuer.Address()}
+	eur := txnbuild.CreditAsset{Code: "EURO", Issuer: issuer.Address()}
+	holderAccount := &txnbuild.SimpleAccount{AccountID: holder.Address()}
+	return []txnbuild.Operation{
+		&txnbuild.CreateAccount{Destination: holder.Address(), Amount: "10"},
+		&txnbuild.Payment{Destination: holder.Address(), Amount: "1.5", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.PathPaymentStrictReceive{
+			SendAsset: txnbuild.NativeAsset{}, SendMax: "10", Destination: holder.Address(),
+			DestAsset: usd, DestAmount: "1", Path: []txnbuild.Asset{eur},
+		},
+		&txnbuild.PathPaymentStrictSend{
+			SendAsset: usd, SendAmount: "1", Destination: holder.Address(),
+			DestAsset: txnbuild.NativeAsset{}, DestMin: "9", SourceAccount: holderAccount,
+		},
+		&txnbuild.ManageSellOffer{Selling: usd, Buying: txnbuild.NativeAsset{}, Amount: "5", Price: "0.5", OfferID: 12},
+		&txnbuild.ManageBuyOffer{Selling: txnbuild.NativeAsset{}, Buying: eur, Amount: "5", Price: "2"},
+		&txnbuild.CreatePassiveSellOffer{Selling: eur, Buying: usd, Amount: "3", Price: "1.25"},
+		&txnbuild.SetOptions{
+			InflationDestination: txnbuild.NewInflationDestination(holder.Address()),
+			ClearFlags:           []txnbuild.AccountFlag{txnbuild.AuthImmutable},
+			LowThreshold:         txnbuild.NewThreshold(0),
+			HighThreshold:        txnbuild.NewThreshold(3),
+			Signer:               &txnbuild.Signer{Address: holder.Address(), Weight: 0},
+		},
+		&txnbuild.ChangeTrust{Line: usd, Limit: "922337203685.4775807", SourceAccount: holderAccount},
+		&txnbuild.AllowTrust{Trustor: holder.Address(), Type: usd, Authorize: false},
+		&txnbuild.Inflation{},
+		&txnbuild.ManageData{Name: "text", Value: []byte("value")},
+		&txnbuild.ManageData{Name: "binary", Value: []byte{0, 1, 2, 255}},
+		&txnbuild.ManageData{Name: "deleted"},
+		&txnbuild.BumpSequence{BumpTo: 3556091187167300},
+		&txnbuild.AccountMerge{Destination: holder.Address(), SourceAccount: holderAccount},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	sourceAccount := txnbuild.NewSimpleAccount(issuer.Address(), 3556091187167235)
+	tx := txnbuild.Transaction{
+		SourceAccount: &sourceAccount,
+		Operations:    allOperations(),
+		BaseFee:       txnbuild.MinBaseFee,
+		Memo:          txnbuild.MemoHash{1, 2, 3},
+		Timebounds:    txnbuild.NewTimebounds(10, 1600000000),
+		Network:       network.TestNetworkPassphrase,
+	}
+	txeB64, err := tx.BuildSignEncode(issuer, holder)
+	require.NoError(t, err)
+
+	spec, err := FromXDR(txeB64)
+	require.NoError(t, err)
+	assert.Equal(t, issuer.Address(), spec.SourceAccount)
+	assert.Equal(t, int64(3556091187167236), spec.Sequence)
+	assert.Equal(t, &TimeBounds{MinTime: 10, MaxTime: 1600000000}, spec.TimeBounds)
+	assert.Equal(t, MemoTypeHash, spec.Memo.Type)
+	assert.Equal(t, "0102030000000000000000000000000000000000000000000000000000000000", spec.Memo.Value)
+	require.Len(t, spec.Operations, len(allOperations()))
+	assert.Equal(t, "EURO:"+issuer.Address(), spec.Operations[2].Path[0])
+	assert.Equal(t, holder.Address(), spec.Operations[3].SourceAccount)
+	assert.Equal(t, []string{"auth_immutable"}, spec.Operations[7].ClearFlags)
+	assert.Equal(t, "USD", spec.Operations[9].AssetCode)
+	assert.Equal(t, "value", *spec.Operations[11].Value)
+	assert.Equal(t, "AAEC/w==", *spec.Operations[12].ValueBase64)
+	assert.Nil(t, spec.Operations[13].Value)
+	assert.Nil(t, spec.Operations[13].ValueBase64)
+
+	// the spec serializes and builds back to the same transaction, without
+	// its signatures
+	for _, path := range []string{"tx.yaml", "tx.json"} {
+		data, err := spec.Marshal(path)
+		require.NoError(t, err)
+		reparsed, err := Parse(data)
+		require.NoError(t, err)
+		assert.Equal(t, spec, reparsed)
+
+		reparsed.NetworkPassphrase = network.TestNetworkPassphrase
+		rebuilt, err := reparsed.Transaction()
+		require.NoError(t, err)
+		require.NoError(t, rebuilt.Build())
+		expectedHash, err := tx.HashHex()
+		require.NoError(t, err)
+		rebuiltHash, err := rebuilt.HashHex()
+		require.NoError(t, err)
+		assert.Equal(t, expectedHash, rebuiltHash)
+
+		respec, err := FromTransaction(&rebuilt)
+		require.NoError(t, err)
+		assert.Equal(t, reparsed, respec)
+	}
+}
+
+func TestFromTransactionNotBuilt(t *testing.T) {
+	_, err := FromTransaction(&txnbuild.Transaction{})
+	assert.EqualError(t, err, "transaction must be built before it is converted to a spec")
+}
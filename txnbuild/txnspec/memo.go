@@ -0,0 +1,69 @@
+package txnspec
+
+import (
+	"encoding/hex"
+	"strconv"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+)
+
+// Memo types of Memo.Type.
+const (
+	MemoTypeText   = "text"
+	MemoTypeID     = "id"
+	MemoTypeHash   = "hash"
+	MemoTypeReturn = "return"
+)
+
+func (m Memo) memo() (txnbuild.Memo, error) {
+	switch m.Type {
+	case MemoTypeText:
+		return txnbuild.MemoText(m.Value), nil
+	case MemoTypeID:
+		id, err := strconv.ParseUint(m.Value, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing id")
+		}
+		return txnbuild.MemoID(id), nil
+	case MemoTypeHash:
+		hash, err := parseHash(m.Value)
+		return txnbuild.MemoHash(hash), err
+	case MemoTypeReturn:
+		hash, err := parseHash(m.Value)
+		return txnbuild.MemoReturn(hash), err
+	default:
+		return nil, errors.Errorf("unknown memo type %q", m.Type)
+	}
+}
+
+func parseHash(value string) ([32]byte, error) {
+	var hash [32]byte
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		return hash, errors.Wrap(err, "decoding hash")
+	}
+	if len(decoded) != len(hash) {
+		return hash, errors.Errorf("hash must be %d bytes long", len(hash))
+	}
+	copy(hash[:], decoded)
+	return hash, nil
+}
+
+// memoSpec returns the spec of a memo, or nil if the memo is empty.
+func memoSpec(memo txnbuild.Memo) (*Memo, error) {
+	switch m := memo.(type) {
+	case txnbuild.MemoText:
+		return &Memo{Type: MemoTypeText, Value: string(m)}, nil
+	case txnbuild.MemoID:
+		return &Memo{Type: MemoTypeID, Value: strconv.FormatUint(uint64(m), 10)}, nil
+	case txnbuild.MemoHash:
+		return &Memo{Type: MemoTypeHash, Value: hex.EncodeToString(m[:])}, nil
+	case txnbuild.MemoReturn:
+		return &Memo{Type: MemoTypeReturn, Value: hex.EncodeToString(m[:])}, nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, errors.Errorf("unknown memo type %T", memo)
+	}
+}
@@ -0,0 +1,488 @@
+package txnspec
+
+import (
+	"encoding/base64"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/paydex-core/paydex-go/support/errors"
+	"github.com/paydex-core/paydex-go/txnbuild"
+)
+
+// Operation types of Operation.Type, one for each txnbuild.Operation.
+const (
+	TypeCreateAccount            = "create_account"
+	TypePayment                  = "payment"
+	TypePathPaymentStrictReceive = "path_payment_strict_receive"
+	TypePathPaymentStrictSend    = "path_payment_strict_send"
+	TypeManageSellOffer          = "manage_sell_offer"
+	TypeManageBuyOffer           = "manage_buy_offer"
+	TypeCreatePassiveSellOffer   = "create_passive_sell_offer"
+	TypeSetOptions               = "set_options"
+	TypeChangeTrust              = "change_trust"
+	TypeAllowTrust               = "allow_trust"
+	TypeAccountMerge             = "account_merge"
+	TypeInflation                = "inflation"
+	TypeManageData               = "manage_data"
+	TypeBumpSequence             = "bump_sequence"
+)
+
+// Account flags of Operation.SetFlags and Operation.ClearFlags.
+var flagNames = map[txnbuild.AccountFlag]string{
+	txnbuild.AuthRequired:  "auth_required",
+	txnbuild.AuthRevocable: "auth_revocable",
+	txnbuild.AuthImmutable: "auth_immutable",
+}
+
+// Operation is the spec of an operation. Type selects the operation, and the
+// fields used by each type are named after the fields of the matching
+// txnbuild operation:
+//
+//   - create_account: destination, starting_balance
+//   - payment: destination, asset, amount
+//   - path_payment_strict_receive: send_asset, send_max, destination,
+//     dest_asset, dest_amount, path
+//   - path_payment_strict_send: send_asset, send_amount, destination,
+//     dest_asset, dest_min, path
+//   - manage_sell_offer, manage_buy_offer: selling, buying, amount, price,
+//     offer_id
+//   - create_passive_sell_offer: selling, buying, amount, price
+//   - set_options: inflation_destination, set_flags, clear_flags,
+//     master_weight, low_threshold, med_threshold, high_threshold,
+//     home_domain, signer
+//   - change_trust: line, limit
+//   - allow_trust: trustor, asset_code, authorize
+//   - account_merge: destination
+//   - inflation: no fields
+//   - manage_data: name, and value or value_base64; the entry is deleted
+//     when neither is set
+//   - bump_sequence: bump_to
+//
+// Every operation may have a source_account.
+type Operation struct {
+	Type          string `json:"type" yaml:"type"`
+	SourceAccount string `json:"source_account,omitempty" yaml:"source_account,omitempty"`
+
+	Destination     string `json:"destination,omitempty" yaml:"destination,omitempty"`
+	StartingBalance string `json:"starting_balance,omitempty" yaml:"starting_balance,omitempty"`
+	Asset           string `json:"asset,omitempty" yaml:"asset,omitempty"`
+	Amount          string `json:"amount,omitempty" yaml:"amount,omitempty"`
+
+	SendAsset  string   `json:"send_asset,omitempty" yaml:"send_asset,omitempty"`
+	SendMax    string   `json:"send_max,omitempty" yaml:"send_max,omitempty"`
+	SendAmount string   `json:"send_amount,omitempty" yaml:"send_amount,omitempty"`
+	DestAsset  string   `json:"dest_asset,omitempty" yaml:"dest_asset,omitempty"`
+	DestAmount string   `json:"dest_amount,omitempty" yaml:"dest_amount,omitempty"`
+	DestMin    string   `json:"dest_min,omitempty" yaml:"dest_min,omitempty"`
+	Path       []string `json:"path,omitempty" yaml:"path,omitempty"`
+
+	Selling string `json:"selling,omitempty" yaml:"selling,omitempty"`
+	Buying  string `json:"buying,omitempty" yaml:"buying,omitempty"`
+	Price   string `json:"price,omitempty" yaml:"price,omitempty"`
+	OfferID int64  `json:"offer_id,omitempty" yaml:"offer_id,omitempty"`
+
+	InflationDestination *string  `json:"inflation_destination,omitempty" yaml:"inflation_destination,omitempty"`
+	SetFlags             []string `json:"set_flags,omitempty" yaml:"set_flags,omitempty"`
+	ClearFlags           []string `json:"clear_flags,omitempty" yaml:"clear_flags,omitempty"`
+	MasterWeight         *uint8   `json:"master_weight,omitempty" yaml:"master_weight,omitempty"`
+	LowThreshold         *uint8   `json:"low_threshold,omitempty" yaml:"low_threshold,omitempty"`
+	MedThreshold         *uint8   `json:"med_threshold,omitempty" yaml:"med_threshold,omitempty"`
+	HighThreshold        *uint8   `json:"high_threshold,omitempty" yaml:"high_threshold,omitempty"`
+	HomeDomain           *string  `json:"home_domain,omitempty" yaml:"home_domain,omitempty"`
+	Signer               *Signer  `json:"signer,omitempty" yaml:"signer,omitempty"`
+
+	Line  string `json:"line,omitempty" yaml:"line,omitempty"`
+	Limit string `json:"limit,omitempty" yaml:"limit,omitempty"`
+
+	Trustor   string `json:"trustor,omitempty" yaml:"trustor,omitempty"`
+	AssetCode string `json:"asset_code,omitempty" yaml:"asset_code,omitempty"`
+	Authorize bool   `json:"authorize,omitempty" yaml:"authorize,omitempty"`
+
+	Name        string  `json:"name,omitempty" yaml:"name,omitempty"`
+	Value       *string `json:"value,omitempty" yaml:"value,omitempty"`
+	ValueBase64 *string `json:"value_base64,omitempty" yaml:"value_base64,omitempty"`
+
+	BumpTo int64 `json:"bump_to,omitempty" yaml:"bump_to,omitempty"`
+}
+
+// Signer is the spec of the signer of a set_options operation. A weight of 0
+// removes the signer.
+type Signer struct {
+	Key    string `json:"key" yaml:"key"`
+	Weight uint8  `json:"weight" yaml:"weight"`
+}
+
+// operation returns the txnbuild operation described by the spec.
+func (o Operation) operation() (txnbuild.Operation, error) {
+	var sourceAccount txnbuild.Account
+	if o.SourceAccount != "" {
+		sourceAccount = &txnbuild.SimpleAccount{AccountID: o.SourceAccount}
+	}
+
+	switch o.Type {
+	case TypeCreateAccount:
+		return &txnbuild.CreateAccount{
+			Destination:   o.Destination,
+			Amount:        o.StartingBalance,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypePayment:
+		asset, err := parseAsset("asset", o.Asset)
+		if err != nil {
+			return nil, err
+		}
+		return &txnbuild.Payment{
+			Destination:   o.Destination,
+			Amount:        o.Amount,
+			Asset:         asset,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypePathPaymentStrictReceive:
+		sendAsset, destAsset, path, err := o.pathPaymentAssets()
+		if err != nil {
+			return nil, err
+		}
+		return &txnbuild.PathPaymentStrictReceive{
+			SendAsset:     sendAsset,
+			SendMax:       o.SendMax,
+			Destination:   o.Destination,
+			DestAsset:     destAsset,
+			DestAmount:    o.DestAmount,
+			Path:          path,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypePathPaymentStrictSend:
+		sendAsset, destAsset, path, err := o.pathPaymentAssets()
+		if err != nil {
+			return nil, err
+		}
+		return &txnbuild.PathPaymentStrictSend{
+			SendAsset:     sendAsset,
+			SendAmount:    o.SendAmount,
+			Destination:   o.Destination,
+			DestAsset:     destAsset,
+			DestMin:       o.DestMin,
+			Path:          path,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypeManageSellOffer, TypeManageBuyOffer, TypeCreatePassiveSellOffer:
+		selling, err := parseAsset("selling", o.Selling)
+		if err != nil {
+			return nil, err
+		}
+		buying, err := parseAsset("buying", o.Buying)
+		if err != nil {
+			return nil, err
+		}
+		switch o.Type {
+		case TypeManageSellOffer:
+			return &txnbuild.ManageSellOffer{
+				Selling:       selling,
+				Buying:        buying,
+				Amount:        o.Amount,
+				Price:         o.Price,
+				OfferID:       o.OfferID,
+				SourceAccount: sourceAccount,
+			}, nil
+		case TypeManageBuyOffer:
+			return &txnbuild.ManageBuyOffer{
+				Selling:       selling,
+				Buying:        buying,
+				Amount:        o.Amount,
+				Price:         o.Price,
+				OfferID:       o.OfferID,
+				SourceAccount: sourceAccount,
+			}, nil
+		default:
+			return &txnbuild.CreatePassiveSellOffer{
+				Selling:       selling,
+				Buying:        buying,
+				Amount:        o.Amount,
+				Price:         o.Price,
+				SourceAccount: sourceAccount,
+			}, nil
+		}
+	case TypeSetOptions:
+		return o.setOptions(sourceAccount)
+	case TypeChangeTrust:
+		line, err := parseAsset("line", o.Line)
+		if err != nil {
+			return nil, err
+		}
+		return &txnbuild.ChangeTrust{
+			Line:          line,
+			Limit:         o.Limit,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypeAllowTrust:
+		return &txnbuild.AllowTrust{
+			Trustor:       o.Trustor,
+			Type:          txnbuild.CreditAsset{Code: o.AssetCode},
+			Authorize:     o.Authorize,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypeAccountMerge:
+		return &txnbuild.AccountMerge{
+			Destination:   o.Destination,
+			SourceAccount: sourceAccount,
+		}, nil
+	case TypeInflation:
+		return &txnbuild.Inflation{SourceAccount: sourceAccount}, nil
+	case TypeManageData:
+		op := &txnbuild.ManageData{Name: o.Name, SourceAccount: sourceAccount}
+		switch {
+		case o.Value != nil && o.ValueBase64 != nil:
+			return nil, errors.New("value and value_base64 are mutually exclusive")
+		case o.Value != nil:
+			op.Value = []byte(*o.Value)
+		case o.ValueBase64 != nil:
+			value, err := base64.StdEncoding.DecodeString(*o.ValueBase64)
+			if err != nil {
+				return nil, errors.Wrap(err, "decoding value_base64")
+			}
+			op.Value = value
+		}
+		return op, nil
+	case TypeBumpSequence:
+		return &txnbuild.BumpSequence{
+			BumpTo:        o.BumpTo,
+			SourceAccount: sourceAccount,
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown operation type %q", o.Type)
+	}
+}
+
+func (o Operation) pathPaymentAssets() (sendAsset, destAsset txnbuild.Asset, path []txnbuild.Asset, err error) {
+	sendAsset, err = parseAsset("send_asset", o.SendAsset)
+	if err != nil {
+		return
+	}
+	destAsset, err = parseAsset("dest_asset", o.DestAsset)
+	if err != nil {
+		return
+	}
+	for _, s := range o.Path {
+		var asset txnbuild.Asset
+		asset, err = parseAsset("path", s)
+		if err != nil {
+			return
+		}
+		path = append(path, asset)
+	}
+	return
+}
+
+func (o Operation) setOptions(sourceAccount txnbuild.Account) (*txnbuild.SetOptions, error) {
+	op := &txnbuild.SetOptions{
+		InflationDestination: o.InflationDestination,
+		HomeDomain:           o.HomeDomain,
+		SourceAccount:        sourceAccount,
+	}
+
+	var err error
+	if op.SetFlags, err = parseFlags(o.SetFlags); err != nil {
+		return nil, errors.Wrap(err, "invalid set_flags")
+	}
+	if op.ClearFlags, err = parseFlags(o.ClearFlags); err != nil {
+		return nil, errors.Wrap(err, "invalid clear_flags")
+	}
+
+	op.MasterWeight = threshold(o.MasterWeight)
+	op.LowThreshold = threshold(o.LowThreshold)
+	op.MediumThreshold = threshold(o.MedThreshold)
+	op.HighThreshold = threshold(o.HighThreshold)
+
+	if o.Signer != nil {
+		op.Signer = &txnbuild.Signer{
+			Address: o.Signer.Key,
+			Weight:  txnbuild.Threshold(o.Signer.Weight),
+		}
+	}
+	return op, nil
+}
+
+func threshold(weight *uint8) *txnbuild.Threshold {
+	if weight == nil {
+		return nil
+	}
+	return txnbuild.NewThreshold(txnbuild.Threshold(*weight))
+}
+
+func weight(threshold *txnbuild.Threshold) *uint8 {
+	if threshold == nil {
+		return nil
+	}
+	w := uint8(*threshold)
+	return &w
+}
+
+func parseFlags(names []string) ([]txnbuild.AccountFlag, error) {
+	var flags []txnbuild.AccountFlag
+	for _, name := range names {
+		found := false
+		for flag, flagName := range flagNames {
+			if name == flagName {
+				flags = append(flags, flag)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("unknown flag %q", name)
+		}
+	}
+	return flags, nil
+}
+
+func formatFlags(flags []txnbuild.AccountFlag) ([]string, error) {
+	var names []string
+	for _, flag := range flags {
+		name, ok := flagNames[flag]
+		if !ok {
+			return nil, errors.Errorf("unknown flag %d", flag)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// parseAsset parses an asset written as "native" or "CODE:ISSUER".
+func parseAsset(field, s string) (txnbuild.Asset, error) {
+	if s == "native" {
+		return txnbuild.NativeAsset{}, nil
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.Errorf("%s: %q is not a valid asset, it must be \"native\" or \"CODE:ISSUER\"", field, s)
+	}
+	return txnbuild.CreditAsset{Code: parts[0], Issuer: parts[1]}, nil
+}
+
+func formatAsset(asset txnbuild.Asset) string {
+	if asset == nil {
+		return ""
+	}
+	if asset.IsNative() {
+		return "native"
+	}
+	return asset.GetCode() + ":" + asset.GetIssuer()
+}
+
+func formatAssets(assets []txnbuild.Asset) []string {
+	var s []string
+	for _, asset := range assets {
+		s = append(s, formatAsset(asset))
+	}
+	return s
+}
+
+// operationSpec returns the spec of a txnbuild operation.
+func operationSpec(op txnbuild.Operation) (Operation, error) {
+	var spec Operation
+	if sourceAccount := op.GetSourceAccount(); sourceAccount != nil {
+		spec.SourceAccount = sourceAccount.GetAccountID()
+	}
+
+	switch op := op.(type) {
+	case *txnbuild.CreateAccount:
+		spec.Type = TypeCreateAccount
+		spec.Destination = op.Destination
+		spec.StartingBalance = op.Amount
+	case *txnbuild.Payment:
+		spec.Type = TypePayment
+		spec.Destination = op.Destination
+		spec.Asset = formatAsset(op.Asset)
+		spec.Amount = op.Amount
+	case *txnbuild.PathPaymentStrictReceive:
+		spec.Type = TypePathPaymentStrictReceive
+		spec.SendAsset = formatAsset(op.SendAsset)
+		spec.SendMax = op.SendMax
+		spec.Destination = op.Destination
+		spec.DestAsset = formatAsset(op.DestAsset)
+		spec.DestAmount = op.DestAmount
+		spec.Path = formatAssets(op.Path)
+	case *txnbuild.PathPaymentStrictSend:
+		spec.Type = TypePathPaymentStrictSend
+		spec.SendAsset = formatAsset(op.SendAsset)
+		spec.SendAmount = op.SendAmount
+		spec.Destination = op.Destination
+		spec.DestAsset = formatAsset(op.DestAsset)
+		spec.DestMin = op.DestMin
+		spec.Path = formatAssets(op.Path)
+	case *txnbuild.ManageSellOffer:
+		spec.Type = TypeManageSellOffer
+		spec.Selling = formatAsset(op.Selling)
+		spec.Buying = formatAsset(op.Buying)
+		spec.Amount = op.Amount
+		spec.Price = op.Price
+		spec.OfferID = op.OfferID
+	case *txnbuild.ManageBuyOffer:
+		spec.Type = TypeManageBuyOffer
+		spec.Selling = formatAsset(op.Selling)
+		spec.Buying = formatAsset(op.Buying)
+		spec.Amount = op.Amount
+		spec.Price = op.Price
+		spec.OfferID = op.OfferID
+	case *txnbuild.CreatePassiveSellOffer:
+		spec.Type = TypeCreatePassiveSellOffer
+		spec.Selling = formatAsset(op.Selling)
+		spec.Buying = formatAsset(op.Buying)
+		spec.Amount = op.Amount
+		spec.Price = op.Price
+	case *txnbuild.SetOptions:
+		spec.Type = TypeSetOptions
+		spec.InflationDestination = op.InflationDestination
+		spec.HomeDomain = op.HomeDomain
+		var err error
+		if spec.SetFlags, err = formatFlags(op.SetFlags); err != nil {
+			return Operation{}, err
+		}
+		if spec.ClearFlags, err = formatFlags(op.ClearFlags); err != nil {
+			return Operation{}, err
+		}
+		spec.MasterWeight = weight(op.MasterWeight)
+		spec.LowThreshold = weight(op.LowThreshold)
+		spec.MedThreshold = weight(op.MediumThreshold)
+		spec.HighThreshold = weight(op.HighThreshold)
+		if op.Signer != nil {
+			spec.Signer = &Signer{Key: op.Signer.Address, Weight: uint8(op.Signer.Weight)}
+		}
+	case *txnbuild.ChangeTrust:
+		spec.Type = TypeChangeTrust
+		spec.Line = formatAsset(op.Line)
+		spec.Limit = op.Limit
+	case *txnbuild.AllowTrust:
+		spec.Type = TypeAllowTrust
+		spec.Trustor = op.Trustor
+		if op.Type != nil {
+			spec.AssetCode = op.Type.GetCode()
+		}
+		spec.Authorize = op.Authorize
+	case *txnbuild.AccountMerge:
+		spec.Type = TypeAccountMerge
+		spec.Destination = op.Destination
+	case *txnbuild.Inflation:
+		spec.Type = TypeInflation
+	case *txnbuild.ManageData:
+		spec.Type = TypeManageData
+		spec.Name = op.Name
+		if op.Value != nil {
+			// Values are written as text when possible, so that they
+			// can be reviewed.
+			value := string(op.Value)
+			if utf8.Valid(op.Value) && !strings.ContainsRune(value, 0) {
+				spec.Value = &value
+			} else {
+				encoded := base64.StdEncoding.EncodeToString(op.Value)
+				spec.ValueBase64 = &encoded
+			}
+		}
+	case *txnbuild.BumpSequence:
+		spec.Type = TypeBumpSequence
+		spec.BumpTo = op.BumpTo
+	default:
+		return Operation{}, errors.Errorf("unknown operation %T", op)
+	}
+	return spec, nil
+}
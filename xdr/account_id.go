@@ -84,6 +84,15 @@ func MustAddress(address string) AccountId {
 	return aid
 }
 
+// AccountIdFromEd25519 returns the AccountId backed by the provided raw
+// ed25519 public key.
+func AccountIdFromEd25519(key Uint256) AccountId {
+	return AccountId{
+		Type:    PublicKeyTypePublicKeyTypeEd25519,
+		Ed25519: &key,
+	}
+}
+
 // AddressToAccountId returns an AccountId for a given address string.
 // If the address is not valid the error returned will not be nil
 func AddressToAccountId(address string) (AccountId, error) {
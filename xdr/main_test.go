@@ -27,7 +27,7 @@ func ExampleUnmarshal() {
 		log.Fatal(err)
 	}
 
-	fmt.Printf("This tx has %d operations\n", len(tx.Tx.Operations))
+	fmt.Printf("This tx has %d operations\n", len(tx.Operations()))
 	// Output: read 192 bytes
 	// This tx has 1 operations
 }
@@ -0,0 +1,160 @@
+package xdr
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/paydex-core/paydex-go/strkey"
+)
+
+// Address returns the strkey encoded form of this MuxedAccount. Accounts
+// backed by a plain ed25519 key are encoded as G... addresses and multiplexed
+// accounts are encoded as M... addresses. This method will panic if the
+// MuxedAccount is backed by a key of an unknown type.
+func (m *MuxedAccount) Address() string {
+	address, err := m.GetAddress()
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+// GetAddress returns the strkey encoded form of this MuxedAccount, or an error
+// if the MuxedAccount is backed by a key of an unknown type.
+func (m *MuxedAccount) GetAddress() (string, error) {
+	if m == nil {
+		return "", nil
+	}
+
+	switch m.Type {
+	case CryptoKeyTypeKeyTypeEd25519:
+		ed := m.MustEd25519()
+		raw := make([]byte, 32)
+		copy(raw, ed[:])
+		return strkey.Encode(strkey.VersionByteAccountID, raw)
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		med := m.MustMed25519()
+		raw := make([]byte, 40)
+		copy(raw, med.Ed25519[:])
+		binary.BigEndian.PutUint64(raw[32:], uint64(med.Id))
+		return strkey.Encode(strkey.VersionByteMuxedAccount, raw)
+	default:
+		return "", fmt.Errorf("Unknown muxed account type: %v", m.Type)
+	}
+}
+
+// SetAddress modifies the receiver, setting its value to the MuxedAccount
+// form of the provided address. Both G... and M... addresses are accepted.
+func (m *MuxedAccount) SetAddress(address string) error {
+	if m == nil {
+		return nil
+	}
+
+	version, err := strkey.Version(address)
+	if err != nil {
+		return err
+	}
+
+	switch version {
+	case strkey.VersionByteAccountID:
+		raw, err := strkey.Decode(strkey.VersionByteAccountID, address)
+		if err != nil {
+			return err
+		}
+		if len(raw) != 32 {
+			return errors.New("invalid address")
+		}
+
+		var ui Uint256
+		copy(ui[:], raw)
+		*m, err = NewMuxedAccount(CryptoKeyTypeKeyTypeEd25519, ui)
+		return err
+	case strkey.VersionByteMuxedAccount:
+		raw, err := strkey.Decode(strkey.VersionByteMuxedAccount, address)
+		if err != nil {
+			return err
+		}
+		if len(raw) != 40 {
+			return errors.New("invalid muxed address")
+		}
+
+		var med MuxedAccountMed25519
+		copy(med.Ed25519[:], raw[:32])
+		med.Id = Uint64(binary.BigEndian.Uint64(raw[32:]))
+		*m, err = NewMuxedAccount(CryptoKeyTypeKeyTypeMuxedEd25519, med)
+		return err
+	default:
+		return errors.New("invalid address")
+	}
+}
+
+// Id returns the multiplexing id of the MuxedAccount and true, or 0 and false
+// if the MuxedAccount is a plain ed25519 account.
+func (m MuxedAccount) Id() (uint64, bool) {
+	med, ok := m.GetMed25519()
+	if !ok {
+		return 0, false
+	}
+	return uint64(med.Id), true
+}
+
+// ToAccountId returns the AccountId backing the MuxedAccount, discarding the
+// multiplexing id if there is one.
+func (m MuxedAccount) ToAccountId() AccountId {
+	switch m.Type {
+	case CryptoKeyTypeKeyTypeEd25519:
+		return AccountIdFromEd25519(m.MustEd25519())
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		return AccountIdFromEd25519(m.MustMed25519().Ed25519)
+	default:
+		panic(fmt.Errorf("Unknown muxed account type: %v", m.Type))
+	}
+}
+
+// ToMuxedAccount returns the MuxedAccount equivalent of the AccountId, which
+// has no multiplexing id.
+func (aid AccountId) ToMuxedAccount() MuxedAccount {
+	switch aid.Type {
+	case PublicKeyTypePublicKeyTypeEd25519:
+		return MuxedAccountFromEd25519(aid.MustEd25519())
+	default:
+		panic(fmt.Errorf("Unknown account id type: %v", aid.Type))
+	}
+}
+
+// MuxedAccountFromEd25519 returns the MuxedAccount backed by the provided raw
+// ed25519 public key, without a multiplexing id.
+func MuxedAccountFromEd25519(key Uint256) MuxedAccount {
+	return MuxedAccount{
+		Type:    CryptoKeyTypeKeyTypeEd25519,
+		Ed25519: &key,
+	}
+}
+
+// MustMuxedAddress returns the MuxedAccount for the provided G... or M...
+// address, panicking if the address is invalid.
+func MustMuxedAddress(address string) MuxedAccount {
+	muxed := MuxedAccount{}
+	err := muxed.SetAddress(address)
+	if err != nil {
+		panic(err)
+	}
+	return muxed
+}
+
+// MustMuxedAddressPtr is like MustMuxedAddress but returns a pointer.
+func MustMuxedAddressPtr(address string) *MuxedAccount {
+	muxed := MustMuxedAddress(address)
+	return &muxed
+}
+
+// AddressToMuxedAccount returns a MuxedAccount for a given G... or M...
+// address string. If the address is not valid the error returned will not be
+// nil.
+func AddressToMuxedAccount(address string) (MuxedAccount, error) {
+	result := MuxedAccount{}
+	err := result.SetAddress(address)
+
+	return result, err
+}
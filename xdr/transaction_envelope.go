@@ -0,0 +1,195 @@
+package xdr
+
+import "fmt"
+
+// IsFeeBump returns true if the transaction envelope is a fee bump transaction
+func (e TransactionEnvelope) IsFeeBump() bool {
+	return e.Type == EnvelopeTypeEnvelopeTypeTxFeeBump
+}
+
+// FeeBumpAccount returns the account paying for the fee bump transaction
+func (e TransactionEnvelope) FeeBumpAccount() MuxedAccount {
+	return e.MustFeeBump().Tx.FeeSource
+}
+
+// FeeBumpFee returns the fee defined for the fee bump transaction
+func (e TransactionEnvelope) FeeBumpFee() int64 {
+	return int64(e.MustFeeBump().Tx.Fee)
+}
+
+// FeeBumpSignatures returns the list of signatures for the fee bump transaction
+func (e TransactionEnvelope) FeeBumpSignatures() []DecoratedSignature {
+	return e.MustFeeBump().Signatures
+}
+
+// InnerTransactionEnvelope returns the V1 envelope wrapped by a fee bump
+// transaction.
+func (e TransactionEnvelope) InnerTransactionEnvelope() TransactionV1Envelope {
+	return e.MustFeeBump().Tx.InnerTx.MustV1()
+}
+
+// SourceAccount returns the source account for the transaction
+// If the transaction envelope is for a fee bump transaction, SourceAccount()
+// returns the source account of the inner transaction
+func (e TransactionEnvelope) SourceAccount() MuxedAccount {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return MuxedAccountFromEd25519(e.V0.Tx.SourceAccountEd25519)
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Tx.SourceAccount
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Tx.SourceAccount
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// Fee returns the fee defined for the transaction envelope
+// If the transaction envelope is for a fee bump transaction, Fee()
+// returns the fee defined in the inner transaction
+func (e TransactionEnvelope) Fee() uint32 {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return uint32(e.V0.Tx.Fee)
+	case EnvelopeTypeEnvelopeTypeTx:
+		return uint32(e.V1.Tx.Fee)
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return uint32(e.InnerTransactionEnvelope().Tx.Fee)
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// SeqNum returns the sequence number set in the transaction envelope
+// Note for fee bump transactions, SeqNum() returns the sequence number
+// of the inner transaction
+func (e TransactionEnvelope) SeqNum() int64 {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return int64(e.V0.Tx.SeqNum)
+	case EnvelopeTypeEnvelopeTypeTx:
+		return int64(e.V1.Tx.SeqNum)
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return int64(e.InnerTransactionEnvelope().Tx.SeqNum)
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// TimeBounds returns the time bounds set in the transaction envelope
+// Note for fee bump transactions, TimeBounds() returns the time bounds
+// of the inner transaction
+func (e TransactionEnvelope) TimeBounds() *TimeBounds {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return e.V0.Tx.TimeBounds
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Tx.TimeBounds
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Tx.TimeBounds
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// Memo returns the memo set in the transaction envelope
+// Note for fee bump transactions, Memo() returns the memo
+// of the inner transaction
+func (e TransactionEnvelope) Memo() Memo {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return e.V0.Tx.Memo
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Tx.Memo
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Tx.Memo
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// Operations returns the operations set in the transaction envelope
+// Note for fee bump transactions, Operations() returns the operations
+// of the inner transaction
+func (e TransactionEnvelope) Operations() []Operation {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return e.V0.Tx.Operations
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Tx.Operations
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Tx.Operations
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// Signatures returns the list of signatures included in the transaction envelope
+// Note for fee bump transactions, Signatures() returns the signatures
+// of the inner transaction
+func (e TransactionEnvelope) Signatures() []DecoratedSignature {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return e.V0.Signatures
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Signatures
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Signatures
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// Transaction returns the transaction wrapped by a V0 or V1 envelope. V0
+// transactions are converted to their V1 form, which has the same hash and
+// signature payload. For fee bump envelopes the inner transaction is returned.
+func (e TransactionEnvelope) Transaction() Transaction {
+	switch e.Type {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return e.V0.Tx.ToTransaction()
+	case EnvelopeTypeEnvelopeTypeTx:
+		return e.V1.Tx
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return e.InnerTransactionEnvelope().Tx
+	default:
+		panic(fmt.Errorf("Unknown envelope type: %v", e.Type))
+	}
+}
+
+// ToTransaction converts a TransactionV0 into the equivalent Transaction.
+func (tx TransactionV0) ToTransaction() Transaction {
+	return Transaction{
+		SourceAccount: MuxedAccountFromEd25519(tx.SourceAccountEd25519),
+		Fee:           tx.Fee,
+		SeqNum:        tx.SeqNum,
+		TimeBounds:    tx.TimeBounds,
+		Memo:          tx.Memo,
+		Operations:    tx.Operations,
+	}
+}
+
+// NewTransactionV0Envelope builds a V0 (legacy) envelope for a transaction
+// whose source account is a plain ed25519 key. Envelopes in this format are
+// accepted by every protocol version. Transactions with a multiplexed source
+// account must use a V1 envelope.
+func NewTransactionV0Envelope(tx Transaction, signatures []DecoratedSignature) (TransactionEnvelope, error) {
+	key, ok := tx.SourceAccount.GetEd25519()
+	if !ok {
+		return TransactionEnvelope{}, fmt.Errorf("source account of a V0 transaction must be an ed25519 key")
+	}
+
+	return TransactionEnvelope{
+		Type: EnvelopeTypeEnvelopeTypeTxV0,
+		V0: &TransactionV0Envelope{
+			Tx: TransactionV0{
+				SourceAccountEd25519: key,
+				Fee:                  tx.Fee,
+				SeqNum:               tx.SeqNum,
+				TimeBounds:           tx.TimeBounds,
+				Memo:                 tx.Memo,
+				Operations:           tx.Operations,
+			},
+			Signatures: signatures,
+		},
+	}, nil
+}
@@ -0,0 +1,19 @@
+package xdr
+
+// Successful returns true if the transaction succeeded. For fee bump
+// transactions this means the inner transaction succeeded as well.
+func (r TransactionResult) Successful() bool {
+	return r.Result.Code == TransactionResultCodeTxSuccess ||
+		r.Result.Code == TransactionResultCodeTxFeeBumpInnerSuccess
+}
+
+// OperationResults returns the operation results for the transaction. For fee
+// bump transactions the operation results of the inner transaction are
+// returned.
+func (r TransactionResult) OperationResults() ([]OperationResult, bool) {
+	innerResults, ok := r.Result.GetInnerResultPair()
+	if ok {
+		return innerResults.Result.Result.GetResults()
+	}
+	return r.Result.GetResults()
+}
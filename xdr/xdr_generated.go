@@ -2597,26 +2597,32 @@ var (
 //
 //   enum EnvelopeType
 //    {
+//        ENVELOPE_TYPE_TX_V0 = 0,
 //        ENVELOPE_TYPE_SCP = 1,
 //        ENVELOPE_TYPE_TX = 2,
 //        ENVELOPE_TYPE_AUTH = 3,
-//        ENVELOPE_TYPE_SCPVALUE = 4
+//        ENVELOPE_TYPE_SCPVALUE = 4,
+//        ENVELOPE_TYPE_TX_FEE_BUMP = 5
 //    };
 //
 type EnvelopeType int32
 
 const (
-	EnvelopeTypeEnvelopeTypeScp      EnvelopeType = 1
-	EnvelopeTypeEnvelopeTypeTx       EnvelopeType = 2
-	EnvelopeTypeEnvelopeTypeAuth     EnvelopeType = 3
-	EnvelopeTypeEnvelopeTypeScpvalue EnvelopeType = 4
+	EnvelopeTypeEnvelopeTypeTxV0      EnvelopeType = 0
+	EnvelopeTypeEnvelopeTypeScp       EnvelopeType = 1
+	EnvelopeTypeEnvelopeTypeTx        EnvelopeType = 2
+	EnvelopeTypeEnvelopeTypeAuth      EnvelopeType = 3
+	EnvelopeTypeEnvelopeTypeScpvalue  EnvelopeType = 4
+	EnvelopeTypeEnvelopeTypeTxFeeBump EnvelopeType = 5
 )
 
 var envelopeTypeMap = map[int32]string{
+	0: "EnvelopeTypeEnvelopeTypeTxV0",
 	1: "EnvelopeTypeEnvelopeTypeScp",
 	2: "EnvelopeTypeEnvelopeTypeTx",
 	3: "EnvelopeTypeEnvelopeTypeAuth",
 	4: "EnvelopeTypeEnvelopeTypeScpvalue",
+	5: "EnvelopeTypeEnvelopeTypeTxFeeBump",
 }
 
 // ValidEnum validates a proposed value for this enum.  Implements
@@ -6112,6 +6118,165 @@ var (
 	_ encoding.BinaryUnmarshaler = (*AuthenticatedMessage)(nil)
 )
 
+// MuxedAccountMed25519 is an XDR NestedStruct defines as:
+//
+//   struct
+//        {
+//            uint64 id;
+//            uint256 ed25519;
+//        }
+//
+type MuxedAccountMed25519 struct {
+	Id      Uint64
+	Ed25519 Uint256
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s MuxedAccountMed25519) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *MuxedAccountMed25519) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*MuxedAccountMed25519)(nil)
+	_ encoding.BinaryUnmarshaler = (*MuxedAccountMed25519)(nil)
+)
+
+// MuxedAccount is an XDR Union defines as:
+//
+//   union MuxedAccount switch (CryptoKeyType type)
+//    {
+//    case KEY_TYPE_ED25519:
+//        uint256 ed25519;
+//    case KEY_TYPE_MUXED_ED25519:
+//        struct
+//        {
+//            uint64 id;
+//            uint256 ed25519;
+//        } med25519;
+//    };
+//
+type MuxedAccount struct {
+	Type     CryptoKeyType
+	Ed25519  *Uint256
+	Med25519 *MuxedAccountMed25519
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u MuxedAccount) SwitchFieldName() string {
+	return "Type"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of MuxedAccount
+func (u MuxedAccount) ArmForSwitch(sw int32) (string, bool) {
+	switch CryptoKeyType(sw) {
+	case CryptoKeyTypeKeyTypeEd25519:
+		return "Ed25519", true
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		return "Med25519", true
+	}
+	return "-", false
+}
+
+// NewMuxedAccount creates a new  MuxedAccount.
+func NewMuxedAccount(aType CryptoKeyType, value interface{}) (result MuxedAccount, err error) {
+	result.Type = aType
+	switch CryptoKeyType(aType) {
+	case CryptoKeyTypeKeyTypeEd25519:
+		tv, ok := value.(Uint256)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be Uint256")
+			return
+		}
+		result.Ed25519 = &tv
+	case CryptoKeyTypeKeyTypeMuxedEd25519:
+		tv, ok := value.(MuxedAccountMed25519)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be MuxedAccountMed25519")
+			return
+		}
+		result.Med25519 = &tv
+	}
+	return
+}
+
+// MustEd25519 retrieves the Ed25519 value from the union,
+// panicing if the value is not set.
+func (u MuxedAccount) MustEd25519() Uint256 {
+	val, ok := u.GetEd25519()
+
+	if !ok {
+		panic("arm Ed25519 is not set")
+	}
+
+	return val
+}
+
+// GetEd25519 retrieves the Ed25519 value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u MuxedAccount) GetEd25519() (result Uint256, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "Ed25519" {
+		result = *u.Ed25519
+		ok = true
+	}
+
+	return
+}
+
+// MustMed25519 retrieves the Med25519 value from the union,
+// panicing if the value is not set.
+func (u MuxedAccount) MustMed25519() MuxedAccountMed25519 {
+	val, ok := u.GetMed25519()
+
+	if !ok {
+		panic("arm Med25519 is not set")
+	}
+
+	return val
+}
+
+// GetMed25519 retrieves the Med25519 value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u MuxedAccount) GetMed25519() (result MuxedAccountMed25519, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "Med25519" {
+		result = *u.Med25519
+		ok = true
+	}
+
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s MuxedAccount) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *MuxedAccount) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*MuxedAccount)(nil)
+	_ encoding.BinaryUnmarshaler = (*MuxedAccount)(nil)
+)
+
 // DecoratedSignature is an XDR Struct defines as:
 //
 //   struct DecoratedSignature
@@ -6265,13 +6430,13 @@ var (
 //
 //   struct PaymentOp
 //    {
-//        AccountID destination; // recipient of the payment
-//        Asset asset;           // what they end up with
-//        int64 amount;          // amount they end up with
+//        MuxedAccount destination; // recipient of the payment
+//        Asset asset;              // what they end up with
+//        int64 amount;             // amount they end up with
 //    };
 //
 type PaymentOp struct {
-	Destination AccountId
+	Destination MuxedAccount
 	Asset       Asset
 	Amount      Int64
 }
@@ -6303,9 +6468,9 @@ var (
 //                         // send (excluding fees).
 //                         // The operation will fail if can't be met
 //
-//        AccountID destination; // recipient of the payment
-//        Asset destAsset;       // what they end up with
-//        int64 destAmount;      // amount they end up with
+//        MuxedAccount destination; // recipient of the payment
+//        Asset destAsset;          // what they end up with
+//        int64 destAmount;         // amount they end up with
 //
 //        Asset path<5>; // additional hops it must go through to get there
 //    };
@@ -6313,7 +6478,7 @@ var (
 type PathPaymentStrictReceiveOp struct {
 	SendAsset   Asset
 	SendMax     Int64
-	Destination AccountId
+	Destination MuxedAccount
 	DestAsset   Asset
 	DestAmount  Int64
 	Path        []Asset `xdrmaxsize:"5"`
@@ -6344,11 +6509,11 @@ var (
 //        Asset sendAsset;  // asset we pay with
 //        int64 sendAmount; // amount of sendAsset to send (excluding fees)
 //
-//        AccountID destination; // recipient of the payment
-//        Asset destAsset;       // what they end up with
-//        int64 destMin;         // the minimum amount of dest asset to
-//                               // be received
-//                               // The operation will fail if it can't be met
+//        MuxedAccount destination; // recipient of the payment
+//        Asset destAsset;          // what they end up with
+//        int64 destMin;            // the minimum amount of dest asset to
+//                                  // be received
+//                                  // The operation will fail if it can't be met
 //
 //        Asset path<5>; // additional hops it must go through to get there
 //    };
@@ -6356,7 +6521,7 @@ var (
 type PathPaymentStrictSendOp struct {
 	SendAsset   Asset
 	SendAmount  Int64
-	Destination AccountId
+	Destination MuxedAccount
 	DestAsset   Asset
 	DestMin     Int64
 	Path        []Asset `xdrmaxsize:"5"`
@@ -6833,7 +6998,7 @@ var (
 //        case ALLOW_TRUST:
 //            AllowTrustOp allowTrustOp;
 //        case ACCOUNT_MERGE:
-//            AccountID destination;
+//            MuxedAccount destination;
 //        case INFLATION:
 //            void;
 //        case MANAGE_DATA:
@@ -6856,7 +7021,7 @@ type OperationBody struct {
 	SetOptionsOp               *SetOptionsOp
 	ChangeTrustOp              *ChangeTrustOp
 	AllowTrustOp               *AllowTrustOp
-	Destination                *AccountId
+	Destination                *MuxedAccount
 	ManageDataOp               *ManageDataOp
 	BumpSequenceOp             *BumpSequenceOp
 	ManageBuyOfferOp           *ManageBuyOfferOp
@@ -6966,9 +7131,9 @@ func NewOperationBody(aType OperationType, value interface{}) (result OperationB
 		}
 		result.AllowTrustOp = &tv
 	case OperationTypeAccountMerge:
-		tv, ok := value.(AccountId)
+		tv, ok := value.(MuxedAccount)
 		if !ok {
-			err = fmt.Errorf("invalid value, must be AccountId")
+			err = fmt.Errorf("invalid value, must be MuxedAccount")
 			return
 		}
 		result.Destination = &tv
@@ -7208,7 +7373,7 @@ func (u OperationBody) GetAllowTrustOp() (result AllowTrustOp, ok bool) {
 
 // MustDestination retrieves the Destination value from the union,
 // panicing if the value is not set.
-func (u OperationBody) MustDestination() AccountId {
+func (u OperationBody) MustDestination() MuxedAccount {
 	val, ok := u.GetDestination()
 
 	if !ok {
@@ -7220,7 +7385,7 @@ func (u OperationBody) MustDestination() AccountId {
 
 // GetDestination retrieves the Destination value from the union,
 // returning ok if the union's switch indicated the value is valid.
-func (u OperationBody) GetDestination() (result AccountId, ok bool) {
+func (u OperationBody) GetDestination() (result MuxedAccount, ok bool) {
 	armName, _ := u.ArmForSwitch(int32(u.Type))
 
 	if armName == "Destination" {
@@ -7356,7 +7521,7 @@ var (
 //        // sourceAccount is the account used to run the operation
 //        // if not set, the runtime defaults to "sourceAccount" specified at
 //        // the transaction level
-//        AccountID* sourceAccount;
+//        MuxedAccount* sourceAccount;
 //
 //        union switch (OperationType type)
 //        {
@@ -7377,7 +7542,7 @@ var (
 //        case ALLOW_TRUST:
 //            AllowTrustOp allowTrustOp;
 //        case ACCOUNT_MERGE:
-//            AccountID destination;
+//            MuxedAccount destination;
 //        case INFLATION:
 //            void;
 //        case MANAGE_DATA:
@@ -7393,7 +7558,7 @@ var (
 //    };
 //
 type Operation struct {
-	SourceAccount *AccountId
+	SourceAccount *MuxedAccount
 	Body          OperationBody
 }
 
@@ -7716,6 +7881,141 @@ var (
 //
 const MaxOpsPerTx = 100
 
+// TransactionV0Ext is an XDR NestedUnion defines as:
+//
+//   union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//
+type TransactionV0Ext struct {
+	V int32
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u TransactionV0Ext) SwitchFieldName() string {
+	return "V"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of TransactionV0Ext
+func (u TransactionV0Ext) ArmForSwitch(sw int32) (string, bool) {
+	switch int32(sw) {
+	case 0:
+		return "", true
+	}
+	return "-", false
+}
+
+// NewTransactionV0Ext creates a new  TransactionV0Ext.
+func NewTransactionV0Ext(v int32, value interface{}) (result TransactionV0Ext, err error) {
+	result.V = v
+	switch int32(v) {
+	case 0:
+		// void
+	}
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionV0Ext) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionV0Ext) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionV0Ext)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionV0Ext)(nil)
+)
+
+// TransactionV0 is an XDR Struct defines as:
+//
+//   struct TransactionV0
+//    {
+//        uint256 sourceAccountEd25519;
+//        uint32 fee;
+//        SequenceNumber seqNum;
+//        TimeBounds* timeBounds;
+//        Memo memo;
+//        Operation operations<MAX_OPS_PER_TX>;
+//        union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//        ext;
+//    };
+//
+type TransactionV0 struct {
+	SourceAccountEd25519 Uint256
+	Fee                  Uint32
+	SeqNum               SequenceNumber
+	TimeBounds           *TimeBounds
+	Memo                 Memo
+	Operations           []Operation `xdrmaxsize:"100"`
+	Ext                  TransactionV0Ext
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionV0) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionV0) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionV0)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionV0)(nil)
+)
+
+// TransactionV0Envelope is an XDR Struct defines as:
+//
+//   struct TransactionV0Envelope
+//    {
+//        TransactionV0 tx;
+//        /* Each decorated signature is a signature over the SHA256 hash of
+//         * a TransactionSignaturePayload */
+//        DecoratedSignature signatures<20>;
+//    };
+//
+type TransactionV0Envelope struct {
+	Tx         TransactionV0
+	Signatures []DecoratedSignature `xdrmaxsize:"20"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionV0Envelope) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionV0Envelope) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionV0Envelope)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionV0Envelope)(nil)
+)
+
 // TransactionExt is an XDR NestedUnion defines as:
 //
 //   union switch (int v)
@@ -7777,7 +8077,7 @@ var (
 //   struct Transaction
 //    {
 //        // account used to run the transaction
-//        AccountID sourceAccount;
+//        MuxedAccount sourceAccount;
 //
 //        // the fee the sourceAccount will pay
 //        uint32 fee;
@@ -7802,7 +8102,7 @@ var (
 //    };
 //
 type Transaction struct {
-	SourceAccount AccountId
+	SourceAccount MuxedAccount
 	Fee           Uint32
 	SeqNum        SequenceNumber
 	TimeBounds    *TimeBounds
@@ -7829,70 +8129,102 @@ var (
 	_ encoding.BinaryUnmarshaler = (*Transaction)(nil)
 )
 
-// TransactionSignaturePayloadTaggedTransaction is an XDR NestedUnion defines as:
+// TransactionV1Envelope is an XDR Struct defines as:
+//
+//   struct TransactionV1Envelope
+//    {
+//        Transaction tx;
+//        /* Each decorated signature is a signature over the SHA256 hash of
+//         * a TransactionSignaturePayload */
+//        DecoratedSignature signatures<20>;
+//    };
+//
+type TransactionV1Envelope struct {
+	Tx         Transaction
+	Signatures []DecoratedSignature `xdrmaxsize:"20"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionV1Envelope) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionV1Envelope) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionV1Envelope)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionV1Envelope)(nil)
+)
+
+// FeeBumpTransactionInnerTx is an XDR NestedUnion defines as:
 //
 //   union switch (EnvelopeType type)
 //        {
 //        case ENVELOPE_TYPE_TX:
-//            Transaction tx;
-//            /* All other values of type are invalid */
+//            TransactionV1Envelope v1;
 //        }
 //
-type TransactionSignaturePayloadTaggedTransaction struct {
+type FeeBumpTransactionInnerTx struct {
 	Type EnvelopeType
-	Tx   *Transaction
+	V1   *TransactionV1Envelope
 }
 
 // SwitchFieldName returns the field name in which this union's
 // discriminant is stored
-func (u TransactionSignaturePayloadTaggedTransaction) SwitchFieldName() string {
+func (u FeeBumpTransactionInnerTx) SwitchFieldName() string {
 	return "Type"
 }
 
 // ArmForSwitch returns which field name should be used for storing
-// the value for an instance of TransactionSignaturePayloadTaggedTransaction
-func (u TransactionSignaturePayloadTaggedTransaction) ArmForSwitch(sw int32) (string, bool) {
+// the value for an instance of FeeBumpTransactionInnerTx
+func (u FeeBumpTransactionInnerTx) ArmForSwitch(sw int32) (string, bool) {
 	switch EnvelopeType(sw) {
 	case EnvelopeTypeEnvelopeTypeTx:
-		return "Tx", true
+		return "V1", true
 	}
 	return "-", false
 }
 
-// NewTransactionSignaturePayloadTaggedTransaction creates a new  TransactionSignaturePayloadTaggedTransaction.
-func NewTransactionSignaturePayloadTaggedTransaction(aType EnvelopeType, value interface{}) (result TransactionSignaturePayloadTaggedTransaction, err error) {
+// NewFeeBumpTransactionInnerTx creates a new  FeeBumpTransactionInnerTx.
+func NewFeeBumpTransactionInnerTx(aType EnvelopeType, value interface{}) (result FeeBumpTransactionInnerTx, err error) {
 	result.Type = aType
 	switch EnvelopeType(aType) {
 	case EnvelopeTypeEnvelopeTypeTx:
-		tv, ok := value.(Transaction)
+		tv, ok := value.(TransactionV1Envelope)
 		if !ok {
-			err = fmt.Errorf("invalid value, must be Transaction")
+			err = fmt.Errorf("invalid value, must be TransactionV1Envelope")
 			return
 		}
-		result.Tx = &tv
+		result.V1 = &tv
 	}
 	return
 }
 
-// MustTx retrieves the Tx value from the union,
+// MustV1 retrieves the V1 value from the union,
 // panicing if the value is not set.
-func (u TransactionSignaturePayloadTaggedTransaction) MustTx() Transaction {
-	val, ok := u.GetTx()
+func (u FeeBumpTransactionInnerTx) MustV1() TransactionV1Envelope {
+	val, ok := u.GetV1()
 
 	if !ok {
-		panic("arm Tx is not set")
+		panic("arm V1 is not set")
 	}
 
 	return val
 }
 
-// GetTx retrieves the Tx value from the union,
+// GetV1 retrieves the V1 value from the union,
 // returning ok if the union's switch indicated the value is valid.
-func (u TransactionSignaturePayloadTaggedTransaction) GetTx() (result Transaction, ok bool) {
+func (u FeeBumpTransactionInnerTx) GetV1() (result TransactionV1Envelope, ok bool) {
 	armName, _ := u.ArmForSwitch(int32(u.Type))
 
-	if armName == "Tx" {
-		result = *u.Tx
+	if armName == "V1" {
+		result = *u.V1
 		ok = true
 	}
 
@@ -7900,91 +8232,480 @@ func (u TransactionSignaturePayloadTaggedTransaction) GetTx() (result Transactio
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
-func (s TransactionSignaturePayloadTaggedTransaction) MarshalBinary() ([]byte, error) {
+func (s FeeBumpTransactionInnerTx) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
 	_, err := Marshal(b, s)
 	return b.Bytes(), err
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *TransactionSignaturePayloadTaggedTransaction) UnmarshalBinary(inp []byte) error {
+func (s *FeeBumpTransactionInnerTx) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
 
 var (
-	_ encoding.BinaryMarshaler   = (*TransactionSignaturePayloadTaggedTransaction)(nil)
-	_ encoding.BinaryUnmarshaler = (*TransactionSignaturePayloadTaggedTransaction)(nil)
+	_ encoding.BinaryMarshaler   = (*FeeBumpTransactionInnerTx)(nil)
+	_ encoding.BinaryUnmarshaler = (*FeeBumpTransactionInnerTx)(nil)
 )
 
-// TransactionSignaturePayload is an XDR Struct defines as:
+// FeeBumpTransactionExt is an XDR NestedUnion defines as:
 //
-//   struct TransactionSignaturePayload
+//   union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//
+type FeeBumpTransactionExt struct {
+	V int32
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u FeeBumpTransactionExt) SwitchFieldName() string {
+	return "V"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of FeeBumpTransactionExt
+func (u FeeBumpTransactionExt) ArmForSwitch(sw int32) (string, bool) {
+	switch int32(sw) {
+	case 0:
+		return "", true
+	}
+	return "-", false
+}
+
+// NewFeeBumpTransactionExt creates a new  FeeBumpTransactionExt.
+func NewFeeBumpTransactionExt(v int32, value interface{}) (result FeeBumpTransactionExt, err error) {
+	result.V = v
+	switch int32(v) {
+	case 0:
+		// void
+	}
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s FeeBumpTransactionExt) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *FeeBumpTransactionExt) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*FeeBumpTransactionExt)(nil)
+	_ encoding.BinaryUnmarshaler = (*FeeBumpTransactionExt)(nil)
+)
+
+// FeeBumpTransaction is an XDR Struct defines as:
+//
+//   struct FeeBumpTransaction
 //    {
-//        Hash networkId;
+//        MuxedAccount feeSource;
+//        int64 fee;
 //        union switch (EnvelopeType type)
 //        {
 //        case ENVELOPE_TYPE_TX:
-//            Transaction tx;
-//            /* All other values of type are invalid */
+//            TransactionV1Envelope v1;
 //        }
-//        taggedTransaction;
+//        innerTx;
+//        union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//        ext;
 //    };
 //
-type TransactionSignaturePayload struct {
-	NetworkId         Hash
-	TaggedTransaction TransactionSignaturePayloadTaggedTransaction
+type FeeBumpTransaction struct {
+	FeeSource MuxedAccount
+	Fee       Int64
+	InnerTx   FeeBumpTransactionInnerTx
+	Ext       FeeBumpTransactionExt
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
-func (s TransactionSignaturePayload) MarshalBinary() ([]byte, error) {
+func (s FeeBumpTransaction) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
 	_, err := Marshal(b, s)
 	return b.Bytes(), err
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *TransactionSignaturePayload) UnmarshalBinary(inp []byte) error {
+func (s *FeeBumpTransaction) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
 
 var (
-	_ encoding.BinaryMarshaler   = (*TransactionSignaturePayload)(nil)
-	_ encoding.BinaryUnmarshaler = (*TransactionSignaturePayload)(nil)
+	_ encoding.BinaryMarshaler   = (*FeeBumpTransaction)(nil)
+	_ encoding.BinaryUnmarshaler = (*FeeBumpTransaction)(nil)
 )
 
-// TransactionEnvelope is an XDR Struct defines as:
+// FeeBumpTransactionEnvelope is an XDR Struct defines as:
 //
-//   struct TransactionEnvelope
+//   struct FeeBumpTransactionEnvelope
 //    {
-//        Transaction tx;
+//        FeeBumpTransaction tx;
 //        /* Each decorated signature is a signature over the SHA256 hash of
 //         * a TransactionSignaturePayload */
 //        DecoratedSignature signatures<20>;
 //    };
 //
-type TransactionEnvelope struct {
-	Tx         Transaction
-	Signatures []DecoratedSignature `xdrmaxsize:"20"`
+type FeeBumpTransactionEnvelope struct {
+	Tx         FeeBumpTransaction
+	Signatures []DecoratedSignature `xdrmaxsize:"20"`
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s FeeBumpTransactionEnvelope) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *FeeBumpTransactionEnvelope) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*FeeBumpTransactionEnvelope)(nil)
+	_ encoding.BinaryUnmarshaler = (*FeeBumpTransactionEnvelope)(nil)
+)
+
+// TransactionEnvelope is an XDR Union defines as:
+//
+//   union TransactionEnvelope switch (EnvelopeType type)
+//    {
+//    case ENVELOPE_TYPE_TX_V0:
+//        TransactionV0Envelope v0;
+//    case ENVELOPE_TYPE_TX:
+//        TransactionV1Envelope v1;
+//    case ENVELOPE_TYPE_TX_FEE_BUMP:
+//        FeeBumpTransactionEnvelope feeBump;
+//    };
+//
+type TransactionEnvelope struct {
+	Type    EnvelopeType
+	V0      *TransactionV0Envelope
+	V1      *TransactionV1Envelope
+	FeeBump *FeeBumpTransactionEnvelope
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u TransactionEnvelope) SwitchFieldName() string {
+	return "Type"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of TransactionEnvelope
+func (u TransactionEnvelope) ArmForSwitch(sw int32) (string, bool) {
+	switch EnvelopeType(sw) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		return "V0", true
+	case EnvelopeTypeEnvelopeTypeTx:
+		return "V1", true
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return "FeeBump", true
+	}
+	return "-", false
+}
+
+// NewTransactionEnvelope creates a new  TransactionEnvelope.
+func NewTransactionEnvelope(aType EnvelopeType, value interface{}) (result TransactionEnvelope, err error) {
+	result.Type = aType
+	switch EnvelopeType(aType) {
+	case EnvelopeTypeEnvelopeTypeTxV0:
+		tv, ok := value.(TransactionV0Envelope)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be TransactionV0Envelope")
+			return
+		}
+		result.V0 = &tv
+	case EnvelopeTypeEnvelopeTypeTx:
+		tv, ok := value.(TransactionV1Envelope)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be TransactionV1Envelope")
+			return
+		}
+		result.V1 = &tv
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		tv, ok := value.(FeeBumpTransactionEnvelope)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be FeeBumpTransactionEnvelope")
+			return
+		}
+		result.FeeBump = &tv
+	}
+	return
+}
+
+// MustV0 retrieves the V0 value from the union,
+// panicing if the value is not set.
+func (u TransactionEnvelope) MustV0() TransactionV0Envelope {
+	val, ok := u.GetV0()
+
+	if !ok {
+		panic("arm V0 is not set")
+	}
+
+	return val
+}
+
+// GetV0 retrieves the V0 value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionEnvelope) GetV0() (result TransactionV0Envelope, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "V0" {
+		result = *u.V0
+		ok = true
+	}
+
+	return
+}
+
+// MustV1 retrieves the V1 value from the union,
+// panicing if the value is not set.
+func (u TransactionEnvelope) MustV1() TransactionV1Envelope {
+	val, ok := u.GetV1()
+
+	if !ok {
+		panic("arm V1 is not set")
+	}
+
+	return val
+}
+
+// GetV1 retrieves the V1 value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionEnvelope) GetV1() (result TransactionV1Envelope, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "V1" {
+		result = *u.V1
+		ok = true
+	}
+
+	return
+}
+
+// MustFeeBump retrieves the FeeBump value from the union,
+// panicing if the value is not set.
+func (u TransactionEnvelope) MustFeeBump() FeeBumpTransactionEnvelope {
+	val, ok := u.GetFeeBump()
+
+	if !ok {
+		panic("arm FeeBump is not set")
+	}
+
+	return val
+}
+
+// GetFeeBump retrieves the FeeBump value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionEnvelope) GetFeeBump() (result FeeBumpTransactionEnvelope, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "FeeBump" {
+		result = *u.FeeBump
+		ok = true
+	}
+
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionEnvelope) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionEnvelope) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionEnvelope)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionEnvelope)(nil)
+)
+
+// TransactionSignaturePayloadTaggedTransaction is an XDR NestedUnion defines as:
+//
+//   union switch (EnvelopeType type)
+//        {
+//        // Backwards Compatibility: Use ENVELOPE_TYPE_TX to sign ENVELOPE_TYPE_TX_V0
+//        case ENVELOPE_TYPE_TX:
+//            Transaction tx;
+//        case ENVELOPE_TYPE_TX_FEE_BUMP:
+//            FeeBumpTransaction feeBump;
+//        }
+//
+type TransactionSignaturePayloadTaggedTransaction struct {
+	Type    EnvelopeType
+	Tx      *Transaction
+	FeeBump *FeeBumpTransaction
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u TransactionSignaturePayloadTaggedTransaction) SwitchFieldName() string {
+	return "Type"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of TransactionSignaturePayloadTaggedTransaction
+func (u TransactionSignaturePayloadTaggedTransaction) ArmForSwitch(sw int32) (string, bool) {
+	switch EnvelopeType(sw) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		return "Tx", true
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		return "FeeBump", true
+	}
+	return "-", false
+}
+
+// NewTransactionSignaturePayloadTaggedTransaction creates a new  TransactionSignaturePayloadTaggedTransaction.
+func NewTransactionSignaturePayloadTaggedTransaction(aType EnvelopeType, value interface{}) (result TransactionSignaturePayloadTaggedTransaction, err error) {
+	result.Type = aType
+	switch EnvelopeType(aType) {
+	case EnvelopeTypeEnvelopeTypeTx:
+		tv, ok := value.(Transaction)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be Transaction")
+			return
+		}
+		result.Tx = &tv
+	case EnvelopeTypeEnvelopeTypeTxFeeBump:
+		tv, ok := value.(FeeBumpTransaction)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be FeeBumpTransaction")
+			return
+		}
+		result.FeeBump = &tv
+	}
+	return
+}
+
+// MustTx retrieves the Tx value from the union,
+// panicing if the value is not set.
+func (u TransactionSignaturePayloadTaggedTransaction) MustTx() Transaction {
+	val, ok := u.GetTx()
+
+	if !ok {
+		panic("arm Tx is not set")
+	}
+
+	return val
+}
+
+// GetTx retrieves the Tx value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionSignaturePayloadTaggedTransaction) GetTx() (result Transaction, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "Tx" {
+		result = *u.Tx
+		ok = true
+	}
+
+	return
+}
+
+// MustFeeBump retrieves the FeeBump value from the union,
+// panicing if the value is not set.
+func (u TransactionSignaturePayloadTaggedTransaction) MustFeeBump() FeeBumpTransaction {
+	val, ok := u.GetFeeBump()
+
+	if !ok {
+		panic("arm FeeBump is not set")
+	}
+
+	return val
+}
+
+// GetFeeBump retrieves the FeeBump value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionSignaturePayloadTaggedTransaction) GetFeeBump() (result FeeBumpTransaction, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Type))
+
+	if armName == "FeeBump" {
+		result = *u.FeeBump
+		ok = true
+	}
+
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s TransactionSignaturePayloadTaggedTransaction) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *TransactionSignaturePayloadTaggedTransaction) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*TransactionSignaturePayloadTaggedTransaction)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionSignaturePayloadTaggedTransaction)(nil)
+)
+
+// TransactionSignaturePayload is an XDR Struct defines as:
+//
+//   struct TransactionSignaturePayload
+//    {
+//        Hash networkId;
+//        union switch (EnvelopeType type)
+//        {
+//        // Backwards Compatibility: Use ENVELOPE_TYPE_TX to sign ENVELOPE_TYPE_TX_V0
+//        case ENVELOPE_TYPE_TX:
+//            Transaction tx;
+//        case ENVELOPE_TYPE_TX_FEE_BUMP:
+//            FeeBumpTransaction feeBump;
+//        }
+//        taggedTransaction;
+//    };
+//
+type TransactionSignaturePayload struct {
+	NetworkId         Hash
+	TaggedTransaction TransactionSignaturePayloadTaggedTransaction
 }
 
 // MarshalBinary implements encoding.BinaryMarshaler.
-func (s TransactionEnvelope) MarshalBinary() ([]byte, error) {
+func (s TransactionSignaturePayload) MarshalBinary() ([]byte, error) {
 	b := new(bytes.Buffer)
 	_, err := Marshal(b, s)
 	return b.Bytes(), err
 }
 
 // UnmarshalBinary implements encoding.BinaryUnmarshaler.
-func (s *TransactionEnvelope) UnmarshalBinary(inp []byte) error {
+func (s *TransactionSignaturePayload) UnmarshalBinary(inp []byte) error {
 	_, err := Unmarshal(bytes.NewReader(inp), s)
 	return err
 }
 
 var (
-	_ encoding.BinaryMarshaler   = (*TransactionEnvelope)(nil)
-	_ encoding.BinaryUnmarshaler = (*TransactionEnvelope)(nil)
+	_ encoding.BinaryMarshaler   = (*TransactionSignaturePayload)(nil)
+	_ encoding.BinaryUnmarshaler = (*TransactionSignaturePayload)(nil)
 )
 
 // ClaimOfferAtom is an XDR Struct defines as:
@@ -11119,7 +11840,8 @@ var (
 //
 //   enum TransactionResultCode
 //    {
-//        txSUCCESS = 0, // all operations succeeded
+//        txFEE_BUMP_INNER_SUCCESS = 1, // fee bump inner transaction succeeded
+//        txSUCCESS = 0,                // all operations succeeded
 //
 //        txFAILED = -1, // one of the operations failed (none were applied)
 //
@@ -11133,12 +11855,16 @@ var (
 //        txNO_ACCOUNT = -8,           // source account not found
 //        txINSUFFICIENT_FEE = -9,     // fee is too small
 //        txBAD_AUTH_EXTRA = -10,      // unused signatures attached to transaction
-//        txINTERNAL_ERROR = -11       // an unknown error occured
+//        txINTERNAL_ERROR = -11,      // an unknown error occured
+//
+//        txNOT_SUPPORTED = -12,        // transaction type not supported
+//        txFEE_BUMP_INNER_FAILED = -13 // fee bump inner transaction failed
 //    };
 //
 type TransactionResultCode int32
 
 const (
+	TransactionResultCodeTxFeeBumpInnerSuccess TransactionResultCode = 1
 	TransactionResultCodeTxSuccess             TransactionResultCode = 0
 	TransactionResultCodeTxFailed              TransactionResultCode = -1
 	TransactionResultCodeTxTooEarly            TransactionResultCode = -2
@@ -11151,9 +11877,12 @@ const (
 	TransactionResultCodeTxInsufficientFee     TransactionResultCode = -9
 	TransactionResultCodeTxBadAuthExtra        TransactionResultCode = -10
 	TransactionResultCodeTxInternalError       TransactionResultCode = -11
+	TransactionResultCodeTxNotSupported        TransactionResultCode = -12
+	TransactionResultCodeTxFeeBumpInnerFailed  TransactionResultCode = -13
 )
 
 var transactionResultCodeMap = map[int32]string{
+	1:   "TransactionResultCodeTxFeeBumpInnerSuccess",
 	0:   "TransactionResultCodeTxSuccess",
 	-1:  "TransactionResultCodeTxFailed",
 	-2:  "TransactionResultCodeTxTooEarly",
@@ -11166,6 +11895,8 @@ var transactionResultCodeMap = map[int32]string{
 	-9:  "TransactionResultCodeTxInsufficientFee",
 	-10: "TransactionResultCodeTxBadAuthExtra",
 	-11: "TransactionResultCodeTxInternalError",
+	-12: "TransactionResultCodeTxNotSupported",
+	-13: "TransactionResultCodeTxFeeBumpInnerFailed",
 }
 
 // ValidEnum validates a proposed value for this enum.  Implements
@@ -11199,10 +11930,317 @@ var (
 	_ encoding.BinaryUnmarshaler = (*TransactionResultCode)(nil)
 )
 
+// InnerTransactionResultResult is an XDR NestedUnion defines as:
+//
+//   union switch (TransactionResultCode code)
+//        {
+//        // txFEE_BUMP_INNER_SUCCESS is not included
+//        case txSUCCESS:
+//        case txFAILED:
+//            OperationResult results<>;
+//        case txTOO_EARLY:
+//        case txTOO_LATE:
+//        case txMISSING_OPERATION:
+//        case txBAD_SEQ:
+//        case txBAD_AUTH:
+//        case txINSUFFICIENT_BALANCE:
+//        case txNO_ACCOUNT:
+//        case txINSUFFICIENT_FEE:
+//        case txBAD_AUTH_EXTRA:
+//        case txINTERNAL_ERROR:
+//        case txNOT_SUPPORTED:
+//            // txFEE_BUMP_INNER_FAILED is not included
+//            void;
+//        }
+//
+type InnerTransactionResultResult struct {
+	Code    TransactionResultCode
+	Results *[]OperationResult
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u InnerTransactionResultResult) SwitchFieldName() string {
+	return "Code"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of InnerTransactionResultResult
+func (u InnerTransactionResultResult) ArmForSwitch(sw int32) (string, bool) {
+	switch TransactionResultCode(sw) {
+	case TransactionResultCodeTxSuccess:
+		return "Results", true
+	case TransactionResultCodeTxFailed:
+		return "Results", true
+	case TransactionResultCodeTxTooEarly:
+		return "", true
+	case TransactionResultCodeTxTooLate:
+		return "", true
+	case TransactionResultCodeTxMissingOperation:
+		return "", true
+	case TransactionResultCodeTxBadSeq:
+		return "", true
+	case TransactionResultCodeTxBadAuth:
+		return "", true
+	case TransactionResultCodeTxInsufficientBalance:
+		return "", true
+	case TransactionResultCodeTxNoAccount:
+		return "", true
+	case TransactionResultCodeTxInsufficientFee:
+		return "", true
+	case TransactionResultCodeTxBadAuthExtra:
+		return "", true
+	case TransactionResultCodeTxInternalError:
+		return "", true
+	case TransactionResultCodeTxNotSupported:
+		return "", true
+	}
+	return "-", false
+}
+
+// NewInnerTransactionResultResult creates a new  InnerTransactionResultResult.
+func NewInnerTransactionResultResult(code TransactionResultCode, value interface{}) (result InnerTransactionResultResult, err error) {
+	result.Code = code
+	switch TransactionResultCode(code) {
+	case TransactionResultCodeTxSuccess:
+		tv, ok := value.([]OperationResult)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be []OperationResult")
+			return
+		}
+		result.Results = &tv
+	case TransactionResultCodeTxFailed:
+		tv, ok := value.([]OperationResult)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be []OperationResult")
+			return
+		}
+		result.Results = &tv
+	case TransactionResultCodeTxTooEarly:
+		// void
+	case TransactionResultCodeTxTooLate:
+		// void
+	case TransactionResultCodeTxMissingOperation:
+		// void
+	case TransactionResultCodeTxBadSeq:
+		// void
+	case TransactionResultCodeTxBadAuth:
+		// void
+	case TransactionResultCodeTxInsufficientBalance:
+		// void
+	case TransactionResultCodeTxNoAccount:
+		// void
+	case TransactionResultCodeTxInsufficientFee:
+		// void
+	case TransactionResultCodeTxBadAuthExtra:
+		// void
+	case TransactionResultCodeTxInternalError:
+		// void
+	case TransactionResultCodeTxNotSupported:
+		// void
+	}
+	return
+}
+
+// MustResults retrieves the Results value from the union,
+// panicing if the value is not set.
+func (u InnerTransactionResultResult) MustResults() []OperationResult {
+	val, ok := u.GetResults()
+
+	if !ok {
+		panic("arm Results is not set")
+	}
+
+	return val
+}
+
+// GetResults retrieves the Results value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u InnerTransactionResultResult) GetResults() (result []OperationResult, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Code))
+
+	if armName == "Results" {
+		result = *u.Results
+		ok = true
+	}
+
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s InnerTransactionResultResult) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *InnerTransactionResultResult) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*InnerTransactionResultResult)(nil)
+	_ encoding.BinaryUnmarshaler = (*InnerTransactionResultResult)(nil)
+)
+
+// InnerTransactionResultExt is an XDR NestedUnion defines as:
+//
+//   union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//
+type InnerTransactionResultExt struct {
+	V int32
+}
+
+// SwitchFieldName returns the field name in which this union's
+// discriminant is stored
+func (u InnerTransactionResultExt) SwitchFieldName() string {
+	return "V"
+}
+
+// ArmForSwitch returns which field name should be used for storing
+// the value for an instance of InnerTransactionResultExt
+func (u InnerTransactionResultExt) ArmForSwitch(sw int32) (string, bool) {
+	switch int32(sw) {
+	case 0:
+		return "", true
+	}
+	return "-", false
+}
+
+// NewInnerTransactionResultExt creates a new  InnerTransactionResultExt.
+func NewInnerTransactionResultExt(v int32, value interface{}) (result InnerTransactionResultExt, err error) {
+	result.V = v
+	switch int32(v) {
+	case 0:
+		// void
+	}
+	return
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s InnerTransactionResultExt) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *InnerTransactionResultExt) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*InnerTransactionResultExt)(nil)
+	_ encoding.BinaryUnmarshaler = (*InnerTransactionResultExt)(nil)
+)
+
+// InnerTransactionResult is an XDR Struct defines as:
+//
+//   struct InnerTransactionResult
+//    {
+//        // Always 0. Here for binary compatibility.
+//        int64 feeCharged;
+//
+//        union switch (TransactionResultCode code)
+//        {
+//        // txFEE_BUMP_INNER_SUCCESS is not included
+//        case txSUCCESS:
+//        case txFAILED:
+//            OperationResult results<>;
+//        case txTOO_EARLY:
+//        case txTOO_LATE:
+//        case txMISSING_OPERATION:
+//        case txBAD_SEQ:
+//        case txBAD_AUTH:
+//        case txINSUFFICIENT_BALANCE:
+//        case txNO_ACCOUNT:
+//        case txINSUFFICIENT_FEE:
+//        case txBAD_AUTH_EXTRA:
+//        case txINTERNAL_ERROR:
+//        case txNOT_SUPPORTED:
+//            // txFEE_BUMP_INNER_FAILED is not included
+//            void;
+//        }
+//        result;
+//
+//        // reserved for future use
+//        union switch (int v)
+//        {
+//        case 0:
+//            void;
+//        }
+//        ext;
+//    };
+//
+type InnerTransactionResult struct {
+	FeeCharged Int64
+	Result     InnerTransactionResultResult
+	Ext        InnerTransactionResultExt
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s InnerTransactionResult) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *InnerTransactionResult) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*InnerTransactionResult)(nil)
+	_ encoding.BinaryUnmarshaler = (*InnerTransactionResult)(nil)
+)
+
+// InnerTransactionResultPair is an XDR Struct defines as:
+//
+//   struct InnerTransactionResultPair
+//    {
+//        Hash transactionHash;          // hash of the inner transaction
+//        InnerTransactionResult result; // result for the inner transaction
+//    };
+//
+type InnerTransactionResultPair struct {
+	TransactionHash Hash
+	Result          InnerTransactionResult
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (s InnerTransactionResultPair) MarshalBinary() ([]byte, error) {
+	b := new(bytes.Buffer)
+	_, err := Marshal(b, s)
+	return b.Bytes(), err
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *InnerTransactionResultPair) UnmarshalBinary(inp []byte) error {
+	_, err := Unmarshal(bytes.NewReader(inp), s)
+	return err
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*InnerTransactionResultPair)(nil)
+	_ encoding.BinaryUnmarshaler = (*InnerTransactionResultPair)(nil)
+)
+
 // TransactionResultResult is an XDR NestedUnion defines as:
 //
 //   union switch (TransactionResultCode code)
 //        {
+//        case txFEE_BUMP_INNER_SUCCESS:
+//        case txFEE_BUMP_INNER_FAILED:
+//            InnerTransactionResultPair innerResultPair;
 //        case txSUCCESS:
 //        case txFAILED:
 //            OperationResult results<>;
@@ -11211,8 +12249,9 @@ var (
 //        }
 //
 type TransactionResultResult struct {
-	Code    TransactionResultCode
-	Results *[]OperationResult
+	Code            TransactionResultCode
+	InnerResultPair *InnerTransactionResultPair
+	Results         *[]OperationResult
 }
 
 // SwitchFieldName returns the field name in which this union's
@@ -11225,6 +12264,10 @@ func (u TransactionResultResult) SwitchFieldName() string {
 // the value for an instance of TransactionResultResult
 func (u TransactionResultResult) ArmForSwitch(sw int32) (string, bool) {
 	switch TransactionResultCode(sw) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		return "InnerResultPair", true
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		return "InnerResultPair", true
 	case TransactionResultCodeTxSuccess:
 		return "Results", true
 	case TransactionResultCodeTxFailed:
@@ -11238,6 +12281,20 @@ func (u TransactionResultResult) ArmForSwitch(sw int32) (string, bool) {
 func NewTransactionResultResult(code TransactionResultCode, value interface{}) (result TransactionResultResult, err error) {
 	result.Code = code
 	switch TransactionResultCode(code) {
+	case TransactionResultCodeTxFeeBumpInnerSuccess:
+		tv, ok := value.(InnerTransactionResultPair)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be InnerTransactionResultPair")
+			return
+		}
+		result.InnerResultPair = &tv
+	case TransactionResultCodeTxFeeBumpInnerFailed:
+		tv, ok := value.(InnerTransactionResultPair)
+		if !ok {
+			err = fmt.Errorf("invalid value, must be InnerTransactionResultPair")
+			return
+		}
+		result.InnerResultPair = &tv
 	case TransactionResultCodeTxSuccess:
 		tv, ok := value.([]OperationResult)
 		if !ok {
@@ -11258,6 +12315,31 @@ func NewTransactionResultResult(code TransactionResultCode, value interface{}) (
 	return
 }
 
+// MustInnerResultPair retrieves the InnerResultPair value from the union,
+// panicing if the value is not set.
+func (u TransactionResultResult) MustInnerResultPair() InnerTransactionResultPair {
+	val, ok := u.GetInnerResultPair()
+
+	if !ok {
+		panic("arm InnerResultPair is not set")
+	}
+
+	return val
+}
+
+// GetInnerResultPair retrieves the InnerResultPair value from the union,
+// returning ok if the union's switch indicated the value is valid.
+func (u TransactionResultResult) GetInnerResultPair() (result InnerTransactionResultPair, ok bool) {
+	armName, _ := u.ArmForSwitch(int32(u.Code))
+
+	if armName == "InnerResultPair" {
+		result = *u.InnerResultPair
+		ok = true
+	}
+
+	return
+}
+
 // MustResults retrieves the Results value from the union,
 // panicing if the value is not set.
 func (u TransactionResultResult) MustResults() []OperationResult {
@@ -11365,6 +12447,9 @@ var (
 //
 //        union switch (TransactionResultCode code)
 //        {
+//        case txFEE_BUMP_INNER_SUCCESS:
+//        case txFEE_BUMP_INNER_FAILED:
+//            InnerTransactionResultPair innerResultPair;
 //        case txSUCCESS:
 //        case txFAILED:
 //            OperationResult results<>;
@@ -11566,21 +12651,26 @@ var (
 //    {
 //        KEY_TYPE_ED25519 = 0,
 //        KEY_TYPE_PRE_AUTH_TX = 1,
-//        KEY_TYPE_HASH_X = 2
+//        KEY_TYPE_HASH_X = 2,
+//        // MUXED enum values for supported type are derived from the enum values
+//        // above by ORing them with 0x100
+//        KEY_TYPE_MUXED_ED25519 = 0x100
 //    };
 //
 type CryptoKeyType int32
 
 const (
-	CryptoKeyTypeKeyTypeEd25519   CryptoKeyType = 0
-	CryptoKeyTypeKeyTypePreAuthTx CryptoKeyType = 1
-	CryptoKeyTypeKeyTypeHashX     CryptoKeyType = 2
+	CryptoKeyTypeKeyTypeEd25519      CryptoKeyType = 0
+	CryptoKeyTypeKeyTypePreAuthTx    CryptoKeyType = 1
+	CryptoKeyTypeKeyTypeHashX        CryptoKeyType = 2
+	CryptoKeyTypeKeyTypeMuxedEd25519 CryptoKeyType = 256
 )
 
 var cryptoKeyTypeMap = map[int32]string{
-	0: "CryptoKeyTypeKeyTypeEd25519",
-	1: "CryptoKeyTypeKeyTypePreAuthTx",
-	2: "CryptoKeyTypeKeyTypeHashX",
+	0:   "CryptoKeyTypeKeyTypeEd25519",
+	1:   "CryptoKeyTypeKeyTypePreAuthTx",
+	2:   "CryptoKeyTypeKeyTypeHashX",
+	256: "CryptoKeyTypeKeyTypeMuxedEd25519",
 }
 
 // ValidEnum validates a proposed value for this enum.  Implements